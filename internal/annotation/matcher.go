@@ -33,17 +33,8 @@ type MatchResult struct {
 
 // Match finds an annotation that applies to the given finding
 func (m *Matcher) Match(finding *types.Finding) MatchResult {
-	// Determine which file location to use
-	var filename string
-	var line int
-
-	if finding.NewLocation != nil {
-		filename = finding.NewLocation.Filename
-		line = finding.NewLocation.Line
-	} else if finding.OldLocation != nil {
-		filename = finding.OldLocation.Filename
-		line = finding.OldLocation.Line
-	} else {
+	filename, line, ok := findingLocation(finding)
+	if !ok {
 		return MatchResult{Matched: false}
 	}
 
@@ -52,42 +43,98 @@ func (m *Matcher) Match(finding *types.Finding) MatchResult {
 		return MatchResult{Matched: false}
 	}
 
-	// Check file-level annotations first
-	for _, ann := range anns {
-		if ann.Scope == ScopeFile && ann.MatchesRule(finding.RuleID) {
-			return MatchResult{Matched: true, Annotation: ann}
+	// Check file-level annotations first, then line ranges, then blocks
+	for _, scope := range []Scope{ScopeFile, ScopeRange, ScopeBlock} {
+		for _, ann := range anns {
+			if ann.Scope == scope && ann.MatchesRule(finding.RuleID) && m.appliesAt(ann, filename, line) {
+				return MatchResult{Matched: true, Annotation: ann}
+			}
 		}
 	}
 
-	// Check block-level annotations
-	// Find the annotation on the line immediately before the finding's block
-	for _, ann := range anns {
-		if ann.Scope != ScopeBlock {
-			continue
-		}
+	return MatchResult{Matched: false}
+}
 
-		if !ann.MatchesRule(finding.RuleID) {
-			continue
-		}
+// findingLocation returns the file and line annotations are matched
+// against: the finding's new location, or its old one if it has none
+func findingLocation(finding *types.Finding) (string, int, bool) {
+	if finding.NewLocation != nil {
+		return finding.NewLocation.Filename, finding.NewLocation.Line, true
+	}
+	if finding.OldLocation != nil {
+		return finding.OldLocation.Filename, finding.OldLocation.Line, true
+	}
+	return "", 0, false
+}
 
-		// Check if the annotation is on the line immediately before the finding
-		// or immediately before the block containing the finding
-		if ann.Line == line-1 {
-			return MatchResult{Matched: true, Annotation: ann}
-		}
+// appliesAt reports whether ann covers line of filename, regardless of the
+// rules it names
+func (m *Matcher) appliesAt(ann *Annotation, filename string, line int) bool {
+	switch ann.Scope {
+	case ScopeFile:
+		return true
+	case ScopeRange:
+		return ann.CoversLine(line)
+	}
 
-		// Check if annotation is immediately before a block that contains this line
-		if blocks, ok := m.blockStarts[filename]; ok {
-			for blockLine := range blocks {
-				if ann.Line == blockLine-1 && line >= blockLine {
-					// The annotation is right before a block, and the finding is at or after that block
-					return MatchResult{Matched: true, Annotation: ann}
-				}
-			}
+	// Address-targeted annotations match only their own block
+	if ann.BlockLine > 0 {
+		return line == ann.BlockLine
+	}
+
+	// Check if the annotation is on the line immediately before the finding
+	// or immediately before the block containing the finding
+	if ann.Line == line-1 {
+		return true
+	}
+	for blockLine := range m.blockStarts[filename] {
+		if ann.Line == blockLine-1 && line >= blockLine {
+			// The annotation is right before a block, and the finding is at or after that block
+			return true
 		}
 	}
+	return false
+}
 
-	return MatchResult{Matched: false}
+// NearMissReason describes why an annotation did not apply to a finding
+type NearMissReason string
+
+const (
+	// NearMissRule is an annotation at the finding's location that names
+	// other rules
+	NearMissRule NearMissReason = "rule mismatch"
+	// NearMissAddress is a sidecar ignore for the finding's rule that
+	// targets another block
+	NearMissAddress NearMissReason = "address mismatch"
+)
+
+// NearMiss is an annotation that almost applied to a finding
+type NearMiss struct {
+	Annotation *Annotation
+	Reason     NearMissReason
+}
+
+// NearMisses returns the annotations in the finding's file that would have
+// applied to it but for their rules or the block address they target. It
+// is meant to explain a finding that Match found no annotation for.
+func (m *Matcher) NearMisses(finding *types.Finding) []NearMiss {
+	filename, line, ok := findingLocation(finding)
+	if !ok {
+		return nil
+	}
+
+	var misses []NearMiss
+	for _, ann := range m.annotations[filename] {
+		matchesRule := ann.MatchesRule(finding.RuleID)
+		appliesAt := m.appliesAt(ann, filename, line)
+		switch {
+		case appliesAt && !matchesRule:
+			misses = append(misses, NearMiss{Annotation: ann, Reason: NearMissRule})
+		case !appliesAt && matchesRule && ann.Address != "":
+			misses = append(misses, NearMiss{Annotation: ann, Reason: NearMissAddress})
+		}
+	}
+	return misses
 }
 
 // GovernanceConfig contains settings for annotation governance
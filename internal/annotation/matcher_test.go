@@ -114,6 +114,72 @@ func TestMatcherMatch(t *testing.T) {
 	}
 }
 
+func TestMatcherMatch_BeginEndRegion(t *testing.T) {
+	src := `variable "before" {}
+
+# tfbreak:ignore-begin input-removed # generated block
+variable "a" {}
+variable "b" {}
+# tfbreak:ignore-end
+
+variable "after" {}
+`
+	anns, err := NewParser(newTestResolver()).ParseFile("test.tf", []byte(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	blockStarts, err := FindBlockStarts("test.tf", []byte(src))
+	if err != nil {
+		t.Fatalf("FindBlockStarts failed: %v", err)
+	}
+	m := NewMatcher(anns, map[string]map[int]string{"test.tf": blockStarts})
+
+	tests := []struct {
+		name        string
+		ruleID      string
+		line        int
+		expectMatch bool
+	}{
+		{name: "inside region", ruleID: "BC002", line: 4, expectMatch: true},
+		{name: "last block inside region", ruleID: "BC002", line: 5, expectMatch: true},
+		{name: "before region", ruleID: "BC002", line: 1, expectMatch: false},
+		{name: "after region", ruleID: "BC002", line: 8, expectMatch: false},
+		{name: "other rule inside region", ruleID: "BC001", line: 4, expectMatch: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finding := &types.Finding{
+				RuleID:      tt.ruleID,
+				OldLocation: &types.FileRange{Filename: "test.tf", Line: tt.line},
+			}
+			result := m.Match(finding)
+			if result.Matched != tt.expectMatch {
+				t.Errorf("expected match=%v, got match=%v", tt.expectMatch, result.Matched)
+			}
+			if result.Matched && result.Annotation.Reason != "generated block" {
+				t.Errorf("matched annotation reason = %q, want %q", result.Annotation.Reason, "generated block")
+			}
+		})
+	}
+}
+
+func TestMatcherMatch_IgnoreRange(t *testing.T) {
+	m := NewMatcher([]*Annotation{
+		{Scope: ScopeRange, Filename: "test.tf", Line: 1, StartLine: 10, EndLine: 20},
+	}, nil)
+
+	for line, want := range map[int]bool{9: false, 10: true, 15: true, 20: true, 21: false} {
+		finding := &types.Finding{
+			RuleID:      "BC001",
+			NewLocation: &types.FileRange{Filename: "test.tf", Line: line},
+		}
+		if got := m.Match(finding).Matched; got != want {
+			t.Errorf("line %d: expected match=%v, got match=%v", line, want, got)
+		}
+	}
+}
+
 func TestCheckGovernance(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -236,3 +302,35 @@ func TestCheckGovernance(t *testing.T) {
 		})
 	}
 }
+
+func TestMatcherNearMisses(t *testing.T) {
+	m := NewMatcher([]*Annotation{
+		// Right before the finding, for another rule
+		{Scope: ScopeBlock, RuleIDs: []string{"BC002"}, Filename: "test.tf", Line: 4},
+		// Right rule, but for another block
+		{Scope: ScopeBlock, RuleIDs: []string{"BC001"}, Filename: "test.tf", Line: 19, BlockLine: 20, Address: "variable.other"},
+		// Right rule, written somewhere else in the file
+		{Scope: ScopeBlock, RuleIDs: []string{"BC001"}, Filename: "test.tf", Line: 30},
+		// Another file
+		{Scope: ScopeFile, RuleIDs: []string{"BC002"}, Filename: "other.tf", Line: 1},
+	}, nil)
+
+	finding := &types.Finding{
+		RuleID:      "BC001",
+		NewLocation: &types.FileRange{Filename: "test.tf", Line: 5},
+	}
+	if result := m.Match(finding); result.Matched {
+		t.Fatal("expected no match")
+	}
+
+	misses := m.NearMisses(finding)
+	if len(misses) != 2 {
+		t.Fatalf("expected 2 near misses, got %+v", misses)
+	}
+	if misses[0].Annotation.Line != 4 || misses[0].Reason != NearMissRule {
+		t.Errorf("misses[0] = line %d %s, want line 4 %s", misses[0].Annotation.Line, misses[0].Reason, NearMissRule)
+	}
+	if misses[1].Annotation.Address != "variable.other" || misses[1].Reason != NearMissAddress {
+		t.Errorf("misses[1] = %s %s, want variable.other %s", misses[1].Annotation.Address, misses[1].Reason, NearMissAddress)
+	}
+}
@@ -2,6 +2,7 @@ package annotation
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,8 +15,14 @@ const annotationPrefix = "tfbreak:"
 
 // Regex patterns for parsing annotations
 var (
-	// Matches: tfbreak:ignore or tfbreak:ignore-file
-	directiveRe = regexp.MustCompile(`tfbreak:(ignore-file|ignore)(?:\s+(.*))?$`)
+	// Matches: tfbreak:ignore, tfbreak:ignore-file, tfbreak:ignore-range or tfbreak:ignore-begin
+	directiveRe = regexp.MustCompile(`tfbreak:(ignore-file|ignore-range|ignore-begin|ignore)(?:\s+(.*))?$`)
+
+	// Matches: tfbreak:ignore-end, which closes the latest tfbreak:ignore-begin
+	rangeEndRe = regexp.MustCompile(`^tfbreak:ignore-end(?:\s|$)`)
+
+	// Matches the trailing start-end line range of tfbreak:ignore-range
+	lineRangeRe = regexp.MustCompile(`(?:^|\s)(\d+)-(\d+)$`)
 
 	// Matches metadata: key="value" (legacy format)
 	metadataRe = regexp.MustCompile(`(\w+)="([^"]*)"`)
@@ -52,6 +59,10 @@ func (p *Parser) ParseFile(filename string, src []byte) ([]*Annotation, error) {
 
 	var annotations []*Annotation
 
+	// ignore-begin annotations waiting for their ignore-end; a begin without
+	// an end is dropped
+	var openRanges []*Annotation
+
 	for _, token := range tokens {
 		if token.Type != hclsyntax.TokenComment {
 			continue
@@ -68,12 +79,28 @@ func (p *Parser) ParseFile(filename string, src []byte) ([]*Annotation, error) {
 			continue
 		}
 
-		ann, err := p.parseAnnotation(text, filename, token.Range.Start.Line)
-		if err != nil {
+		line := token.Range.Start.Line
+		if rangeEndRe.MatchString(text) {
+			if n := len(openRanges); n > 0 {
+				ann := openRanges[n-1]
+				openRanges = openRanges[:n-1]
+				ann.EndLine = line
+				annotations = append(annotations, ann)
+			}
+			continue
+		}
+
+		ann, err := p.parseAnnotation(text, filename, line)
+		if err != nil || ann == nil {
 			// Skip invalid annotations (could log a warning)
 			continue
 		}
 
+		if ann.Scope == ScopeRange && ann.EndLine == 0 {
+			openRanges = append(openRanges, ann)
+			continue
+		}
+
 		annotations = append(annotations, ann)
 	}
 
@@ -99,9 +126,29 @@ func (p *Parser) parseAnnotation(text, filename string, line int) (*Annotation,
 	}
 
 	// Set scope based on directive
-	if directive == "ignore-file" {
+	switch directive {
+	case "ignore-file":
 		ann.Scope = ScopeFile
-	} else {
+	case "ignore-range":
+		// The range is the last field before any trailing reason
+		rulesPart, _, _ := strings.Cut(rest, "#")
+		m := lineRangeRe.FindStringSubmatch(strings.TrimSpace(rulesPart))
+		if m == nil {
+			return nil, nil
+		}
+		start, _ := strconv.Atoi(m[1])
+		end, _ := strconv.Atoi(m[2])
+		if start < 1 || end < start {
+			return nil, nil
+		}
+		ann.Scope = ScopeRange
+		ann.StartLine, ann.EndLine = start, end
+		rest = strings.Replace(rest, strings.TrimSpace(m[0]), "", 1)
+	case "ignore-begin":
+		// EndLine is set when the matching ignore-end is found
+		ann.Scope = ScopeRange
+		ann.StartLine = line
+	default:
 		ann.Scope = ScopeBlock
 	}
 
@@ -363,3 +363,81 @@ output "bar" {
 		t.Error("expected to find output block")
 	}
 }
+
+func TestParseFile_IgnoreRange(t *testing.T) {
+	src := `# tfbreak:ignore-range required-input-added 3-9 # generated inputs
+variable "a" {}
+
+# tfbreak:ignore-begin input-removed, input-type-changed # vendored block
+variable "b" {}
+variable "c" {}
+# tfbreak:ignore-end
+
+# tfbreak:ignore-begin
+variable "d" {}
+`
+	parser := NewParser(newTestResolver())
+	anns, err := parser.ParseFile("test.tf", []byte(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	// The unterminated ignore-begin on line 9 is dropped
+	if len(anns) != 2 {
+		t.Fatalf("expected 2 annotations, got %d", len(anns))
+	}
+
+	byStart := map[int]*Annotation{}
+	for _, ann := range anns {
+		if ann.Scope != ScopeRange {
+			t.Errorf("annotation on line %d has scope %v, want ScopeRange", ann.Line, ann.Scope)
+		}
+		byStart[ann.StartLine] = ann
+	}
+
+	explicit := byStart[3]
+	if explicit == nil || explicit.EndLine != 9 {
+		t.Fatalf("expected ignore-range covering 3-9, got %+v", explicit)
+	}
+	if len(explicit.RuleIDs) != 1 || explicit.RuleIDs[0] != "BC001" {
+		t.Errorf("ignore-range rules = %v, want [BC001]", explicit.RuleIDs)
+	}
+	if explicit.Reason != "generated inputs" {
+		t.Errorf("ignore-range reason = %q, want %q", explicit.Reason, "generated inputs")
+	}
+
+	region := byStart[4]
+	if region == nil || region.EndLine != 7 {
+		t.Fatalf("expected ignore-begin/end covering 4-7, got %+v", region)
+	}
+	if len(region.RuleIDs) != 2 || region.RuleIDs[0] != "BC002" || region.RuleIDs[1] != "BC004" {
+		t.Errorf("ignore-begin rules = %v, want [BC002 BC004]", region.RuleIDs)
+	}
+	if region.Reason != "vendored block" {
+		t.Errorf("ignore-begin reason = %q, want %q", region.Reason, "vendored block")
+	}
+}
+
+func TestParseAnnotation_InvalidIgnoreRange(t *testing.T) {
+	parser := NewParser(newTestResolver())
+
+	for _, text := range []string{
+		"tfbreak:ignore-range required-input-added",
+		"tfbreak:ignore-range required-input-added 9-3",
+		"tfbreak:ignore-range 0-3",
+	} {
+		ann, err := parser.parseAnnotation(text, "test.tf", 1)
+		if err != nil {
+			t.Fatalf("parseAnnotation(%q) failed: %v", text, err)
+		}
+		if ann != nil {
+			t.Errorf("parseAnnotation(%q) = %+v, want nil", text, ann)
+		}
+	}
+
+	// Without rules, the range ignores all rules
+	ann, _ := parser.parseAnnotation("tfbreak:ignore-range 2-4", "test.tf", 1)
+	if ann == nil || ann.StartLine != 2 || ann.EndLine != 4 || len(ann.RuleIDs) != 0 {
+		t.Errorf("parseAnnotation(range without rules) = %+v, want all rules on 2-4", ann)
+	}
+}
@@ -0,0 +1,183 @@
+package annotation
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// SidecarFilename is the name of the module-local ignore file placed next to
+// a module's .tf files
+const SidecarFilename = "module.tfbreak.hcl"
+
+// sidecarFile is the HCL schema of a sidecar file
+type sidecarFile struct {
+	Ignores []*sidecarIgnoreBlock `hcl:"ignore,block"`
+}
+
+// sidecarIgnoreBlock is the HCL schema of an ignore block
+type sidecarIgnoreBlock struct {
+	Rules   []string `hcl:"rules,optional"`
+	Address string   `hcl:"address,optional"`
+	Reason  string   `hcl:"reason,optional"`
+	Ticket  string   `hcl:"ticket,optional"`
+	Expires string   `hcl:"expires,optional"`
+
+	DeclRange hcl.Range `hcl:",def_range"`
+}
+
+// SidecarIgnore is a single ignore entry from a sidecar file
+type SidecarIgnore struct {
+	// RuleIDs is the list of rule IDs to ignore (empty = all rules)
+	RuleIDs []string
+
+	// Address is the targeted block address (e.g., "variable.region",
+	// "aws_s3_bucket.logs"). Empty means the entire module.
+	Address string
+
+	// Reason is the documented reason for ignoring
+	Reason string
+
+	// Ticket is an optional ticket/issue reference
+	Ticket string
+
+	// Expires is an optional expiration date
+	Expires *time.Time
+
+	// Location of the ignore block in the sidecar file
+	Filename string
+	Line     int
+}
+
+// ParseSidecar parses ignore blocks from a sidecar file.
+// Unlike inline annotations, unknown rule names and malformed dates are
+// reported as errors since the sidecar is a configuration file.
+func (p *Parser) ParseSidecar(filename string, src []byte) ([]*SidecarIgnore, error) {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to parse %s: %s", filename, diags.Error())
+	}
+
+	var sf sidecarFile
+	if diags := gohcl.DecodeBody(file.Body, nil, &sf); diags.HasErrors() {
+		return nil, fmt.Errorf("failed to decode %s: %s", filename, diags.Error())
+	}
+
+	ignores := make([]*SidecarIgnore, 0, len(sf.Ignores))
+	for _, block := range sf.Ignores {
+		ignore := &SidecarIgnore{
+			Address:  block.Address,
+			Reason:   block.Reason,
+			Ticket:   block.Ticket,
+			Filename: filename,
+			Line:     block.DeclRange.Start.Line,
+		}
+
+		for _, spec := range block.Rules {
+			if spec == "all" {
+				ignore.RuleIDs = nil
+				break
+			}
+			id, ok := p.resolver.ResolveRuleID(spec)
+			if !ok {
+				return nil, fmt.Errorf("%s:%d: unknown rule %q", filename, ignore.Line, spec)
+			}
+			ignore.RuleIDs = append(ignore.RuleIDs, id)
+		}
+
+		if block.Expires != "" {
+			t, err := time.Parse("2006-01-02", block.Expires)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: invalid expires date %q (must be YYYY-MM-DD)", filename, ignore.Line, block.Expires)
+			}
+			ignore.Expires = &t
+		}
+
+		ignores = append(ignores, ignore)
+	}
+
+	return ignores, nil
+}
+
+// ResolveSidecar converts sidecar ignores into annotations the Matcher understands.
+// blocks maps filename -> block address -> block start line for every file the
+// ignores may apply to. Passing the files of both the old and new module lets
+// address-targeted ignores match findings for removed blocks.
+// Ignores without an address become file-level annotations on every file;
+// ignores whose address is not found produce no annotations.
+func ResolveSidecar(ignores []*SidecarIgnore, blocks map[string]map[string]int) []*Annotation {
+	filenames := make([]string, 0, len(blocks))
+	for filename := range blocks {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	var annotations []*Annotation
+	for _, ignore := range ignores {
+		for _, filename := range filenames {
+			ann := &Annotation{
+				RuleIDs:  ignore.RuleIDs,
+				Reason:   ignore.Reason,
+				Ticket:   ignore.Ticket,
+				Expires:  ignore.Expires,
+				Filename: filename,
+				Address:  ignore.Address,
+			}
+
+			if ignore.Address == "" {
+				ann.Scope = ScopeFile
+				annotations = append(annotations, ann)
+				continue
+			}
+
+			line, ok := blocks[filename][ignore.Address]
+			if !ok {
+				continue
+			}
+			ann.Scope = ScopeBlock
+			ann.Line = line - 1
+			ann.BlockLine = line
+			annotations = append(annotations, ann)
+		}
+	}
+
+	return annotations
+}
+
+// FindBlockAddresses finds the block addresses declared in an HCL file and
+// their starting lines. Addresses use the form "variable.NAME", "output.NAME",
+// "module.NAME", "TYPE.NAME" for resources, and "data.TYPE.NAME" for data sources.
+func FindBlockAddresses(filename string, src []byte) (map[string]int, error) {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	addresses := make(map[string]int)
+
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return addresses, nil
+	}
+
+	for _, block := range body.Blocks {
+		var addr string
+		switch {
+		case block.Type == "resource" && len(block.Labels) == 2:
+			addr = block.Labels[0] + "." + block.Labels[1]
+		case block.Type == "data" && len(block.Labels) == 2:
+			addr = "data." + block.Labels[0] + "." + block.Labels[1]
+		case (block.Type == "variable" || block.Type == "output" || block.Type == "module") && len(block.Labels) == 1:
+			addr = block.Type + "." + block.Labels[0]
+		default:
+			continue
+		}
+		addresses[addr] = block.Range().Start.Line
+	}
+
+	return addresses, nil
+}
@@ -0,0 +1,182 @@
+package annotation
+
+import (
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+func TestParseSidecar(t *testing.T) {
+	src := []byte(`
+ignore {
+  rules   = ["input-removed"]
+  address = "variable.legacy"
+  reason  = "deprecated in v2"
+  expires = "2099-01-01"
+}
+
+ignore {
+  reason = "experimental module"
+}
+`)
+
+	parser := NewParser(newTestResolver())
+	ignores, err := parser.ParseSidecar("module.tfbreak.hcl", src)
+	if err != nil {
+		t.Fatalf("ParseSidecar() error = %v", err)
+	}
+	if len(ignores) != 2 {
+		t.Fatalf("got %d ignores, want 2", len(ignores))
+	}
+
+	first := ignores[0]
+	if len(first.RuleIDs) != 1 || first.RuleIDs[0] != "BC002" {
+		t.Errorf("RuleIDs = %v, want [BC002]", first.RuleIDs)
+	}
+	if first.Address != "variable.legacy" {
+		t.Errorf("Address = %q, want %q", first.Address, "variable.legacy")
+	}
+	if first.Reason != "deprecated in v2" {
+		t.Errorf("Reason = %q", first.Reason)
+	}
+	if first.Expires == nil || first.Expires.Year() != 2099 {
+		t.Errorf("Expires = %v, want 2099-01-01", first.Expires)
+	}
+	if first.Line != 2 {
+		t.Errorf("Line = %d, want 2", first.Line)
+	}
+
+	if second := ignores[1]; second.RuleIDs != nil || second.Address != "" {
+		t.Errorf("second ignore = %+v, want all rules for the whole module", second)
+	}
+}
+
+func TestParseSidecar_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"unknown rule", `ignore { rules = ["no-such-rule"] }`},
+		{"invalid expires", `ignore { expires = "next week" }`},
+		{"unknown attribute", `ignore { target = "variable.x" }`},
+		{"invalid HCL", `ignore {`},
+	}
+
+	parser := NewParser(newTestResolver())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parser.ParseSidecar("module.tfbreak.hcl", []byte(tt.src)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestFindBlockAddresses(t *testing.T) {
+	src := []byte(`variable "region" {}
+
+output "id" {
+  value = "x"
+}
+
+resource "aws_s3_bucket" "logs" {}
+
+data "aws_region" "current" {}
+
+module "vpc" {
+  source = "./vpc"
+}
+
+locals {
+  a = 1
+}
+`)
+
+	addrs, err := FindBlockAddresses("main.tf", src)
+	if err != nil {
+		t.Fatalf("FindBlockAddresses() error = %v", err)
+	}
+
+	want := map[string]int{
+		"variable.region":         1,
+		"output.id":               3,
+		"aws_s3_bucket.logs":      7,
+		"data.aws_region.current": 9,
+		"module.vpc":              11,
+	}
+	if len(addrs) != len(want) {
+		t.Errorf("got %d addresses, want %d: %v", len(addrs), len(want), addrs)
+	}
+	for addr, line := range want {
+		if addrs[addr] != line {
+			t.Errorf("addrs[%q] = %d, want %d", addr, addrs[addr], line)
+		}
+	}
+}
+
+func TestSidecarMatchesLikeInline(t *testing.T) {
+	// variables.tf declares "a" on line 2 and "b" on line 4, with an inline
+	// annotation for "a" on line 1
+	inline := []*Annotation{
+		{Scope: ScopeBlock, RuleIDs: []string{"BC001"}, Filename: "variables.tf", Line: 1, Reason: "inline"},
+	}
+	inlineMatcher := NewMatcher(inline, map[string]map[int]string{
+		"variables.tf": {2: "variable", 4: "variable"},
+	})
+
+	sidecar := ResolveSidecar([]*SidecarIgnore{
+		{RuleIDs: []string{"BC001"}, Address: "variable.a", Reason: "sidecar"},
+	}, map[string]map[string]int{
+		"variables.tf": {"variable.a": 2, "variable.b": 4},
+	})
+	sidecarMatcher := NewMatcher(sidecar, nil)
+
+	findings := []*types.Finding{
+		{RuleID: "BC001", NewLocation: &types.FileRange{Filename: "variables.tf", Line: 2}},
+		{RuleID: "RC006", NewLocation: &types.FileRange{Filename: "variables.tf", Line: 2}},
+	}
+	for _, f := range findings {
+		want := inlineMatcher.Match(f).Matched
+		if got := sidecarMatcher.Match(f).Matched; got != want {
+			t.Errorf("%s at line %d: sidecar matched = %v, inline matched = %v", f.RuleID, f.NewLocation.Line, got, want)
+		}
+	}
+
+	// The address targets only its own block, not later blocks in the file
+	other := &types.Finding{RuleID: "BC001", NewLocation: &types.FileRange{Filename: "variables.tf", Line: 4}}
+	if sidecarMatcher.Match(other).Matched {
+		t.Error("sidecar ignore for variable.a matched variable.b")
+	}
+}
+
+func TestResolveSidecar_RemovedBlock(t *testing.T) {
+	// Removed variables only exist in the old version's files
+	anns := ResolveSidecar([]*SidecarIgnore{
+		{RuleIDs: []string{"BC002"}, Address: "variable.legacy"},
+	}, map[string]map[string]int{
+		"/old/variables.tf": {"variable.legacy": 5},
+		"/new/variables.tf": {},
+	})
+
+	matcher := NewMatcher(anns, nil)
+	finding := &types.Finding{RuleID: "BC002", OldLocation: &types.FileRange{Filename: "/old/variables.tf", Line: 5}}
+	if !matcher.Match(finding).Matched {
+		t.Error("expected sidecar ignore to match removed variable")
+	}
+}
+
+func TestResolveSidecar_ModuleWide(t *testing.T) {
+	anns := ResolveSidecar([]*SidecarIgnore{{Reason: "experimental"}}, map[string]map[string]int{
+		"main.tf":      {},
+		"variables.tf": {},
+	})
+
+	if len(anns) != 2 {
+		t.Fatalf("got %d annotations, want one per file", len(anns))
+	}
+	for _, ann := range anns {
+		if ann.Scope != ScopeFile {
+			t.Errorf("annotation for %s has scope %v, want ScopeFile", ann.Filename, ann.Scope)
+		}
+	}
+}
@@ -30,6 +30,8 @@ const (
 	ScopeBlock Scope = iota
 	// ScopeFile applies to the entire file
 	ScopeFile
+	// ScopeRange applies to the lines from StartLine to EndLine
+	ScopeRange
 )
 
 // Annotation represents a parsed tfbreak ignore annotation
@@ -53,9 +55,19 @@ type Annotation struct {
 	Filename string
 	Line     int
 
-	// BlockLine is the line of the block this annotation applies to (for ScopeBlock)
-	// This is set during matching, not parsing
+	// BlockLine is the line of the block this annotation applies to (for ScopeBlock).
+	// It is set for sidecar ignores resolved from a block address; when set,
+	// only findings located on exactly this line match.
 	BlockLine int
+
+	// Address is the block address targeted by a sidecar ignore (empty for inline annotations)
+	Address string
+
+	// StartLine and EndLine are the inclusive line range covered by a
+	// ScopeRange annotation, from tfbreak:ignore-range or an
+	// ignore-begin/ignore-end pair
+	StartLine int
+	EndLine   int
 }
 
 // IsExpired returns true if the annotation has an expiration date that has passed
@@ -66,6 +78,11 @@ func (a *Annotation) IsExpired() bool {
 	return time.Now().After(*a.Expires)
 }
 
+// CoversLine returns true if this is a range annotation that covers line
+func (a *Annotation) CoversLine(line int) bool {
+	return a.Scope == ScopeRange && line >= a.StartLine && line <= a.EndLine
+}
+
 // MatchesRule returns true if this annotation applies to the given rule ID
 func (a *Annotation) MatchesRule(ruleID string) bool {
 	// Empty list means all rules
@@ -0,0 +1,165 @@
+// Package baseline records the findings of a check so later checks can
+// ignore them and fail only on new breaking changes.
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// Version is the version of the baseline file format
+const Version = 1
+
+// IgnoreReason is the reason recorded on findings matched by a baseline
+const IgnoreReason = "baselined"
+
+// Entry is a known finding
+type Entry struct {
+	// RuleID is the rule that reported the finding
+	RuleID string `json:"rule_id"`
+
+	// Module is the module path in recursive mode, empty otherwise
+	Module string `json:"module,omitempty"`
+
+	// File is the base name of the file the finding refers to
+	File string `json:"file,omitempty"`
+
+	// Message is the finding's message with whitespace normalized
+	Message string `json:"message"`
+
+	// Fingerprint identifies the finding; entries are matched by it.
+	// See types.Finding.Fingerprint.
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Baseline is a set of known findings
+type Baseline struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"findings"`
+
+	known map[string]bool
+}
+
+// New creates a baseline of the findings that are not already ignored.
+// Entries are sorted and deduplicated so the file diffs cleanly.
+func New(findings []*types.Finding) *Baseline {
+	b := &Baseline{Version: Version, Entries: []Entry{}}
+	seen := make(map[string]bool)
+	for _, f := range findings {
+		if f.Ignored {
+			continue
+		}
+		e := entryFor(f)
+		if seen[e.Fingerprint] {
+			continue
+		}
+		seen[e.Fingerprint] = true
+		b.Entries = append(b.Entries, e)
+	}
+	sort.Slice(b.Entries, func(i, j int) bool {
+		a, c := b.Entries[i], b.Entries[j]
+		if a.Module != c.Module {
+			return a.Module < c.Module
+		}
+		if a.File != c.File {
+			return a.File < c.File
+		}
+		if a.RuleID != c.RuleID {
+			return a.RuleID < c.RuleID
+		}
+		return a.Message < c.Message
+	})
+	b.index()
+	return b
+}
+
+// Load reads a baseline file written by Save
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	if b.Version != Version {
+		return nil, fmt.Errorf("baseline %s has unsupported version %d (expected %d)", path, b.Version, Version)
+	}
+	for i, e := range b.Entries {
+		if e.Fingerprint == "" {
+			return nil, fmt.Errorf("baseline %s: finding %d has no fingerprint", path, i)
+		}
+	}
+	b.index()
+	return &b, nil
+}
+
+// Save writes the baseline to path as indented JSON
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	return nil
+}
+
+// Match reports whether the finding is in the baseline
+func (b *Baseline) Match(f *types.Finding) bool {
+	return b.known[f.Fingerprint()]
+}
+
+// Apply marks the findings in the baseline as ignored and returns how many
+// it marked. Call Compute on the result afterwards.
+func (b *Baseline) Apply(result *types.CheckResult) int {
+	marked := 0
+	for _, f := range result.Findings {
+		if !f.Ignored && b.Match(f) {
+			f.Ignored = true
+			f.IgnoreReason = IgnoreReason
+			marked++
+		}
+	}
+	return marked
+}
+
+// index builds the fingerprint set used by Match
+func (b *Baseline) index() {
+	b.known = make(map[string]bool, len(b.Entries))
+	for _, e := range b.Entries {
+		b.known[e.Fingerprint] = true
+	}
+}
+
+// entryFor builds the baseline entry of a finding. The file is reduced to
+// its base name and the message's whitespace is collapsed, as they are in
+// the finding's fingerprint.
+func entryFor(f *types.Finding) Entry {
+	e := Entry{
+		RuleID:      f.RuleID,
+		Module:      filepath.ToSlash(f.Module),
+		Message:     normalizeMessage(f.Message),
+		Fingerprint: f.Fingerprint(),
+	}
+	if f.NewLocation != nil {
+		e.File = filepath.Base(f.NewLocation.Filename)
+	} else if f.OldLocation != nil {
+		e.File = filepath.Base(f.OldLocation.Filename)
+	}
+	return e
+}
+
+// normalizeMessage collapses runs of whitespace into single spaces
+func normalizeMessage(message string) string {
+	return strings.Join(strings.Fields(message), " ")
+}
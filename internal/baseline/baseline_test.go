@@ -0,0 +1,137 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+func finding(ruleID, file string, line int, message string) *types.Finding {
+	return types.NewFinding(ruleID, "", types.SeverityError, message).
+		WithOldLocation(&types.FileRange{Filename: file, Line: line})
+}
+
+func TestSaveLoad_RoundTrip(t *testing.T) {
+	ignored := finding("BC002", "/tmp/a/outputs.tf", 4, `Output "x" was removed`)
+	ignored.Ignored = true
+	b := New([]*types.Finding{
+		finding("BC002", "/tmp/a/variables.tf", 3, `Variable "b" was removed`),
+		finding("BC001", "/tmp/a/variables.tf", 9, `New required variable "c" has no default`),
+		finding("BC002", "/tmp/a/variables.tf", 3, `Variable "b" was removed`),
+		ignored,
+	})
+
+	// Already ignored findings and duplicates are left out, and entries are sorted
+	if len(b.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", b.Entries)
+	}
+	if b.Entries[0].RuleID != "BC001" || b.Entries[1].RuleID != "BC002" {
+		t.Errorf("unexpected entry order: %+v", b.Entries)
+	}
+	if b.Entries[0].File != "variables.tf" {
+		t.Errorf("File = %q, want base name", b.Entries[0].File)
+	}
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := b.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(loaded.Entries) != 2 || loaded.Entries[1].Fingerprint != b.Entries[1].Fingerprint {
+		t.Errorf("loaded entries = %+v, want %+v", loaded.Entries, b.Entries)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	b := New([]*types.Finding{
+		finding("BC002", "/tmp/worktree-1/variables.tf", 3, `Variable "b" was removed`),
+	})
+
+	tests := []struct {
+		name string
+		f    *types.Finding
+		want bool
+	}{
+		{"same finding", finding("BC002", "/tmp/worktree-1/variables.tf", 3, `Variable "b" was removed`), true},
+		{"moved line and checkout", finding("BC002", "/tmp/worktree-2/variables.tf", 40, `Variable "b" was removed`), true},
+		{"reflowed message", finding("BC002", "variables.tf", 3, "Variable \"b\"\n  was removed "), true},
+		{"other message", finding("BC002", "variables.tf", 3, `Variable "c" was removed`), false},
+		{"other file", finding("BC002", "main.tf", 3, `Variable "b" was removed`), false},
+		{"other rule", finding("BC001", "variables.tf", 3, `Variable "b" was removed`), false},
+	}
+	for _, tt := range tests {
+		if got := b.Match(tt.f); got != tt.want {
+			t.Errorf("%s: Match() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// Modules in recursive mode are told apart
+	inModule := finding("BC002", "variables.tf", 3, `Variable "b" was removed`)
+	inModule.Module = "modules/network"
+	if b.Match(inModule) {
+		t.Error("expected a finding in another module not to match")
+	}
+}
+
+func TestApply(t *testing.T) {
+	b := New([]*types.Finding{finding("BC002", "variables.tf", 3, `Variable "b" was removed`)})
+
+	result := types.NewCheckResult("old", "new", types.SeverityError)
+	result.AddFinding(finding("BC002", "variables.tf", 3, `Variable "b" was removed`))
+	result.AddFinding(finding("BC002", "variables.tf", 5, `Variable "d" was removed`))
+
+	if marked := b.Apply(result); marked != 1 {
+		t.Errorf("Apply() = %d, want 1", marked)
+	}
+	if !result.Findings[0].Ignored || result.Findings[0].IgnoreReason != IgnoreReason {
+		t.Errorf("expected the known finding to be ignored as %q, got %+v", IgnoreReason, result.Findings[0])
+	}
+	result.Compute()
+	if result.Result != "FAIL" || result.Summary.Error != 1 || result.Summary.Ignored != 1 {
+		t.Errorf("result = %s, summary = %+v, want FAIL with 1 error and 1 ignored", result.Result, result.Summary)
+	}
+}
+
+func TestMatch_SharesFindingFingerprint(t *testing.T) {
+	f := finding("BC002", "/tmp/a/variables.tf", 3, `Variable "b" was removed`)
+	f.Module = "modules/network"
+	b := New([]*types.Finding{f})
+
+	if b.Entries[0].Fingerprint != f.Fingerprint() {
+		t.Errorf("entry fingerprint = %q, want the finding's %q", b.Entries[0].Fingerprint, f.Fingerprint())
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"invalid.json":     `{`,
+		"version.json":     `{"version": 2, "findings": []}`,
+		"fingerprint.json": `{"version": 1, "findings": [{"rule_id": "BC001", "message": "m"}]}`,
+	}
+	wants := map[string]string{
+		"invalid.json":     "failed to parse baseline",
+		"version.json":     "unsupported version 2",
+		"fingerprint.json": "finding 0 has no fingerprint",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := Load(path)
+		if err == nil || !strings.Contains(err.Error(), wants[name]) {
+			t.Errorf("%s: Load() error = %v, want %q", name, err, wants[name])
+		}
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected an error for a missing baseline")
+	}
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseAgainst splits an --against value of the form <url>@<ref[:path]>
+// into the repository URL and the ref. The user part of the URL
+// (git@host:org/repo, https://user@host/repo) is skipped before looking
+// for the separator, and the first @ after it is used, so a path after
+// the ref may itself contain @.
+func parseAgainst(spec string) (url, ref string, err error) {
+	start := againstPathStart(spec)
+	idx := strings.Index(spec[start:], "@")
+	if idx == -1 {
+		return "", "", fmt.Errorf("--against must be <url>@<ref>, got %q", spec)
+	}
+	idx += start
+
+	url, ref = spec[:idx], spec[idx+1:]
+	if url == "" || ref == "" {
+		return "", "", fmt.Errorf("--against must be <url>@<ref>, got %q", spec)
+	}
+	return url, ref, nil
+}
+
+// againstPathStart returns the index where the repository path of a git URL
+// begins, after any scheme, user, and host
+func againstPathStart(spec string) int {
+	// scheme://[user@]host/path
+	if scheme, rest, ok := strings.Cut(spec, "://"); ok {
+		if slash := strings.Index(rest, "/"); slash != -1 {
+			return len(scheme) + len("://") + slash
+		}
+		return len(spec)
+	}
+
+	// [user@]host:path, as git treats a colon before any slash, except a
+	// Windows drive letter (C:\...)
+	colon := strings.Index(spec, ":")
+	slash := strings.IndexAny(spec, `/\`)
+	if colon > 1 && (slash == -1 || colon < slash) {
+		return colon + 1
+	}
+
+	// A local path
+	return 0
+}
+
+// applyAgainst expands --against into the --repo and --base flags of the
+// mixed mode, comparing the local directory against the remote ref
+func applyAgainst() error {
+	url, ref, err := parseAgainst(againstFlag)
+	if err != nil {
+		return err
+	}
+	repoFlag, baseFlag = url, ref
+	return nil
+}
@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestParseAgainst(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantURL string
+		wantRef string
+		wantErr bool
+	}{
+		{
+			name:    "https URL",
+			input:   "https://github.com/org/mod@v1.0.0",
+			wantURL: "https://github.com/org/mod",
+			wantRef: "v1.0.0",
+		},
+		{
+			name:    "https URL with user",
+			input:   "https://user@github.com/org/mod.git@main",
+			wantURL: "https://user@github.com/org/mod.git",
+			wantRef: "main",
+		},
+		{
+			name:    "ssh URL",
+			input:   "ssh://git@github.com/org/mod.git@main",
+			wantURL: "ssh://git@github.com/org/mod.git",
+			wantRef: "main",
+		},
+		{
+			name:    "scp-like URL",
+			input:   "git@github.com:org/mod.git@v2",
+			wantURL: "git@github.com:org/mod.git",
+			wantRef: "v2",
+		},
+		{
+			name:    "ref with path",
+			input:   "git@github.com:org/mod.git@v2:modules/vpc",
+			wantURL: "git@github.com:org/mod.git",
+			wantRef: "v2:modules/vpc",
+		},
+		{
+			name:    "path containing @",
+			input:   "https://github.com/org/mod@main:modules/@scoped",
+			wantURL: "https://github.com/org/mod",
+			wantRef: "main:modules/@scoped",
+		},
+		{
+			name:    "file URL",
+			input:   "file:///srv/git/mod@main",
+			wantURL: "file:///srv/git/mod",
+			wantRef: "main",
+		},
+		{
+			name:    "local path",
+			input:   "/srv/git/mod@main:src",
+			wantURL: "/srv/git/mod",
+			wantRef: "main:src",
+		},
+		{
+			name:    "windows path",
+			input:   `C:\git\mod@main`,
+			wantURL: `C:\git\mod`,
+			wantRef: "main",
+		},
+		{
+			name:    "no ref",
+			input:   "https://github.com/org/mod",
+			wantErr: true,
+		},
+		{
+			name:    "only the user part has @",
+			input:   "git@github.com:org/mod.git",
+			wantErr: true,
+		},
+		{
+			name:    "empty ref",
+			input:   "https://github.com/org/mod@",
+			wantErr: true,
+		},
+		{
+			name:    "empty URL",
+			input:   "@main",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, ref, err := parseAgainst(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseAgainst(%q) = %q, %q, want error", tt.input, url, ref)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseAgainst(%q) error = %v", tt.input, err)
+			}
+			if url != tt.wantURL || ref != tt.wantRef {
+				t.Errorf("parseAgainst(%q) = %q, %q, want %q, %q", tt.input, url, ref, tt.wantURL, tt.wantRef)
+			}
+		})
+	}
+}
+
+func TestValidateCheckArgs_Against(t *testing.T) {
+	origAgainst, origBase, origHead, origRepo, origPR := againstFlag, baseFlag, headFlag, repoFlag, prFlag
+	defer func() {
+		againstFlag, baseFlag, headFlag, repoFlag, prFlag = origAgainst, origBase, origHead, origRepo, origPR
+	}()
+	baseFlag, headFlag, repoFlag, prFlag = "", "", "", 0
+
+	againstFlag = "https://github.com/org/mod@v1"
+	for _, args := range [][]string{nil, {"./new"}} {
+		if err := validateCheckArgs(&cobra.Command{}, args); err != nil {
+			t.Errorf("validateCheckArgs(%v) error = %v", args, err)
+		}
+	}
+	if err := validateCheckArgs(&cobra.Command{}, []string{"./a", "./b"}); err == nil {
+		t.Error("expected error for two positional arguments with --against")
+	}
+
+	againstFlag = "https://github.com/org/mod"
+	if err := validateCheckArgs(&cobra.Command{}, nil); err == nil || !contains(err.Error(), "<url>@<ref>") {
+		t.Errorf("expected format error, got %v", err)
+	}
+
+	againstFlag = "https://github.com/org/mod@" + lastTagRef
+	if err := validateCheckArgs(&cobra.Command{}, nil); err == nil {
+		t.Error("expected error for --against with " + lastTagRef)
+	}
+
+	againstFlag = "https://github.com/org/mod@v1"
+	baseFlag = "main"
+	if err := validateCheckArgs(&cobra.Command{}, nil); err == nil {
+		t.Error("expected error for --against with --base")
+	}
+	baseFlag, repoFlag = "", "https://github.com/org/other"
+	if err := validateCheckArgs(&cobra.Command{}, nil); err == nil {
+		t.Error("expected error for --against with --repo")
+	}
+}
+
+func TestAgainst_ComparesWorkingDirAgainstRemoteRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	sourceDir := t.TempDir()
+	runTestGit(t, sourceDir, "init", "-b", "main")
+	runTestGit(t, sourceDir, "config", "user.email", "test@test.com")
+	runTestGit(t, sourceDir, "config", "user.name", "Test User")
+	writeTestFile(t, filepath.Join(sourceDir, "modules", "vpc", "main.tf"), `variable "a" {}`)
+	runTestGit(t, sourceDir, "add", ".")
+	runTestGit(t, sourceDir, "commit", "-m", "Initial commit")
+	runTestGit(t, sourceDir, "tag", "v1.0.0")
+
+	origAgainst, origBase, origRepo := againstFlag, baseFlag, repoFlag
+	defer func() { againstFlag, baseFlag, repoFlag = origAgainst, origBase, origRepo }()
+
+	againstFlag = "file://" + sourceDir + "@v1.0.0:modules/vpc"
+	baseFlag, repoFlag = "", ""
+	if err := applyAgainst(); err != nil {
+		t.Fatalf("applyAgainst() error = %v", err)
+	}
+	if repoFlag != "file://"+sourceDir || baseFlag != "v1.0.0:modules/vpc" {
+		t.Fatalf("applyAgainst() set --repo %q --base %q", repoFlag, baseFlag)
+	}
+	if mode := determineMode(); mode != modeMixed {
+		t.Fatalf("determineMode() = %v, want modeMixed", mode)
+	}
+
+	// Without new_dir the current directory is compared
+	t.Chdir(t.TempDir())
+	oldDir, newDir, cleanup, err := resolveDirectories(context.Background(), modeMixed, nil)
+	if err != nil {
+		t.Fatalf("resolveDirectories() error = %v", err)
+	}
+	defer cleanup()
+
+	if newDir != "." {
+		t.Errorf("newDir = %q, want .", newDir)
+	}
+	if _, err := os.Stat(filepath.Join(oldDir, "main.tf")); err != nil {
+		t.Errorf("expected main.tf from the remote ref in %s: %v", oldDir, err)
+	}
+}
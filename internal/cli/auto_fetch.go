@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jokarl/tfbreak-core/internal/git"
+)
+
+// autoFetchRemote is the remote --auto-fetch fetches missing refs from
+const autoFetchRemote = "origin"
+
+// resolveLocalRef checks that ref exists in the repository at repoRoot. With
+// --auto-fetch, a ref missing from a shallow clone is fetched from origin and
+// resolved once more; there is no second fetch, so a ref the remote does not
+// have either fails instead of looping.
+func resolveLocalRef(ctx context.Context, repoRoot, ref string) error {
+	_, err := git.ResolveRef(repoRoot, ref)
+	if err == nil {
+		return nil
+	}
+
+	var notFound *git.ErrRefNotFound
+	if !autoFetchFlag || !errors.As(err, &notFound) || !notFound.IsShallow {
+		return formatRefNotFoundError(ref, repoRoot, err)
+	}
+
+	if verboseFlag {
+		fmt.Fprintf(os.Stderr, "Ref %s not found in shallow clone, fetching it from %s\n", ref, autoFetchRemote)
+	}
+
+	ctx, cancel := remoteContext(ctx)
+	defer cancel()
+	if fetchErr := git.FetchRef(ctx, repoRoot, autoFetchRemote, ref); fetchErr != nil {
+		return formatAutoFetchError(ref, repoRoot, fetchErr)
+	}
+
+	if _, err := git.ResolveRef(repoRoot, ref); err != nil {
+		return formatRefNotFoundError(ref, repoRoot, err)
+	}
+	return nil
+}
+
+// formatAutoFetchError explains why --auto-fetch could not fetch ref
+func formatAutoFetchError(ref, repoRoot string, err error) error {
+	if timeoutErr := formatRemoteTimeoutError(autoFetchRemote, err); timeoutErr != nil {
+		return timeoutErr
+	}
+
+	var notConfigured *git.ErrRemoteNotConfigured
+	if errors.As(err, &notConfigured) {
+		return fmt.Errorf(`Error: ref '%s' not found in shallow clone, and --auto-fetch cannot fetch it: %w
+
+Add the remote, or fetch the ref yourself:
+
+  git fetch <remote> %s`, ref, err, ref)
+	}
+
+	var notFound *git.ErrRefNotFound
+	if errors.As(err, &notFound) {
+		return fmt.Errorf(`Error: ref '%s' not found in repository or on remote '%s'
+
+Check that the ref exists:
+  git ls-remote %s %s`, ref, autoFetchRemote, autoFetchRemote, ref)
+	}
+
+	return fmt.Errorf("Error: ref '%s' not found in shallow clone, and fetching it from '%s' failed: %w", ref, autoFetchRemote, err)
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestResolveLocalRef_AutoFetch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	sourceDir := t.TempDir()
+	runTestGit(t, sourceDir, "init", "-b", "main")
+	runTestGit(t, sourceDir, "config", "user.email", "test@test.com")
+	runTestGit(t, sourceDir, "config", "user.name", "Test User")
+	writeTestFile(t, filepath.Join(sourceDir, "main.tf"), `variable "a" {}`)
+	runTestGit(t, sourceDir, "add", ".")
+	runTestGit(t, sourceDir, "commit", "-m", "Initial commit")
+	runTestGit(t, sourceDir, "tag", "v1.0.0")
+	writeTestFile(t, filepath.Join(sourceDir, "main.tf"), `variable "b" {}`)
+	runTestGit(t, sourceDir, "commit", "-am", "Second commit")
+
+	cloneDir := t.TempDir()
+	runTestGit(t, cloneDir, "clone", "--quiet", "--depth=1", "--no-tags", "file://"+sourceDir, ".")
+
+	origAutoFetch := autoFetchFlag
+	defer func() { autoFetchFlag = origAutoFetch }()
+
+	// Without --auto-fetch the shallow clone hint is shown
+	autoFetchFlag = false
+	err := resolveLocalRef(context.Background(), cloneDir, "v1.0.0")
+	if err == nil || !contains(err.Error(), "shallow clone") {
+		t.Fatalf("expected shallow clone error, got %v", err)
+	}
+
+	autoFetchFlag = true
+	if err := resolveLocalRef(context.Background(), cloneDir, "v1.0.0"); err != nil {
+		t.Fatalf("resolveLocalRef() with --auto-fetch error = %v", err)
+	}
+
+	// A ref the remote does not have either fails after a single fetch
+	err = resolveLocalRef(context.Background(), cloneDir, "v9.9.9")
+	if err == nil || !contains(err.Error(), "on remote 'origin'") {
+		t.Fatalf("expected remote ref not found error, got %v", err)
+	}
+
+	// Without an origin remote there is nothing to fetch from
+	runTestGit(t, cloneDir, "remote", "remove", "origin")
+	err = resolveLocalRef(context.Background(), cloneDir, "v2.0.0")
+	if err == nil || !contains(err.Error(), "is not configured") {
+		t.Fatalf("expected remote not configured error, got %v", err)
+	}
+}
+
+func TestValidateCheckArgs_AutoFetch(t *testing.T) {
+	origBase, origRepo, origAutoFetch := baseFlag, repoFlag, autoFetchFlag
+	defer func() { baseFlag, repoFlag, autoFetchFlag = origBase, origRepo, origAutoFetch }()
+
+	autoFetchFlag = true
+	baseFlag, repoFlag = "", ""
+	if err := validateCheckArgs(&cobra.Command{}, nil); err == nil {
+		t.Error("expected error for --auto-fetch without --base")
+	}
+
+	baseFlag, repoFlag = "main", "https://example.com/repo.git"
+	if err := validateCheckArgs(&cobra.Command{}, nil); err == nil {
+		t.Error("expected error for --auto-fetch with --repo")
+	}
+
+	baseFlag, repoFlag = "main", ""
+	if err := validateCheckArgs(&cobra.Command{}, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -1,22 +1,30 @@
 package cli
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/jokarl/tfbreak-core/internal/annotation"
+	"github.com/jokarl/tfbreak-core/internal/baseline"
 	"github.com/jokarl/tfbreak-core/internal/config"
 	"github.com/jokarl/tfbreak-core/internal/git"
 	"github.com/jokarl/tfbreak-core/internal/loader"
 	"github.com/jokarl/tfbreak-core/internal/output"
+	"github.com/jokarl/tfbreak-core/internal/parallel"
 	"github.com/jokarl/tfbreak-core/internal/pathfilter"
 	"github.com/jokarl/tfbreak-core/internal/rules"
 	"github.com/jokarl/tfbreak-core/internal/types"
@@ -25,25 +33,70 @@ import (
 
 var (
 	// Output flags
-	formatFlag  string
-	outputFlag  string
-	colorFlag   string
-	quietFlag   bool
-	verboseFlag bool
+	formatFlag    string
+	outputFlag    string
+	outputDirFlag string
+	colorFlag     string
+	quietFlag     bool
+	verboseFlag   bool
+
+	explainExitCodeFlag    bool
+	explainSuppressionFlag bool
+	bitmaskExitFlag        bool
+	compareCountFlag       bool
+	hideIgnoredFlag        bool
+	noStepSummaryFlag      bool
+
+	// JSON layout flags
+	jsonIndentFlag  bool
+	compactJSONFlag bool
+
+	// SARIF flags
+	sarifRelativeToFlag string
+	sarifCategoryFlag   string
 
 	// Policy flags
-	failOnFlag    string
-	enableFlag    []string
-	disableFlag   []string
-	severityFlags []string
-	onlyFlag      []string
+	failOnFlag                string
+	treatWarningsAsErrorsFlag bool
+	treatRiskyAsErrorsFlag    bool
+	strictFlag                bool
+	enableFlag                []string
+	disableFlag               []string
+	severityFlags             []string
+	onlyFlag                  []string
+	rulesFromFlag             string
+
+	// Moved block validation flags
+	resourceMoveCheckFlag bool
+
+	// Lock file comparison flags
+	compareProvidersLockStrictFlag bool
+
+	// Rule scope flags
+	compareModuleInterfaceOnlyFlag bool
+	compareScopeFlag               string
+	compareIgnoreWhitespaceFlag    bool
+
+	// Incremental gating flags
+	onlyChangedRulesFlag  string
+	compareJSONOutputFlag string
+	baselineFlag          string
+	writeBaselineFlag     bool
+
+	// Concurrency flags
+	parallelismFlag int
+	timeoutFlag     time.Duration
 
 	// Path flags
-	configFlag    string
-	includeFlag   []string
-	excludeFlag   []string
-	filterFlag    string
-	recursiveFlag bool
+	configFlag      string
+	profileFlag     string
+	printConfigFlag string
+	includeFlag     []string
+	excludeFlag     []string
+	filterFlag      string
+	recursiveFlag   bool
+	tfvarsDirFlag   string
+	fileFlag        bool
 
 	// Annotation flags
 	noAnnotationsFlag bool
@@ -51,11 +104,26 @@ var (
 
 	// Output enhancement flags
 	includeRemediationFlag bool
+	showIDsFlag            bool
+
+	// Rename detection flags
+	minConfidenceFlag float64
+
+	// Deprecation marker flags
+	moduleVersionFlag string
 
 	// Git ref flags
-	baseFlag string
-	headFlag string
-	repoFlag string
+	baseFlag                  string
+	headFlag                  string
+	repoFlag                  string
+	againstFlag               string
+	prFlag                    int
+	prRemoteFlag              string
+	allowDubiousOwnershipFlag bool
+	remoteTimeoutFlag         time.Duration
+	mergeBaseFlag             bool
+	autoFetchFlag             bool
+	strictRefsFlag            bool
 )
 
 var checkCmd = &cobra.Command{
@@ -69,6 +137,7 @@ Git ref comparison modes:
   tfbreak check --base <ref[:path]> [new_dir]       Compare working dir against local ref
   tfbreak check --base <ref[:path]> --head <ref[:path]>    Compare two local refs
   tfbreak check --repo <url> --base <ref[:path]> --head <ref[:path]>  Compare two remote refs
+  tfbreak check --against <url>@<ref[:path]> [new_dir]   Compare working dir against remote ref
 
 The ref:path syntax (like git show) specifies a subdirectory within the ref.
 If no path is specified, the repository root is used.
@@ -79,7 +148,8 @@ Examples:
   tfbreak check --base main:modules/vpc ./     Compare modules/vpc at main vs ./
   tfbreak check --base v1.0.0 --head v2.0.0    Compare two local tags
   tfbreak check --base v1:src --head v2:src    Compare src/ directory between tags
-  tfbreak check --repo https://github.com/org/mod --base v1 --head v2  Remote mode`,
+  tfbreak check --repo https://github.com/org/mod --base v1 --head v2  Remote mode
+  tfbreak check --against https://github.com/org/mod@v1 ./  Compare ./ against remote tag v1`,
 	Args: validateCheckArgs,
 	RunE: runCheck,
 }
@@ -88,25 +158,60 @@ func init() {
 	rootCmd.AddCommand(checkCmd)
 
 	// Output flags
-	checkCmd.Flags().StringVar(&formatFlag, "format", "", "Output format: text, json, compact, checkstyle, junit, sarif")
-	checkCmd.Flags().StringVarP(&outputFlag, "output", "o", "", "Write output to file instead of stdout")
+	checkCmd.Flags().StringVar(&formatFlag, "format", "", "Output format: text, json, compact, checkstyle, junit, sarif, ndjson, jsonl, rdjson, gitlab, markdown")
+	checkCmd.Flags().StringVarP(&outputFlag, "output", "o", "", "Write output to a file path, file:// URI, or - for stdout")
+	checkCmd.Flags().StringVar(&outputDirFlag, "output-dir", "", "Write one report per module plus index.json to this directory (requires --recursive)")
 	checkCmd.Flags().StringVar(&colorFlag, "color", "", "Color mode: auto, always, never")
 	checkCmd.Flags().BoolVarP(&quietFlag, "quiet", "q", false, "Suppress non-error output")
 	checkCmd.Flags().BoolVarP(&verboseFlag, "verbose", "v", false, "Verbose output")
+	checkCmd.Flags().BoolVar(&explainExitCodeFlag, "explain-exit-code", false, "Print why the check passed or failed to stderr")
+	checkCmd.Flags().BoolVar(&bitmaskExitFlag, "bitmask-exit", false, "Exit with 64 plus a bitmask of the severities present instead of 0 or 1: 1=NOTICE, 2=WARNING, 4=risky, 8=ERROR, 16=DEPRECATION")
+	checkCmd.Flags().BoolVar(&explainSuppressionFlag, "explain-suppression", false, "Print to stderr which annotation suppressed each finding, or why none did")
+	checkCmd.Flags().BoolVar(&hideIgnoredFlag, "hide-ignored", false, "Leave ignored findings out of the output; the summary still counts them")
+	checkCmd.Flags().BoolVar(&noStepSummaryFlag, "no-step-summary", false, "Do not append a Markdown summary to $GITHUB_STEP_SUMMARY in GitHub Actions")
+	checkCmd.Flags().BoolVar(&compareCountFlag, "compare-count", false, "Print a tally of added, removed, and changed variables, outputs, resources, and modules to stderr (always on with --verbose)")
+	checkCmd.Flags().BoolVar(&jsonIndentFlag, "json-indent", false, "Indent JSON and SARIF output (default when writing to a terminal)")
+	checkCmd.Flags().BoolVar(&compactJSONFlag, "compact-json", false, "Write JSON and SARIF output on a single line (default when not writing to a terminal)")
+	checkCmd.Flags().StringVar(&sarifRelativeToFlag, "sarif-relative-to", "", "Emit SARIF, GitLab, and Markdown locations relative to the git repository containing this directory")
+	checkCmd.Flags().Lookup("sarif-relative-to").NoOptDefVal = "."
+	checkCmd.Flags().StringVar(&sarifCategoryFlag, "sarif-category", "", "Code scanning category for SARIF output; per-module reports append the module path (default \"tfbreak\" with --output-dir)")
 
 	// Policy flags
-	checkCmd.Flags().StringVar(&failOnFlag, "minimum-failure-severity", "", "Minimum severity to fail: ERROR, WARNING, NOTICE")
+	checkCmd.Flags().StringVar(&failOnFlag, "minimum-failure-severity", "", "Minimum severity to fail: ERROR, WARNING, DEPRECATION, NOTICE")
+	checkCmd.Flags().BoolVar(&treatWarningsAsErrorsFlag, "treat-warnings-as-errors", false, "Fail on any WARNING finding, regardless of the failure threshold; reported severities are unchanged")
+	checkCmd.Flags().BoolVar(&treatRiskyAsErrorsFlag, "treat-risky-as-errors", false, "Fail on any WARNING finding in the risky category, regardless of the failure threshold")
+	checkCmd.Flags().BoolVar(&strictFlag, "strict", false, "Fail instead of warning when a compared directory has no Terraform files or declares nothing")
 	checkCmd.Flags().StringSliceVar(&enableFlag, "enable-rule", nil, "Enable rules by ID or name (can be repeated)")
 	checkCmd.Flags().StringSliceVar(&disableFlag, "disable-rule", nil, "Disable rules by ID or name (can be repeated)")
 	checkCmd.Flags().StringSliceVar(&severityFlags, "severity", nil, "Override rule severity (RULE=SEV)")
 	checkCmd.Flags().StringSliceVar(&onlyFlag, "only", nil, "Run only these rules by ID or name (can be repeated)")
+	checkCmd.Flags().StringVar(&rulesFromFlag, "rules-from", "", "Run only the rules listed in this file, one ID, name, or ID prefix (BC*) per line")
+	checkCmd.Flags().BoolVar(&resourceMoveCheckFlag, "resource-move-check", false, "Run only the moved block validation rules (BC102-BC104)")
+	checkCmd.Flags().BoolVar(&compareModuleInterfaceOnlyFlag, "compare-module-interface-only", false, "Run only rules for the module's public interface (variables, outputs, versions, module calls); skip resource and moved block rules and plugins")
+	checkCmd.Flags().StringVar(&compareScopeFlag, "compare-scope", string(rules.ScopeModule), "Kind of module compared, which adjusts the rules that apply and their severities: module (reusable) or root (deployable)")
+	checkCmd.Flags().BoolVar(&compareIgnoreWhitespaceFlag, "compare-ignore-whitespace", true, "Ignore whitespace, comment, and trailing comma changes in variable types and validation conditions")
+	checkCmd.Flags().BoolVar(&compareProvidersLockStrictFlag, "compare-providers-lock-strict", false, "Report provider hash changes in .terraform.lock.hcl at the same version (RC202)")
+
+	checkCmd.Flags().StringVar(&onlyChangedRulesFlag, "only-changed-rules", "", "Report only findings whose rule did not fire at the same location in this previous JSON result")
+	checkCmd.Flags().StringVar(&compareJSONOutputFlag, "compare-json-output", "", "Fail only on findings missing from this stored JSON result of a known-good run; findings it contains are reported as ignored")
+	checkCmd.Flags().StringVar(&baselineFlag, "baseline", "", "Report findings recorded in this baseline file as ignored, so only new findings fail the check")
+	checkCmd.Flags().BoolVar(&writeBaselineFlag, "write-baseline", false, "Record the current findings in the --baseline file instead of reading it")
+
+	// Concurrency flags
+	checkCmd.Flags().IntVar(&parallelismFlag, "parallelism", 0, "Maximum number of rules, modules, and plugins checked concurrently (0 = number of CPUs)")
+	checkCmd.Flags().DurationVar(&timeoutFlag, "timeout", 0, "Stop the whole run after this long, e.g. 5m, report the results gathered so far, and exit with code 124 (0 = no limit)")
 
 	// Config and path flags
 	checkCmd.Flags().StringVarP(&configFlag, "config", "c", "", "Path to config file")
+	checkCmd.Flags().StringVar(&profileFlag, "profile", "", "Config profile to merge over the base config (default: the \"default\" profile, if defined)")
+	checkCmd.Flags().StringVar(&printConfigFlag, "print-config", "", "Print the effective configuration after flag overrides as hcl or json, then exit")
+	checkCmd.Flags().Lookup("print-config").NoOptDefVal = "hcl"
 	checkCmd.Flags().StringSliceVar(&includeFlag, "include", nil, "Include patterns (overrides config)")
 	checkCmd.Flags().StringSliceVar(&excludeFlag, "exclude", nil, "Exclude patterns (overrides config)")
 	checkCmd.Flags().StringVar(&filterFlag, "filter", "", "Limit scan to specific directory")
 	checkCmd.Flags().BoolVar(&recursiveFlag, "recursive", false, "Scan subdirectories containing .tf files")
+	checkCmd.Flags().BoolVar(&fileFlag, "file", false, "Compare two single .tf files instead of directories, running only the module interface rules")
+	checkCmd.Flags().StringVar(&tfvarsDirFlag, "tfvars-dir", "", "Report required variables of the new module not set by the .tfvars files in this directory")
 
 	// Annotation flags
 	checkCmd.Flags().BoolVar(&noAnnotationsFlag, "no-annotations", false, "Disable annotation processing")
@@ -114,11 +219,26 @@ func init() {
 
 	// Output enhancement flags
 	checkCmd.Flags().BoolVar(&includeRemediationFlag, "include-remediation", false, "Include remediation guidance for each finding")
+	checkCmd.Flags().BoolVar(&showIDsFlag, "show-ids", false, "Show a short stable ID for each finding in text and markdown output")
+
+	// Rename detection flags
+	checkCmd.Flags().Float64Var(&minConfidenceFlag, "min-confidence", 0, "Minimum similarity (0.0-1.0) for a match to be reported as a rename")
+
+	// Deprecation marker flags
+	checkCmd.Flags().StringVar(&moduleVersionFlag, "module-version", "", "Version of the new module, for reporting deprecated variables that are past their stated removal version")
 
 	// Git ref flags
-	checkCmd.Flags().StringVar(&baseFlag, "base", "", "Git ref for old configuration (branch, tag, or commit)")
+	checkCmd.Flags().StringVar(&baseFlag, "base", "", "Git ref for old configuration (branch, tag, or commit, or @last-tag for the most recent tag reachable from HEAD)")
 	checkCmd.Flags().StringVar(&headFlag, "head", "", "Git ref for new configuration (requires --base)")
 	checkCmd.Flags().StringVar(&repoFlag, "repo", "", "Remote repository URL (requires --base)")
+	checkCmd.Flags().StringVar(&againstFlag, "against", "", "Compare new_dir (default: .) against a remote ref, given as <url>@<ref[:path]>; shorthand for --repo <url> --base <ref[:path]>")
+	checkCmd.Flags().IntVar(&prFlag, "pr", 0, "Pull request number to compare as --head, fetched using the configured pr_ref_template")
+	checkCmd.Flags().StringVar(&prRemoteFlag, "pr-remote", "origin", "Remote to fetch the --pr ref from when --repo is not set")
+	checkCmd.Flags().BoolVar(&allowDubiousOwnershipFlag, "allow-dubious-ownership", false, "Trust the local repository for this run if git reports dubious ownership (safe.directory)")
+	checkCmd.Flags().BoolVar(&mergeBaseFlag, "merge-base", false, "Compare against the commit where the head diverged from --base, like git diff base...head, instead of the tip of --base")
+	checkCmd.Flags().BoolVar(&strictRefsFlag, "strict-refs", false, "Require --base and --head to be full commit SHAs or fully-qualified refs (refs/heads/..., refs/tags/...), not short names")
+	checkCmd.Flags().BoolVar(&autoFetchFlag, "auto-fetch", false, "In a shallow clone, fetch a --base or --head ref that is missing locally from origin and retry once")
+	checkCmd.Flags().DurationVar(&remoteTimeoutFlag, "remote-timeout", 0, "Abort each remote git operation (ls-remote, clone, fetch) that takes longer than this, e.g. 30s (0 = no limit)")
 }
 
 // checkMode represents the comparison mode
@@ -138,9 +258,20 @@ func validateCheckArgs(cmd *cobra.Command, args []string) error {
 	hasHead := headFlag != ""
 	hasRepo := repoFlag != ""
 
-	// --head requires --base
-	if hasHead && !hasBase {
-		return errors.New("--head requires --base to be specified")
+	// --against stands in for --repo and --base, with new_dir defaulting to .
+	hasAgainst := againstFlag != ""
+	if hasAgainst {
+		if hasRepo || hasBase || hasHead || prFlag != 0 {
+			return errors.New("--against cannot be used with --repo, --base, --head, or --pr")
+		}
+		_, ref, err := parseAgainst(againstFlag)
+		if err != nil {
+			return err
+		}
+		if parseRefSpec(ref).Ref == lastTagRef {
+			return fmt.Errorf("--against cannot use %s", lastTagRef)
+		}
+		hasRepo, hasBase = true, true
 	}
 
 	// --repo requires --base
@@ -148,6 +279,128 @@ func validateCheckArgs(cmd *cobra.Command, args []string) error {
 		return errors.New("--repo requires --base to be specified")
 	}
 
+	// --pr stands in for --head, and outside --repo the base defaults to the
+	// pull request's target branch
+	if prFlag < 0 {
+		return fmt.Errorf("--pr must be a positive pull request number, got %d", prFlag)
+	}
+	if prFlag > 0 {
+		if hasHead {
+			return errors.New("--pr and --head cannot be used together")
+		}
+		hasHead = true
+		if !hasRepo {
+			hasBase = true
+		}
+	}
+
+	// --head requires --base
+	if hasHead && !hasBase {
+		return errors.New("--head requires --base to be specified")
+	}
+
+	// The last tag is looked up in the local repository
+	if hasRepo && parseRefSpec(baseFlag).Ref == lastTagRef {
+		return fmt.Errorf("--base %s cannot be used with --repo", lastTagRef)
+	}
+
+	// The merge base is found in the local history; remote clones are shallow
+	if mergeBaseFlag {
+		if !hasBase {
+			return errors.New("--merge-base requires --base to be specified")
+		}
+		if hasRepo {
+			return errors.New("--merge-base cannot be used with --repo")
+		}
+	}
+
+	if writeBaselineFlag && baselineFlag == "" {
+		return errors.New("--write-baseline requires --baseline to be specified")
+	}
+
+	// Missing refs are fetched into the local repository
+	if autoFetchFlag {
+		if !hasBase {
+			return errors.New("--auto-fetch requires --base to be specified")
+		}
+		if hasRepo {
+			return errors.New("--auto-fetch cannot be used with --repo")
+		}
+	}
+
+	// --min-confidence is a similarity score
+	if minConfidenceFlag < 0.0 || minConfidenceFlag > 1.0 {
+		return fmt.Errorf("--min-confidence must be between 0.0 and 1.0, got %v", minConfidenceFlag)
+	}
+
+	// --module-version is compared with the removal versions in descriptions
+	if moduleVersionFlag != "" {
+		if err := rules.ValidateModuleVersion(moduleVersionFlag); err != nil {
+			return fmt.Errorf("invalid --module-version: %w", err)
+		}
+	}
+
+	if remoteTimeoutFlag < 0 {
+		return fmt.Errorf("--remote-timeout must be 0 (no limit) or greater, got %s", remoteTimeoutFlag)
+	}
+
+	if timeoutFlag < 0 {
+		return fmt.Errorf("--timeout must be 0 (no limit) or greater, got %s", timeoutFlag)
+	}
+
+	if parallelismFlag < 0 {
+		return fmt.Errorf("--parallelism must be 0 (auto) or greater, got %d", parallelismFlag)
+	}
+
+	if jsonIndentFlag && compactJSONFlag {
+		return errors.New("--json-indent and --compact-json cannot be used together")
+	}
+
+	// --output URIs must name a registered sink, checked before any work is done
+	if scheme, _, ok := strings.Cut(outputFlag, "://"); ok && !slices.Contains(output.SinkSchemes(), scheme) {
+		return fmt.Errorf("unsupported --output scheme %q (supported: %s, or - for stdout)", scheme, strings.Join(output.SinkSchemes(), ", "))
+	}
+
+	// --output-dir writes per-module reports, which only exist in recursive mode
+	if outputDirFlag != "" {
+		if !recursiveFlag {
+			return errors.New("--output-dir requires --recursive")
+		}
+		if outputFlag != "" {
+			return errors.New("--output-dir and --output cannot be used together")
+		}
+	}
+
+	// --print-config writes the configuration in one of two syntaxes
+	if printConfigFlag != "" && printConfigFlag != "hcl" && printConfigFlag != "json" {
+		return fmt.Errorf("--print-config must be hcl or json, got %q", printConfigFlag)
+	}
+
+	// --compare-scope names one of the known scopes
+	if _, err := rules.ParseScope(compareScopeFlag); err != nil {
+		return fmt.Errorf("invalid --compare-scope: %w", err)
+	}
+
+	// --tfvars-dir is checked against a single module
+	if tfvarsDirFlag != "" && recursiveFlag {
+		return errors.New("--tfvars-dir cannot be used with --recursive")
+	}
+
+	// --file compares two files given as arguments, outside of git and discovery
+	if fileFlag {
+		switch {
+		case hasBase || hasRepo:
+			return errors.New("--file cannot be used with --base, --head, --repo, --against, or --pr")
+		case recursiveFlag:
+			return errors.New("--file cannot be used with --recursive")
+		case filterFlag != "":
+			return errors.New("--file cannot be used with --filter")
+		case len(args) != 2:
+			return errors.New("exactly two file arguments required with --file: <old_file> <new_file>")
+		}
+		return nil
+	}
+
 	// Determine expected arguments based on mode
 	if hasRepo {
 		if hasHead {
@@ -155,6 +408,11 @@ func validateCheckArgs(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
 				return errors.New("no positional arguments expected with --repo --base --head")
 			}
+		} else if hasAgainst {
+			// --against: optional new_dir (defaults to .)
+			if len(args) > 1 {
+				return errors.New("at most one positional argument (new_dir) expected with --against")
+			}
 		} else {
 			// --repo --base: need new_dir
 			if len(args) != 1 {
@@ -205,23 +463,66 @@ func determineMode() checkMode {
 }
 
 func runCheck(cmd *cobra.Command, args []string) error {
+	// --timeout bounds everything from here on, git operations included
+	ctx, cancel := checkContext()
+	defer cancel()
+
+	// Read the rule list before any checkout work, so a typo fails fast
+	if rulesFromFlag != "" {
+		ids, err := readRulesFile(rulesFromFlag)
+		if err != nil {
+			return err
+		}
+		rulesFromIDs = ids
+	}
+
+	// Two single files are compared directly
+	if fileFlag {
+		return finishCheck(runSingleCheck(ctx, args[0], args[1]), nil)
+	}
+
+	// Turn --against into the --repo and --base of the mixed mode
+	if againstFlag != "" {
+		if err := applyAgainst(); err != nil {
+			return err
+		}
+	}
+
+	// Fetch the pull request ref and turn it into --base/--head
+	if prFlag > 0 {
+		if err := applyPullRequest(ctx); err != nil {
+			exitGitError(ctx, err)
+		}
+	}
+
+	// Turn --base @last-tag into the tag it stands for
+	if parseRefSpec(baseFlag).Ref == lastTagRef {
+		if err := applyLastTag(); err != nil {
+			exitGitError(ctx, err)
+		}
+	}
+
+	// Move --base back to where the head diverged from it
+	if mergeBaseFlag {
+		if err := applyMergeBase(ctx); err != nil {
+			exitGitError(ctx, err)
+		}
+	}
+
 	mode := determineMode()
 
 	// For git modes, run pre-flight checks
 	if mode != modeDirectory {
-		if err := runPreflightChecks(mode); err != nil {
-			// Exit code 2 for git-related errors
-			fmt.Fprintln(os.Stderr, err.Error())
-			os.Exit(2)
+		if err := runPreflightChecks(ctx, mode); err != nil {
+			exitGitError(ctx, err)
 		}
 	}
 
 	// Get old and new directories based on mode
-	oldDir, newDir, cleanup, err := resolveDirectories(mode, args)
+	oldDir, newDir, cleanup, err := resolveDirectories(ctx, mode, args)
 	if err != nil {
 		if mode != modeDirectory {
-			fmt.Fprintln(os.Stderr, err.Error())
-			os.Exit(2)
+			exitGitError(ctx, err)
 		}
 		return err
 	}
@@ -248,110 +549,672 @@ func runCheck(cmd *cobra.Command, args []string) error {
 
 	// Handle recursive mode
 	if recursiveFlag {
-		return runRecursiveCheck(cmd, scanOldDir, scanNewDir, cleanup)
+		return finishCheck(runRecursiveCheck(ctx, cmd, scanOldDir, scanNewDir, cleanup), cleanup)
 	}
 
-	return runSingleCheck(scanOldDir, scanNewDir)
+	return finishCheck(runSingleCheck(ctx, scanOldDir, scanNewDir), cleanup)
 }
 
-// runSingleCheck performs a check on a single directory pair
-func runSingleCheck(oldDir, newDir string) error {
-	// Load configuration
-	cfg, err := config.Load(configFlag, oldDir)
+// exitCodeTimeout is the exit code of a run cut short by --timeout, the
+// same as timeout(1)
+const exitCodeTimeout = 124
+
+// errTimedOut is returned by a check that --timeout cut short after its
+// partial result was rendered
+var errTimedOut = errors.New("check timed out")
+
+// checkContext returns the context of a check run, done once --timeout has
+// passed
+func checkContext() (context.Context, context.CancelFunc) {
+	if timeoutFlag > 0 {
+		return context.WithTimeout(context.Background(), timeoutFlag)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// exitGitError reports a failed git step and exits with code 2, or with
+// exitCodeTimeout if --timeout expired during it
+func exitGitError(ctx context.Context, err error) {
+	if ctx.Err() != nil {
+		fmt.Fprintf(os.Stderr, "Error: --timeout of %s expired before there were any results: %v\n", timeoutFlag, err)
+		os.Exit(exitCodeTimeout)
+	}
+	fmt.Fprintln(os.Stderr, err.Error())
+	os.Exit(2)
+}
+
+// finishCheck passes on the error of a check, except that a check cut short
+// by --timeout exits with exitCodeTimeout once cleanup has removed any
+// worktrees or clones
+func finishCheck(err error, cleanup func()) error {
+	if !errors.Is(err, errTimedOut) {
+		return err
+	}
+	if cleanup != nil {
+		cleanup()
+	}
+	fmt.Fprintf(os.Stderr, "Error: --timeout of %s expired, results are incomplete\n", timeoutFlag)
+	os.Exit(exitCodeTimeout)
+	return nil
+}
+
+// markTimedOut records in result that --timeout cut the check short
+func markTimedOut(result *types.CheckResult, detail string) {
+	result.TimedOut = true
+	result.AddWarning(types.WarningSourceTimeout, fmt.Sprintf("--timeout of %s expired %s", timeoutFlag, detail))
+}
+
+// loadCheckConfig loads the configuration for a check and, with --verbose,
+// reports where it came from
+func loadCheckConfig(oldDir string) (*config.Config, error) {
+	cfg, err := config.LoadProfile(configFlag, oldDir, profileFlag)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if verboseFlag {
+		fmt.Fprintln(os.Stderr, describeConfigSource(cfg))
+	}
+	return cfg, nil
+}
+
+// describeConfigSource returns a one-line note naming the config file in use
+// and how it was found
+func describeConfigSource(cfg *config.Config) string {
+	switch cfg.Source() {
+	case config.SourceFlag:
+		return fmt.Sprintf("Using config %s (from --config)", cfg.ConfigPath())
+	case config.SourceEnv:
+		return fmt.Sprintf("Using config %s (from %s)", cfg.ConfigPath(), config.ConfigEnv)
+	case config.SourceSearch:
+		return fmt.Sprintf("Using config %s (found by search)", cfg.ConfigPath())
+	default:
+		return "Using default config (no .tfbreak.hcl found)"
+	}
+}
+
+// runSingleCheck performs a check on a single directory pair, or with
+// --file a pair of files. Once ctx is done, the findings gathered so far are
+// rendered and errTimedOut is returned.
+func runSingleCheck(ctx context.Context, oldDir, newDir string) error {
+	// Load configuration; with --file it is looked up next to the old file
+	configDir := oldDir
+	if fileFlag {
+		configDir = filepath.Dir(oldDir)
+	}
+	cfg, err := loadCheckConfig(configDir)
+	if err != nil {
+		return err
 	}
 
 	// Apply CLI flag overrides
 	applyFlagOverrides(cfg)
+	if printConfigFlag != "" {
+		return printConfig(os.Stdout, cfg)
+	}
 
 	// Parse fail-on severity
 	failOn, err := types.ParseSeverity(cfg.Policy.FailOn)
 	if err != nil {
 		return fmt.Errorf("invalid fail_on value: %w", err)
 	}
+	failOnCategory, err := cfg.GetFailOnCategories()
+	if err != nil {
+		return fmt.Errorf("invalid fail_on value: %w", err)
+	}
+
+	previous, err := loadPreviousResult(onlyChangedRulesFlag)
+	if err != nil {
+		return err
+	}
+	reference, err := loadPreviousResult(compareJSONOutputFlag)
+	if err != nil {
+		return err
+	}
+	known, err := loadBaseline()
+	if err != nil {
+		return err
+	}
 
 	// Create path filter
 	filter := pathfilter.New(cfg.Paths.Include, cfg.Paths.Exclude)
 
 	// Load old config with path filtering
-	oldSnapshot, err := loader.LoadWithFilter(oldDir, filter)
+	oldSnapshot, err := loadSnapshot(ctx, oldDir, filter)
 	if err != nil {
+		if ctx.Err() != nil {
+			return renderUnloaded(cfg, oldDir, newDir, failOn)
+		}
 		return fmt.Errorf("failed to load old config: %w", err)
 	}
 
 	// Load new config with path filtering
-	newSnapshot, err := loader.LoadWithFilter(newDir, filter)
+	newSnapshot, err := loadSnapshot(ctx, newDir, filter)
 	if err != nil {
+		if ctx.Err() != nil {
+			return renderUnloaded(cfg, oldDir, newDir, failOn)
+		}
 		return fmt.Errorf("failed to load new config: %w", err)
 	}
 
+	// An empty module usually means the wrong directory was given
+	emptyWarnings, err := reportEmptyModules(os.Stderr, oldSnapshot, newSnapshot)
+	if err != nil {
+		return err
+	}
+
+	// Report required variables the tfvars set does not cover
+	if tfvarsDirFlag != "" {
+		if err := reportTFVarsCoverage(os.Stderr, newSnapshot, tfvarsDirFlag); err != nil {
+			return err
+		}
+	}
+
 	// Create and configure engine
 	engine := rules.NewDefaultEngine()
 	configureEngine(engine, cfg)
+	if err := checkRequiredRules(engine, cfg); err != nil {
+		return err
+	}
+	lockWarnings := loadProviderLocks(os.Stderr, engine, oldSnapshot, newSnapshot)
 
 	// Run rules with options
 	checkOpts := rules.CheckOptions{
-		IncludeRemediation: includeRemediationFlag,
+		IncludeRemediation:    includeRemediationFlag,
+		FailOnCategory:        failOnCategory,
+		TreatWarningsAsErrors: cfg.Policy.TreatWarningsAsErrors,
+		TreatRiskyAsErrors:    cfg.Policy.TreatRiskyAsErrors,
+		EscalateRequired:      cfg.IsEscalateRequiredEnabled(),
+		EscalateFanOut:        cfg.GetEscalateFanOut(),
+		HelpURLBase:           cfg.GetHelpURLBase(),
+		IgnoreWhitespace:      compareIgnoreWhitespaceFlag,
+		PathOverrides:         pathOverrides(cfg),
 	}
 	result := engine.CheckWithOptions(oldDir, newDir, oldSnapshot, newSnapshot, failOn, checkOpts)
-
-	// Execute plugin rules if any plugins are configured
-	if err := executePluginRules(cfg, oldDir, newDir, result, verboseFlag); err != nil {
-		if verboseFlag {
-			fmt.Fprintf(os.Stderr, "Warning: plugin execution error: %v\n", err)
+	result.OldRef, result.NewRef = checkRefs()
+	result.Warnings = append(result.Warnings, emptyWarnings...)
+	result.Warnings = append(result.Warnings, lockWarnings...)
+
+	// Once --timeout has expired, the built-in rule findings are reported
+	// without plugin rules or annotations
+	if ctx.Err() != nil {
+		markTimedOut(result, "before plugin rules and annotations ran")
+	}
+
+	// Execute plugin rules if any plugins are configured. Plugin rules
+	// inspect resources, so interface-only mode skips them.
+	refs := plugin.RefContext{
+		BaseRef:    result.OldRef,
+		HeadRef:    result.NewRef,
+		ModulePath: pluginModulePath(newDir),
+	}
+	if !interfaceOnly(cfg) && !result.TimedOut {
+		if err := executePluginRules(cfg, oldDir, newDir, refs, result, verboseFlag); err != nil {
+			result.AddWarning(types.WarningSourcePlugin, err.Error())
+			if verboseFlag {
+				fmt.Fprintf(os.Stderr, "Warning: plugin execution error: %v\n", err)
+			}
 		}
 	}
 
 	// Process annotations if enabled
-	if cfg.IsAnnotationsEnabled() && !noAnnotationsFlag {
-		if err := processAnnotations(newDir, filter, cfg, result); err != nil {
+	if cfg.IsAnnotationsEnabled() && !noAnnotationsFlag && !result.TimedOut {
+		// A malformed sidecar is a configuration error, unlike inline
+		// annotations. Sidecars belong to a module directory, so --file
+		// uses inline annotations only.
+		var sidecar []*annotation.SidecarIgnore
+		if !fileFlag {
+			sidecar, err = loadSidecarIgnores(newDir)
+			if err != nil {
+				return err
+			}
+		}
+		stats, explanations, err := processAnnotations(oldDir, newDir, filter, cfg, sidecar, result)
+		if err != nil {
 			// Log warning but don't fail
+			result.AddWarning(types.WarningSourceAnnotations, fmt.Sprintf("failed to process annotations: %v", err))
 			if verboseFlag {
 				fmt.Fprintf(os.Stderr, "Warning: failed to process annotations: %v\n", err)
 			}
+		} else {
+			result.Annotations = stats
+			if verboseFlag {
+				printAnnotationStats(os.Stderr, stats)
+			}
+			if explainSuppressionFlag {
+				printSuppressionExplanations(os.Stderr, explanations)
+			}
 		}
 	}
 
+	// Findings recorded in the --baseline file are known, not new. This
+	// runs after annotations so that suppressed findings are not recorded,
+	// and before --only-changed-rules and --compare-json-output so that the
+	// baseline does not depend on them.
+	if err := applyBaseline(known, result); err != nil {
+		return err
+	}
+
+	// Keep only newly triggered rules with --only-changed-rules
+	if previous != nil {
+		removeKnownRules(result, previous)
+	}
+
+	// Only findings new since the --compare-json-output run count
+	if reference != nil {
+		ignoreKnownFindings(result, reference)
+	}
+
 	// Recompute result after annotation processing
 	result.Compute()
 
-	// Determine output writer
-	var writer *os.File
-	if outputFlag != "" {
-		f, err := os.Create(outputFlag)
-		if err != nil {
-			return fmt.Errorf("failed to create output file: %w", err)
+	if err := renderResult(cfg, result); err != nil {
+		return err
+	}
+	if compareCountFlag || verboseFlag {
+		printChangeCount(os.Stderr, types.DiffSnapshots(oldSnapshot, newSnapshot))
+	}
+	if explainExitCodeFlag {
+		fmt.Fprintln(os.Stderr, explainExitCode(result))
+	}
+
+	// Set exit code based on result; a partial result cannot pass or fail
+	if result.TimedOut {
+		return errTimedOut
+	}
+	if bitmaskExitFlag {
+		exitWithSeverityBitmask(result.Summary)
+		return nil
+	}
+	if result.Result == "FAIL" {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+// renderUnloaded renders the empty result of a check that --timeout stopped
+// before its configuration was loaded, and returns errTimedOut
+func renderUnloaded(cfg *config.Config, oldDir, newDir string, failOn types.Severity) error {
+	result := types.NewCheckResult(oldDir, newDir, failOn)
+	result.OldRef, result.NewRef = checkRefs()
+	markTimedOut(result, "before the configuration was loaded")
+	result.Compute()
+	if err := renderResult(cfg, result); err != nil {
+		return err
+	}
+	return errTimedOut
+}
+
+// loadModule loads a module directory with path filtering. It is a variable
+// so tests can slow it down.
+var loadModule = loader.LoadWithFilter
+
+// loadSnapshot loads the module in dir with path filtering, or with --file
+// the single file at that path
+func loadSnapshot(ctx context.Context, path string, filter *pathfilter.Filter) (*types.ModuleSnapshot, error) {
+	if fileFlag {
+		return loadWithContext(ctx, func() (*types.ModuleSnapshot, error) {
+			return loader.LoadFile(path)
+		})
+	}
+	load := loadModule
+	return loadWithContext(ctx, func() (*types.ModuleSnapshot, error) {
+		return load(path, filter)
+	})
+}
+
+// loadWithContext runs load, returning ctx.Err() instead if ctx is done
+// first. The loader cannot be interrupted, so an abandoned load runs on in
+// the background until the process exits.
+func loadWithContext(ctx context.Context, load func() (*types.ModuleSnapshot, error)) (*types.ModuleSnapshot, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	type loaded struct {
+		snapshot *types.ModuleSnapshot
+		err      error
+	}
+	done := make(chan loaded, 1)
+	go func() {
+		snapshot, err := load()
+		done <- loaded{snapshot, err}
+	}()
+
+	select {
+	case l := <-done:
+		return l.snapshot, l.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// reportEmptyModules writes a warning for each empty module found by
+// loader.CheckEmpty and returns them for the result, or returns the first
+// one as an error with --strict
+func reportEmptyModules(w io.Writer, old, new *types.ModuleSnapshot) ([]types.ResultWarning, error) {
+	var warnings []types.ResultWarning
+	for _, err := range loader.CheckEmpty(old, new) {
+		if strictFlag {
+			return nil, err
+		}
+		fmt.Fprintf(w, "Warning: %v\n", err)
+		warnings = append(warnings, types.ResultWarning{
+			Source:   types.WarningSourceLoader,
+			Message:  err.Error(),
+			Location: &types.FileRange{Filename: err.Dir},
+		})
+	}
+	return warnings, nil
+}
+
+// loadProviderLocks adds the dependency lock files to the snapshots when
+// RC202 is enabled. A malformed lock file is written as a warning and
+// returned for the result, so it only costs RC202 its findings.
+func loadProviderLocks(w io.Writer, engine *rules.Engine, snapshots ...*types.ModuleSnapshot) []types.ResultWarning {
+	if cfg := engine.GetConfig(providerLockHashRuleID); cfg == nil || !cfg.Enabled {
+		return nil
+	}
+	var warnings []types.ResultWarning
+	for _, snapshot := range snapshots {
+		if err := loader.LoadProviderLocks(snapshot); err != nil {
+			fmt.Fprintf(w, "Warning: %v\n", err)
+			warnings = append(warnings, types.ResultWarning{
+				Source:   types.WarningSourceLoader,
+				Message:  err.Error(),
+				Location: &types.FileRange{Filename: filepath.Join(snapshot.Path, loader.LockFilename)},
+			})
 		}
-		defer f.Close()
-		writer = f
+	}
+	return warnings
+}
+
+// printChangeCount writes the --compare-count tally of structural changes
+func printChangeCount(w io.Writer, diff types.SnapshotDiff) {
+	fmt.Fprintf(w, "Changes: %s\n", diff)
+}
+
+// totalChanges sums the structural changes of all checked modules
+func totalChanges(modules []moduleResult) types.SnapshotDiff {
+	var total types.SnapshotDiff
+	for _, m := range modules {
+		total = total.Add(m.Changes)
+	}
+	return total
+}
+
+// explainExitCode describes how the result's PASS or FAIL was derived from
+// its findings and threshold, e.g.
+// "FAIL: 2 findings at or above ERROR (threshold ERROR); 3 ignored"
+func explainExitCode(result *types.CheckResult) string {
+	var failing int
+	for _, f := range result.Findings {
+		if result.Fails(f) {
+			failing++
+		}
+	}
+
+	var threshold string
+	if result.FailOnCategory != nil {
+		var thresholds []string
+		for _, c := range types.Categories() {
+			if t, ok := result.FailOnCategory[c]; ok {
+				thresholds = append(thresholds, fmt.Sprintf("%s=%s", c, t))
+			}
+		}
+		if len(thresholds) == 0 {
+			thresholds = append(thresholds, "none")
+		}
+		threshold = fmt.Sprintf("at or above their category threshold (thresholds %s)", strings.Join(thresholds, ", "))
 	} else {
-		writer = os.Stdout
+		threshold = fmt.Sprintf("at or above %s (threshold %s)", result.FailOn, result.FailOn)
+	}
+	if result.TreatWarningsAsErrors {
+		threshold += ", or warnings treated as errors"
+	} else if result.TreatRiskyAsErrors {
+		threshold += ", or risky warnings treated as errors"
 	}
 
-	// Skip output if quiet and no findings
-	if !quietFlag || result.Result == "FAIL" {
-		// Determine color mode
-		colorEnabled := shouldUseColor(writer, cfg.Output.Color)
+	noun := "findings"
+	if failing == 1 {
+		noun = "finding"
+	}
+	return fmt.Sprintf("%s: %d %s %s; %d ignored", result.Result, failing, noun, threshold, result.Summary.Ignored)
+}
+
+// exitCodeBitmask is set in every --bitmask-exit code for a run with
+// findings. It puts the codes in 65-95, apart from 1 (FAIL and errors),
+// 2 (invalid arguments and git failures), exitCodeTimeout, and 130
+// (interrupted), so scripts can tell findings from failures.
+const exitCodeBitmask = 64
 
-		// Create renderer and output
-		format := output.Format(cfg.Output.Format)
-		renderer := output.NewRenderer(format, colorEnabled)
-		if err := renderer.Render(writer, result); err != nil {
-			return fmt.Errorf("failed to render output: %w", err)
+// Exit code bits set by --bitmask-exit for each kind of non-ignored finding
+// present. A risky WARNING sets both exitBitWarning and exitBitRisky.
+const (
+	exitBitNotice = 1 << iota
+	exitBitWarning
+	exitBitRisky
+	exitBitError
+	exitBitDeprecation
+)
+
+// severityBitmask returns the --bitmask-exit exit code for a summary: the
+// exitBit of each severity with a non-ignored finding, plus exitBitRisky if
+// any finding is in the risky category. It is 0 without findings.
+func severityBitmask(summary types.Summary) int {
+	var code int
+	if summary.Notice > 0 {
+		code |= exitBitNotice
+	}
+	if summary.Warning > 0 {
+		code |= exitBitWarning
+	}
+	if summary.ByCategory[string(types.CategoryRisky)] > 0 {
+		code |= exitBitRisky
+	}
+	if summary.Error > 0 {
+		code |= exitBitError
+	}
+	if summary.Deprecation > 0 {
+		code |= exitBitDeprecation
+	}
+	return code
+}
+
+// bitmaskExitCode returns the --bitmask-exit exit code for a severity
+// bitmask: 0 without findings, exitCodeBitmask plus the bitmask otherwise
+func bitmaskExitCode(mask int) int {
+	if mask == 0 {
+		return 0
+	}
+	return exitCodeBitmask | mask
+}
+
+// exitWithSeverityBitmask exits with the bitmaskExitCode of summary, unless
+// it has no findings
+func exitWithSeverityBitmask(summary types.Summary) {
+	if code := bitmaskExitCode(severityBitmask(summary)); code != 0 {
+		os.Exit(code)
+	}
+}
+
+// reportTFVarsCoverage writes a warning to w for each required variable of
+// snap that the .tfvars files in dir do not set
+func reportTFVarsCoverage(w io.Writer, snap *types.ModuleSnapshot, dir string) error {
+	vars, err := loader.LoadTFVars(dir)
+	if err != nil {
+		return fmt.Errorf("failed to load tfvars: %w", err)
+	}
+
+	for _, v := range loader.UncoveredVariables(snap, vars) {
+		fmt.Fprintf(w, "Warning: required variable %q (%s:%d) is not set in %s\n",
+			v.Name, filepath.Base(v.DeclRange.Filename), v.DeclRange.Line, dir)
+	}
+	return nil
+}
+
+// loadPreviousResult reads a JSON result from an earlier run: the one given
+// to --only-changed-rules, or the --compare-json-output reference.
+// Returns nil if path is empty.
+func loadPreviousResult(path string) (*types.CheckResult, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read previous result: %w", err)
+	}
+
+	var previous types.CheckResult
+	if err := json.Unmarshal(data, &previous); err != nil {
+		return nil, fmt.Errorf("failed to parse previous result %s (expected --format json output): %w", path, err)
+	}
+	return &previous, nil
+}
+
+// removeKnownRules drops findings whose rule already fired at the same
+// location in the previous result, noting how many in verbose mode.
+// The caller recomputes the summary.
+func removeKnownRules(result, previous *types.CheckResult) {
+	removed := result.RemoveKnownRules(previous)
+	if verboseFlag && removed > 0 {
+		fmt.Fprintf(os.Stderr, "Omitted %d findings of rules that fired in the previous result\n", removed)
+	}
+}
+
+// ignoreKnownFindings marks findings already present in the
+// --compare-json-output reference result as ignored
+func ignoreKnownFindings(result, reference *types.CheckResult) {
+	marked := result.IgnoreKnownFindings(reference, "reported in "+compareJSONOutputFlag)
+	if verboseFlag && marked > 0 {
+		fmt.Fprintf(os.Stderr, "Ignored %d findings also reported in %s\n", marked, compareJSONOutputFlag)
+	}
+}
+
+// loadBaseline reads the --baseline file. It returns nil if no baseline is
+// given or --write-baseline is about to create it.
+func loadBaseline() (*baseline.Baseline, error) {
+	if baselineFlag == "" || writeBaselineFlag {
+		return nil, nil
+	}
+	return baseline.Load(baselineFlag)
+}
+
+// applyBaseline marks the findings recorded in the baseline as ignored. With
+// --write-baseline, the current findings are recorded first, so the run that
+// creates the baseline passes as later runs would.
+func applyBaseline(known *baseline.Baseline, result *types.CheckResult) error {
+	if writeBaselineFlag {
+		if result.TimedOut {
+			fmt.Fprintf(os.Stderr, "Warning: not writing baseline %s from a partial result\n", baselineFlag)
+			return nil
+		}
+		known = baseline.New(result.Findings)
+		if err := known.Save(baselineFlag); err != nil {
+			return err
+		}
+		if !quietFlag {
+			fmt.Fprintf(os.Stderr, "Wrote %d findings to baseline %s\n", len(known.Entries), baselineFlag)
 		}
 	}
+	if known == nil {
+		return nil
+	}
 
-	// Set exit code based on result
-	if result.Result == "FAIL" {
-		os.Exit(1)
+	marked := known.Apply(result)
+	if verboseFlag && marked > 0 {
+		fmt.Fprintf(os.Stderr, "Ignored %d findings recorded in baseline %s\n", marked, baselineFlag)
+	}
+	return nil
+}
+
+// renderResult writes the result to --output (or stdout) in the configured format.
+// Output is skipped in quiet mode unless the check failed. In GitHub Actions,
+// the result is also appended to the job summary.
+func renderResult(cfg *config.Config, result *types.CheckResult) (err error) {
+	writeStepSummary(cfg, result)
+
+	// Resolve the output destination (stdout, a file, or a registered sink)
+	writer, err := output.OpenSink(outputFlag)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := writer.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close output: %w", closeErr)
+		}
+	}()
+
+	// Skip output if quiet and no findings
+	if quietFlag && result.Result != "FAIL" {
+		return nil
+	}
+
+	// Create renderer and output
+	format := output.Format(cfg.Output.Format)
+	renderer := output.NewRendererWithOptions(format, output.Options{
+		ColorEnabled:       shouldUseColor(writer, cfg.Output.Color),
+		Verbose:            verboseFlag,
+		IncludeRemediation: includeRemediationFlag,
+		ShowIDs:            showIDsFlag,
+		SourceRoots:        sourceRoots(format, result.OldPath, result.NewPath),
+		CompactJSON:        useCompactJSON(writer),
+		SARIFCategory:      sarifCategoryFlag,
+		HideIgnored:        !cfg.IsShowIgnoredEnabled(),
+	})
+	if err := renderer.Render(writer, result); err != nil {
+		return fmt.Errorf("failed to render output: %w", err)
 	}
 
 	return nil
 }
 
+// sourceRoots returns the directories SARIF and GitLab locations are made
+// relative to with --sarif-relative-to: the root of the git repository
+// containing the given directory (or the directory itself outside git),
+// followed by the checkout roots of the compared directories, so files in
+// temporary worktrees and clones map to the same repository paths. GitLab
+// reports need repository paths, and Markdown is read in pull requests, so
+// both default to the current directory.
+func sourceRoots(format output.Format, oldPath, newPath string) []string {
+	relativeTo := sarifRelativeToFlag
+	if relativeTo == "" && (format == output.FormatGitLab || format == output.FormatMarkdown) {
+		relativeTo = "."
+	}
+	if relativeTo == "" {
+		return nil
+	}
+
+	var roots []string
+	addRoot := func(dir string) {
+		if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+			dir = resolved
+		}
+		if !slices.Contains(roots, dir) {
+			roots = append(roots, dir)
+		}
+	}
+
+	if root, err := git.FindGitRoot(relativeTo); err == nil {
+		addRoot(root)
+	} else if abs, err := filepath.Abs(relativeTo); err == nil {
+		addRoot(abs)
+	}
+	for _, dir := range []string{oldPath, newPath} {
+		if dir == "" {
+			continue
+		}
+		if root, err := git.FindGitRoot(dir); err == nil {
+			addRoot(root)
+		}
+	}
+
+	return roots
+}
+
 // runRecursiveCheck finds all module directories and runs checks on each
-func runRecursiveCheck(_ *cobra.Command, oldDir, newDir string, _ func()) error {
+func runRecursiveCheck(ctx context.Context, _ *cobra.Command, oldDir, newDir string, _ func()) error {
 	// Find all directories with .tf files in newDir
 	modules := findModuleDirs(newDir)
 	if len(modules) == 0 {
@@ -359,34 +1222,206 @@ func runRecursiveCheck(_ *cobra.Command, oldDir, newDir string, _ func()) error
 	}
 
 	// Load configuration once for common settings
-	cfg, err := config.Load(configFlag, oldDir)
+	cfg, err := loadCheckConfig(oldDir)
+	if err != nil {
+		return err
+	}
+	applyFlagOverrides(cfg)
+	if printConfigFlag != "" {
+		return printConfig(os.Stdout, cfg)
+	}
+
+	failOn, err := types.ParseSeverity(cfg.Policy.FailOn)
+	if err != nil {
+		return fmt.Errorf("invalid fail_on value: %w", err)
+	}
+	failOnCategory, err := cfg.GetFailOnCategories()
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return fmt.Errorf("invalid fail_on value: %w", err)
+	}
+
+	// Verify required rules once up front, before any module is checked
+	engine := rules.NewDefaultEngine()
+	configureEngine(engine, cfg)
+	if err := checkRequiredRules(engine, cfg); err != nil {
+		return err
+	}
+
+	previous, err := loadPreviousResult(onlyChangedRulesFlag)
+	if err != nil {
+		return err
+	}
+	reference, err := loadPreviousResult(compareJSONOutputFlag)
+	if err != nil {
+		return err
+	}
+	known, err := loadBaseline()
+	if err != nil {
+		return err
+	}
+
+	aggregatedResult, moduleResults, err := checkModules(ctx, cfg, oldDir, newDir, modules, failOn, failOnCategory)
+	if err != nil {
+		return err
+	}
+
+	// Findings recorded in the --baseline file are known, not new. As in
+	// runSingleCheck, this runs after module annotations have been applied
+	// and before --only-changed-rules and --compare-json-output, so both
+	// modes write the same baseline.
+	if baselineFlag != "" {
+		if err := applyBaseline(known, aggregatedResult); err != nil {
+			return err
+		}
+		aggregatedResult.Compute()
+		for _, mr := range moduleResults {
+			mr.Result.Compute()
+		}
+	}
+
+	// Keep only newly triggered rules with --only-changed-rules
+	if previous != nil {
+		removeKnownRules(aggregatedResult, previous)
+		aggregatedResult.Compute()
+		for _, mr := range moduleResults {
+			mr.Result.RemoveKnownRules(previous)
+			mr.Result.Compute()
+		}
+	}
+
+	// Only findings new since the --compare-json-output run count. Module
+	// results share their findings with the aggregated result.
+	if reference != nil {
+		ignoreKnownFindings(aggregatedResult, reference)
+		aggregatedResult.Compute()
+		for _, mr := range moduleResults {
+			mr.Result.Compute()
+		}
+	}
+
+	if outputDirFlag != "" {
+		overall, err := writeModuleReports(outputDirFlag, oldDir, newDir, output.Format(cfg.Output.Format), !cfg.IsShowIgnoredEnabled(), moduleResults)
+		if err != nil {
+			return err
+		}
+		if verboseFlag {
+			fmt.Fprintf(os.Stderr, "Wrote %d module reports to %s\n", len(moduleResults), outputDirFlag)
+		}
+		writeStepSummary(cfg, aggregatedResult)
+		if compareCountFlag || verboseFlag {
+			printChangeCount(os.Stderr, totalChanges(moduleResults))
+		}
+		if explainExitCodeFlag {
+			fmt.Fprintln(os.Stderr, explainExitCode(aggregatedResult))
+		}
+		if aggregatedResult.TimedOut {
+			return errTimedOut
+		}
+		if bitmaskExitFlag {
+			exitWithSeverityBitmask(aggregatedResult.Summary)
+			return nil
+		}
+		if overall == "FAIL" {
+			os.Exit(1)
+		}
+		return nil
+	}
+
+	if err := renderResult(cfg, aggregatedResult); err != nil {
+		return err
+	}
+	if compareCountFlag || verboseFlag {
+		printChangeCount(os.Stderr, totalChanges(moduleResults))
+	}
+	if explainExitCodeFlag {
+		fmt.Fprintln(os.Stderr, explainExitCode(aggregatedResult))
+	}
+
+	if aggregatedResult.TimedOut {
+		return errTimedOut
+	}
+	if bitmaskExitFlag {
+		exitWithSeverityBitmask(aggregatedResult.Summary)
+		return nil
+	}
+	if aggregatedResult.Result == "FAIL" {
+		os.Exit(1)
 	}
-	applyFlagOverrides(cfg)
 
-	failOn, err := types.ParseSeverity(cfg.Policy.FailOn)
-	if err != nil {
-		return fmt.Errorf("invalid fail_on value: %w", err)
-	}
+	return nil
+}
 
-	// Aggregate results from all modules
+// checkModules runs the check on each module directory under newDir against
+// the same relative path under oldDir. Returns the aggregated result, whose
+// findings are tagged with their module path, and the per-module results for
+// --output-dir. Modules missing from oldDir or failing to load are skipped.
+// Once ctx is done, modules not yet checked are left out and the result is
+// marked as timed out. Each module's annotations and module.tfbreak.hcl
+// sidecar apply to its own findings; a malformed sidecar is an error.
+func checkModules(ctx context.Context, cfg *config.Config, oldDir, newDir string, modules []string, failOn types.Severity, failOnCategory map[types.Category]types.Severity) (*types.CheckResult, []moduleResult, error) {
+	// Aggregate results from all modules, keeping per-module results for --output-dir
 	aggregatedResult := types.NewCheckResult(oldDir, newDir, failOn)
+	aggregatedResult.FailOnCategory = failOnCategory
+	aggregatedResult.TreatWarningsAsErrors = cfg.Policy.TreatWarningsAsErrors
+	aggregatedResult.TreatRiskyAsErrors = cfg.Policy.TreatRiskyAsErrors
+	aggregatedResult.OldRef, aggregatedResult.NewRef = checkRefs()
+	var moduleResults []moduleResult
 	filter := pathfilter.New(cfg.Paths.Include, cfg.Paths.Exclude)
+	load := loadModule
 
-	for _, modulePath := range modules {
+	// All modules share one engine. This is safe while they are checked
+	// concurrently: the rule registry is frozen, and Evaluate only reads the
+	// engine's configuration, which is not changed after this point. Rules
+	// within a module run sequentially to stay within --parallelism.
+	engine := rules.NewDefaultEngine()
+	configureEngine(engine, cfg)
+	if len(modules) > 1 {
+		engine.SetParallelism(1)
+	}
+	checkOpts := rules.CheckOptions{
+		IncludeRemediation:    includeRemediationFlag,
+		FailOnCategory:        failOnCategory,
+		TreatWarningsAsErrors: cfg.Policy.TreatWarningsAsErrors,
+		TreatRiskyAsErrors:    cfg.Policy.TreatRiskyAsErrors,
+		EscalateRequired:      cfg.IsEscalateRequiredEnabled(),
+		EscalateFanOut:        cfg.GetEscalateFanOut(),
+		HelpURLBase:           cfg.GetHelpURLBase(),
+		IgnoreWhitespace:      compareIgnoreWhitespaceFlag,
+		PathOverrides:         pathOverrides(cfg),
+		OldRoot:               oldDir,
+		NewRoot:               newDir,
+	}
+
+	// Results are kept in module order; nil marks a skipped module, with the
+	// reason in skipped
+	results := make([]*types.CheckResult, len(modules))
+	skipped := make([]string, len(modules))
+	changes := make([]types.SnapshotDiff, len(modules))
+	relPaths := make([]string, len(modules))
+	explanations := make([][]suppressionExplanation, len(modules))
+	sidecarErrs := make([]error, len(modules))
+	annotate := cfg.IsAnnotationsEnabled() && !noAnnotationsFlag
+	parallel.ForEach(parallelismFlag, len(modules), func(i int) {
+		modulePath := modules[i]
 		relPath, err := filepath.Rel(newDir, modulePath)
 		if err != nil {
 			relPath = modulePath
 		}
+		relPaths[i] = relPath
 		oldModulePath := filepath.Join(oldDir, relPath)
 
+		// Modules not checked before --timeout are left out, not skipped
+		if ctx.Err() != nil {
+			return
+		}
+
 		// Skip if old module doesn't exist
 		if _, err := os.Stat(oldModulePath); os.IsNotExist(err) {
+			skipped[i] = "not found in old directory"
 			if verboseFlag {
 				fmt.Fprintf(os.Stderr, "Skipping %s (not found in old directory)\n", relPath)
 			}
-			continue
+			return
 		}
 
 		if verboseFlag {
@@ -394,69 +1429,125 @@ func runRecursiveCheck(_ *cobra.Command, oldDir, newDir string, _ func()) error
 		}
 
 		// Load snapshots for this module
-		oldSnapshot, err := loader.LoadWithFilter(oldModulePath, filter)
+		oldSnapshot, err := loadWithContext(ctx, func() (*types.ModuleSnapshot, error) {
+			return load(oldModulePath, filter)
+		})
 		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			skipped[i] = fmt.Sprintf("failed to load old config: %v", err)
 			if verboseFlag {
 				fmt.Fprintf(os.Stderr, "Warning: failed to load old config for %s: %v\n", relPath, err)
 			}
-			continue
+			return
 		}
 
-		newSnapshot, err := loader.LoadWithFilter(modulePath, filter)
+		newSnapshot, err := loadWithContext(ctx, func() (*types.ModuleSnapshot, error) {
+			return load(modulePath, filter)
+		})
 		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			skipped[i] = fmt.Sprintf("failed to load new config: %v", err)
 			if verboseFlag {
 				fmt.Fprintf(os.Stderr, "Warning: failed to load new config for %s: %v\n", relPath, err)
 			}
-			continue
+			return
 		}
 
-		// Create and configure engine for this module
-		engine := rules.NewDefaultEngine()
-		configureEngine(engine, cfg)
+		lockWarnings := loadProviderLocks(os.Stderr, engine, oldSnapshot, newSnapshot)
 
 		// Run rules
-		checkOpts := rules.CheckOptions{
-			IncludeRemediation: includeRemediationFlag,
-		}
 		result := engine.CheckWithOptions(oldModulePath, modulePath, oldSnapshot, newSnapshot, failOn, checkOpts)
-
-		// Add findings to aggregated result
-		for _, finding := range result.Findings {
-			aggregatedResult.AddFinding(finding)
+		result.OldRef, result.NewRef = aggregatedResult.OldRef, aggregatedResult.NewRef
+		result.Warnings = append(result.Warnings, lockWarnings...)
+
+		if annotate {
+			sidecar, err := loadSidecarIgnores(modulePath)
+			if err != nil {
+				sidecarErrs[i] = err
+				return
+			}
+			stats, explained, err := processAnnotations(oldModulePath, modulePath, filter, cfg, sidecar, result)
+			if err != nil {
+				result.AddWarning(types.WarningSourceAnnotations, fmt.Sprintf("failed to process annotations: %v", err))
+				if verboseFlag {
+					fmt.Fprintf(os.Stderr, "Warning: failed to process annotations for %s: %v\n", relPath, err)
+				}
+			} else {
+				result.Annotations = stats
+				explanations[i] = explained
+			}
+			result.Compute()
 		}
-	}
 
-	// Recompute aggregated result
-	aggregatedResult.Compute()
+		results[i] = result
+		changes[i] = types.DiffSnapshots(oldSnapshot, newSnapshot)
+	})
 
-	// Determine output writer
-	var writer *os.File
-	if outputFlag != "" {
-		f, err := os.Create(outputFlag)
+	for _, err := range sidecarErrs {
 		if err != nil {
-			return fmt.Errorf("failed to create output file: %w", err)
+			return nil, nil, err
 		}
-		defer f.Close()
-		writer = f
-	} else {
-		writer = os.Stdout
 	}
 
-	// Skip output if quiet and no findings
-	if !quietFlag || aggregatedResult.Result == "FAIL" {
-		colorEnabled := shouldUseColor(writer, cfg.Output.Color)
-		format := output.Format(cfg.Output.Format)
-		renderer := output.NewRenderer(format, colorEnabled)
-		if err := renderer.Render(writer, aggregatedResult); err != nil {
-			return fmt.Errorf("failed to render output: %w", err)
+	checked := 0
+	for i, result := range results {
+		relPath := relPaths[i]
+		if result == nil {
+			if skipped[i] == "" {
+				continue
+			}
+			aggregatedResult.Warnings = append(aggregatedResult.Warnings, types.ResultWarning{
+				Source:  types.WarningSourceLoader,
+				Message: fmt.Sprintf("skipped module %s: %s", relPath, skipped[i]),
+				Module:  filepath.ToSlash(relPath),
+			})
+			continue
+		}
+
+		// Tag findings with their module and add them to the aggregated result
+		module := filepath.ToSlash(relPath)
+		for _, finding := range result.Findings {
+			finding.Module = module
+			aggregatedResult.AddFinding(finding)
 		}
+		for j := range result.Warnings {
+			result.Warnings[j].Module = module
+		}
+		aggregatedResult.Warnings = append(aggregatedResult.Warnings, result.Warnings...)
+		aggregatedResult.Modules = append(aggregatedResult.Modules, module)
+		if stats := result.Annotations; stats != nil {
+			if aggregatedResult.Annotations == nil {
+				aggregatedResult.Annotations = &types.AnnotationStats{}
+			}
+			aggregatedResult.Annotations.Parsed += stats.Parsed
+			aggregatedResult.Annotations.Matched += stats.Matched
+			aggregatedResult.Annotations.Unmatched += stats.Unmatched
+			aggregatedResult.Annotations.Expired += stats.Expired
+			aggregatedResult.Annotations.GovernanceViolations += stats.GovernanceViolations
+		}
+		if explainSuppressionFlag {
+			printSuppressionExplanations(os.Stderr, explanations[i])
+		}
+		moduleResults = append(moduleResults, moduleResult{RelPath: relPath, Result: result, Changes: changes[i]})
+		checked++
 	}
 
-	if aggregatedResult.Result == "FAIL" {
-		os.Exit(1)
+	if ctx.Err() != nil {
+		markTimedOut(aggregatedResult, fmt.Sprintf("after checking %d of %d modules", checked, len(modules)))
 	}
 
-	return nil
+	if verboseFlag && aggregatedResult.Annotations != nil {
+		printAnnotationStats(os.Stderr, aggregatedResult.Annotations)
+	}
+
+	// Recompute aggregated result
+	aggregatedResult.Compute()
+
+	return aggregatedResult, moduleResults, nil
 }
 
 // findModuleDirs finds all directories containing .tf files under root
@@ -494,10 +1585,22 @@ func applyFlagOverrides(cfg *config.Config) {
 	if colorFlag != "" {
 		cfg.Output.Color = colorFlag
 	}
+	if hideIgnoredFlag {
+		showIgnored := false
+		cfg.Output.ShowIgnored = &showIgnored
+	}
 
 	// Policy overrides
 	if failOnFlag != "" {
+		// The flag sets a single threshold for all categories
 		cfg.Policy.FailOn = failOnFlag
+		cfg.Policy.FailOnCategory = nil
+	}
+	if treatWarningsAsErrorsFlag {
+		cfg.Policy.TreatWarningsAsErrors = true
+	}
+	if treatRiskyAsErrorsFlag {
+		cfg.Policy.TreatRiskyAsErrors = true
 	}
 
 	// Path overrides (replace entirely, don't merge)
@@ -512,6 +1615,23 @@ func applyFlagOverrides(cfg *config.Config) {
 	if requireReasonFlag {
 		cfg.Annotations.RequireReason = true
 	}
+
+	// Deprecation overrides
+	if moduleVersionFlag != "" {
+		if cfg.Deprecation == nil {
+			cfg.Deprecation = &config.DeprecationConfig{}
+		}
+		cfg.Deprecation.ModuleVersion = moduleVersionFlag
+	}
+}
+
+// printConfig writes the effective configuration to w in the syntax
+// selected by --print-config
+func printConfig(w io.Writer, cfg *config.Config) error {
+	if printConfigFlag == "json" {
+		return cfg.WriteJSON(w)
+	}
+	return cfg.WriteHCL(w)
 }
 
 // resolveRuleID converts a rule identifier (ID or name) to its canonical ID.
@@ -535,34 +1655,91 @@ func resolveRuleID(identifier string) string {
 	return upper
 }
 
+// movedBlockRuleIDs are the rules run by --resource-move-check
+var movedBlockRuleIDs = []string{"BC102", "BC103", "BC104"}
+
+// providerLockHashRuleID is the rule enabled by --compare-providers-lock-strict
+const providerLockHashRuleID = "RC202"
+
+// pathOverrides returns the rule settings of the config's override blocks,
+// in config order, with rule names resolved to IDs
+func pathOverrides(cfg *config.Config) []rules.PathOverride {
+	var overrides []rules.PathOverride
+	for _, o := range cfg.Overrides {
+		for _, rc := range o.Rules {
+			override := rules.PathOverride{
+				Pattern: o.Path,
+				RuleID:  resolveRuleID(rc.ID),
+				Enabled: rc.Enabled,
+			}
+			if rc.Severity != nil {
+				if sev, err := types.ParseSeverity(*rc.Severity); err == nil {
+					override.Severity = &sev
+				}
+			}
+			overrides = append(overrides, override)
+		}
+	}
+	return overrides
+}
+
 // configureEngine applies config settings to the rules engine
 func configureEngine(engine *rules.Engine, cfg *config.Config) {
+	applyRenameDetectionSettings(cfg)
+	applyDeprecationSettings(cfg)
+	engine.SetParallelism(parallelismFlag)
+
+	// Interface-only mode is applied last, so no other setting re-enables
+	// a resource rule
+	if interfaceOnly(cfg) {
+		defer engine.DisableNonInterfaceRules()
+	}
+
+	// Lock file hash comparison is noisy, so it is off by default and only
+	// enabled by --compare-providers-lock-strict, the root scope, or the config below
+	if compareProvidersLockStrictFlag {
+		engine.EnableRule(providerLockHashRuleID)
+	}
+
+	// The scope sets the baseline that the config file and flags adjust
+	if scope, err := rules.ParseScope(compareScopeFlag); err == nil {
+		engine.ApplyScope(scope)
+	}
+
+	// --rules-from and --resource-move-check narrow the run like --only
+	only := append(slices.Clone(onlyFlag), rulesFromIDs...)
+	if resourceMoveCheckFlag {
+		only = append(only, movedBlockRuleIDs...)
+	}
+
 	// If --only is specified, disable all rules first, then enable only the specified ones
-	if len(onlyFlag) > 0 {
+	if len(only) > 0 {
 		engine.DisableAllRules()
-		for _, identifier := range onlyFlag {
+		for _, identifier := range only {
 			ruleID := resolveRuleID(identifier)
 			engine.EnableRule(ruleID)
 		}
 		return // Skip other enable/disable logic
 	}
 
-	// Apply rule configurations from config file
+	// Apply rule configurations from config file. Rules blocks are labeled
+	// with rule names, so resolve them to IDs like the CLI flags.
 	for _, rc := range cfg.Rules {
+		ruleID := resolveRuleID(rc.ID)
 		if rc.Enabled != nil {
 			if *rc.Enabled {
-				engine.EnableRule(rc.ID)
+				engine.EnableRule(ruleID)
 			} else {
-				engine.DisableRule(rc.ID)
+				engine.DisableRule(ruleID)
 			}
 		}
 		if rc.Severity != nil {
 			sev, err := types.ParseSeverity(*rc.Severity)
 			if err == nil {
-				ruleCfg := engine.GetConfig(rc.ID)
+				ruleCfg := engine.GetConfig(ruleID)
 				if ruleCfg != nil {
 					ruleCfg.Severity = sev
-					engine.SetConfig(rc.ID, ruleCfg)
+					engine.SetConfig(ruleID, ruleCfg)
 				}
 			}
 		}
@@ -598,17 +1775,93 @@ func configureEngine(engine *rules.Engine, cfg *config.Config) {
 	}
 }
 
-// processAnnotations parses annotations and matches them to findings
-func processAnnotations(dir string, filter *pathfilter.Filter, cfg *config.Config, result *types.CheckResult) error {
-	var allAnnotations []*annotation.Annotation
-	blockStarts := make(map[string]map[int]string)
+// interfaceOnly returns whether only the module's public interface is
+// checked, from --compare-module-interface-only or policy.interface_only.
+// A single file with --file is only part of a module, so the rules that need
+// the whole of it do not apply either.
+func interfaceOnly(cfg *config.Config) bool {
+	return compareModuleInterfaceOnlyFlag || fileFlag || cfg.IsInterfaceOnlyEnabled()
+}
+
+// checkRequiredRules returns an error if any rule listed in policy.required_rules
+// was disabled by the config file, a per-path override, or CLI flags
+// (--disable-rule, --only)
+func checkRequiredRules(engine *rules.Engine, cfg *config.Config) error {
+	var disabled []string
+	overrides := pathOverrides(cfg)
+	for _, identifier := range cfg.GetRequiredRules() {
+		ruleID := resolveRuleID(identifier)
+		if ruleCfg := engine.GetConfig(ruleID); ruleCfg == nil || !ruleCfg.Enabled {
+			disabled = append(disabled, fmt.Sprintf("%s (%s)", identifier, ruleID))
+			continue
+		}
+		for _, o := range overrides {
+			if o.RuleID == ruleID && o.Enabled != nil && !*o.Enabled {
+				disabled = append(disabled, fmt.Sprintf("%s (%s) in override %q", identifier, ruleID, o.Pattern))
+			}
+		}
+	}
+
+	if len(disabled) > 0 {
+		return fmt.Errorf("required rules are disabled: %s (policy.required_rules does not allow disabling them)", strings.Join(disabled, ", "))
+	}
+	return nil
+}
+
+// applyRenameDetectionSettings configures the rename heuristic rules from config and CLI flags
+func applyRenameDetectionSettings(cfg *config.Config) {
+	rules.SetRenameDetectionSettings(&rules.RenameDetectionSettings{
+		Enabled:             cfg.IsRenameDetectionEnabled(),
+		SimilarityThreshold: cfg.GetSimilarityThreshold(),
+		MinConfidence:       minConfidenceFlag,
+	})
+}
+
+// applyDeprecationSettings configures the deprecation marker rule from config.
+// --module-version is applied to the config by applyFlagOverrides.
+func applyDeprecationSettings(cfg *config.Config) {
+	settings := rules.DefaultDeprecationSettings()
+	if marker := cfg.GetDeprecationMarker(); marker != "" {
+		// The config is validated, so the pattern compiles
+		if re, err := regexp.Compile(marker); err == nil {
+			settings.Marker = re
+		}
+	}
+	settings.ModuleVersion = cfg.GetModuleVersion()
+	rules.SetDeprecationSettings(settings)
+}
 
-	// Create a resolver from the rules registry
+// newAnnotationParser creates an annotation parser that resolves rule names from the registry
+func newAnnotationParser() *annotation.Parser {
 	resolver := annotation.NewRegistryResolver(rules.DefaultRegistry.NameToIDMap())
-	parser := annotation.NewParser(resolver)
+	return annotation.NewParser(resolver)
+}
+
+// loadSidecarIgnores parses the module.tfbreak.hcl sidecar in dir, if present
+func loadSidecarIgnores(dir string) ([]*annotation.SidecarIgnore, error) {
+	path := filepath.Join(dir, annotation.SidecarFilename)
+	src, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
 
-	// Parse annotations from all files
-	err := filter.WalkDir(dir, func(path string, d os.DirEntry) error {
+	return newAnnotationParser().ParseSidecar(path, src)
+}
+
+// processAnnotations parses inline annotations from newDir, merges in sidecar
+// ignores, and matches them to findings. Address-targeted sidecar ignores are
+// resolved against both oldDir and newDir so they also cover removed blocks.
+// It also explains, for each finding, why it was or was not suppressed.
+func processAnnotations(oldDir, newDir string, filter *pathfilter.Filter, cfg *config.Config, sidecar []*annotation.SidecarIgnore, result *types.CheckResult) (*types.AnnotationStats, []suppressionExplanation, error) {
+	var allAnnotations []*annotation.Annotation
+	blockStarts := make(map[string]map[int]string)
+	blockAddresses := make(map[string]map[string]int)
+
+	parser := newAnnotationParser()
+	parseFile := func(path string) error {
 		src, err := os.ReadFile(path)
 		if err != nil {
 			return err
@@ -625,10 +1878,55 @@ func processAnnotations(dir string, filter *pathfilter.Filter, cfg *config.Confi
 			blockStarts[path] = blocks
 		}
 
+		if addrs, err := annotation.FindBlockAddresses(path, src); err == nil {
+			blockAddresses[path] = addrs
+		}
+
 		return nil
-	})
-	if err != nil {
-		return err
+	}
+
+	if fileFlag {
+		// Findings from --file carry the path as given (see loader.LoadFile)
+		if err := parseFile(newDir); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		// Findings carry absolute paths (see loader.Load), so walk absolute paths
+		dir, err := filepath.Abs(newDir)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Parse annotations from all files
+		err = filter.WalkDir(dir, func(path string, d os.DirEntry) error {
+			return parseFile(path)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(sidecar) > 0 {
+		// Removed blocks only exist in the old version
+		absOld, err := filepath.Abs(oldDir)
+		if err != nil {
+			return nil, nil, err
+		}
+		err = filter.WalkDir(absOld, func(path string, d os.DirEntry) error {
+			src, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if addrs, err := annotation.FindBlockAddresses(path, src); err == nil {
+				blockAddresses[path] = addrs
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		allAnnotations = append(allAnnotations, annotation.ResolveSidecar(sidecar, blockAddresses)...)
 	}
 
 	// Create matcher
@@ -642,10 +1940,18 @@ func processAnnotations(dir string, filter *pathfilter.Filter, cfg *config.Confi
 		DenyRuleIDs:   cfg.Annotations.DenyRuleIDs,
 	}
 
-	// Match annotations to findings
+	// Match annotations to findings, recording what became of each
+	// annotation that applies to one
+	outcomes := make(map[*annotation.Annotation]*int)
+	stats := &types.AnnotationStats{Parsed: len(allAnnotations)}
+	explanations := make([]suppressionExplanation, 0, len(result.Findings))
 	for _, finding := range result.Findings {
 		matchResult := matcher.Match(finding)
 		if !matchResult.Matched {
+			explanations = append(explanations, suppressionExplanation{
+				Finding:    finding,
+				NearMisses: matcher.NearMisses(finding),
+			})
 			continue
 		}
 
@@ -653,6 +1959,19 @@ func processAnnotations(dir string, filter *pathfilter.Filter, cfg *config.Confi
 
 		// Check governance
 		violation := annotation.CheckGovernance(ann, govCfg)
+		switch {
+		case ann.IsExpired():
+			outcomes[ann] = &stats.Expired
+		case violation != nil:
+			outcomes[ann] = &stats.GovernanceViolations
+		default:
+			outcomes[ann] = &stats.Matched
+		}
+		explanations = append(explanations, suppressionExplanation{
+			Finding:    finding,
+			Annotation: ann,
+			Violation:  violation,
+		})
 		if violation != nil {
 			// Add governance violation as a warning to the finding
 			finding.Detail = fmt.Sprintf("%s (governance: %s)", finding.Detail, violation.Message)
@@ -672,7 +1991,122 @@ func processAnnotations(dir string, filter *pathfilter.Filter, cfg *config.Confi
 		}
 	}
 
-	return nil
+	for _, counter := range outcomes {
+		*counter++
+	}
+	stats.Unmatched = stats.Parsed - len(outcomes)
+
+	return stats, explanations, nil
+}
+
+// printAnnotationStats writes the --verbose summary of annotation use
+func printAnnotationStats(w io.Writer, stats *types.AnnotationStats) {
+	fmt.Fprintf(w, "Annotations: %d parsed, %d matched, %d unmatched, %d expired, %d governance violations\n",
+		stats.Parsed, stats.Matched, stats.Unmatched, stats.Expired, stats.GovernanceViolations)
+}
+
+// suppressionExplanation records why a finding was or was not suppressed by
+// an annotation
+type suppressionExplanation struct {
+	Finding *types.Finding
+
+	// Annotation is the annotation that applies to the finding, if any. It
+	// suppressed the finding unless it is expired or Violation is set.
+	Annotation *annotation.Annotation
+	Violation  *annotation.GovernanceViolation
+
+	// NearMisses are annotations that almost applied to a finding that no
+	// annotation applies to
+	NearMisses []annotation.NearMiss
+}
+
+// String describes the outcome for the finding, followed by an indented
+// line per near miss
+func (e suppressionExplanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s", e.Finding.RuleID, findingPosition(e.Finding))
+	switch {
+	case e.Annotation == nil:
+		b.WriteString(": not suppressed, no annotation applies")
+	case e.Annotation.IsExpired():
+		fmt.Fprintf(&b, ": not suppressed, %s expired on %s", describeAnnotation(e.Annotation), e.Annotation.Expires.Format("2006-01-02"))
+	case e.Violation != nil:
+		fmt.Fprintf(&b, ": not suppressed, %s violates governance: %s", describeAnnotation(e.Annotation), e.Violation.Message)
+	default:
+		fmt.Fprintf(&b, ": suppressed by %s", describeAnnotation(e.Annotation))
+	}
+
+	for _, miss := range e.NearMisses {
+		fmt.Fprintf(&b, "\n  %s: %s", describeAnnotation(miss.Annotation), miss.Reason)
+		switch miss.Reason {
+		case annotation.NearMissRule:
+			fmt.Fprintf(&b, " (ignores %s, not %s)", strings.Join(miss.Annotation.RuleIDs, ", "), e.Finding.RuleID)
+		case annotation.NearMissAddress:
+			fmt.Fprintf(&b, " (targets %s)", miss.Annotation.Address)
+		}
+	}
+	return b.String()
+}
+
+// findingPosition returns the file:line annotations are matched against for
+// f, or "(no location)"
+func findingPosition(f *types.Finding) string {
+	loc := f.NewLocation
+	if loc == nil {
+		loc = f.OldLocation
+	}
+	if loc == nil {
+		return "(no location)"
+	}
+	return fmt.Sprintf("%s:%d", loc.Filename, loc.Line)
+}
+
+// describeAnnotation names an annotation by where it was written: its
+// file and line for inline annotations, or its address for sidecar ignores
+func describeAnnotation(ann *annotation.Annotation) string {
+	switch {
+	case ann.Address != "":
+		return fmt.Sprintf("sidecar ignore for %s", ann.Address)
+	case ann.Line == 0:
+		return "sidecar ignore"
+	default:
+		return fmt.Sprintf("annotation at %s:%d", ann.Filename, ann.Line)
+	}
+}
+
+// printSuppressionExplanations writes the --explain-suppression report,
+// one entry per finding
+func printSuppressionExplanations(w io.Writer, explanations []suppressionExplanation) {
+	for _, e := range explanations {
+		fmt.Fprintln(w, e)
+	}
+}
+
+// checkRefs returns the git refs being compared, without any :path suffix.
+// Both are empty in directory mode; newRef is empty when comparing against
+// the working tree.
+func checkRefs() (oldRef, newRef string) {
+	if baseFlag != "" {
+		oldRef = parseRefSpec(baseFlag).Ref
+	}
+	if headFlag != "" {
+		newRef = parseRefSpec(headFlag).Ref
+	}
+	return oldRef, newRef
+}
+
+// pluginModulePath returns the module path reported to plugins. When the new
+// configuration is a checked-out ref, newDir is a temporary worktree, so the
+// ref's path (plus --filter) is used instead.
+func pluginModulePath(newDir string) string {
+	if headFlag == "" {
+		return newDir
+	}
+	path := parseRefSpec(headFlag).Path
+	if path == "" {
+		path = "."
+	}
+	return filepath.Join(path, filterFlag)
 }
 
 // refSpec represents a parsed ref:path specification
@@ -710,24 +2144,48 @@ func parseRefSpec(spec string) refSpec {
 	}
 }
 
-func shouldUseColor(f *os.File, colorMode string) bool {
+func shouldUseColor(w io.Writer, colorMode string) bool {
 	switch colorMode {
 	case "always":
 		return true
 	case "never":
 		return false
 	default: // auto
-		// Check if stdout is a terminal
-		stat, err := f.Stat()
-		if err != nil {
-			return false
-		}
-		return (stat.Mode() & os.ModeCharDevice) != 0
+		return isTerminal(w)
+	}
+}
+
+// useCompactJSON reports whether JSON and SARIF output written to w is
+// written on a single line. Unless --json-indent or --compact-json says
+// otherwise, output for a terminal is indented for reading and anything
+// else, such as a pipe or file, is compact.
+func useCompactJSON(w io.Writer) bool {
+	switch {
+	case jsonIndentFlag:
+		return false
+	case compactJSONFlag:
+		return true
+	default:
+		return !isTerminal(w)
+	}
+}
+
+// isTerminal reports whether w is a terminal. Writers that are not backed by
+// a file, such as custom output sinks, never are.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(interface{ Stat() (os.FileInfo, error) })
+	if !ok {
+		return false
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		return false
 	}
+	return (stat.Mode() & os.ModeCharDevice) != 0
 }
 
 // runPreflightChecks performs pre-flight validation for git modes
-func runPreflightChecks(mode checkMode) error {
+func runPreflightChecks(ctx context.Context, mode checkMode) error {
 	// 1. Check if git is installed
 	if !git.Available() {
 		return fmt.Errorf(`Error: git is not installed or not in PATH
@@ -756,7 +2214,17 @@ Please upgrade git: https://git-scm.com/downloads`, versionErr.Current)
 		if err != nil {
 			return fmt.Errorf("failed to get current directory: %w", err)
 		}
-		if !git.IsGitRepository(cwd) {
+		// Without a .git on disk there is no need to ask git
+		var repoRoot string
+		if git.IsGitRepository(cwd) {
+			repoRoot, err = findRepoRoot(cwd)
+		} else {
+			err = &git.ErrNotARepository{Dir: cwd}
+		}
+		if err != nil {
+			if git.IsDubiousOwnership(err) {
+				return fmt.Errorf("Error: %w\n\nOr rerun with --allow-dubious-ownership to trust it for this run only", err)
+			}
 			return fmt.Errorf(`Error: not a git repository (or any parent up to /)
 
 The --base flag requires running from within a git repository.
@@ -764,28 +2232,47 @@ Either:
   - Run from inside a git repository
   - Use --repo to compare remote repositories`)
 		}
+
+		// A freshly initialized repository has no ref to check out
+		if err := git.CheckUnbornHead(repoRoot); err != nil {
+			return fmt.Errorf("Error: %w\n\nOr compare two directories without --base", err)
+		}
 	}
 
 	// 4. Validate refs exist (parse ref:path specs to extract just the ref)
 	baseSpec := parseRefSpec(baseFlag)
 	headSpec := parseRefSpec(headFlag)
 
+	// With --strict-refs, a ref must not depend on how git resolves short
+	// names. Refs derived from --pr, @last-tag, and --merge-base are already
+	// fully qualified.
+	if strictRefsFlag {
+		var repoRoot string
+		if mode == modeLocalRef || mode == modeTwoLocalRefs {
+			cwd, _ := os.Getwd()
+			repoRoot, _ = findRepoRoot(cwd)
+		}
+		if err := checkStrictRefs(repoRoot, baseSpec.Ref, headSpec.Ref); err != nil {
+			return err
+		}
+	}
+
 	if mode == modeLocalRef || mode == modeTwoLocalRefs {
 		cwd, _ := os.Getwd()
-		repoRoot, err := git.FindGitRoot(cwd)
+		repoRoot, err := findRepoRoot(cwd)
 		if err != nil {
 			return err
 		}
 
 		// Check base ref
-		if _, err := git.ResolveRef(repoRoot, baseSpec.Ref); err != nil {
-			return formatRefNotFoundError(baseSpec.Ref, repoRoot, err)
+		if err := resolveLocalRef(ctx, repoRoot, baseSpec.Ref); err != nil {
+			return err
 		}
 
 		// Check head ref if specified
 		if headFlag != "" {
-			if _, err := git.ResolveRef(repoRoot, headSpec.Ref); err != nil {
-				return formatRefNotFoundError(headSpec.Ref, repoRoot, err)
+			if err := resolveLocalRef(ctx, repoRoot, headSpec.Ref); err != nil {
+				return err
 			}
 		}
 	}
@@ -793,13 +2280,13 @@ Either:
 	// For remote mode, validate remote refs
 	if mode == modeRemoteRefs || mode == modeMixed {
 		// Validate base ref exists remotely
-		if _, _, err := git.ResolveRemoteRef(repoFlag, baseSpec.Ref); err != nil {
+		if err := resolveRemoteRef(ctx, baseSpec.Ref); err != nil {
 			return formatRemoteRefNotFoundError(baseSpec.Ref, repoFlag, err)
 		}
 
 		// Validate head ref if specified
 		if headFlag != "" {
-			if _, _, err := git.ResolveRemoteRef(repoFlag, headSpec.Ref); err != nil {
+			if err := resolveRemoteRef(ctx, headSpec.Ref); err != nil {
 				return formatRemoteRefNotFoundError(headSpec.Ref, repoFlag, err)
 			}
 		}
@@ -808,8 +2295,93 @@ Either:
 	return nil
 }
 
+// checkStrictRefs returns an error for the first of refs that is not a full
+// commit SHA or fully-qualified ref, as --strict-refs requires. Empty refs
+// are skipped. Short names are looked up in the repository at dir, if set,
+// to suggest the refs they stand for.
+func checkStrictRefs(dir string, refs ...string) error {
+	for _, ref := range refs {
+		if ref == "" {
+			continue
+		}
+		if err := git.CheckFullyQualifiedRef(dir, ref); err != nil {
+			return fmt.Errorf("Error: --strict-refs: %w", err)
+		}
+	}
+	return nil
+}
+
+// remoteContext bounds a single remote git operation by --remote-timeout,
+// within the run's ctx
+func remoteContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if remoteTimeoutFlag > 0 {
+		return context.WithTimeout(ctx, remoteTimeoutFlag)
+	}
+	return context.WithCancel(ctx)
+}
+
+// resolveRemoteRef checks that ref exists in the --repo remote
+func resolveRemoteRef(ctx context.Context, ref string) error {
+	ctx, cancel := remoteContext(ctx)
+	defer cancel()
+	_, _, err := git.ResolveRemoteRef(ctx, repoFlag, ref)
+	return err
+}
+
+// formatRemoteTimeoutError reports a remote git operation cut short by
+// --remote-timeout, or nil if err is not a timeout
+func formatRemoteTimeoutError(url string, err error) error {
+	if !errors.Is(err, context.DeadlineExceeded) {
+		return nil
+	}
+	return fmt.Errorf(`Error: remote '%s' timed out after %s
+
+Git error: %v
+
+The server may be unreachable or slow. Check the URL and your network,
+or raise --remote-timeout.`, git.RedactURL(url), remoteTimeoutFlag, err)
+}
+
+// cleanupWorktrees removes the worktrees of a run, reporting any that could
+// not be removed with --verbose
+func cleanupWorktrees(pool *git.WorktreePool) {
+	if err := pool.Cleanup(); err != nil && verboseFlag {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// findRepoRoot returns the root of the git repository containing dir.
+// With --allow-dubious-ownership, a repository git refuses to use because of
+// its ownership is trusted for the rest of the run instead of failing.
+func findRepoRoot(dir string) (string, error) {
+	root, err := git.FindGitRoot(dir)
+	var ownershipErr *git.ErrDubiousOwnership
+	if allowDubiousOwnershipFlag && errors.As(err, &ownershipErr) {
+		git.TrustDirectory(ownershipErr.Path)
+		return git.FindGitRoot(dir)
+	}
+	return root, err
+}
+
 // formatRefNotFoundError formats a user-friendly error for missing local refs
 func formatRefNotFoundError(ref, repoDir string, originalErr error) error {
+	// An ambiguous ref exists, so the error already says what to do
+	if git.IsAmbiguousRef(originalErr) {
+		return fmt.Errorf("Error: %w", originalErr)
+	}
+
+	// So does an empty repository
+	var unbornErr *git.ErrUnbornHead
+	if errors.As(originalErr, &unbornErr) {
+		return fmt.Errorf("Error: %w", originalErr)
+	}
+
+	// And a missing stash entry
+	var stashErr *git.ErrNoStash
+	if errors.As(originalErr, &stashErr) {
+		return fmt.Errorf("Error: %w", originalErr)
+	}
+
 	isShallow, _ := git.IsShallowClone(repoDir)
 	if isShallow {
 		return fmt.Errorf(`Error: ref '%s' not found in repository
@@ -838,6 +2410,11 @@ Check that the ref exists:
 
 // formatRemoteRefNotFoundError formats a user-friendly error for missing remote refs
 func formatRemoteRefNotFoundError(ref, url string, err error) error {
+	if timeoutErr := formatRemoteTimeoutError(url, err); timeoutErr != nil {
+		return timeoutErr
+	}
+	url = git.RedactURL(url)
+
 	// Check if it's an authentication/access error
 	if git.IsAuthError(err) {
 		return fmt.Errorf(`Error: cannot access repository '%s'
@@ -860,13 +2437,19 @@ Available branches:
 }
 
 // validateSubdirPath checks that a subdirectory path exists within a root directory.
-// Returns a user-friendly error if the path doesn't exist.
+// Returns a user-friendly error if the path doesn't exist. Since a module may
+// have moved between refs, a missing path lists directories with the same name
+// at that ref and shows how to give each ref its own path.
 func validateSubdirPath(rootDir, subPath, ref string) error {
+	if !filepath.IsLocal(subPath) {
+		return fmt.Errorf("path '%s' at ref '%s' must be relative to the repository root and stay inside it", subPath, ref)
+	}
+
 	fullPath := filepath.Join(rootDir, subPath)
 	info, err := os.Stat(fullPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("path '%s' does not exist at ref '%s'", subPath, ref)
+			return fmt.Errorf("path '%s' does not exist at ref '%s'%s", subPath, ref, relocationHint(rootDir, subPath, ref))
 		}
 		return fmt.Errorf("cannot access path '%s' at ref '%s': %w", subPath, ref, err)
 	}
@@ -876,9 +2459,61 @@ func validateSubdirPath(rootDir, subPath, ref string) error {
 	return nil
 }
 
+// validateWorktreeSubdir validates a ref:path path within a worktree. If the
+// path is missing from a sparse worktree, the rest of the tree is checked out
+// first so that the error can suggest where the module went.
+func validateWorktreeSubdir(wt *git.Worktree, subPath, ref string) error {
+	err := validateSubdirPath(wt.Path, subPath, ref)
+	if err != nil && wt.SparsePath != "" && wt.DisableSparse() == nil {
+		return validateSubdirPath(wt.Path, subPath, ref)
+	}
+	return err
+}
+
+// maxRelocationCandidates caps the directories suggested by relocationHint
+const maxRelocationCandidates = 5
+
+// relocationHint returns the help appended to a missing path error: the
+// directories at the ref that share the missing directory's name, and the
+// ref:path syntax for comparing a module across relocated paths.
+func relocationHint(rootDir, subPath, ref string) string {
+	name := filepath.Base(subPath)
+	var candidates []string
+	_ = filepath.WalkDir(rootDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		if d.Name() == ".git" || d.Name() == ".terraform" {
+			return filepath.SkipDir
+		}
+		if path != rootDir && d.Name() == name {
+			if rel, err := filepath.Rel(rootDir, path); err == nil {
+				candidates = append(candidates, filepath.ToSlash(rel))
+			}
+		}
+		if len(candidates) >= maxRelocationCandidates {
+			return filepath.SkipAll
+		}
+		return nil
+	})
+
+	var b strings.Builder
+	if len(candidates) > 0 {
+		fmt.Fprintf(&b, "\n\nDirectories named '%s' at ref '%s':\n", name, ref)
+		for _, c := range candidates {
+			fmt.Fprintf(&b, "  %s\n", c)
+		}
+	} else {
+		b.WriteString("\n")
+	}
+	b.WriteString("\nIf the module moved between refs, give each ref its own path:\n")
+	b.WriteString("  --base <ref>:<old path> --head <ref>:<new path>")
+	return b.String()
+}
+
 // resolveDirectories resolves old and new directories based on mode
 // Returns directories and a cleanup function (may be nil)
-func resolveDirectories(mode checkMode, args []string) (oldDir, newDir string, cleanup func(), err error) {
+func resolveDirectories(ctx context.Context, mode checkMode, args []string) (oldDir, newDir string, cleanup func(), err error) {
 	// Parse ref:path specs
 	baseSpec := parseRefSpec(baseFlag)
 	headSpec := parseRefSpec(headFlag)
@@ -897,57 +2532,61 @@ func resolveDirectories(mode checkMode, args []string) (oldDir, newDir string, c
 
 		// Create worktree for base ref
 		cwd, _ := os.Getwd()
-		repoRoot, err := git.FindGitRoot(cwd)
+		repoRoot, err := findRepoRoot(cwd)
 		if err != nil {
 			return "", "", nil, err
 		}
 
-		worktree, err := git.CreateWorktree(repoRoot, baseSpec.Ref)
+		pool := git.NewWorktreePool(repoRoot)
+		cleanup = func() { cleanupWorktrees(pool) }
+
+		worktree, err := pool.GetSparse(baseSpec.Ref, baseSpec.Path)
 		if err != nil {
+			cleanup()
 			return "", "", nil, fmt.Errorf("failed to create worktree for %s: %w", baseSpec.Ref, err)
 		}
 
 		// Apply path within worktree if specified
 		oldDir = worktree.Path
 		if baseSpec.Path != "" {
-			if err := validateSubdirPath(worktree.Path, baseSpec.Path, baseSpec.Ref); err != nil {
-				worktree.Remove()
+			if err := validateWorktreeSubdir(worktree, baseSpec.Path, baseSpec.Ref); err != nil {
+				cleanup()
 				return "", "", nil, err
 			}
 			oldDir = filepath.Join(worktree.Path, baseSpec.Path)
 		}
 
-		return oldDir, newDir, func() { worktree.Remove() }, nil
+		return oldDir, newDir, cleanup, nil
 
 	case modeTwoLocalRefs:
 		cwd, _ := os.Getwd()
-		repoRoot, err := git.FindGitRoot(cwd)
+		repoRoot, err := findRepoRoot(cwd)
 		if err != nil {
 			return "", "", nil, err
 		}
 
+		// The same ref and path on both sides share one worktree
+		pool := git.NewWorktreePool(repoRoot)
+		cleanup = func() { cleanupWorktrees(pool) }
+
 		// Create worktree for base ref
-		baseWorktree, err := git.CreateWorktree(repoRoot, baseSpec.Ref)
+		baseWorktree, err := pool.GetSparse(baseSpec.Ref, baseSpec.Path)
 		if err != nil {
+			cleanup()
 			return "", "", nil, fmt.Errorf("failed to create worktree for %s: %w", baseSpec.Ref, err)
 		}
 
 		// Create worktree for head ref
-		headWorktree, err := git.CreateWorktree(repoRoot, headSpec.Ref)
+		headWorktree, err := pool.GetSparse(headSpec.Ref, headSpec.Path)
 		if err != nil {
-			baseWorktree.Remove()
+			cleanup()
 			return "", "", nil, fmt.Errorf("failed to create worktree for %s: %w", headSpec.Ref, err)
 		}
 
-		cleanup = func() {
-			baseWorktree.Remove()
-			headWorktree.Remove()
-		}
-
 		// Apply paths within worktrees if specified
 		oldDir = baseWorktree.Path
 		if baseSpec.Path != "" {
-			if err := validateSubdirPath(baseWorktree.Path, baseSpec.Path, baseSpec.Ref); err != nil {
+			if err := validateWorktreeSubdir(baseWorktree, baseSpec.Path, baseSpec.Ref); err != nil {
 				cleanup()
 				return "", "", nil, err
 			}
@@ -955,7 +2594,7 @@ func resolveDirectories(mode checkMode, args []string) (oldDir, newDir string, c
 		}
 		newDir = headWorktree.Path
 		if headSpec.Path != "" {
-			if err := validateSubdirPath(headWorktree.Path, headSpec.Path, headSpec.Ref); err != nil {
+			if err := validateWorktreeSubdir(headWorktree, headSpec.Path, headSpec.Ref); err != nil {
 				cleanup()
 				return "", "", nil, err
 			}
@@ -966,8 +2605,13 @@ func resolveDirectories(mode checkMode, args []string) (oldDir, newDir string, c
 
 	case modeRemoteRefs:
 		// Clone both refs from remote
-		baseClone, headClone, err := git.CloneForComparison(repoFlag, baseSpec.Ref, headSpec.Ref)
+		ctx, cancel := remoteContext(ctx)
+		baseClone, headClone, err := git.CloneForComparison(ctx, repoFlag, baseSpec.Ref, headSpec.Ref)
+		cancel()
 		if err != nil {
+			if timeoutErr := formatRemoteTimeoutError(repoFlag, err); timeoutErr != nil {
+				return "", "", nil, timeoutErr
+			}
 			return "", "", nil, err
 		}
 
@@ -997,12 +2641,21 @@ func resolveDirectories(mode checkMode, args []string) (oldDir, newDir string, c
 		return oldDir, newDir, cleanup, nil
 
 	case modeMixed:
-		// Clone base ref from remote, use local new_dir
-		newDir = args[0]
+		// Clone base ref from remote, use local new_dir (--against defaults it to ".")
+		if len(args) > 0 {
+			newDir = args[0]
+		} else {
+			newDir = "."
+		}
 
-		baseClone, err := git.ShallowClone(repoFlag, baseSpec.Ref)
+		ctx, cancel := remoteContext(ctx)
+		baseClone, err := git.ShallowClone(ctx, repoFlag, baseSpec.Ref)
+		cancel()
 		if err != nil {
-			return "", "", nil, fmt.Errorf("failed to clone %s from %s: %w", baseSpec.Ref, repoFlag, err)
+			if timeoutErr := formatRemoteTimeoutError(repoFlag, err); timeoutErr != nil {
+				return "", "", nil, timeoutErr
+			}
+			return "", "", nil, fmt.Errorf("failed to clone %s from %s: %w", baseSpec.Ref, git.RedactURL(repoFlag), err)
 		}
 
 		// Apply path within clone if specified
@@ -1022,9 +2675,10 @@ func resolveDirectories(mode checkMode, args []string) (oldDir, newDir string, c
 }
 
 // executePluginRules discovers, loads, and executes plugin rules.
-// Plugin findings are added to the result.
+// Plugin findings are added to the result, and plugins that fail to load or
+// run are recorded as its warnings.
 // Returns an error if configured plugins are missing (user should run tfbreak init).
-func executePluginRules(cfg *config.Config, oldDir, newDir string, result *types.CheckResult, verbose bool) error {
+func executePluginRules(cfg *config.Config, oldDir, newDir string, refs plugin.RefContext, result *types.CheckResult, verbose bool) error {
 	// Check for missing plugins before attempting to load
 	missing := plugin.GetMissingPlugins(cfg)
 	if len(missing) > 0 {
@@ -1038,11 +2692,13 @@ func executePluginRules(cfg *config.Config, oldDir, newDir string, result *types
 	// Create plugin manager
 	mgr := plugin.NewManager(cfg)
 	defer mgr.Close()
+	mgr.SetParallelism(parallelismFlag)
 
 	// Discover and load plugins (no auto-download)
 	count, loadErrs := mgr.DiscoverAndLoad()
-	if len(loadErrs) > 0 && verbose {
-		for _, err := range loadErrs {
+	for _, err := range loadErrs {
+		result.AddWarning(types.WarningSourcePlugin, err.Error())
+		if verbose {
 			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
 		}
 	}
@@ -1071,9 +2727,10 @@ func executePluginRules(cfg *config.Config, oldDir, newDir string, result *types
 	}
 
 	// Execute plugin rules
-	findings, execErrs := mgr.ExecuteRules(oldFiles, newFiles)
-	if len(execErrs) > 0 && verbose {
-		for _, err := range execErrs {
+	findings, execErrs := mgr.ExecuteRulesWithRefs(oldFiles, newFiles, refs)
+	for _, err := range execErrs {
+		result.AddWarning(types.WarningSourcePlugin, err.Error())
+		if verbose {
 			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
 		}
 	}
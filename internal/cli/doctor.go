@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jokarl/tfbreak-core/internal/config"
+	"github.com/jokarl/tfbreak-core/internal/git"
+	"github.com/jokarl/tfbreak-core/plugin"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the tfbreak environment",
+	Long: `Run a series of environment checks and report their status:
+- git availability and version
+- whether the current directory is inside a git repository
+- config file discovery
+- the resolved plugin directory
+- discovered plugins and whether they load
+
+Each check is reported as OK, WARN, or FAIL. The command exits with
+code 1 if any check fails.
+
+Example:
+  tfbreak doctor`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().StringVarP(&configFlag, "config", "c", "", "Path to config file")
+}
+
+// doctorStatus is the outcome of a single doctor check
+type doctorStatus string
+
+const (
+	doctorOK   doctorStatus = "OK"
+	doctorWarn doctorStatus = "WARN"
+	doctorFail doctorStatus = "FAIL"
+)
+
+// doctorCheck is a single line in the doctor checklist
+type doctorCheck struct {
+	Name   string
+	Status doctorStatus
+	Detail string
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	checks := runDoctorChecks()
+	renderDoctorChecks(os.Stdout, checks)
+
+	for _, c := range checks {
+		if c.Status == doctorFail {
+			os.Exit(1)
+		}
+	}
+	return nil
+}
+
+// runDoctorChecks runs all environment checks in a fixed order
+func runDoctorChecks() []doctorCheck {
+	var checks []doctorCheck
+	checks = append(checks, checkGit()...)
+	checks = append(checks, checkGitRepository())
+
+	cfg, cfgCheck := checkConfig()
+	checks = append(checks, cfgCheck)
+	if cfg == nil {
+		// Plugin checks depend on config; fall back to defaults
+		cfg = config.Default()
+	}
+
+	checks = append(checks, checkPluginDir(cfg))
+	checks = append(checks, checkPlugins(cfg)...)
+	return checks
+}
+
+// checkGit reports git availability and version
+func checkGit() []doctorCheck {
+	if !git.Available() {
+		return []doctorCheck{
+			{Name: "git", Status: doctorFail, Detail: "git is not installed or not in PATH"},
+			{Name: "git version", Status: doctorFail, Detail: "skipped (git not available)"},
+		}
+	}
+
+	checks := []doctorCheck{{Name: "git", Status: doctorOK, Detail: "found in PATH"}}
+
+	v, err := git.GetVersion()
+	if err != nil {
+		return append(checks, doctorCheck{Name: "git version", Status: doctorFail, Detail: err.Error()})
+	}
+	if !v.AtLeast(git.MinVersionMajor, git.MinVersionMinor) {
+		return append(checks, doctorCheck{
+			Name:   "git version",
+			Status: doctorFail,
+			Detail: fmt.Sprintf("%s is below minimum required %d.%d", v, git.MinVersionMajor, git.MinVersionMinor),
+		})
+	}
+	return append(checks, doctorCheck{Name: "git version", Status: doctorOK, Detail: v.String()})
+}
+
+// checkGitRepository reports whether the current directory is inside a git repository.
+// This is only required for --base/--head comparisons, so a miss is a warning.
+func checkGitRepository() doctorCheck {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return doctorCheck{Name: "git repository", Status: doctorWarn, Detail: err.Error()}
+	}
+	root, err := git.FindGitRoot(cwd)
+	if git.IsDubiousOwnership(err) {
+		return doctorCheck{Name: "git repository", Status: doctorWarn, Detail: "git refuses to use this repository (dubious ownership); add it to safe.directory or use --allow-dubious-ownership"}
+	}
+	if err != nil {
+		return doctorCheck{Name: "git repository", Status: doctorWarn, Detail: "current directory is not a git repository (--base requires one)"}
+	}
+	return doctorCheck{Name: "git repository", Status: doctorOK, Detail: root}
+}
+
+// checkConfig reports config file discovery results
+func checkConfig() (*config.Config, doctorCheck) {
+	cfg, err := config.Load(configFlag, "")
+	if err != nil {
+		return nil, doctorCheck{Name: "config", Status: doctorFail, Detail: err.Error()}
+	}
+	if cfg.ConfigPath() == "" {
+		return cfg, doctorCheck{Name: "config", Status: doctorOK, Detail: "no .tfbreak.hcl found, using defaults"}
+	}
+	return cfg, doctorCheck{Name: "config", Status: doctorOK, Detail: cfg.ConfigPath()}
+}
+
+// checkPluginDir reports the resolved plugin installation directory
+func checkPluginDir(cfg *config.Config) doctorCheck {
+	pluginDir := cfg.GetPluginDir()
+	if pluginDir == "" {
+		pluginDir = plugin.GetDefaultPluginDir()
+	}
+	if pluginDir == "" {
+		return doctorCheck{Name: "plugin directory", Status: doctorWarn, Detail: "could not determine plugin directory"}
+	}
+	if _, err := os.Stat(pluginDir); err != nil {
+		return doctorCheck{Name: "plugin directory", Status: doctorWarn, Detail: pluginDir + " (does not exist)"}
+	}
+	return doctorCheck{Name: "plugin directory", Status: doctorOK, Detail: pluginDir}
+}
+
+// checkPlugins reports discovered plugins, whether they load, and configured plugins that are missing
+func checkPlugins(cfg *config.Config) []doctorCheck {
+	var checks []doctorCheck
+
+	discovered, err := plugin.Discover(cfg)
+	if err != nil {
+		return []doctorCheck{{Name: "plugins", Status: doctorFail, Detail: err.Error()}}
+	}
+
+	loader := plugin.NewLoader()
+	for _, info := range discovered {
+		name := "plugin " + info.Name
+		if !info.Enabled {
+			checks = append(checks, doctorCheck{Name: name, Status: doctorOK, Detail: "disabled in config"})
+			continue
+		}
+		loaded, err := loader.Load(info)
+		if err != nil {
+			checks = append(checks, doctorCheck{Name: name, Status: doctorFail, Detail: err.Error()})
+			continue
+		}
+		checks = append(checks, doctorCheck{
+			Name:   name,
+			Status: doctorOK,
+			Detail: fmt.Sprintf("%s (%d rules)", info.Path, len(loaded.RuleSet.RuleNames())),
+		})
+		loaded.Close()
+	}
+
+	for _, pc := range plugin.GetMissingPlugins(cfg) {
+		checks = append(checks, doctorCheck{
+			Name:   "plugin " + pc.Name,
+			Status: doctorWarn,
+			Detail: "configured but not installed (run 'tfbreak --init')",
+		})
+	}
+
+	if len(checks) == 0 {
+		checks = append(checks, doctorCheck{Name: "plugins", Status: doctorOK, Detail: "none configured or discovered"})
+	}
+
+	return checks
+}
+
+// renderDoctorChecks prints the checklist and a summary line
+func renderDoctorChecks(w io.Writer, checks []doctorCheck) {
+	var warnings, failures int
+	for _, c := range checks {
+		fmt.Fprintf(w, "[%-4s] %-18s %s\n", c.Status, c.Name, c.Detail)
+		switch c.Status {
+		case doctorWarn:
+			warnings++
+		case doctorFail:
+			failures++
+		}
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%d checks, %d warnings, %d failures\n", len(checks), warnings, failures)
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/config"
+)
+
+func TestRunDoctorChecks(t *testing.T) {
+	checks := runDoctorChecks()
+
+	// Fixed checks always run, in order, before any plugin entries
+	wantPrefix := []string{"git", "git version", "git repository", "config", "plugin directory"}
+	if len(checks) < len(wantPrefix)+1 {
+		t.Fatalf("expected at least %d checks, got %d", len(wantPrefix)+1, len(checks))
+	}
+	for i, name := range wantPrefix {
+		if checks[i].Name != name {
+			t.Errorf("checks[%d].Name = %q, want %q", i, checks[i].Name, name)
+		}
+	}
+
+	for _, c := range checks {
+		switch c.Status {
+		case doctorOK, doctorWarn, doctorFail:
+		default:
+			t.Errorf("check %q has invalid status %q", c.Name, c.Status)
+		}
+		if c.Detail == "" {
+			t.Errorf("check %q has empty detail", c.Name)
+		}
+	}
+}
+
+func TestCheckPluginDir_Missing(t *testing.T) {
+	cfg := config.Default()
+	cfg.ConfigBlock.PluginDir = t.TempDir() + "/does-not-exist"
+
+	c := checkPluginDir(cfg)
+	if c.Status != doctorWarn {
+		t.Errorf("Status = %q, want %q", c.Status, doctorWarn)
+	}
+	if !strings.Contains(c.Detail, "does not exist") {
+		t.Errorf("Detail = %q, want mention of missing directory", c.Detail)
+	}
+}
+
+func TestCheckPluginDir_Exists(t *testing.T) {
+	cfg := config.Default()
+	cfg.ConfigBlock.PluginDir = t.TempDir()
+
+	c := checkPluginDir(cfg)
+	if c.Status != doctorOK {
+		t.Errorf("Status = %q, want %q", c.Status, doctorOK)
+	}
+}
+
+func TestRenderDoctorChecks(t *testing.T) {
+	checks := []doctorCheck{
+		{Name: "git", Status: doctorOK, Detail: "found in PATH"},
+		{Name: "git repository", Status: doctorWarn, Detail: "not a repo"},
+		{Name: "config", Status: doctorFail, Detail: "parse error"},
+	}
+
+	var buf bytes.Buffer
+	renderDoctorChecks(&buf, checks)
+	out := buf.String()
+
+	for _, want := range []string{"[OK  ] git", "[WARN] git repository", "[FAIL] config", "3 checks, 1 warnings, 1 failures"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
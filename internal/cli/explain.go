@@ -2,14 +2,19 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
 	"github.com/jokarl/tfbreak-core/internal/rules"
+	"github.com/jokarl/tfbreak-core/internal/types"
 )
 
+var explainColorFlag string
+
 var explainCmd = &cobra.Command{
 	Use:   "explain <rule_id>",
 	Short: "Show rule documentation",
@@ -20,59 +25,111 @@ var explainCmd = &cobra.Command{
 - Example code (before and after)
 - Remediation guidance
 
+The rule can be given by ID or by name.
+
 Example:
-  tfbreak explain BC001`,
+  tfbreak explain BC001
+  tfbreak explain required-input-added`,
 	Args: cobra.ExactArgs(1),
 	RunE: runExplain,
 }
 
 func init() {
 	rootCmd.AddCommand(explainCmd)
+
+	explainCmd.Flags().StringVar(&explainColorFlag, "color", "auto", "Color mode: auto, always, never")
 }
 
 func runExplain(cmd *cobra.Command, args []string) error {
-	ruleID := strings.ToUpper(args[0])
+	switch explainColorFlag {
+	case "auto", "always", "never":
+	default:
+		return fmt.Errorf("invalid color mode: %s (must be 'auto', 'always', or 'never')", explainColorFlag)
+	}
+
+	ruleID := resolveRuleID(args[0])
 
 	doc := rules.GetDocumentation(ruleID)
 	if doc == nil {
-		fmt.Fprintf(os.Stderr, "Error: unknown rule ID: %s\n", ruleID)
-		fmt.Fprintln(os.Stderr, "")
-		fmt.Fprintln(os.Stderr, "Available rules:")
-		for _, id := range rules.DefaultRegistry.IDs() {
-			r, _ := rules.DefaultRegistry.Get(id)
-			fmt.Fprintf(os.Stderr, "  %s  %s\n", id, r.Name())
-		}
+		printUnknownRule(os.Stderr, args[0])
 		os.Exit(2)
 	}
 
-	// Print the documentation
-	fmt.Printf("%s: %s\n", doc.ID, doc.Name)
-	fmt.Printf("Severity: %s\n", doc.DefaultSeverity)
-	fmt.Println()
-	fmt.Println(doc.Description)
-	fmt.Println()
+	renderRuleDoc(os.Stdout, doc, shouldUseColor(os.Stdout, explainColorFlag))
+	return nil
+}
+
+// printUnknownRule writes the error for an explain argument that is neither
+// a rule ID nor a rule name, followed by the rules that exist
+func printUnknownRule(w io.Writer, identifier string) {
+	fmt.Fprintf(w, "Error: unknown rule: %s\n", identifier)
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Run 'tfbreak explain' with one of these rule IDs or names:")
+	for _, id := range rules.DefaultRegistry.IDs() {
+		r, _ := rules.DefaultRegistry.Get(id)
+		fmt.Fprintf(w, "  %s  %s\n", id, r.Name())
+	}
+}
+
+// renderRuleDoc writes a rule's documentation to w, with the title, severity,
+// and section headings highlighted if colorEnabled is set
+func renderRuleDoc(w io.Writer, doc *rules.RuleDoc, colorEnabled bool) {
+	heading := colorFunc(colorEnabled, color.Bold)
+
+	fmt.Fprintf(w, "%s\n", heading(fmt.Sprintf("%s: %s", doc.ID, doc.Name)))
+	fmt.Fprintf(w, "Severity: %s\n", colorSeverity(doc.DefaultSeverity, colorEnabled))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, doc.Description)
+	fmt.Fprintln(w)
 
 	if doc.ExampleOld != "" || doc.ExampleNew != "" {
-		fmt.Println("Example:")
-		fmt.Println()
+		fmt.Fprintln(w, heading("Example:"))
+		fmt.Fprintln(w)
 		if doc.ExampleOld != "" {
-			fmt.Println("Old configuration:")
-			fmt.Println(indent(doc.ExampleOld, "  "))
-			fmt.Println()
+			fmt.Fprintln(w, "Old configuration:")
+			fmt.Fprintln(w, indent(doc.ExampleOld, "  "))
+			fmt.Fprintln(w)
 		}
 		if doc.ExampleNew != "" {
-			fmt.Println("New configuration:")
-			fmt.Println(indent(doc.ExampleNew, "  "))
-			fmt.Println()
+			fmt.Fprintln(w, "New configuration:")
+			fmt.Fprintln(w, indent(doc.ExampleNew, "  "))
+			fmt.Fprintln(w)
 		}
 	}
 
 	if doc.Remediation != "" {
-		fmt.Println("Remediation:")
-		fmt.Println(indent(doc.Remediation, "  "))
+		fmt.Fprintln(w, heading("Remediation:"))
+		fmt.Fprintln(w, indent(doc.Remediation, "  "))
 	}
+}
 
-	return nil
+// colorFunc returns a function that formats its arguments with attrs if
+// enabled is set, whether or not stdout is a terminal, and as plain text
+// otherwise
+func colorFunc(enabled bool, attrs ...color.Attribute) func(a ...interface{}) string {
+	c := color.New(attrs...)
+	if enabled {
+		c.EnableColor()
+	} else {
+		c.DisableColor()
+	}
+	return c.SprintFunc()
+}
+
+// colorSeverity formats a severity in the colors of the text output format
+func colorSeverity(s types.Severity, enabled bool) string {
+	switch s {
+	case types.SeverityError:
+		return colorFunc(enabled, color.FgRed, color.Bold)(s.String())
+	case types.SeverityWarning:
+		return colorFunc(enabled, color.FgYellow)(s.String())
+	case types.SeverityDeprecation:
+		return colorFunc(enabled, color.FgMagenta)(s.String())
+	case types.SeverityNotice:
+		return colorFunc(enabled, color.FgCyan)(s.String())
+	default:
+		return s.String()
+	}
 }
 
 // indent adds a prefix to each line of text
@@ -1,8 +1,11 @@
 package cli
 
 import (
+	"bytes"
 	"strings"
 	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/rules"
 )
 
 func TestIndent(t *testing.T) {
@@ -103,3 +106,61 @@ func TestIndent_WhitespaceHandling(t *testing.T) {
 		t.Errorf("expected prefix to be added, got: %q", result)
 	}
 }
+
+func TestRunExplain_RuleName(t *testing.T) {
+	if err := runExplain(nil, []string{"required-input-added"}); err != nil {
+		t.Errorf("runExplain returned error for rule name: %v", err)
+	}
+}
+
+func TestRunExplain_InvalidColor(t *testing.T) {
+	orig := explainColorFlag
+	defer func() { explainColorFlag = orig }()
+
+	explainColorFlag = "sometimes"
+	if err := runExplain(nil, []string{"BC001"}); err == nil || !strings.Contains(err.Error(), "invalid color mode") {
+		t.Errorf("runExplain() error = %v, want invalid color mode", err)
+	}
+}
+
+func TestRenderRuleDoc(t *testing.T) {
+	doc := rules.GetDocumentation(resolveRuleID("input-type-changed"))
+	if doc == nil {
+		t.Fatal("expected documentation for input-type-changed")
+	}
+
+	var buf bytes.Buffer
+	renderRuleDoc(&buf, doc, false)
+	out := buf.String()
+	for _, want := range []string{
+		"BC004: input-type-changed\nSeverity: ERROR\n\n" + doc.Description + "\n",
+		"Example:\n\nOld configuration:\n  variable",
+		"New configuration:\n  variable",
+		"Remediation:\n  ",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Errorf("expected no color codes without color:\n%s", out)
+	}
+
+	buf.Reset()
+	renderRuleDoc(&buf, doc, true)
+	if !strings.Contains(buf.String(), "\x1b[31;1mERROR") {
+		t.Errorf("expected colored severity:\n%s", buf.String())
+	}
+}
+
+func TestPrintUnknownRule(t *testing.T) {
+	var buf bytes.Buffer
+	printUnknownRule(&buf, "BC999")
+	out := buf.String()
+	if !strings.HasPrefix(out, "Error: unknown rule: BC999\n") {
+		t.Errorf("unexpected error line:\n%s", out)
+	}
+	if !strings.Contains(out, "  BC004  input-type-changed\n") {
+		t.Errorf("expected the available rules to be listed:\n%s", out)
+	}
+}
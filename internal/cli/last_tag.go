@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jokarl/tfbreak-core/internal/git"
+)
+
+// lastTagRef is the --base ref that stands for the most recent tag
+// reachable from HEAD, for checking what broke since the last release
+const lastTagRef = "@last-tag"
+
+// applyLastTag replaces @last-tag in --base with the most recent tag
+// reachable from HEAD in the current repository, keeping any :path suffix.
+// The tag is given as a full ref so a branch of the same name cannot make
+// it ambiguous.
+func applyLastTag() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	repoRoot, err := findRepoRoot(cwd)
+	if err != nil {
+		return fmt.Errorf("Error: --base %s requires running from within a git repository: %w", lastTagRef, err)
+	}
+
+	tag, err := git.LastReachableTag(repoRoot)
+	if err != nil {
+		return fmt.Errorf("Error: cannot resolve --base %s: %w", lastTagRef, err)
+	}
+
+	spec := parseRefSpec(baseFlag)
+	baseFlag = "refs/tags/" + tag
+	if spec.Path != "" {
+		baseFlag += ":" + spec.Path
+	}
+	if verboseFlag {
+		fmt.Fprintf(os.Stderr, "Comparing against the last tag, %s\n", tag)
+	}
+	return nil
+}
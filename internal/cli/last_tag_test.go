@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestApplyLastTag(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	runTestGit(t, repoDir, "init")
+	runTestGit(t, repoDir, "config", "user.email", "test@test.com")
+	runTestGit(t, repoDir, "config", "user.name", "Test User")
+	writeTestFile(t, filepath.Join(repoDir, "modules", "vpc", "main.tf"), `variable "a" {}`)
+	runTestGit(t, repoDir, "add", ".")
+	runTestGit(t, repoDir, "commit", "-m", "Release")
+	runTestGit(t, repoDir, "tag", "v1.0.0")
+	writeTestFile(t, filepath.Join(repoDir, "modules", "vpc", "extra.tf"), `variable "b" {}`)
+	runTestGit(t, repoDir, "add", ".")
+	runTestGit(t, repoDir, "commit", "-m", "Unreleased change")
+	// A branch named like the tag must not make the base ambiguous
+	runTestGit(t, repoDir, "branch", "v1.0.0")
+	t.Chdir(repoDir)
+
+	origBase := baseFlag
+	defer func() { baseFlag = origBase }()
+
+	baseFlag = "@last-tag:modules/vpc"
+	if err := applyLastTag(); err != nil {
+		t.Fatalf("applyLastTag() error = %v", err)
+	}
+	if baseFlag != "refs/tags/v1.0.0:modules/vpc" {
+		t.Errorf("baseFlag = %q, want refs/tags/v1.0.0:modules/vpc", baseFlag)
+	}
+
+	oldDir, _, cleanup, err := resolveDirectories(context.Background(), modeLocalRef, []string{"modules/vpc"})
+	if err != nil {
+		t.Fatalf("resolveDirectories() error = %v", err)
+	}
+	defer cleanup()
+	if _, err := os.Stat(filepath.Join(oldDir, "extra.tf")); !os.IsNotExist(err) {
+		t.Errorf("extra.tf should not exist at the last tag, stat error = %v", err)
+	}
+}
+
+func TestApplyLastTag_NoTags(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	runTestGit(t, repoDir, "init")
+	runTestGit(t, repoDir, "config", "user.email", "test@test.com")
+	runTestGit(t, repoDir, "config", "user.name", "Test User")
+	runTestGit(t, repoDir, "commit", "--allow-empty", "-m", "Initial commit")
+	t.Chdir(repoDir)
+
+	origBase := baseFlag
+	defer func() { baseFlag = origBase }()
+
+	baseFlag = "@last-tag"
+	err := applyLastTag()
+	if err == nil || !strings.Contains(err.Error(), "no tags are reachable from HEAD") {
+		t.Errorf("applyLastTag() error = %v, want no tags error", err)
+	}
+	if baseFlag != "@last-tag" {
+		t.Errorf("baseFlag = %q, want it unchanged on error", baseFlag)
+	}
+}
+
+func TestValidateCheckArgs_LastTagWithRepo(t *testing.T) {
+	origBase, origRepo := baseFlag, repoFlag
+	defer func() { baseFlag, repoFlag = origBase, origRepo }()
+
+	baseFlag, repoFlag = "@last-tag", "https://github.com/org/repo"
+	err := validateCheckArgs(&cobra.Command{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "cannot be used with --repo") {
+		t.Errorf("validateCheckArgs() error = %v, want --repo conflict", err)
+	}
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jokarl/tfbreak-core/internal/git"
+)
+
+// applyMergeBase replaces the --base ref with the commit where the head
+// diverged from it, keeping any :path suffix, so that changes made on the
+// base branch since then are not reported. The head is --head, or HEAD when
+// the new configuration is the working directory.
+func applyMergeBase(ctx context.Context) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	repoRoot, err := findRepoRoot(cwd)
+	if err != nil {
+		return fmt.Errorf("Error: --merge-base requires running from within a git repository: %w", err)
+	}
+
+	baseSpec := parseRefSpec(baseFlag)
+	headRef := "HEAD"
+	if headFlag != "" {
+		headRef = parseRefSpec(headFlag).Ref
+	}
+
+	// The merge base replaces --base with a SHA, so --strict-refs checks
+	// the refs as given
+	if strictRefsFlag {
+		if err := checkStrictRefs(repoRoot, baseSpec.Ref, parseRefSpec(headFlag).Ref); err != nil {
+			return err
+		}
+	}
+
+	// Check both refs first, so a missing one gets the usual explanation
+	for _, ref := range []string{baseSpec.Ref, headRef} {
+		if err := resolveLocalRef(ctx, repoRoot, ref); err != nil {
+			return err
+		}
+	}
+
+	sha, err := git.MergeBase(repoRoot, baseSpec.Ref, headRef)
+	if err != nil {
+		var noMergeBase *git.ErrNoMergeBase
+		if errors.As(err, &noMergeBase) {
+			return fmt.Errorf("Error: --merge-base: %w\n\nTo compare against the tip of '%s' instead, leave out --merge-base.", err, baseSpec.Ref)
+		}
+		return fmt.Errorf("Error: cannot find the merge base of '%s' and '%s': %w", baseSpec.Ref, headRef, err)
+	}
+
+	baseFlag = sha
+	if baseSpec.Path != "" {
+		baseFlag += ":" + baseSpec.Path
+	}
+	if verboseFlag {
+		fmt.Fprintf(os.Stderr, "Comparing against the merge base of %s and %s, %s\n", baseSpec.Ref, headRef, sha)
+	}
+	return nil
+}
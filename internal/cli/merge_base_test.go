@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jokarl/tfbreak-core/internal/git"
+)
+
+func TestApplyMergeBase(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	runTestGit(t, repoDir, "init", "-b", "main")
+	runTestGit(t, repoDir, "config", "user.email", "test@test.com")
+	runTestGit(t, repoDir, "config", "user.name", "Test User")
+	writeTestFile(t, filepath.Join(repoDir, "modules", "vpc", "main.tf"), `variable "a" {}`)
+	runTestGit(t, repoDir, "add", ".")
+	runTestGit(t, repoDir, "commit", "-m", "Initial commit")
+	forkPoint, err := git.ResolveRef(repoDir, "HEAD")
+	if err != nil {
+		t.Fatalf("ResolveRef() error = %v", err)
+	}
+
+	runTestGit(t, repoDir, "checkout", "-q", "-b", "feature")
+	writeTestFile(t, filepath.Join(repoDir, "modules", "vpc", "feature.tf"), `variable "b" {}`)
+	runTestGit(t, repoDir, "add", ".")
+	runTestGit(t, repoDir, "commit", "-m", "Feature change")
+
+	// main moves ahead after the feature branch was created
+	runTestGit(t, repoDir, "checkout", "-q", "main")
+	writeTestFile(t, filepath.Join(repoDir, "modules", "vpc", "main_only.tf"), `variable "c" {}`)
+	runTestGit(t, repoDir, "add", ".")
+	runTestGit(t, repoDir, "commit", "-m", "Change on main")
+	runTestGit(t, repoDir, "checkout", "-q", "feature")
+	t.Chdir(repoDir)
+
+	origBase, origHead := baseFlag, headFlag
+	defer func() { baseFlag, headFlag = origBase, origHead }()
+
+	baseFlag, headFlag = "main:modules/vpc", ""
+	if err := applyMergeBase(context.Background()); err != nil {
+		t.Fatalf("applyMergeBase(context.Background()) error = %v", err)
+	}
+	if baseFlag != forkPoint+":modules/vpc" {
+		t.Errorf("baseFlag = %q, want %s:modules/vpc", baseFlag, forkPoint)
+	}
+
+	oldDir, _, cleanup, err := resolveDirectories(context.Background(), modeLocalRef, []string{"modules/vpc"})
+	if err != nil {
+		t.Fatalf("resolveDirectories() error = %v", err)
+	}
+	defer cleanup()
+	if _, err := os.Stat(filepath.Join(oldDir, "main_only.tf")); !os.IsNotExist(err) {
+		t.Errorf("main_only.tf should not exist at the merge base, stat error = %v", err)
+	}
+
+	// An explicit head is used instead of HEAD
+	runTestGit(t, repoDir, "checkout", "-q", "main")
+	baseFlag, headFlag = "main", "feature"
+	if err := applyMergeBase(context.Background()); err != nil {
+		t.Fatalf("applyMergeBase(context.Background()) with --head error = %v", err)
+	}
+	if baseFlag != forkPoint {
+		t.Errorf("baseFlag = %q, want %s", baseFlag, forkPoint)
+	}
+}
+
+func TestApplyMergeBase_NoCommonAncestor(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	runTestGit(t, repoDir, "init", "-b", "main")
+	runTestGit(t, repoDir, "config", "user.email", "test@test.com")
+	runTestGit(t, repoDir, "config", "user.name", "Test User")
+	runTestGit(t, repoDir, "commit", "--allow-empty", "-m", "Initial commit")
+	runTestGit(t, repoDir, "checkout", "-q", "--orphan", "unrelated")
+	runTestGit(t, repoDir, "commit", "--allow-empty", "-m", "Unrelated root")
+	t.Chdir(repoDir)
+
+	origBase, origHead := baseFlag, headFlag
+	defer func() { baseFlag, headFlag = origBase, origHead }()
+
+	baseFlag, headFlag = "main", ""
+	err := applyMergeBase(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "refs 'main' and 'HEAD' have no common ancestor") {
+		t.Errorf("applyMergeBase(context.Background()) error = %v, want no common ancestor error", err)
+	}
+	if baseFlag != "main" {
+		t.Errorf("baseFlag = %q, want it unchanged on error", baseFlag)
+	}
+}
+
+func TestValidateCheckArgs_MergeBase(t *testing.T) {
+	origBase, origHead, origRepo, origMergeBase := baseFlag, headFlag, repoFlag, mergeBaseFlag
+	defer func() { baseFlag, headFlag, repoFlag, mergeBaseFlag = origBase, origHead, origRepo, origMergeBase }()
+
+	mergeBaseFlag = true
+
+	baseFlag, headFlag, repoFlag = "", "", ""
+	err := validateCheckArgs(&cobra.Command{}, []string{"./old", "./new"})
+	if err == nil || !strings.Contains(err.Error(), "--merge-base requires --base") {
+		t.Errorf("validateCheckArgs() error = %v, want --base required", err)
+	}
+
+	baseFlag, headFlag, repoFlag = "v1", "v2", "https://github.com/org/repo"
+	err = validateCheckArgs(&cobra.Command{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "cannot be used with --repo") {
+		t.Errorf("validateCheckArgs() error = %v, want --repo conflict", err)
+	}
+
+	baseFlag, headFlag, repoFlag = "main", "", ""
+	if err := validateCheckArgs(&cobra.Command{}, []string{"./"}); err != nil {
+		t.Errorf("validateCheckArgs() unexpected error = %v", err)
+	}
+}
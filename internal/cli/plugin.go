@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jokarl/tfbreak-core/internal/config"
+	"github.com/jokarl/tfbreak-core/plugin"
+)
+
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Manage tfbreak plugins",
+}
+
+var pluginVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check that installed plugins are compatible with this tfbreak",
+	Long: `Start every discovered plugin, check that it completes the handshake
+with a plugin protocol version this tfbreak supports, and report its
+version and rule count.
+
+Disabled plugins are listed but not started. The command exits with
+code 1 if any enabled plugin is incompatible or fails to load.
+
+Example:
+  tfbreak plugin verify`,
+	Args: cobra.NoArgs,
+	RunE: runPluginVerify,
+}
+
+func init() {
+	rootCmd.AddCommand(pluginCmd)
+	pluginCmd.AddCommand(pluginVerifyCmd)
+
+	pluginVerifyCmd.Flags().StringVarP(&configFlag, "config", "c", "", "Path to config file")
+}
+
+func runPluginVerify(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load(configFlag, "")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	results, err := verifyPlugins(cfg)
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		fmt.Println("No plugins discovered.")
+		return nil
+	}
+
+	renderPluginVerify(os.Stdout, results)
+	for _, r := range results {
+		if r.Info.Enabled && !r.Compatible() {
+			os.Exit(1)
+		}
+	}
+	return nil
+}
+
+// verifyPlugins checks every discovered plugin. Disabled plugins are
+// returned unchecked.
+func verifyPlugins(cfg *config.Config) ([]plugin.Compatibility, error) {
+	discovered, err := plugin.Discover(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover plugins: %w", err)
+	}
+
+	loader := plugin.NewLoader()
+	results := make([]plugin.Compatibility, 0, len(discovered))
+	for _, info := range discovered {
+		if !info.Enabled {
+			results = append(results, plugin.Compatibility{Info: info})
+			continue
+		}
+		results = append(results, loader.Verify(info))
+	}
+	return results, nil
+}
+
+// renderPluginVerify prints one row per plugin and the error of each
+// plugin that cannot be used
+func renderPluginVerify(w io.Writer, results []plugin.Compatibility) {
+	fmt.Fprintf(w, "%-20s %-10s %-8s %-5s %s\n", "NAME", "VERSION", "PROTOCOL", "RULES", "STATUS")
+	for _, r := range results {
+		version, protocol, rules := "-", "-", "-"
+		if r.RuleSetVersion != "" {
+			version = r.RuleSetVersion
+		}
+		if r.ProtocolVersion != 0 {
+			protocol = fmt.Sprint(r.ProtocolVersion)
+		}
+
+		var status string
+		var incompatible *plugin.IncompatiblePluginError
+		switch {
+		case !r.Info.Enabled:
+			status = "disabled"
+		case errors.As(r.Err, &incompatible):
+			status = "incompatible"
+		case r.Err != nil:
+			status = "failed"
+		default:
+			status = "compatible"
+			rules = fmt.Sprint(r.RuleCount)
+		}
+		fmt.Fprintf(w, "%-20s %-10s %-8s %-5s %s\n", r.Info.Name, version, protocol, rules, status)
+	}
+
+	for _, r := range results {
+		if r.Info.Enabled && !r.Compatible() {
+			fmt.Fprintf(w, "\n%s: %v\n", r.Info.Name, r.Err)
+		}
+	}
+}
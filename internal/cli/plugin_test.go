@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/config"
+	"github.com/jokarl/tfbreak-core/plugin"
+)
+
+func TestVerifyPlugins_Incompatible(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake plugin is a shell script")
+	}
+
+	// A plugin that completes the handshake line with protocol version 99
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho '1|99|unix|/nonexistent.sock|grpc'\nexec sleep 10\n"
+	if err := os.WriteFile(filepath.Join(dir, "tfbreak-ruleset-fake"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Default()
+	cfg.ConfigBlock.PluginDir = dir
+
+	results, err := verifyPlugins(cfg)
+	if err != nil {
+		t.Fatalf("verifyPlugins() error = %v", err)
+	}
+	if len(results) == 0 || results[0].Info.Name != "fake" {
+		t.Fatalf("expected the fake plugin first, got %+v", results)
+	}
+	var incompatible *plugin.IncompatiblePluginError
+	if !errors.As(results[0].Err, &incompatible) {
+		t.Errorf("Err = %v, want IncompatiblePluginError", results[0].Err)
+	}
+}
+
+func TestRenderPluginVerify(t *testing.T) {
+	results := []plugin.Compatibility{
+		{
+			Info:            plugin.PluginInfo{Name: "azurerm", Enabled: true},
+			ProtocolVersion: 1,
+			RuleSetVersion:  "0.3.0",
+			RuleCount:       12,
+		},
+		{
+			Info:            plugin.PluginInfo{Name: "legacy", Enabled: true},
+			ProtocolVersion: 99,
+			Err:             &plugin.IncompatiblePluginError{Plugin: "legacy", ProtocolVersion: 99},
+		},
+		{
+			Info: plugin.PluginInfo{Name: "broken", Enabled: true},
+			Err:  errors.New("failed to start"),
+		},
+		{
+			Info: plugin.PluginInfo{Name: "off"},
+		},
+	}
+
+	var buf bytes.Buffer
+	renderPluginVerify(&buf, results)
+	out := buf.String()
+
+	for _, want := range []string{
+		"azurerm              0.3.0      1        12    compatible\n",
+		"legacy               -          99       -     incompatible\n",
+		"broken               -          -        -     failed\n",
+		"off                  -          -        -     disabled\n",
+		"\nlegacy: plugin legacy uses plugin protocol version 99",
+		"\nbroken: failed to start\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "off:") {
+		t.Errorf("expected no error for a disabled plugin, got:\n%s", out)
+	}
+}
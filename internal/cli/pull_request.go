@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jokarl/tfbreak-core/internal/config"
+	"github.com/jokarl/tfbreak-core/internal/git"
+)
+
+// applyPullRequest resolves --pr into --head, and into --base when it is not
+// set. With --repo the pull request ref is cloned from the remote like any
+// other ref. Otherwise it is fetched from --pr-remote into the local
+// repository, and the base defaults to the ref's first parent, which for a
+// merge ref such as refs/pull/N/merge is the tip of the target branch.
+func applyPullRequest(ctx context.Context) error {
+	cfg, err := config.LoadProfile(configFlag, "", profileFlag)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	template := cfg.PRRefTemplate()
+
+	if repoFlag != "" {
+		headFlag = git.PullRequestRef(template, prFlag)
+		return nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	repoRoot, err := findRepoRoot(cwd)
+	if err != nil {
+		return fmt.Errorf("Error: --pr requires running from within a git repository or --repo: %w", err)
+	}
+
+	ctx, cancel := remoteContext(ctx)
+	defer cancel()
+	localRef, err := git.FetchPullRequest(ctx, repoRoot, prRemoteFlag, template, prFlag)
+	if err != nil {
+		if timeoutErr := formatRemoteTimeoutError(prRemoteFlag, err); timeoutErr != nil {
+			return timeoutErr
+		}
+		return fmt.Errorf("Error: failed to fetch pull request %d: %w", prFlag, err)
+	}
+
+	headFlag = localRef
+	if baseFlag == "" {
+		baseFlag = localRef + "^1"
+	}
+	if verboseFlag {
+		fmt.Fprintf(os.Stderr, "Fetched pull request %d from %s as %s\n", prFlag, prRemoteFlag, localRef)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// setupPullRequestRepo creates a bare "remote" whose main branch has one
+// variable and whose refs/pull/1/merge merges a second variable into it, and
+// returns a fresh clone of it.
+func setupPullRequestRepo(t *testing.T) string {
+	t.Helper()
+
+	remoteDir := t.TempDir()
+	runTestGit(t, remoteDir, "init", "--bare")
+
+	workDir := t.TempDir()
+	runTestGit(t, workDir, "init")
+	runTestGit(t, workDir, "config", "user.email", "test@test.com")
+	runTestGit(t, workDir, "config", "user.name", "Test User")
+	writeTestFile(t, filepath.Join(workDir, "main.tf"), `variable "a" {}`)
+	runTestGit(t, workDir, "add", ".")
+	runTestGit(t, workDir, "commit", "-m", "Initial commit")
+	runTestGit(t, workDir, "branch", "-M", "main")
+	runTestGit(t, workDir, "remote", "add", "origin", remoteDir)
+	runTestGit(t, workDir, "push", "origin", "main")
+
+	runTestGit(t, workDir, "checkout", "-b", "feature")
+	writeTestFile(t, filepath.Join(workDir, "extra.tf"), `variable "b" {}`)
+	runTestGit(t, workDir, "add", ".")
+	runTestGit(t, workDir, "commit", "-m", "Add variable")
+	runTestGit(t, workDir, "checkout", "main")
+	runTestGit(t, workDir, "merge", "--no-ff", "-m", "Merge pull request #1", "feature")
+	runTestGit(t, workDir, "push", "origin", "HEAD:refs/pull/1/merge")
+
+	cloneDir := t.TempDir()
+	runTestGit(t, cloneDir, "clone", "--quiet", remoteDir, ".")
+	return cloneDir
+}
+
+func TestApplyPullRequest_LocalRemote(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	t.Chdir(setupPullRequestRepo(t))
+
+	origBase, origHead, origPR, origRemote, origConfig := baseFlag, headFlag, prFlag, prRemoteFlag, configFlag
+	defer func() {
+		baseFlag, headFlag, prFlag, prRemoteFlag, configFlag = origBase, origHead, origPR, origRemote, origConfig
+	}()
+
+	baseFlag, headFlag, prFlag, prRemoteFlag, configFlag = "", "", 1, "origin", ""
+	if err := applyPullRequest(context.Background()); err != nil {
+		t.Fatalf("applyPullRequest(context.Background()) error = %v", err)
+	}
+	if headFlag != "refs/tfbreak/pr/1" {
+		t.Errorf("headFlag = %q, want refs/tfbreak/pr/1", headFlag)
+	}
+	if baseFlag != "refs/tfbreak/pr/1^1" {
+		t.Errorf("baseFlag = %q, want refs/tfbreak/pr/1^1", baseFlag)
+	}
+
+	oldDir, newDir, cleanup, err := resolveDirectories(context.Background(), modeTwoLocalRefs, nil)
+	if err != nil {
+		t.Fatalf("resolveDirectories() error = %v", err)
+	}
+	defer cleanup()
+
+	// The base is the target branch before the merge, the head includes it
+	if _, err := os.Stat(filepath.Join(oldDir, "extra.tf")); !os.IsNotExist(err) {
+		t.Errorf("extra.tf should not exist at the base, stat error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(newDir, "extra.tf")); err != nil {
+		t.Errorf("extra.tf missing from the head: %v", err)
+	}
+}
+
+func TestApplyPullRequest_ConfiguredTemplate(t *testing.T) {
+	origBase, origHead, origPR, origRepo, origConfig := baseFlag, headFlag, prFlag, repoFlag, configFlag
+	defer func() {
+		baseFlag, headFlag, prFlag, repoFlag, configFlag = origBase, origHead, origPR, origRepo, origConfig
+	}()
+
+	configFlag = filepath.Join(t.TempDir(), ".tfbreak.hcl")
+	writeTestFile(t, configFlag, `
+version = 1
+
+git {
+  pr_ref_template = "refs/merge-requests/%d/head"
+}
+`)
+	baseFlag, headFlag, prFlag, repoFlag = "main", "", 7, "https://gitlab.example.com/org/module.git"
+	if err := applyPullRequest(context.Background()); err != nil {
+		t.Fatalf("applyPullRequest(context.Background()) error = %v", err)
+	}
+	if headFlag != "refs/merge-requests/7/head" {
+		t.Errorf("headFlag = %q, want refs/merge-requests/7/head", headFlag)
+	}
+	if baseFlag != "main" {
+		t.Errorf("baseFlag = %q, want it left at main", baseFlag)
+	}
+}
+
+func TestValidateCheckArgs_PullRequest(t *testing.T) {
+	origBase, origHead, origPR, origRepo := baseFlag, headFlag, prFlag, repoFlag
+	defer func() {
+		baseFlag, headFlag, prFlag, repoFlag = origBase, origHead, origPR, origRepo
+	}()
+
+	tests := []struct {
+		name    string
+		base    string
+		head    string
+		repo    string
+		pr      int
+		args    []string
+		wantErr string
+	}{
+		{name: "pr alone", pr: 1},
+		{name: "pr with base", base: "main", pr: 1},
+		{name: "pr with repo and base", base: "main", repo: "https://example.com/repo.git", pr: 1},
+		{name: "pr with head", base: "main", head: "feature", pr: 1, wantErr: "--pr and --head cannot be used together"},
+		{name: "pr with repo and no base", repo: "https://example.com/repo.git", pr: 1, wantErr: "--repo requires --base"},
+		{name: "pr with positional args", pr: 1, args: []string{"./new"}, wantErr: "no positional arguments expected"},
+		{name: "negative pr", pr: -1, args: []string{"./old", "./new"}, wantErr: "--pr must be a positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseFlag, headFlag, repoFlag, prFlag = tt.base, tt.head, tt.repo, tt.pr
+			err := validateCheckArgs(&cobra.Command{}, tt.args)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
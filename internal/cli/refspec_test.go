@@ -1,10 +1,14 @@
 package cli
 
 import (
+	"context"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/git"
 )
 
 func TestParseRefSpec(t *testing.T) {
@@ -145,6 +149,22 @@ func TestValidateSubdirPath(t *testing.T) {
 			wantError: true,
 			errSubstr: "does not exist",
 		},
+		{
+			name:      "path escapes root",
+			rootDir:   rootDir,
+			subPath:   "../outside",
+			ref:       "main",
+			wantError: true,
+			errSubstr: "must be relative to the repository root",
+		},
+		{
+			name:      "absolute path",
+			rootDir:   rootDir,
+			subPath:   "/etc",
+			ref:       "main",
+			wantError: true,
+			errSubstr: "must be relative to the repository root",
+		},
 		{
 			name:      "path is a file not directory",
 			rootDir:   rootDir,
@@ -172,3 +192,124 @@ func TestValidateSubdirPath(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSubdirPath_SuggestsRelocatedPath(t *testing.T) {
+	rootDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(rootDir, "modules", "network", "vpc"), 0755); err != nil {
+		t.Fatalf("failed to create test directory: %v", err)
+	}
+
+	err := validateSubdirPath(rootDir, "modules/vpc", "HEAD")
+	if err == nil {
+		t.Fatal("validateSubdirPath() = nil, want error")
+	}
+	for _, want := range []string{
+		"path 'modules/vpc' does not exist at ref 'HEAD'",
+		"Directories named 'vpc' at ref 'HEAD':\n  modules/network/vpc",
+		"--base <ref>:<old path> --head <ref>:<new path>",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error = %q, want it to contain %q", err.Error(), want)
+		}
+	}
+}
+
+// runTestGit runs git in dir, failing the test on error
+func runTestGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+}
+
+func TestResolveDirectories_RelocatedModule(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	runTestGit(t, repoDir, "init")
+	runTestGit(t, repoDir, "config", "user.email", "test@test.com")
+	runTestGit(t, repoDir, "config", "user.name", "Test User")
+	writeTestFile(t, filepath.Join(repoDir, "modules", "vpc", "main.tf"), `variable "cidr" {}`)
+	runTestGit(t, repoDir, "add", ".")
+	runTestGit(t, repoDir, "commit", "-m", "Add vpc module")
+	runTestGit(t, repoDir, "tag", "v1")
+	runTestGit(t, repoDir, "mv", "modules/vpc", "modules/network")
+	runTestGit(t, repoDir, "commit", "-m", "Move vpc module")
+	t.Chdir(repoDir)
+
+	origBase, origHead := baseFlag, headFlag
+	defer func() { baseFlag, headFlag = origBase, origHead }()
+
+	baseFlag, headFlag = "v1:modules/vpc", "HEAD:modules/network"
+	oldDir, newDir, cleanup, err := resolveDirectories(context.Background(), modeTwoLocalRefs, nil)
+	if err != nil {
+		t.Fatalf("resolveDirectories() error = %v", err)
+	}
+	defer cleanup()
+
+	for _, dir := range []string{oldDir, newDir} {
+		if _, err := os.Stat(filepath.Join(dir, "main.tf")); err != nil {
+			t.Errorf("main.tf missing from %s: %v", dir, err)
+		}
+	}
+
+	// The old path no longer exists at HEAD
+	baseFlag, headFlag = "v1:modules/vpc", "HEAD:modules/vpc"
+	_, _, _, err = resolveDirectories(context.Background(), modeTwoLocalRefs, nil)
+	if err == nil {
+		t.Fatal("resolveDirectories() = nil, want error for path missing at head")
+	}
+	if !strings.Contains(err.Error(), "path 'modules/vpc' does not exist at ref 'HEAD'") {
+		t.Errorf("error = %q, want missing path at HEAD", err.Error())
+	}
+}
+
+func TestResolveDirectories_SparseRefLeavesRepoConfig(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+	if !git.GetCapabilities().SparseCheckout {
+		t.Skip("git does not support sparse checkouts in worktrees, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	runTestGit(t, repoDir, "init")
+	runTestGit(t, repoDir, "config", "user.email", "test@test.com")
+	runTestGit(t, repoDir, "config", "user.name", "Test User")
+	writeTestFile(t, filepath.Join(repoDir, "modules", "a", "main.tf"), `variable "a" {}`)
+	writeTestFile(t, filepath.Join(repoDir, "modules", "b", "main.tf"), `variable "b" {}`)
+	runTestGit(t, repoDir, "add", ".")
+	runTestGit(t, repoDir, "commit", "-m", "Add modules")
+	t.Chdir(repoDir)
+
+	configPath := filepath.Join(repoDir, ".git", "config")
+	before, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	origBase, origHead := baseFlag, headFlag
+	defer func() { baseFlag, headFlag = origBase, origHead }()
+
+	baseFlag, headFlag = "HEAD:modules/a", ""
+	oldDir, _, cleanup, err := resolveDirectories(context.Background(), modeLocalRef, []string{"modules/a"})
+	if err != nil {
+		t.Fatalf("resolveDirectories() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(oldDir, "main.tf")); err != nil {
+		t.Errorf("main.tf missing from %s: %v", oldDir, err)
+	}
+	cleanup()
+
+	after, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(after) != string(before) {
+		t.Errorf(".git/config changed by the check:\nbefore:\n%s\nafter:\n%s", before, after)
+	}
+}
@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jokarl/tfbreak-core/internal/output"
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// moduleResult is the check result for a single module in recursive mode
+type moduleResult struct {
+	// RelPath is the module path relative to the scanned root ("." for the root)
+	RelPath string
+
+	// Result is the module's own check result
+	Result *types.CheckResult
+
+	// Changes tallies the module's structural changes for --compare-count
+	Changes types.SnapshotDiff
+}
+
+// reportIndex is the structure of index.json written by --output-dir
+type reportIndex struct {
+	Version string             `json:"version"`
+	OldPath string             `json:"old_path"`
+	NewPath string             `json:"new_path"`
+	Result  string             `json:"result"`
+	Modules []reportIndexEntry `json:"modules"`
+}
+
+// reportIndexEntry summarizes one module report in index.json
+type reportIndexEntry struct {
+	Path    string        `json:"path"`
+	Report  string        `json:"report"`
+	Result  string        `json:"result"`
+	Summary types.Summary `json:"summary"`
+}
+
+// rootModuleReportName is the report base name used for the scanned root module
+const rootModuleReportName = "root"
+
+// reportIndexName is the file name of the index written by --output-dir
+const reportIndexName = "index.json"
+
+// reservedReportPrefix is prepended to a top-level module name that would
+// collide with the root module report or the index
+const reservedReportPrefix = "_"
+
+// moduleReportName returns the report base name, in slash form, for a module
+// at relPath. A top-level module named like the root module report or the
+// index gets reservedReportPrefix, and so does one that already starts with
+// it, so that no two modules share a name.
+func moduleReportName(relPath string) string {
+	name := filepath.ToSlash(filepath.Clean(relPath))
+	if name == "." {
+		return rootModuleReportName
+	}
+	// Only a top-level name can collide with the reserved files
+	if strings.Contains(name, "/") {
+		return name
+	}
+	if name == rootModuleReportName || name == strings.TrimSuffix(reportIndexName, ".json") ||
+		strings.HasPrefix(name, reservedReportPrefix) {
+		return reservedReportPrefix + name
+	}
+	return name
+}
+
+// moduleReportPath returns the report path, relative to the output directory,
+// for a module at relPath
+func moduleReportPath(relPath string, format output.Format) string {
+	return filepath.FromSlash(moduleReportName(relPath)) + "." + format.Extension()
+}
+
+// defaultSARIFCategory prefixes per-module SARIF categories unless
+// --sarif-category is set
+const defaultSARIFCategory = "tfbreak"
+
+// moduleSARIFCategory returns the SARIF category for a module's report, so
+// uploads of different modules' reports do not replace each other's alerts
+func moduleSARIFCategory(relPath string) string {
+	category := sarifCategoryFlag
+	if category == "" {
+		category = defaultSARIFCategory
+	}
+
+	return strings.TrimSuffix(category, "/") + "/" + moduleReportName(relPath)
+}
+
+// writeModuleReports renders each module's result into dir/<module-path>.<ext>
+// and writes an index.json summarizing all modules. Returns the overall result,
+// which is FAIL if any module failed. Ignored findings are left out of the
+// reports when hideIgnored is set.
+func writeModuleReports(dir, oldPath, newPath string, format output.Format, hideIgnored bool, results []moduleResult) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Report files are never terminals, so JSON is compact unless --json-indent
+	opts := output.Options{
+		Verbose:            verboseFlag,
+		IncludeRemediation: includeRemediationFlag,
+		ShowIDs:            showIDsFlag,
+		SourceRoots:        sourceRoots(format, oldPath, newPath),
+		CompactJSON:        !jsonIndentFlag,
+		HideIgnored:        hideIgnored,
+	}
+
+	index := reportIndex{
+		Version: "1.0",
+		OldPath: oldPath,
+		NewPath: newPath,
+		Result:  "PASS",
+		Modules: make([]reportIndexEntry, 0, len(results)),
+	}
+
+	for _, mr := range results {
+		reportPath := moduleReportPath(mr.RelPath, format)
+		opts.SARIFCategory = moduleSARIFCategory(mr.RelPath)
+		renderer := output.NewRendererWithOptions(format, opts)
+		if err := writeReport(filepath.Join(dir, reportPath), renderer, mr.Result); err != nil {
+			return "", err
+		}
+
+		if mr.Result.Result == "FAIL" {
+			index.Result = "FAIL"
+		}
+		index.Modules = append(index.Modules, reportIndexEntry{
+			Path:    filepath.ToSlash(mr.RelPath),
+			Report:  filepath.ToSlash(reportPath),
+			Result:  mr.Result.Result,
+			Summary: mr.Result.Summary,
+		})
+	}
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode index: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, reportIndexName), append(data, '\n'), 0644); err != nil {
+		return "", fmt.Errorf("failed to write index: %w", err)
+	}
+
+	return index.Result, nil
+}
+
+// writeReport renders a single result to path, creating parent directories
+func writeReport(path string, renderer output.Renderer, result *types.CheckResult) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer f.Close()
+
+	if err := renderer.Render(f, result); err != nil {
+		return fmt.Errorf("failed to render report %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/output"
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+func newModuleResult(relPath string, findings ...*types.Finding) moduleResult {
+	r := types.NewCheckResult(filepath.Join("/old", relPath), filepath.Join("/new", relPath), types.SeverityError)
+	for _, f := range findings {
+		r.AddFinding(f)
+	}
+	r.Compute()
+	return moduleResult{RelPath: relPath, Result: r}
+}
+
+func TestWriteModuleReports(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "reports")
+
+	results := []moduleResult{
+		newModuleResult("."),
+		newModuleResult("modules/vpc",
+			types.NewFinding("BC002", "input-removed", types.SeverityError, `Variable "cidr" was removed`)),
+		newModuleResult("modules/eks",
+			types.NewFinding("RC006", "input-default-changed", types.SeverityWarning, `Variable "size" default changed`)),
+	}
+
+	overall, err := writeModuleReports(dir, "/old", "/new", output.FormatJSON, false, results)
+	if err != nil {
+		t.Fatalf("writeModuleReports() error = %v", err)
+	}
+	if overall != "FAIL" {
+		t.Errorf("overall result = %q, want FAIL", overall)
+	}
+
+	// Each module gets its own report mirroring the module tree
+	for _, rel := range []string{"root.json", "modules/vpc.json", "modules/eks.json"} {
+		data, err := os.ReadFile(filepath.Join(dir, rel))
+		if err != nil {
+			t.Fatalf("missing report %s: %v", rel, err)
+		}
+		var report struct {
+			Findings []json.RawMessage `json:"findings"`
+		}
+		if err := json.Unmarshal(data, &report); err != nil {
+			t.Errorf("report %s is not valid JSON: %v", rel, err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "index.json"))
+	if err != nil {
+		t.Fatalf("missing index.json: %v", err)
+	}
+	var index reportIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatalf("index.json is not valid JSON: %v", err)
+	}
+
+	if index.Result != "FAIL" {
+		t.Errorf("index result = %q, want FAIL", index.Result)
+	}
+	if len(index.Modules) != 3 {
+		t.Fatalf("index has %d modules, want 3", len(index.Modules))
+	}
+
+	vpc := index.Modules[1]
+	if vpc.Path != "modules/vpc" || vpc.Report != "modules/vpc.json" {
+		t.Errorf("vpc entry = %+v", vpc)
+	}
+	if vpc.Result != "FAIL" || vpc.Summary.Error != 1 {
+		t.Errorf("vpc entry result = %q, errors = %d, want FAIL with 1 error", vpc.Result, vpc.Summary.Error)
+	}
+	if eks := index.Modules[2]; eks.Result != "PASS" || eks.Summary.Warning != 1 {
+		t.Errorf("eks entry result = %q, warnings = %d, want PASS with 1 warning", eks.Result, eks.Summary.Warning)
+	}
+}
+
+func TestWriteModuleReports_AllPass(t *testing.T) {
+	dir := t.TempDir()
+
+	results := []moduleResult{
+		newModuleResult("a"),
+		newModuleResult("b"),
+	}
+
+	overall, err := writeModuleReports(dir, "/old", "/new", output.FormatText, false, results)
+	if err != nil {
+		t.Fatalf("writeModuleReports() error = %v", err)
+	}
+	if overall != "PASS" {
+		t.Errorf("overall result = %q, want PASS", overall)
+	}
+
+	for _, rel := range []string{"a.txt", "b.txt", "index.json"} {
+		if _, err := os.Stat(filepath.Join(dir, rel)); err != nil {
+			t.Errorf("expected %s to exist: %v", rel, err)
+		}
+	}
+}
+
+func TestWriteModuleReports_SARIFCategories(t *testing.T) {
+	origCategory := sarifCategoryFlag
+	defer func() { sarifCategoryFlag = origCategory }()
+
+	results := []moduleResult{
+		newModuleResult("."),
+		newModuleResult("modules/vpc"),
+	}
+
+	readIDs := func(t *testing.T, dir string) []string {
+		t.Helper()
+		var ids []string
+		for _, rel := range []string{"root.sarif", "modules/vpc.sarif"} {
+			data, err := os.ReadFile(filepath.Join(dir, rel))
+			if err != nil {
+				t.Fatalf("missing report %s: %v", rel, err)
+			}
+			var report struct {
+				Runs []struct {
+					AutomationDetails struct {
+						ID string `json:"id"`
+					} `json:"automationDetails"`
+				} `json:"runs"`
+			}
+			if err := json.Unmarshal(data, &report); err != nil {
+				t.Fatalf("report %s is not valid JSON: %v", rel, err)
+			}
+			ids = append(ids, report.Runs[0].AutomationDetails.ID)
+		}
+		return ids
+	}
+
+	// Each module's report gets its own category
+	sarifCategoryFlag = ""
+	dir := t.TempDir()
+	if _, err := writeModuleReports(dir, "/old", "/new", output.FormatSARIF, false, results); err != nil {
+		t.Fatalf("writeModuleReports() error = %v", err)
+	}
+	if got, want := readIDs(t, dir), []string{"tfbreak/root/", "tfbreak/modules/vpc/"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("automationDetails ids = %v, want %v", got, want)
+	}
+
+	// --sarif-category replaces the prefix
+	sarifCategoryFlag = "infra"
+	dir = t.TempDir()
+	if _, err := writeModuleReports(dir, "/old", "/new", output.FormatSARIF, false, results); err != nil {
+		t.Fatalf("writeModuleReports() error = %v", err)
+	}
+	if got, want := readIDs(t, dir), []string{"infra/root/", "infra/modules/vpc/"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("automationDetails ids = %v, want %v", got, want)
+	}
+}
+
+func TestModuleReportPath_ReservedNames(t *testing.T) {
+	tests := map[string]string{
+		".":           "root.json",
+		"root":        "_root.json",
+		"index":       "_index.json",
+		"_root":       "__root.json",
+		"modules/vpc": "modules/vpc.json",
+		"root/vpc":    "root/vpc.json",
+		"_a/index":    "_a/index.json",
+	}
+	for relPath, want := range tests {
+		if got := filepath.ToSlash(moduleReportPath(filepath.FromSlash(relPath), output.FormatJSON)); got != want {
+			t.Errorf("moduleReportPath(%q) = %q, want %q", relPath, got, want)
+		}
+	}
+
+	// Reports of modules named like the reserved files do not replace them
+	dir := t.TempDir()
+	results := []moduleResult{
+		newModuleResult("."),
+		newModuleResult("root",
+			types.NewFinding("BC002", "input-removed", types.SeverityError, `Variable "cidr" was removed`)),
+		newModuleResult("index"),
+	}
+	if _, err := writeModuleReports(dir, "/old", "/new", output.FormatJSON, false, results); err != nil {
+		t.Fatalf("writeModuleReports() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "index.json"))
+	if err != nil {
+		t.Fatalf("missing index.json: %v", err)
+	}
+	var index reportIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatalf("index.json was overwritten: %v", err)
+	}
+	if len(index.Modules) != 3 || index.Modules[1].Report != "_root.json" || index.Modules[2].Report != "_index.json" {
+		t.Errorf("index modules = %+v", index.Modules)
+	}
+	data, err = os.ReadFile(filepath.Join(dir, "root.json"))
+	if err != nil {
+		t.Fatalf("missing root.json: %v", err)
+	}
+	var root struct {
+		Findings []json.RawMessage `json:"findings"`
+	}
+	if err := json.Unmarshal(data, &root); err != nil || len(root.Findings) != 0 {
+		t.Errorf("root.json was overwritten by the report of the module named root: %s", data)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/jokarl/tfbreak-core/internal/config"
+	"github.com/jokarl/tfbreak-core/internal/rules"
 	"github.com/jokarl/tfbreak-core/plugin"
 )
 
@@ -50,6 +51,14 @@ callers or destroy state.`,
 }
 
 func init() {
+	// The rules package's init functions, which register the built-in rules,
+	// have all run by now. Nothing registers rules at runtime, so freeze the
+	// registry for concurrent reads.
+	rules.DefaultRegistry.Freeze()
+
+	// Resolve the rule names in config files against the registered rules
+	config.SetRuleValidator(registryRuleValidator{rules.DefaultRegistry})
+
 	// Disable default help command (keep -h/--help flags on subcommands)
 	rootCmd.SetHelpCommand(&cobra.Command{Hidden: true})
 
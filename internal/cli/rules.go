@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jokarl/tfbreak-core/internal/rules"
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+var rulesFormatFlag string
+
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "List the built-in rules",
+	Long: `List every built-in rule with its ID, name, default severity, and
+category, sorted by rule ID.
+
+The text format prints one table per category. The JSON format also
+includes each rule's description, for generating a rule catalog or
+checking that a config file only names rules that exist.
+
+Example:
+  tfbreak rules
+  tfbreak rules --format json`,
+	Args: cobra.NoArgs,
+	RunE: runRules,
+}
+
+func init() {
+	rootCmd.AddCommand(rulesCmd)
+
+	rulesCmd.Flags().StringVar(&rulesFormatFlag, "format", "text", "Output format: text, json")
+}
+
+// ruleCatalogEntry is one rule in the output of tfbreak rules
+type ruleCatalogEntry struct {
+	ID              string         `json:"id"`
+	Name            string         `json:"name"`
+	DefaultSeverity types.Severity `json:"default_severity"`
+	Description     string         `json:"description"`
+	Category        types.Category `json:"category"`
+	// Prefix is the letters of the ID that name its category, e.g. "BC"
+	Prefix string `json:"prefix"`
+}
+
+func runRules(cmd *cobra.Command, args []string) error {
+	catalog := ruleCatalog(rules.DefaultRegistry)
+
+	switch rulesFormatFlag {
+	case "text":
+		renderRuleCatalog(os.Stdout, catalog)
+		return nil
+	case "json":
+		return writeRuleCatalogJSON(os.Stdout, catalog)
+	default:
+		return fmt.Errorf("invalid format: %s (must be 'text' or 'json')", rulesFormatFlag)
+	}
+}
+
+// ruleCatalog returns an entry for each rule in registry, sorted by ID
+func ruleCatalog(registry *rules.Registry) []ruleCatalogEntry {
+	var catalog []ruleCatalogEntry
+	for _, r := range registry.All() {
+		entry := ruleCatalogEntry{
+			ID:              r.ID(),
+			Name:            r.Name(),
+			DefaultSeverity: r.DefaultSeverity(),
+			Description:     r.Description(),
+			Category:        types.CategoryForRuleID(r.ID()),
+			Prefix:          strings.TrimRight(r.ID(), "0123456789"),
+		}
+		if doc := rules.GetDocumentation(r.ID()); doc != nil && doc.Description != "" {
+			entry.Description = doc.Description
+		}
+		catalog = append(catalog, entry)
+	}
+	sort.Slice(catalog, func(i, j int) bool {
+		return catalog[i].ID < catalog[j].ID
+	})
+	return catalog
+}
+
+// writeRuleCatalogJSON writes the catalog as an indented JSON object with a
+// "rules" array
+func writeRuleCatalogJSON(w io.Writer, catalog []ruleCatalogEntry) error {
+	if catalog == nil {
+		catalog = []ruleCatalogEntry{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Rules []ruleCatalogEntry `json:"rules"`
+	}{catalog})
+}
+
+// renderRuleCatalog prints a table of rules for each category that has any,
+// in the order of types.Categories
+func renderRuleCatalog(w io.Writer, catalog []ruleCatalogEntry) {
+	nameWidth := len("NAME")
+	for _, e := range catalog {
+		nameWidth = max(nameWidth, len(e.Name))
+	}
+
+	first := true
+	for _, category := range types.Categories() {
+		var entries []ruleCatalogEntry
+		for _, e := range catalog {
+			if e.Category == category {
+				entries = append(entries, e)
+			}
+		}
+		if len(entries) == 0 {
+			continue
+		}
+
+		if !first {
+			fmt.Fprintln(w)
+		}
+		first = false
+
+		fmt.Fprintf(w, "%s:\n", category)
+		fmt.Fprintf(w, "  %-7s %-*s %s\n", "ID", nameWidth, "NAME", "SEVERITY")
+		for _, e := range entries {
+			fmt.Fprintf(w, "  %-7s %-*s %s\n", e.ID, nameWidth, e.Name, e.DefaultSeverity)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jokarl/tfbreak-core/internal/rules"
+)
+
+// rulesFromIDs are the rule IDs read from --rules-from, run like --only
+var rulesFromIDs []string
+
+// readRulesFile reads a --rules-from file and returns the rule IDs it
+// selects. Each line holds a rule ID or name, or an ID prefix ending in "*"
+// such as "BC*" for all breaking-change rules. Text after "#" is a comment.
+func readRulesFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+	defer f.Close()
+
+	var ids []string
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		matched, err := resolveRuleSelector(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNum, err)
+		}
+		ids = append(ids, matched...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+
+	// An empty list would otherwise run every rule
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("rules file %s lists no rules", path)
+	}
+	return ids, nil
+}
+
+// resolveRuleSelector resolves one --rules-from entry to rule IDs
+func resolveRuleSelector(selector string) ([]string, error) {
+	if prefix, ok := strings.CutSuffix(selector, "*"); ok {
+		prefix = strings.ToUpper(prefix)
+		var ids []string
+		for _, id := range rules.DefaultRegistry.IDs() {
+			if strings.HasPrefix(id, prefix) {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) == 0 {
+			return nil, fmt.Errorf("no rules match %q", selector)
+		}
+		return ids, nil
+	}
+
+	ruleID := resolveRuleID(selector)
+	if _, ok := rules.DefaultRegistry.Get(ruleID); !ok {
+		return nil, fmt.Errorf("unknown rule %q (did you mean %q?)", selector, closestRule(selector))
+	}
+	return []string{ruleID}, nil
+}
+
+// closestRule returns the rule ID or name with the smallest edit distance
+// to identifier
+func closestRule(identifier string) string {
+	identifier = strings.ToLower(identifier)
+	best, bestDistance := "", -1
+	for name, id := range rules.DefaultRegistry.NameToIDMap() {
+		for _, candidate := range []string{name, id} {
+			distance := rules.LevenshteinDistance(identifier, strings.ToLower(candidate))
+			if bestDistance < 0 || distance < bestDistance || (distance == bestDistance && candidate < best) {
+				best, bestDistance = candidate, distance
+			}
+		}
+	}
+	return best
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/config"
+	"github.com/jokarl/tfbreak-core/internal/rules"
+)
+
+func TestReadRulesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.txt")
+	writeTestFile(t, path, `# Release gate rules
+BC001
+input-removed   # by name
+rc006
+
+BC10*           # resource and module removals, moved block checks
+`)
+
+	ids, err := readRulesFile(path)
+	if err != nil {
+		t.Fatalf("readRulesFile() error = %v", err)
+	}
+
+	want := []string{"BC001", "BC002", "RC006"}
+	for _, id := range rules.DefaultRegistry.IDs() {
+		if strings.HasPrefix(id, "BC10") {
+			want = append(want, id)
+		}
+	}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("readRulesFile() = %v, want %v", ids, want)
+	}
+}
+
+func TestReadRulesFile_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"typo in name", "input-removd\n", `rules.txt:1: unknown rule "input-removd" (did you mean "input-removed"?)`},
+		{"typo in ID", "BC001\nBC0002\n", `rules.txt:2: unknown rule "BC0002"`},
+		{"unmatched prefix", "ZZ*\n", `no rules match "ZZ*"`},
+		{"only comments", "# nothing yet\n\n", "lists no rules"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.txt")
+			writeTestFile(t, path, tt.content)
+
+			_, err := readRulesFile(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("readRulesFile() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := readRulesFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestConfigureEngine_RulesFrom(t *testing.T) {
+	origRulesFrom := rulesFromIDs
+	defer func() { rulesFromIDs = origRulesFrom }()
+
+	rulesFromIDs = []string{"BC001", "RC006"}
+	engine := rules.NewDefaultEngine()
+	configureEngine(engine, config.Default())
+
+	var enabled []string
+	for _, id := range rules.DefaultRegistry.IDs() {
+		if engine.GetConfig(id).Enabled {
+			enabled = append(enabled, id)
+		}
+	}
+	slices.Sort(enabled)
+	if want := []string{"BC001", "RC006"}; !reflect.DeepEqual(enabled, want) {
+		t.Errorf("enabled rules = %v, want %v", enabled, want)
+	}
+}
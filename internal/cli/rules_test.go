@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/rules"
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+func TestRuleCatalog(t *testing.T) {
+	catalog := ruleCatalog(rules.DefaultRegistry)
+
+	if len(catalog) != len(rules.DefaultRegistry.IDs()) {
+		t.Fatalf("expected %d rules, got %d", len(rules.DefaultRegistry.IDs()), len(catalog))
+	}
+	if !sort.SliceIsSorted(catalog, func(i, j int) bool { return catalog[i].ID < catalog[j].ID }) {
+		t.Error("expected rules sorted by ID")
+	}
+
+	for _, e := range catalog {
+		if e.ID != "BC001" {
+			continue
+		}
+		if e.Name != "required-input-added" || e.DefaultSeverity != types.SeverityError {
+			t.Errorf("unexpected BC001 entry: %+v", e)
+		}
+		if e.Category != types.CategoryBreaking || e.Prefix != "BC" {
+			t.Errorf("expected BC001 in category breaking with prefix BC, got %s and %s", e.Category, e.Prefix)
+		}
+		if e.Description == "" {
+			t.Error("expected BC001 to have a description")
+		}
+		return
+	}
+	t.Error("expected BC001 in the catalog")
+}
+
+func TestWriteRuleCatalogJSON(t *testing.T) {
+	catalog := []ruleCatalogEntry{{
+		ID:              "RC006",
+		Name:            "input-default-changed",
+		DefaultSeverity: types.SeverityWarning,
+		Description:     "A variable's default value changed",
+		Category:        types.CategoryRisky,
+		Prefix:          "RC",
+	}}
+
+	var buf bytes.Buffer
+	if err := writeRuleCatalogJSON(&buf, catalog); err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		Rules []map[string]string `json:"rules"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	want := map[string]string{
+		"id":               "RC006",
+		"name":             "input-default-changed",
+		"default_severity": "WARNING",
+		"description":      "A variable's default value changed",
+		"category":         "risky",
+		"prefix":           "RC",
+	}
+	if len(got.Rules) != 1 {
+		t.Fatalf("expected 1 rule, got %d", len(got.Rules))
+	}
+	for k, v := range want {
+		if got.Rules[0][k] != v {
+			t.Errorf("%s = %q, want %q", k, got.Rules[0][k], v)
+		}
+	}
+}
+
+func TestWriteRuleCatalogJSON_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeRuleCatalogJSON(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"rules": []`) {
+		t.Errorf("expected an empty rules array, got %s", buf.String())
+	}
+}
+
+func TestRenderRuleCatalog(t *testing.T) {
+	catalog := []ruleCatalogEntry{
+		{ID: "BC001", Name: "required-input-added", DefaultSeverity: types.SeverityError, Category: types.CategoryBreaking},
+		{ID: "BC002", Name: "input-removed", DefaultSeverity: types.SeverityError, Category: types.CategoryBreaking},
+		{ID: "RC006", Name: "input-default-changed", DefaultSeverity: types.SeverityWarning, Category: types.CategoryRisky},
+	}
+
+	var buf bytes.Buffer
+	renderRuleCatalog(&buf, catalog)
+
+	want := `breaking:
+  ID      NAME                  SEVERITY
+  BC001   required-input-added  ERROR
+  BC002   input-removed         ERROR
+
+risky:
+  ID      NAME                  SEVERITY
+  RC006   input-default-changed WARNING
+`
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
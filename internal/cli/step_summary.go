@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jokarl/tfbreak-core/internal/config"
+	"github.com/jokarl/tfbreak-core/internal/output"
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// stepSummaryEnv names the file GitHub Actions renders as the job summary
+const stepSummaryEnv = "GITHUB_STEP_SUMMARY"
+
+// writeStepSummary appends the result as Markdown to the file named by
+// $GITHUB_STEP_SUMMARY, unless it is unset or --no-step-summary is given.
+// The summary is extra to the primary output, so a failure to write it is
+// only reported as a warning.
+func writeStepSummary(cfg *config.Config, result *types.CheckResult) {
+	path := os.Getenv(stepSummaryEnv)
+	if path == "" || noStepSummaryFlag {
+		return
+	}
+
+	if err := appendStepSummary(path, cfg, result); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write the job summary to %s: %v\n", path, err)
+	}
+}
+
+// appendStepSummary renders result as Markdown at the end of the file at path
+func appendStepSummary(path string, cfg *config.Config, result *types.CheckResult) (err error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	renderer := output.NewRendererWithOptions(output.FormatMarkdown, output.Options{
+		ShowIDs:     showIDsFlag,
+		SourceRoots: sourceRoots(output.FormatMarkdown, result.OldPath, result.NewPath),
+		HideIgnored: !cfg.IsShowIgnoredEnabled(),
+	})
+	return renderer.Render(f, result)
+}
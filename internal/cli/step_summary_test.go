@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/config"
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// TestMain keeps the tests of this package from appending to the job
+// summary when they run in GitHub Actions
+func TestMain(m *testing.M) {
+	os.Unsetenv(stepSummaryEnv)
+	os.Exit(m.Run())
+}
+
+func TestRenderResult_StepSummary(t *testing.T) {
+	origOutput, origNoStepSummary := outputFlag, noStepSummaryFlag
+	defer func() { outputFlag, noStepSummaryFlag = origOutput, origNoStepSummary }()
+
+	dir := t.TempDir()
+	summaryPath := filepath.Join(dir, "summary.md")
+	writeTestFile(t, summaryPath, "# Earlier step\n")
+	t.Setenv(stepSummaryEnv, summaryPath)
+
+	reportPath := filepath.Join(dir, "report.json")
+	outputFlag = reportPath
+	noStepSummaryFlag = false
+
+	cfg := config.Default()
+	cfg.Output.Format = "json"
+	result := &types.CheckResult{OldPath: "./old", NewPath: "./new", Result: "PASS"}
+	if err := renderResult(cfg, result); err != nil {
+		t.Fatalf("renderResult() error = %v", err)
+	}
+
+	// The primary output is written as usual
+	report, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	if !contains(string(report), `"result":"PASS"`) {
+		t.Errorf("report = %s, want JSON result", report)
+	}
+
+	// The summary is appended as Markdown after what earlier steps wrote
+	summary, err := os.ReadFile(summaryPath)
+	if err != nil {
+		t.Fatalf("failed to read summary: %v", err)
+	}
+	want := "# Earlier step\n## tfbreak: PASS\n\nComparing `./old` -> `./new`\n\nNo breaking changes detected.\n"
+	if string(summary) != want {
+		t.Errorf("summary = %q, want %q", summary, want)
+	}
+
+	// --no-step-summary leaves the file alone
+	noStepSummaryFlag = true
+	if err := renderResult(cfg, result); err != nil {
+		t.Fatalf("renderResult() error = %v", err)
+	}
+	after, err := os.ReadFile(summaryPath)
+	if err != nil {
+		t.Fatalf("failed to read summary: %v", err)
+	}
+	if string(after) != want {
+		t.Errorf("summary changed with --no-step-summary: %q", after)
+	}
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jokarl/tfbreak-core/internal/config"
+	"github.com/jokarl/tfbreak-core/internal/rules"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check a config file for problems",
+	Long: `Load the config file and report every problem with it, such as an
+unknown rule name, a severity or fail_on value that does not parse, or an
+include or exclude pattern that is not a valid glob.
+
+The config file is found the same way as for check. Each problem is
+printed with the setting it concerns. The command exits with code 1 if
+any problem is found, and prints "config OK" otherwise.
+
+Example:
+  tfbreak validate
+  tfbreak validate --config ci/.tfbreak.hcl`,
+	Args: cobra.NoArgs,
+	RunE: runValidate,
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+
+	validateCmd.Flags().StringVarP(&configFlag, "config", "c", "", "Path to config file")
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	if !validateConfig(os.Stdout, configFlag) {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// validateConfig loads the config file at configPath, or the one check
+// would find, writes its problems to w, and reports whether there were none
+func validateConfig(w io.Writer, configPath string) bool {
+	cfg, problems, err := config.Check(configPath, "")
+	if err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return false
+	}
+
+	if cfg.ConfigPath() == "" {
+		fmt.Fprintln(w, "config OK (no .tfbreak.hcl found, using defaults)")
+		return true
+	}
+
+	for _, p := range problems {
+		fmt.Fprintf(w, "%s: %s: %s\n", cfg.ConfigPath(), p.Key, p.Message)
+	}
+	switch len(problems) {
+	case 0:
+		fmt.Fprintln(w, "config OK")
+		return true
+	case 1:
+		fmt.Fprintln(w, "\n1 problem found")
+	default:
+		fmt.Fprintf(w, "\n%d problems found\n", len(problems))
+	}
+	return false
+}
+
+// registryRuleValidator resolves the rule names in config files against a
+// rule registry. Like the config package's own list of names, it does not
+// accept rule IDs.
+type registryRuleValidator struct {
+	registry *rules.Registry
+}
+
+func (v registryRuleValidator) IsValidRuleID(ruleID string) bool {
+	_, ok := v.registry.Get(ruleID)
+	return ok
+}
+
+func (v registryRuleValidator) IsValidRuleName(name string) bool {
+	_, ok := v.registry.GetByName(name)
+	return ok
+}
+
+func (v registryRuleValidator) ResolveToID(nameOrID string) (string, bool) {
+	r, ok := v.registry.GetByName(nameOrID)
+	if !ok {
+		return "", false
+	}
+	return r.ID(), true
+}
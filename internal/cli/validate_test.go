@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/rules"
+)
+
+func TestValidateConfig(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, ".tfbreak.hcl")
+	writeTestFile(t, filepath.Join(dir, ".tfbreak.hcl"), `version = 1
+
+paths {
+  include = ["**/*.tf"]
+  exclude = ["[unclosed"]
+}
+
+policy {
+  fail_on = "ERORR"
+}
+
+rules "input-removd" {
+  enabled = false
+}
+
+rules "input-default-changed" {
+  enabled  = true
+  severity = "WARN"
+}
+`)
+
+	var buf bytes.Buffer
+	if validateConfig(&buf, configPath) {
+		t.Fatalf("expected problems, got:\n%s", buf.String())
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		configPath + `: paths exclude[0]: invalid exclude pattern: [unclosed`,
+		configPath + `: policy fail_on: invalid fail_on severity: ERORR`,
+		configPath + `: rules "input-removd": unknown rule: input-removd`,
+		configPath + `: rules "input-default-changed" severity: invalid severity for rule input-default-changed: WARN`,
+		"4 problems found",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestValidateConfig_OK(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, ".tfbreak.hcl"), `version = 1
+
+policy {
+  fail_on = "WARNING"
+}
+
+rules "input-removed" {
+  severity = "WARNING"
+}
+`)
+
+	var buf bytes.Buffer
+	if !validateConfig(&buf, filepath.Join(dir, ".tfbreak.hcl")) {
+		t.Fatalf("expected no problems, got:\n%s", buf.String())
+	}
+	if buf.String() != "config OK\n" {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestValidateConfig_Errors(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "invalid.hcl"), `version = `)
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"missing file", filepath.Join(dir, "missing.hcl"), "config file not found"},
+		{"invalid syntax", filepath.Join(dir, "invalid.hcl"), "failed to parse config file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if validateConfig(&buf, tt.path) {
+				t.Fatal("expected validation to fail")
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("expected output to contain %q, got:\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestRegistryRuleValidator(t *testing.T) {
+	v := registryRuleValidator{rules.DefaultRegistry}
+
+	if id, ok := v.ResolveToID("input-removed"); !ok || id != "BC002" {
+		t.Errorf("ResolveToID(input-removed) = %q, %v, want BC002, true", id, ok)
+	}
+	if _, ok := v.ResolveToID("BC002"); ok {
+		t.Error("expected rule IDs not to resolve, as in the config package")
+	}
+	if !v.IsValidRuleID("BC002") || v.IsValidRuleID("BC999") {
+		t.Error("unexpected IsValidRuleID result")
+	}
+	if !v.IsValidRuleName("input-removed") || v.IsValidRuleName("input-removd") {
+		t.Error("unexpected IsValidRuleName result")
+	}
+}
@@ -9,7 +9,7 @@ import (
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
-	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
 
 	"github.com/jokarl/tfbreak-core/internal/types"
 )
@@ -17,17 +17,28 @@ import (
 // Config represents the tfbreak configuration
 type Config struct {
 	Version         int                     `hcl:"version,attr"`
+	Extends         string                  `hcl:"extends,optional"`
 	ConfigBlock     *ConfigBlockConfig      `hcl:"config,block"`
 	Paths           *PathsConfig            `hcl:"paths,block"`
 	Output          *OutputConfig           `hcl:"output,block"`
 	Policy          *PolicyConfig           `hcl:"policy,block"`
 	Annotations     *AnnotationsConfig      `hcl:"annotations,block"`
 	RenameDetection *RenameDetectionConfig  `hcl:"rename_detection,block"`
+	Deprecation     *DeprecationConfig      `hcl:"deprecation,block"`
 	Rules           []*RuleConfig           `hcl:"rules,block"`
+	Overrides       []*OverrideConfig       `hcl:"override,block"`
 	Plugins         []*PluginConfig         `hcl:"plugin,block"`
+	Profiles        []*ProfileConfig        `hcl:"profile,block"`
+	Git             *GitConfig              `hcl:"git,block"`
 
 	// Internal: path to the loaded config file (empty if using defaults)
 	configPath string
+
+	// Internal: how the config file was found
+	source Source
+
+	// Internal: name of the applied profile (empty if none)
+	profile string
 }
 
 // ConfigBlockConfig defines global tfbreak settings (tflint-aligned)
@@ -51,16 +62,34 @@ type PathsConfig struct {
 
 // OutputConfig defines output settings
 type OutputConfig struct {
-	Format string `hcl:"format,attr"`
-	Color  string `hcl:"color,attr"`
+	Format      string `hcl:"format,optional"`
+	Color       string `hcl:"color,optional"`
+	HelpURLBase string `hcl:"help_url_base,optional"`
+	ShowIgnored *bool  `hcl:"show_ignored,optional"`
 }
 
 // PolicyConfig defines CI policy settings
 type PolicyConfig struct {
-	FailOn                 string `hcl:"fail_on,attr"`
-	TreatWarningsAsErrors  bool   `hcl:"treat_warnings_as_errors,optional"`
+	FailOnExpr             hcl.Expression `hcl:"fail_on,optional"`
+	TreatWarningsAsErrors  bool           `hcl:"treat_warnings_as_errors,optional"`
+	TreatRiskyAsErrors     bool           `hcl:"treat_risky_as_errors,optional"`
+	EscalateRequired       bool           `hcl:"escalate_required,optional"`
+	EscalateFanOut         int            `hcl:"escalate_fan_out,optional"`
+	RequiredRules          []string       `hcl:"required_rules,optional"`
+	InterfaceOnly          bool           `hcl:"interface_only,optional"`
+
+	// FailOn is the scalar fail_on severity, decoded from FailOnExpr
+	// when fail_on is a string
+	FailOn string
+
+	// FailOnCategory maps category names to a severity or "off", decoded
+	// from FailOnExpr when fail_on is an object
+	FailOnCategory map[string]string
 }
 
+// FailOff disables failing for a category in the per-category fail_on form
+const FailOff = "off"
+
 // AnnotationsConfig defines annotation/ignore settings
 type AnnotationsConfig struct {
 	Enabled      *bool    `hcl:"enabled,attr"`
@@ -76,12 +105,43 @@ type RuleConfig struct {
 	Severity *string `hcl:"severity,attr"`
 }
 
+// OverrideConfig defines per-rule settings for findings in files matching a
+// path glob, e.g. override "modules/**" { rules "input-type-changed" { ... } }.
+// Paths are relative to the compared directories.
+type OverrideConfig struct {
+	Path  string        `hcl:"path,label"`
+	Rules []*RuleConfig `hcl:"rules,block"`
+}
+
 // RenameDetectionConfig defines settings for rename heuristic rules (BC003, RC003, BC010)
 type RenameDetectionConfig struct {
 	Enabled             *bool    `hcl:"enabled,attr"`
 	SimilarityThreshold *float64 `hcl:"similarity_threshold,attr"`
 }
 
+// DeprecationConfig defines settings for the deprecation marker rule (RC017)
+type DeprecationConfig struct {
+	// Marker is a regular expression matching a deprecation marker in a
+	// variable description. Its group named "version", or else its first
+	// capture group, is the version the variable is to be removed in.
+	Marker string `hcl:"marker,optional"`
+
+	// ModuleVersion is the version of the module being checked, compared
+	// with the removal versions. --module-version overrides it.
+	ModuleVersion string `hcl:"module_version,optional"`
+}
+
+// GitConfig defines settings for git ref comparison
+type GitConfig struct {
+	// PRRefTemplate is the ref a pull request number resolves to, with %d
+	// standing in for the number (e.g. "refs/merge-requests/%d/head")
+	PRRefTemplate string `hcl:"pr_ref_template,optional"`
+}
+
+// DefaultPRRefTemplate is the ref GitHub publishes for a pull request: the
+// result of merging it into its target branch
+const DefaultPRRefTemplate = "refs/pull/%d/merge"
+
 // DefaultSimilarityThreshold is the default threshold for rename detection
 const DefaultSimilarityThreshold = 0.85
 
@@ -90,6 +150,23 @@ func (c *Config) ConfigPath() string {
 	return c.configPath
 }
 
+// Source returns how the config file was found
+func (c *Config) Source() Source {
+	if c.source == "" {
+		return SourceDefault
+	}
+	return c.source
+}
+
+// PRRefTemplate returns the configured pull request ref template, or
+// DefaultPRRefTemplate if none is configured
+func (c *Config) PRRefTemplate() string {
+	if c.Git == nil || c.Git.PRRefTemplate == "" {
+		return DefaultPRRefTemplate
+	}
+	return c.Git.PRRefTemplate
+}
+
 // GetRuleConfig returns the configuration for a specific rule, or nil if not configured
 func (c *Config) GetRuleConfig(ruleID string) *RuleConfig {
 	for _, rc := range c.Rules {
@@ -146,6 +223,112 @@ func (c *Config) GetSimilarityThreshold() float64 {
 	return *c.RenameDetection.SimilarityThreshold
 }
 
+// GetDeprecationMarker returns the configured deprecation marker pattern, or
+// empty to use the rule's default
+func (c *Config) GetDeprecationMarker() string {
+	if c.Deprecation == nil {
+		return ""
+	}
+	return c.Deprecation.Marker
+}
+
+// GetModuleVersion returns the configured module version, or empty if unset
+func (c *Config) GetModuleVersion() string {
+	if c.Deprecation == nil {
+		return ""
+	}
+	return c.Deprecation.ModuleVersion
+}
+
+// IsEscalateRequiredEnabled returns whether findings for removed required
+// variables should be escalated
+func (c *Config) IsEscalateRequiredEnabled() bool {
+	if c.Policy == nil {
+		return false // disabled by default (opt-in)
+	}
+	return c.Policy.EscalateRequired
+}
+
+// GetEscalateFanOut returns the number of outputs a removed variable must
+// feed for its finding to be escalated, or 0 if fan-out escalation is off
+func (c *Config) GetEscalateFanOut() int {
+	if c.Policy == nil {
+		return 0 // disabled by default (opt-in)
+	}
+	return c.Policy.EscalateFanOut
+}
+
+// IsInterfaceOnlyEnabled returns whether only the rules that check the
+// module's public interface should run
+func (c *Config) IsInterfaceOnlyEnabled() bool {
+	if c.Policy == nil {
+		return false
+	}
+	return c.Policy.InterfaceOnly
+}
+
+// GetHelpURLBase returns the configured base URL for rule documentation
+// links, or empty to use the built-in documentation
+func (c *Config) GetHelpURLBase() string {
+	if c.Output == nil {
+		return ""
+	}
+	return c.Output.HelpURLBase
+}
+
+// IsShowIgnoredEnabled returns whether ignored findings are included in the
+// rendered output
+func (c *Config) IsShowIgnoredEnabled() bool {
+	if c.Output == nil || c.Output.ShowIgnored == nil {
+		return true // shown by default
+	}
+	return *c.Output.ShowIgnored
+}
+
+// GetRequiredRules returns the rules that must not be disabled, as configured
+// in policy.required_rules
+func (c *Config) GetRequiredRules() []string {
+	if c.Policy == nil {
+		return nil
+	}
+	return c.Policy.RequiredRules
+}
+
+// GetFailOnCategories returns the per-category fail thresholds, or nil if
+// fail_on is a plain severity. Categories set to "off" are omitted; categories
+// not listed use the scalar fail_on (which defaults to ERROR).
+func (c *Config) GetFailOnCategories() (map[types.Category]types.Severity, error) {
+	if c.Policy == nil || c.Policy.FailOnCategory == nil {
+		return nil, nil
+	}
+
+	fallback, err := types.ParseSeverity(c.Policy.FailOn)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[types.Category]types.Severity)
+	for _, cat := range types.Categories() {
+		result[cat] = fallback
+	}
+	for name, value := range c.Policy.FailOnCategory {
+		cat, err := types.ParseCategory(name)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(value, FailOff) {
+			delete(result, cat)
+			continue
+		}
+		sev, err := types.ParseSeverity(value)
+		if err != nil {
+			return nil, err
+		}
+		result[cat] = sev
+	}
+	return result, nil
+}
+
 // GetPluginDir returns the configured plugin directory, or empty string if not set
 func (c *Config) GetPluginDir() string {
 	if c.ConfigBlock == nil {
@@ -184,20 +367,27 @@ func (c *Config) GetEnabledPlugins() []string {
 	return enabled
 }
 
-// Load loads configuration from the specified path or searches for it
-// Search order: configPath (if provided), .tfbreak.hcl in cwd, .tfbreak.hcl in oldDir
-func Load(configPath, oldDir string) (*Config, error) {
-	var path string
+// ConfigEnv is the environment variable naming the config file to use when
+// no path is given explicitly
+const ConfigEnv = "TFBREAK_CONFIG"
 
-	if configPath != "" {
-		// Explicit path provided
-		path = configPath
-		if _, err := os.Stat(path); err != nil {
-			return nil, fmt.Errorf("config file not found: %s", path)
-		}
-	} else {
-		// Search for config file
-		path = findConfigFile(oldDir)
+// Source describes how the config file was found
+type Source string
+
+const (
+	SourceFlag    Source = "flag"    // configPath passed to Load (--config)
+	SourceEnv     Source = "env"     // the TFBREAK_CONFIG environment variable
+	SourceSearch  Source = "search"  // .tfbreak.hcl in the current or old directory
+	SourceDefault Source = "default" // no config file, built-in defaults
+)
+
+// Load loads configuration from the specified path or searches for it.
+// Precedence: configPath (if provided), the file named by TFBREAK_CONFIG,
+// .tfbreak.hcl in cwd, .tfbreak.hcl in oldDir, and finally the defaults.
+func Load(configPath, oldDir string) (*Config, error) {
+	path, source, err := locate(configPath, oldDir)
+	if err != nil {
+		return nil, err
 	}
 
 	if path == "" {
@@ -205,7 +395,57 @@ func Load(configPath, oldDir string) (*Config, error) {
 		return Default(), nil
 	}
 
-	return loadFromFile(path)
+	cfg, err := loadFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg.source = source
+	return cfg, nil
+}
+
+// Check loads configuration like Load, but returns every problem found by
+// Problems instead of failing on the first. Errors that stop the file from
+// being read or decoded are returned as an error. If no config file is
+// found, Check returns the defaults, whose ConfigPath is empty.
+func Check(configPath, oldDir string) (*Config, []Problem, error) {
+	path, source, err := locate(configPath, oldDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if path == "" {
+		return Default(), nil, nil
+	}
+
+	cfg, err := decodeFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg.source = source
+	applyDefaults(cfg)
+	return cfg, Problems(cfg), nil
+}
+
+// locate returns the path of the config file Load uses and how it was
+// found, or an empty path if there is none
+func locate(configPath, oldDir string) (string, Source, error) {
+	switch {
+	case configPath != "":
+		// Explicit path provided
+		if _, err := os.Stat(configPath); err != nil {
+			return "", "", fmt.Errorf("config file not found: %s", configPath)
+		}
+		return configPath, SourceFlag, nil
+	case os.Getenv(ConfigEnv) != "":
+		path := os.Getenv(ConfigEnv)
+		if _, err := os.Stat(path); err != nil {
+			return "", "", fmt.Errorf("config file not found: %s (from %s)", path, ConfigEnv)
+		}
+		return path, SourceEnv, nil
+	default:
+		// Search for config file
+		return findConfigFile(oldDir), SourceSearch, nil
+	}
 }
 
 // findConfigFile searches for .tfbreak.hcl in standard locations
@@ -230,27 +470,59 @@ func findConfigFile(oldDir string) string {
 	return ""
 }
 
-// loadFromFile loads and parses a configuration file
+// loadFromFile loads and parses a configuration file, merged over the
+// configs it extends
 func loadFromFile(path string) (*Config, error) {
-	parser := hclparse.NewParser()
-	file, diags := parser.ParseHCLFile(path)
-	if diags.HasErrors() {
-		return nil, fmt.Errorf("failed to parse config file: %s", formatDiagnostics(diags))
+	config, err := decodeFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply defaults for missing optional blocks
+	applyDefaults(config)
+
+	// Validate
+	if err := Validate(config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// decodeFile parses and decodes a configuration file, merged over the
+// configs it extends, without applying defaults or validating it
+func decodeFile(path string) (*Config, error) {
+	body, err := parseConfig(path)
+	if err != nil {
+		return nil, err
 	}
 
 	var config Config
-	decodeDiags := gohcl.DecodeBody(file.Body, nil, &config)
+	decodeDiags := gohcl.DecodeBody(body, nil, &config)
 	if decodeDiags.HasErrors() {
 		return nil, fmt.Errorf("failed to decode config: %s", formatDiagnostics(decodeDiags))
 	}
 
 	config.configPath = path
 
-	// Apply defaults for missing optional blocks
-	applyDefaults(&config)
+	if config.Policy != nil {
+		if err := decodeFailOn(config.Policy); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range config.Profiles {
+		if p.Policy == nil {
+			continue
+		}
+		failOn, failOnCategory, err := decodeFailOnExpr(p.Policy.FailOnExpr)
+		if err != nil {
+			return nil, fmt.Errorf("profile %q: %w", p.Name, err)
+		}
+		p.Policy.FailOn, p.Policy.FailOnCategory = failOn, failOnCategory
+	}
 
-	// Validate
-	if err := Validate(&config); err != nil {
+	// Expand ${env.VAR} references now that the values are decoded
+	if err := interpolateEnv(&config); err != nil {
 		return nil, err
 	}
 
@@ -280,6 +552,51 @@ func formatDiagnostics(diags hcl.Diagnostics) string {
 	return b.String()
 }
 
+// decodeFailOn decodes the policy fail_on attribute, which is either a
+// severity string or an object mapping categories to severities
+func decodeFailOn(policy *PolicyConfig) error {
+	failOn, failOnCategory, err := decodeFailOnExpr(policy.FailOnExpr)
+	if err != nil {
+		return err
+	}
+	policy.FailOn, policy.FailOnCategory = failOn, failOnCategory
+	return nil
+}
+
+// decodeFailOnExpr decodes a fail_on expression into a scalar severity or a
+// map of category thresholds. Both are empty if the attribute is unset.
+func decodeFailOnExpr(expr hcl.Expression) (string, map[string]string, error) {
+	if expr == nil {
+		return "", nil, nil
+	}
+
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		return "", nil, fmt.Errorf("failed to decode config: %s", formatDiagnostics(diags))
+	}
+	if val.IsNull() {
+		return "", nil, nil
+	}
+
+	ty := val.Type()
+	switch {
+	case ty == cty.String:
+		return val.AsString(), nil, nil
+	case ty.IsObjectType() || ty.IsMapType():
+		failOnCategory := make(map[string]string)
+		for it := val.ElementIterator(); it.Next(); {
+			k, v := it.Element()
+			if v.IsNull() || v.Type() != cty.String {
+				return "", nil, fmt.Errorf("invalid fail_on value for %q: must be a severity string or \"off\"", k.AsString())
+			}
+			failOnCategory[k.AsString()] = v.AsString()
+		}
+		return "", failOnCategory, nil
+	default:
+		return "", nil, fmt.Errorf("invalid fail_on: must be a severity string or an object of category thresholds")
+	}
+}
+
 // applyDefaults fills in default values for missing optional config blocks
 func applyDefaults(cfg *Config) {
 	defaults := Default()
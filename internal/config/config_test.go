@@ -1,8 +1,10 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/jokarl/tfbreak-core/internal/types"
@@ -139,6 +141,65 @@ func TestLoadNotFound(t *testing.T) {
 	}
 }
 
+func TestLoadPrecedence(t *testing.T) {
+	// Each config writes a different output format to tell them apart
+	writeConfig := func(t *testing.T, dir, format string) string {
+		t.Helper()
+		path := filepath.Join(dir, ".tfbreak.hcl")
+		content := fmt.Sprintf("version = 1\n\noutput {\n  format = %q\n}\n", format)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write config file: %v", err)
+		}
+		return path
+	}
+
+	flagPath := writeConfig(t, t.TempDir(), "json")
+	envPath := writeConfig(t, t.TempDir(), "sarif")
+	searchDir := t.TempDir()
+	writeConfig(t, searchDir, "junit")
+
+	tests := []struct {
+		name       string
+		configPath string
+		env        string
+		cwd        string
+		wantFormat string
+		wantSource Source
+	}{
+		{"flag beats env", flagPath, envPath, searchDir, "json", SourceFlag},
+		{"env beats search", "", envPath, searchDir, "sarif", SourceEnv},
+		{"search without env", "", "", searchDir, "junit", SourceSearch},
+		{"default without config", "", "", t.TempDir(), "text", SourceDefault},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ConfigEnv, tt.env)
+			t.Chdir(tt.cwd)
+
+			cfg, err := Load(tt.configPath, "")
+			if err != nil {
+				t.Fatalf("failed to load config: %v", err)
+			}
+			if cfg.Output.Format != tt.wantFormat {
+				t.Errorf("format = %s, want %s", cfg.Output.Format, tt.wantFormat)
+			}
+			if cfg.Source() != tt.wantSource {
+				t.Errorf("source = %s, want %s", cfg.Source(), tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestLoadEnvNotFound(t *testing.T) {
+	t.Setenv(ConfigEnv, "/nonexistent/path/.tfbreak.hcl")
+
+	_, err := Load("", "")
+	if err == nil || !strings.Contains(err.Error(), ConfigEnv) {
+		t.Errorf("expected error naming %s, got %v", ConfigEnv, err)
+	}
+}
+
 func TestLoadDefaultsWhenNoConfig(t *testing.T) {
 	// Load from empty directory (no config file)
 	tmpDir := t.TempDir()
@@ -211,6 +272,264 @@ policy {
 	}
 }
 
+func TestLoadFailOnPerCategory(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".tfbreak.hcl")
+
+	configContent := `
+version = 1
+policy {
+  fail_on = {
+    breaking = "ERROR"
+    risky    = "off"
+  }
+}
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := Load(configPath, "")
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	// Scalar alias still defaults to ERROR
+	if cfg.Policy.FailOn != "ERROR" {
+		t.Errorf("expected fail_on 'ERROR', got %s", cfg.Policy.FailOn)
+	}
+
+	thresholds, err := cfg.GetFailOnCategories()
+	if err != nil {
+		t.Fatalf("GetFailOnCategories failed: %v", err)
+	}
+	if sev, ok := thresholds[types.CategoryBreaking]; !ok || sev != types.SeverityError {
+		t.Errorf("breaking threshold = %v (set %v), want ERROR", sev, ok)
+	}
+	if _, ok := thresholds[types.CategoryRisky]; ok {
+		t.Error("expected risky to be off")
+	}
+	// Unlisted categories fall back to the scalar fail_on
+	if sev, ok := thresholds[types.CategoryAdvisory]; !ok || sev != types.SeverityError {
+		t.Errorf("advisory threshold = %v (set %v), want ERROR", sev, ok)
+	}
+}
+
+func TestLoadFailOnScalarHasNoCategories(t *testing.T) {
+	cfg := Default()
+	thresholds, err := cfg.GetFailOnCategories()
+	if err != nil {
+		t.Fatalf("GetFailOnCategories failed: %v", err)
+	}
+	if thresholds != nil {
+		t.Errorf("expected nil thresholds for scalar fail_on, got %v", thresholds)
+	}
+}
+
+func TestLoadFailOnInvalidCategory(t *testing.T) {
+	tests := []struct {
+		name   string
+		failOn string
+	}{
+		{"unknown category", `{ cosmetic = "ERROR" }`},
+		{"invalid severity", `{ breaking = "FATAL" }`},
+		{"non-string value", `{ breaking = 1 }`},
+		{"invalid type", `["ERROR"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			configPath := filepath.Join(tmpDir, ".tfbreak.hcl")
+
+			configContent := "version = 1\npolicy {\n  fail_on = " + tt.failOn + "\n}\n"
+			if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+				t.Fatalf("failed to write config file: %v", err)
+			}
+
+			if _, err := Load(configPath, ""); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestLoadEscalateRequired(t *testing.T) {
+	if Default().IsEscalateRequiredEnabled() {
+		t.Error("expected escalate_required to be disabled by default")
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".tfbreak.hcl")
+
+	configContent := `
+version = 1
+policy {
+  escalate_required = true
+}
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := Load(configPath, "")
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if !cfg.IsEscalateRequiredEnabled() {
+		t.Error("expected escalate_required to be enabled")
+	}
+}
+
+func TestLoadEscalateFanOut(t *testing.T) {
+	if got := Default().GetEscalateFanOut(); got != 0 {
+		t.Errorf("GetEscalateFanOut() = %d, want 0 by default", got)
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".tfbreak.hcl")
+
+	configContent := `
+version = 1
+policy {
+  escalate_fan_out = 3
+}
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := Load(configPath, "")
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if got := cfg.GetEscalateFanOut(); got != 3 {
+		t.Errorf("GetEscalateFanOut() = %d, want 3", got)
+	}
+
+	cfg.Policy.EscalateFanOut = -1
+	if err := Validate(cfg); err == nil {
+		t.Error("expected error for negative escalate_fan_out")
+	}
+}
+
+func TestLoadInterfaceOnly(t *testing.T) {
+	if Default().IsInterfaceOnlyEnabled() {
+		t.Error("expected interface_only to be disabled by default")
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".tfbreak.hcl")
+
+	configContent := `
+version = 1
+policy {
+  interface_only = true
+}
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := Load(configPath, "")
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if !cfg.IsInterfaceOnlyEnabled() {
+		t.Error("expected interface_only to be enabled")
+	}
+}
+
+func TestLoadHelpURLBase(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".tfbreak.hcl")
+
+	configContent := `version = 1
+output {
+  help_url_base = "https://docs.example.com/rules/"
+}
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := Load(configPath, "")
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if got := cfg.GetHelpURLBase(); got != "https://docs.example.com/rules/" {
+		t.Errorf("GetHelpURLBase() = %q, want %q", got, "https://docs.example.com/rules/")
+	}
+	// format and color fall back to defaults when omitted
+	if cfg.Output.Format != "text" {
+		t.Errorf("Output.Format = %q, want text", cfg.Output.Format)
+	}
+}
+
+func TestLoadShowIgnored(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".tfbreak.hcl")
+
+	configContent := `version = 1
+output {
+  show_ignored = false
+}
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := Load(configPath, "")
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if cfg.IsShowIgnoredEnabled() {
+		t.Error("expected show_ignored = false to hide ignored findings")
+	}
+	if !Default().IsShowIgnoredEnabled() {
+		t.Error("expected ignored findings to be shown by default")
+	}
+}
+
+func TestLoadRequiredRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   string
+		wantErr bool
+	}{
+		{"valid rule names", `["resource-removed-no-moved", "input-removed"]`, false},
+		{"unknown rule", `["not-a-rule"]`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			configPath := filepath.Join(tmpDir, ".tfbreak.hcl")
+
+			configContent := "version = 1\npolicy {\n  required_rules = " + tt.rules + "\n}\n"
+			if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+				t.Fatalf("failed to write config file: %v", err)
+			}
+
+			cfg, err := Load(configPath, "")
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to load config: %v", err)
+			}
+			if len(cfg.GetRequiredRules()) != 2 {
+				t.Errorf("GetRequiredRules() = %v, want 2 rules", cfg.GetRequiredRules())
+			}
+		})
+	}
+}
+
 func TestLoadInvalidRuleID(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, ".tfbreak.hcl")
@@ -231,6 +550,65 @@ rules "INVALID_RULE" {
 	}
 }
 
+func TestLoadOverrides(t *testing.T) {
+	tests := []struct {
+		name     string
+		override string
+		wantErr  string
+	}{
+		{
+			name:     "valid",
+			override: "override \"modules/**\" {\n  rules \"input-type-changed\" {\n    severity = \"ERROR\"\n  }\n}\n",
+		},
+		{
+			name:     "invalid glob",
+			override: "override \"modules/[\" {\n  rules \"input-type-changed\" {\n    severity = \"ERROR\"\n  }\n}\n",
+			wantErr:  "invalid override path: modules/[",
+		},
+		{
+			name:     "unknown rule",
+			override: "override \"modules/**\" {\n  rules \"not-a-rule\" {\n    enabled = false\n  }\n}\n",
+			wantErr:  `unknown rule in override "modules/**": not-a-rule`,
+		},
+		{
+			name:     "invalid severity",
+			override: "override \"modules/**\" {\n  rules \"input-type-changed\" {\n    severity = \"FATAL\"\n  }\n}\n",
+			wantErr:  "invalid severity for rule input-type-changed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			configPath := filepath.Join(tmpDir, ".tfbreak.hcl")
+			if err := os.WriteFile(configPath, []byte("version = 1\n"+tt.override), 0644); err != nil {
+				t.Fatalf("failed to write config file: %v", err)
+			}
+
+			cfg, err := Load(configPath, "")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to load config: %v", err)
+			}
+			if len(cfg.Overrides) != 1 || cfg.Overrides[0].Path != "modules/**" {
+				t.Fatalf("Overrides = %+v, want one for modules/**", cfg.Overrides)
+			}
+			rules := cfg.Overrides[0].Rules
+			if len(rules) != 1 || rules[0].ID != "input-type-changed" || rules[0].Severity == nil || *rules[0].Severity != "ERROR" {
+				t.Errorf("override rules = %+v, want input-type-changed at ERROR", rules)
+			}
+			if rules[0].Enabled != nil {
+				t.Error("expected enabled to stay unset")
+			}
+		})
+	}
+}
+
 func TestConfigPath(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, ".tfbreak.hcl")
@@ -407,3 +785,180 @@ func TestConfig_GetEnabledPlugins(t *testing.T) {
 		t.Error("expected aws to be disabled")
 	}
 }
+
+func TestConfig_GitBlock(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".tfbreak.hcl")
+
+	configContent := `
+version = 1
+
+git {
+  pr_ref_template = "refs/merge-requests/%d/head"
+}
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := Load(configPath, "")
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if got := cfg.PRRefTemplate(); got != "refs/merge-requests/%d/head" {
+		t.Errorf("expected GitLab template, got %s", got)
+	}
+	if got := Default().PRRefTemplate(); got != DefaultPRRefTemplate {
+		t.Errorf("expected default template %s, got %s", DefaultPRRefTemplate, got)
+	}
+}
+
+func TestValidate_InvalidPRRefTemplate(t *testing.T) {
+	tests := []string{
+		"pull/%d/merge",
+		"refs/pull/merge",
+		"refs/pull/%d/%d",
+	}
+
+	for _, template := range tests {
+		t.Run(template, func(t *testing.T) {
+			cfg := Default()
+			cfg.Git = &GitConfig{PRRefTemplate: template}
+			if err := Validate(cfg); err == nil {
+				t.Errorf("expected error for template %q", template)
+			}
+		})
+	}
+}
+
+func TestProblems(t *testing.T) {
+	cfg := Default()
+	cfg.Paths.Include = []string{"**/*.tf", "[unclosed"}
+	cfg.Policy.FailOn = "ERORR"
+	cfg.Policy.FailOnCategory = map[string]string{"risky": "LOUD", "breaking": "ERROR"}
+	severity := "WARN"
+	cfg.Rules = []*RuleConfig{
+		{ID: "input-removd"},
+		{ID: "input-removed", Severity: &severity},
+	}
+	cfg.Profiles = []*ProfileConfig{{
+		Name:   "release",
+		Policy: &ProfilePolicyConfig{FailOn: "NONE"},
+	}}
+
+	var keys []string
+	for _, p := range Problems(cfg) {
+		keys = append(keys, p.Key)
+	}
+	want := []string{
+		"paths include[1]",
+		"policy fail_on",
+		"policy fail_on.risky",
+		`rules "input-removd"`,
+		`rules "input-removed" severity`,
+		`profile "release" policy fail_on`,
+	}
+	if strings.Join(keys, "\n") != strings.Join(want, "\n") {
+		t.Errorf("problem keys = %q, want %q", keys, want)
+	}
+
+	if err := Validate(cfg); err == nil || err.Error() != "invalid include pattern: [unclosed (must be a glob pattern)" {
+		t.Errorf("expected Validate to return the first problem, got %v", err)
+	}
+	if problems := Problems(Default()); len(problems) != 0 {
+		t.Errorf("expected no problems with the defaults, got %v", problems)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".tfbreak.hcl")
+	content := `
+version = 1
+
+output {
+  format = "yaml"
+}
+
+policy {
+  fail_on = "ERORR"
+}
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(configPath, ""); err == nil {
+		t.Fatal("expected Load to fail")
+	}
+
+	cfg, problems, err := Check(configPath, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ConfigPath() != configPath || cfg.Source() != SourceFlag {
+		t.Errorf("unexpected config path %q and source %q", cfg.ConfigPath(), cfg.Source())
+	}
+	if len(problems) != 2 || problems[0].Key != "output format" || problems[1].Key != "policy fail_on" {
+		t.Errorf("unexpected problems: %+v", problems)
+	}
+
+	if _, _, err := Check(filepath.Join(tmpDir, "missing.hcl"), ""); err == nil {
+		t.Error("expected error for a missing config file")
+	}
+}
+
+func TestConfig_DeprecationBlock(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".tfbreak.hcl")
+	content := `
+version = 1
+
+deprecation {
+  marker         = "@deprecated until=(?P<version>[0-9.]+)"
+  module_version = "4.2.0"
+}
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath, "")
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if got := cfg.GetDeprecationMarker(); got != "@deprecated until=(?P<version>[0-9.]+)" {
+		t.Errorf("unexpected marker %q", got)
+	}
+	if got := cfg.GetModuleVersion(); got != "4.2.0" {
+		t.Errorf("unexpected module version %q", got)
+	}
+	if Default().GetModuleVersion() != "" || Default().GetDeprecationMarker() != "" {
+		t.Error("expected no deprecation settings by default")
+	}
+}
+
+func TestValidate_InvalidDeprecation(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  DeprecationConfig
+		wantKey string
+	}{
+		{"invalid pattern", DeprecationConfig{Marker: "deprecated ("}, "deprecation marker"},
+		{"no capture group", DeprecationConfig{Marker: "deprecated"}, "deprecation marker"},
+		{"invalid version", DeprecationConfig{ModuleVersion: "latest"}, "deprecation module_version"},
+		{"pre-release version", DeprecationConfig{ModuleVersion: "1.0.0-rc1"}, "deprecation module_version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Deprecation = &tt.config
+			problems := Problems(cfg)
+			if len(problems) != 1 || problems[0].Key != tt.wantKey {
+				t.Errorf("expected one %s problem, got %+v", tt.wantKey, problems)
+			}
+		})
+	}
+}
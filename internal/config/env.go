@@ -0,0 +1,180 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// envRefPattern matches ${env.VAR} and ${env.VAR:-default} references in
+// decoded config string values. A reference with an extra leading $ was
+// escaped in the source and is kept as literal text.
+var envRefPattern = regexp.MustCompile(`\$?\$\{env\.([A-Za-z_][A-Za-z0-9_]*)(:-[^}]*)?\}`)
+
+// envRefStartPattern matches the start of an env reference in config source.
+// The second group holds the $ of a reference escaped as $${env.VAR}.
+var envRefStartPattern = regexp.MustCompile(`(^|[^$])(\$?)\$\{env\.`)
+
+// escapeEnvRefs escapes the env references in config source, so that HCL
+// decodes them as literal text for interpolateEnv to expand instead of as
+// template interpolations, which it would reject. A reference the source
+// escapes as $${env.VAR} decodes as $${env.VAR}, which expandEnv turns into
+// the literal text ${env.VAR}.
+func escapeEnvRefs(src []byte) []byte {
+	return envRefStartPattern.ReplaceAllFunc(src, func(m []byte) []byte {
+		sub := envRefStartPattern.FindSubmatch(m)
+		if len(sub[2]) > 0 {
+			return append(sub[1], "$$${env."...)
+		}
+		return append(sub[1], "$${env."...)
+	})
+}
+
+// quoteEnvRefs escapes every env reference in config source as if it were
+// written as $${env.VAR}, so none of them is expanded. Configs fetched over
+// https are not trusted with the environment, which may hold secrets.
+func quoteEnvRefs(src []byte) []byte {
+	return envRefStartPattern.ReplaceAll(src, []byte("${1}$$$$$${env."))
+}
+
+// expandEnv replaces the env references in s with the values of the
+// environment variables they name. A reference with a default uses it when
+// the variable is unset or empty; one without is an error when it is unset.
+func expandEnv(s string) (string, error) {
+	var err error
+	expanded := envRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		if strings.HasPrefix(ref, "$$") {
+			return ref[1:]
+		}
+		m := envRefPattern.FindStringSubmatch(ref)
+		name, fallback := m[1], m[2]
+		value, ok := os.LookupEnv(name)
+		if fallback != "" {
+			if value == "" {
+				return fallback[len(":-"):]
+			}
+			return value
+		}
+		if !ok && err == nil {
+			err = fmt.Errorf("environment variable %s is not set (referenced as %s)", name, ref)
+		}
+		return value
+	})
+	if err != nil {
+		return "", err
+	}
+	return expanded, nil
+}
+
+// envField is a decoded config string that may contain env references,
+// named as it is written in the config file
+type envField struct {
+	name  string
+	value *string
+}
+
+// interpolateEnv expands the env references in the string values of cfg,
+// including those of its profiles
+func interpolateEnv(cfg *Config) error {
+	for _, f := range envFields(cfg) {
+		expanded, err := expandEnv(*f.value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.name, err)
+		}
+		*f.value = expanded
+	}
+
+	if cfg.Policy != nil {
+		if err := expandEnvMap("policy fail_on", cfg.Policy.FailOnCategory); err != nil {
+			return err
+		}
+	}
+	for _, p := range cfg.Profiles {
+		if p.Policy == nil {
+			continue
+		}
+		if err := expandEnvMap(fmt.Sprintf("profile %q policy fail_on", p.Name), p.Policy.FailOnCategory); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expandEnvMap expands the env references in the values of m, such as the
+// per-category form of fail_on
+func expandEnvMap(name string, m map[string]string) error {
+	for key, value := range m {
+		expanded, err := expandEnv(value)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", name, key, err)
+		}
+		m[key] = expanded
+	}
+	return nil
+}
+
+// envFields returns the string values of cfg in which env references are
+// expanded, other than map values
+func envFields(cfg *Config) []envField {
+	var fields []envField
+	add := func(name string, value *string) {
+		fields = append(fields, envField{name, value})
+	}
+	addList := func(name string, values []string) {
+		for i := range values {
+			add(fmt.Sprintf("%s[%d]", name, i), &values[i])
+		}
+	}
+	addRules := func(prefix string, rules []*RuleConfig) {
+		for _, rc := range rules {
+			if rc.Severity != nil {
+				add(fmt.Sprintf("%srules %q severity", prefix, rc.ID), rc.Severity)
+			}
+		}
+	}
+
+	if cfg.ConfigBlock != nil {
+		add("config plugin_dir", &cfg.ConfigBlock.PluginDir)
+	}
+	if cfg.Paths != nil {
+		addList("paths include", cfg.Paths.Include)
+		addList("paths exclude", cfg.Paths.Exclude)
+	}
+	if cfg.Output != nil {
+		add("output format", &cfg.Output.Format)
+		add("output color", &cfg.Output.Color)
+		add("output help_url_base", &cfg.Output.HelpURLBase)
+	}
+	if cfg.Policy != nil {
+		add("policy fail_on", &cfg.Policy.FailOn)
+		addList("policy required_rules", cfg.Policy.RequiredRules)
+	}
+	if cfg.Annotations != nil {
+		addList("annotations allow_rule_ids", cfg.Annotations.AllowRuleIDs)
+		addList("annotations deny_rule_ids", cfg.Annotations.DenyRuleIDs)
+	}
+	addRules("", cfg.Rules)
+	for _, o := range cfg.Overrides {
+		addRules(fmt.Sprintf("override %q ", o.Path), o.Rules)
+	}
+	for _, pc := range cfg.Plugins {
+		add(fmt.Sprintf("plugin %q version", pc.Name), &pc.Version)
+		add(fmt.Sprintf("plugin %q source", pc.Name), &pc.Source)
+	}
+	for _, p := range cfg.Profiles {
+		prefix := fmt.Sprintf("profile %q ", p.Name)
+		if p.Policy != nil {
+			add(prefix+"policy fail_on", &p.Policy.FailOn)
+			addList(prefix+"policy required_rules", p.Policy.RequiredRules)
+		}
+		addRules(prefix, p.Rules)
+	}
+	if cfg.Deprecation != nil {
+		add("deprecation module_version", &cfg.Deprecation.ModuleVersion)
+	}
+	if cfg.Git != nil {
+		add("git pr_ref_template", &cfg.Git.PRRefTemplate)
+	}
+	return fields
+}
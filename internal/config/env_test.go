@@ -0,0 +1,168 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("TFBREAK_TEST_FORMAT", "json")
+	t.Setenv("TFBREAK_TEST_EMPTY", "")
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{"no references", "text", "text", ""},
+		{"set variable", "${env.TFBREAK_TEST_FORMAT}", "json", ""},
+		{"embedded", "out-${env.TFBREAK_TEST_FORMAT}.txt", "out-json.txt", ""},
+		{"default unused", "${env.TFBREAK_TEST_FORMAT:-sarif}", "json", ""},
+		{"default for unset", "${env.TFBREAK_TEST_UNSET:-sarif}", "sarif", ""},
+		{"default for empty", "${env.TFBREAK_TEST_EMPTY:-sarif}", "sarif", ""},
+		{"empty default", "${env.TFBREAK_TEST_UNSET:-}", "", ""},
+		{"empty without default", "${env.TFBREAK_TEST_EMPTY}", "", ""},
+		{"several", "${env.TFBREAK_TEST_FORMAT}/${env.TFBREAK_TEST_UNSET:-x}", "json/x", ""},
+		{"unset", "${env.TFBREAK_TEST_UNSET}", "", "environment variable TFBREAK_TEST_UNSET is not set"},
+		{"not an env reference", "${var.name}", "${var.name}", ""},
+		{"escaped", "$${env.TFBREAK_TEST_FORMAT}", "${env.TFBREAK_TEST_FORMAT}", ""},
+		{"escaped unset", "$${env.TFBREAK_TEST_UNSET}", "${env.TFBREAK_TEST_UNSET}", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandEnv(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expandEnv(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_EnvInterpolation(t *testing.T) {
+	t.Setenv("TFBREAK_TEST_FAIL_ON", "WARNING")
+	t.Setenv("TFBREAK_TEST_PLUGIN_VERSION", "0.3.0")
+
+	configPath := filepath.Join(t.TempDir(), ".tfbreak.hcl")
+	content := `
+version = 1
+
+output {
+  format = "${env.TFBREAK_TEST_FORMAT:-sarif}"
+}
+
+policy {
+  fail_on = "${env.TFBREAK_TEST_FAIL_ON}"
+}
+
+plugin "azurerm" {
+  enabled = true
+  version = "${env.TFBREAK_TEST_PLUGIN_VERSION}"
+}
+
+profile "release" {
+  policy {
+    fail_on = {
+      breaking = "${env.TFBREAK_TEST_BREAKING:-ERROR}"
+    }
+  }
+}
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Output.Format != "sarif" {
+		t.Errorf("expected format from the default, got %q", cfg.Output.Format)
+	}
+	if cfg.Policy.FailOn != "WARNING" {
+		t.Errorf("expected fail_on from the environment, got %q", cfg.Policy.FailOn)
+	}
+	if pc := cfg.GetPluginConfig("azurerm"); pc == nil || pc.Version != "0.3.0" {
+		t.Errorf("expected plugin version from the environment, got %+v", pc)
+	}
+	if got := cfg.GetProfile("release").Policy.FailOnCategory["breaking"]; got != "ERROR" {
+		t.Errorf("expected profile fail_on from the default, got %q", got)
+	}
+}
+
+func TestLoad_EnvInterpolationEscaped(t *testing.T) {
+	t.Setenv("TFBREAK_TEST_FORMAT", "json")
+
+	configPath := filepath.Join(t.TempDir(), ".tfbreak.hcl")
+	content := `
+version = 1
+
+output {
+  format        = "${env.TFBREAK_TEST_FORMAT}"
+  help_url_base = "https://docs.example.com/$${env.TFBREAK_TEST_FORMAT}"
+}
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Output.Format != "json" {
+		t.Errorf("expected format from the environment, got %q", cfg.Output.Format)
+	}
+	if want := "https://docs.example.com/${env.TFBREAK_TEST_FORMAT}"; cfg.Output.HelpURLBase != want {
+		t.Errorf("expected the escaped reference as literal text %q, got %q", want, cfg.Output.HelpURLBase)
+	}
+}
+
+func TestLoad_EnvInterpolationUnset(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".tfbreak.hcl")
+	content := `
+version = 1
+
+output {
+  format = "${env.TFBREAK_TEST_UNSET}"
+}
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Load(configPath, "")
+	if err == nil {
+		t.Fatal("expected error for unset environment variable")
+	}
+	if !strings.Contains(err.Error(), "output format") || !strings.Contains(err.Error(), "TFBREAK_TEST_UNSET") {
+		t.Errorf("expected error naming the field and variable, got: %v", err)
+	}
+}
+
+func TestLoad_ExtendsEnv(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfig(t, filepath.Join(tmpDir, "base.hcl"), "version = 1\n\noutput {\n  format = \"json\"\n}\n")
+	configPath := filepath.Join(tmpDir, ".tfbreak.hcl")
+	writeConfig(t, configPath, "version = 1\nextends = \"${env.TFBREAK_TEST_BASE:-base.hcl}\"\n")
+
+	cfg, err := Load(configPath, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Output.Format != "json" {
+		t.Errorf("expected format from the parent config, got %q", cfg.Output.Format)
+	}
+}
@@ -0,0 +1,227 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// extendsHTTPClient fetches parent configs given as https:// URLs
+var extendsHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// parseConfig parses the config file at path and every config it extends,
+// and returns their bodies merged with the extending file's settings winning
+func parseConfig(path string) (*hclsyntax.Body, error) {
+	return parseExtending(hclparse.NewParser(), path, nil)
+}
+
+// parseExtending parses the config at location, which is a file path or an
+// https:// URL, and merges it over the config it extends. visited holds the
+// locations on the way to this one, to report a loop instead of recursing
+// forever.
+func parseExtending(parser *hclparse.Parser, location string, visited []string) (*hclsyntax.Body, error) {
+	if !isURL(location) {
+		if abs, err := filepath.Abs(location); err == nil {
+			location = abs
+		}
+	}
+	for _, v := range visited {
+		if v == location {
+			return nil, fmt.Errorf("config extends cycle: %s -> %s", strings.Join(visited, " -> "), location)
+		}
+	}
+	visited = append(visited, location)
+
+	file, err := parseConfigSource(parser, location)
+	if err != nil {
+		return nil, err
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse config file: %s is not native HCL syntax", location)
+	}
+
+	extends, err := extendsTarget(body)
+	if err != nil || extends == "" {
+		return body, err
+	}
+
+	parentLocation, err := resolveExtends(location, extends)
+	if err != nil {
+		return nil, err
+	}
+	parent, err := parseExtending(parser, parentLocation, visited)
+	if err != nil {
+		return nil, err
+	}
+	return mergeBodies(parent, body), nil
+}
+
+// parseConfigSource reads and parses the config at a file path or URL
+func parseConfigSource(parser *hclparse.Parser, location string) (*hcl.File, error) {
+	var src []byte
+	var err error
+	if isURL(location) {
+		// A fetched config must not read the environment, or it could send
+		// secrets elsewhere, e.g. in the URL of the config it extends
+		src, err = fetchConfig(location)
+		src = quoteEnvRefs(src)
+	} else {
+		src, err = readConfigFile(location)
+		src = escapeEnvRefs(src)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	file, diags := parser.ParseHCL(src, location)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to parse config file: %s", formatDiagnostics(diags))
+	}
+	return file, nil
+}
+
+// readConfigFile reads a config file from disk
+func readConfigFile(path string) ([]byte, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return src, nil
+}
+
+// fetchConfig downloads a parent config over HTTPS
+func fetchConfig(location string) ([]byte, error) {
+	resp, err := extendsHTTPClient.Get(location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch config %s: %w", location, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch config %s: %s", location, resp.Status)
+	}
+	src, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch config %s: %w", location, err)
+	}
+	return src, nil
+}
+
+// extendsTarget returns the value of the extends attribute, or empty if the
+// body has none
+func extendsTarget(body *hclsyntax.Body) (string, error) {
+	attr, ok := body.Attributes["extends"]
+	if !ok {
+		return "", nil
+	}
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() {
+		return "", fmt.Errorf("failed to decode config: %s", formatDiagnostics(diags))
+	}
+	if val.IsNull() || !val.IsKnown() || val.Type() != cty.String {
+		return "", fmt.Errorf("%s:%d: extends must be a string", attr.SrcRange.Filename, attr.SrcRange.Start.Line)
+	}
+	extends, err := expandEnv(val.AsString())
+	if err != nil {
+		return "", fmt.Errorf("extends: %w", err)
+	}
+	return extends, nil
+}
+
+// resolveExtends returns the location of the config that the config at
+// location extends. Relative paths are resolved against the directory of the
+// extending file, or against its URL if it was fetched.
+func resolveExtends(location, extends string) (string, error) {
+	if isURL(extends) {
+		return extends, nil
+	}
+	if strings.Contains(extends, "://") {
+		return "", fmt.Errorf("extends %q: only local paths and https:// URLs are supported", extends)
+	}
+
+	if isURL(location) {
+		base, err := url.Parse(location)
+		if err != nil {
+			return "", fmt.Errorf("extends %q: %w", extends, err)
+		}
+		ref, err := url.Parse(filepath.ToSlash(extends))
+		if err != nil {
+			return "", fmt.Errorf("extends %q: %w", extends, err)
+		}
+		return base.ResolveReference(ref).String(), nil
+	}
+
+	if filepath.IsAbs(extends) {
+		return extends, nil
+	}
+	return filepath.Join(filepath.Dir(location), extends), nil
+}
+
+// isURL reports whether location is an https:// URL
+func isURL(location string) bool {
+	return strings.HasPrefix(location, "https://")
+}
+
+// mergeBodies returns child merged over parent. Attributes set in child
+// replace those in parent as a whole, so lists are replaced rather than
+// appended, in the same way CLI flags replace the configured paths. Blocks
+// with the same type and labels are merged recursively; other blocks from
+// both are kept.
+func mergeBodies(parent, child *hclsyntax.Body) *hclsyntax.Body {
+	merged := &hclsyntax.Body{
+		Attributes: hclsyntax.Attributes{},
+		SrcRange:   child.SrcRange,
+		EndRange:   child.EndRange,
+	}
+	for name, attr := range parent.Attributes {
+		merged.Attributes[name] = attr
+	}
+	for name, attr := range child.Attributes {
+		merged.Attributes[name] = attr
+	}
+
+	used := make(map[*hclsyntax.Block]bool)
+	for _, pb := range parent.Blocks {
+		block := pb
+		for _, cb := range child.Blocks {
+			if !used[cb] && sameBlock(pb, cb) {
+				mb := *cb
+				mb.Body = mergeBodies(pb.Body, cb.Body)
+				block = &mb
+				used[cb] = true
+				break
+			}
+		}
+		merged.Blocks = append(merged.Blocks, block)
+	}
+	for _, cb := range child.Blocks {
+		if !used[cb] {
+			merged.Blocks = append(merged.Blocks, cb)
+		}
+	}
+	return merged
+}
+
+// sameBlock reports whether two blocks have the same type and labels
+func sameBlock(a, b *hclsyntax.Block) bool {
+	if a.Type != b.Type || len(a.Labels) != len(b.Labels) {
+		return false
+	}
+	for i := range a.Labels {
+		if a.Labels[i] != b.Labels[i] {
+			return false
+		}
+	}
+	return true
+}
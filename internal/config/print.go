@@ -0,0 +1,249 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// printedBlock is a configuration block prepared for printing
+type printedBlock struct {
+	Type   string
+	Labels []string
+	Attrs  []printedAttr
+	Blocks []printedBlock
+}
+
+// printedAttr is a block attribute prepared for printing
+type printedAttr struct {
+	Name  string
+	Value cty.Value
+}
+
+// WriteHCL writes the configuration to w in .tfbreak.hcl syntax. The output
+// reflects the configuration as it is used, including defaults and any
+// changes made after loading, and can be loaded again with Load.
+func (c *Config) WriteHCL(w io.Writer) error {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+	body.SetAttributeValue("version", cty.NumberIntVal(int64(c.Version)))
+
+	for _, b := range c.printedBlocks() {
+		body.AppendNewline()
+		appendHCLBlock(body, b)
+	}
+
+	_, err := f.WriteTo(w)
+	return err
+}
+
+// appendHCLBlock appends b and the blocks nested in it to body
+func appendHCLBlock(body *hclwrite.Body, b printedBlock) {
+	block := body.AppendNewBlock(b.Type, b.Labels)
+	for _, attr := range b.Attrs {
+		block.Body().SetAttributeValue(attr.Name, attr.Value)
+	}
+	for i, nested := range b.Blocks {
+		if i > 0 || len(b.Attrs) > 0 {
+			block.Body().AppendNewline()
+		}
+		appendHCLBlock(block.Body(), nested)
+	}
+}
+
+// WriteJSON writes the configuration to w in HCL's JSON syntax, with the
+// same content as WriteHCL
+func (c *Config) WriteJSON(w io.Writer) error {
+	doc := map[string]any{"version": c.Version}
+	if err := addJSONBlocks(doc, c.printedBlocks()); err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
+
+// addJSONBlocks adds blocks to doc, an object in HCL's JSON syntax
+func addJSONBlocks(doc map[string]any, blocks []printedBlock) error {
+	for _, b := range blocks {
+		content := make(map[string]any, len(b.Attrs))
+		for _, attr := range b.Attrs {
+			raw, err := ctyjson.Marshal(attr.Value, attr.Value.Type())
+			if err != nil {
+				return fmt.Errorf("failed to encode %s.%s: %w", b.Type, attr.Name, err)
+			}
+			content[attr.Name] = json.RawMessage(raw)
+		}
+		if err := addJSONBlocks(content, b.Blocks); err != nil {
+			return err
+		}
+
+		// Labeled blocks nest their content under the label
+		if len(b.Labels) == 0 {
+			doc[b.Type] = content
+			continue
+		}
+		byLabel, _ := doc[b.Type].(map[string]any)
+		if byLabel == nil {
+			byLabel = make(map[string]any)
+			doc[b.Type] = byLabel
+		}
+		byLabel[b.Labels[0]] = content
+	}
+	return nil
+}
+
+// printedBlocks returns the configuration's blocks in the order of the
+// configuration reference. Unset optional attributes are left out.
+func (c *Config) printedBlocks() []printedBlock {
+	var blocks []printedBlock
+
+	if c.ConfigBlock != nil && c.ConfigBlock.PluginDir != "" {
+		blocks = append(blocks, printedBlock{Type: "config", Attrs: []printedAttr{
+			{"plugin_dir", cty.StringVal(c.ConfigBlock.PluginDir)},
+		}})
+	}
+
+	if c.Paths != nil {
+		blocks = append(blocks, printedBlock{Type: "paths", Attrs: []printedAttr{
+			{"include", stringList(c.Paths.Include)},
+			{"exclude", stringList(c.Paths.Exclude)},
+		}})
+	}
+
+	if c.Output != nil {
+		b := printedBlock{Type: "output", Attrs: []printedAttr{
+			{"format", cty.StringVal(c.Output.Format)},
+			{"color", cty.StringVal(c.Output.Color)},
+		}}
+		if c.Output.HelpURLBase != "" {
+			b.Attrs = append(b.Attrs, printedAttr{"help_url_base", cty.StringVal(c.Output.HelpURLBase)})
+		}
+		if c.Output.ShowIgnored != nil {
+			b.Attrs = append(b.Attrs, printedAttr{"show_ignored", cty.BoolVal(*c.Output.ShowIgnored)})
+		}
+		blocks = append(blocks, b)
+	}
+
+	if c.Policy != nil {
+		failOn := cty.StringVal(c.Policy.FailOn)
+		if c.Policy.FailOnCategory != nil {
+			categories := make(map[string]cty.Value, len(c.Policy.FailOnCategory))
+			for name, value := range c.Policy.FailOnCategory {
+				categories[name] = cty.StringVal(value)
+			}
+			failOn = cty.ObjectVal(categories)
+		}
+		b := printedBlock{Type: "policy", Attrs: []printedAttr{
+			{"fail_on", failOn},
+			{"treat_warnings_as_errors", cty.BoolVal(c.Policy.TreatWarningsAsErrors)},
+			{"treat_risky_as_errors", cty.BoolVal(c.Policy.TreatRiskyAsErrors)},
+			{"escalate_required", cty.BoolVal(c.Policy.EscalateRequired)},
+			{"escalate_fan_out", cty.NumberIntVal(int64(c.Policy.EscalateFanOut))},
+			{"interface_only", cty.BoolVal(c.Policy.InterfaceOnly)},
+		}}
+		if len(c.Policy.RequiredRules) > 0 {
+			b.Attrs = append(b.Attrs, printedAttr{"required_rules", stringList(c.Policy.RequiredRules)})
+		}
+		blocks = append(blocks, b)
+	}
+
+	if c.Annotations != nil {
+		b := printedBlock{Type: "annotations"}
+		if c.Annotations.Enabled != nil {
+			b.Attrs = append(b.Attrs, printedAttr{"enabled", cty.BoolVal(*c.Annotations.Enabled)})
+		}
+		b.Attrs = append(b.Attrs,
+			printedAttr{"require_reason", cty.BoolVal(c.Annotations.RequireReason)},
+			printedAttr{"allow_rule_ids", stringList(c.Annotations.AllowRuleIDs)},
+			printedAttr{"deny_rule_ids", stringList(c.Annotations.DenyRuleIDs)},
+		)
+		blocks = append(blocks, b)
+	}
+
+	if c.RenameDetection != nil {
+		b := printedBlock{Type: "rename_detection"}
+		if c.RenameDetection.Enabled != nil {
+			b.Attrs = append(b.Attrs, printedAttr{"enabled", cty.BoolVal(*c.RenameDetection.Enabled)})
+		}
+		if c.RenameDetection.SimilarityThreshold != nil {
+			b.Attrs = append(b.Attrs, printedAttr{"similarity_threshold", cty.NumberFloatVal(*c.RenameDetection.SimilarityThreshold)})
+		}
+		blocks = append(blocks, b)
+	}
+
+	if c.Deprecation != nil {
+		b := printedBlock{Type: "deprecation"}
+		if c.Deprecation.Marker != "" {
+			b.Attrs = append(b.Attrs, printedAttr{"marker", cty.StringVal(c.Deprecation.Marker)})
+		}
+		if c.Deprecation.ModuleVersion != "" {
+			b.Attrs = append(b.Attrs, printedAttr{"module_version", cty.StringVal(c.Deprecation.ModuleVersion)})
+		}
+		blocks = append(blocks, b)
+	}
+
+	if c.Git != nil && c.Git.PRRefTemplate != "" {
+		blocks = append(blocks, printedBlock{Type: "git", Attrs: []printedAttr{
+			{"pr_ref_template", cty.StringVal(c.Git.PRRefTemplate)},
+		}})
+	}
+
+	for _, rc := range c.Rules {
+		blocks = append(blocks, printedRuleBlock(rc))
+	}
+
+	for _, o := range c.Overrides {
+		b := printedBlock{Type: "override", Labels: []string{o.Path}}
+		for _, rc := range o.Rules {
+			b.Blocks = append(b.Blocks, printedRuleBlock(rc))
+		}
+		blocks = append(blocks, b)
+	}
+
+	for _, pc := range c.Plugins {
+		b := printedBlock{Type: "plugin", Labels: []string{pc.Name}}
+		if pc.Enabled != nil {
+			b.Attrs = append(b.Attrs, printedAttr{"enabled", cty.BoolVal(*pc.Enabled)})
+		}
+		if pc.Version != "" {
+			b.Attrs = append(b.Attrs, printedAttr{"version", cty.StringVal(pc.Version)})
+		}
+		if pc.Source != "" {
+			b.Attrs = append(b.Attrs, printedAttr{"source", cty.StringVal(pc.Source)})
+		}
+		blocks = append(blocks, b)
+	}
+
+	return blocks
+}
+
+// printedRuleBlock returns the rules block for a rule's settings
+func printedRuleBlock(rc *RuleConfig) printedBlock {
+	b := printedBlock{Type: "rules", Labels: []string{rc.ID}}
+	if rc.Enabled != nil {
+		b.Attrs = append(b.Attrs, printedAttr{"enabled", cty.BoolVal(*rc.Enabled)})
+	}
+	if rc.Severity != nil {
+		b.Attrs = append(b.Attrs, printedAttr{"severity", cty.StringVal(*rc.Severity)})
+	}
+	return b
+}
+
+// stringList converts a string slice to a cty list, which is empty rather
+// than null for an empty slice
+func stringList(values []string) cty.Value {
+	if len(values) == 0 {
+		return cty.ListValEmpty(cty.String)
+	}
+	vals := make([]cty.Value, len(values))
+	for i, v := range values {
+		vals[i] = cty.StringVal(v)
+	}
+	return cty.ListVal(vals)
+}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteHCL_RoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".tfbreak.hcl")
+	configContent := `
+version = 1
+
+paths {
+  include = ["modules/**/*.tf"]
+  exclude = []
+}
+
+policy {
+  fail_on = {
+    breaking = "ERROR"
+    risky    = "off"
+  }
+  required_rules = ["input-removed"]
+}
+
+rules "input-default-changed" {
+  enabled  = false
+}
+
+deprecation {
+  marker         = "remove in v?(\\d+)"
+  module_version = "2.0"
+}
+
+override "environments/**" {
+  rules "input-removed" {
+    severity = "WARNING"
+  }
+  rules "input-default-changed" {
+    enabled = true
+  }
+}
+
+plugin "azurerm" {
+  enabled = true
+  version = "0.1.0"
+}
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := Load(configPath, "")
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := cfg.WriteHCL(&buf); err != nil {
+		t.Fatalf("WriteHCL error: %v", err)
+	}
+
+	printedPath := filepath.Join(tmpDir, "printed.hcl")
+	if err := os.WriteFile(printedPath, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("failed to write printed config: %v", err)
+	}
+	printed, err := Load(printedPath, "")
+	if err != nil {
+		t.Fatalf("printed config does not load: %v\n%s", err, buf.String())
+	}
+
+	if got := printed.Paths.Include; len(got) != 1 || got[0] != "modules/**/*.tf" {
+		t.Errorf("paths.include = %v, want [modules/**/*.tf]", got)
+	}
+	if got := printed.Paths.Exclude; len(got) != 1 || got[0] != ".terraform/**" {
+		t.Errorf("paths.exclude = %v, want the default", got)
+	}
+	if printed.Policy.FailOnCategory["breaking"] != "ERROR" || printed.Policy.FailOnCategory["risky"] != "off" {
+		t.Errorf("policy.fail_on = %v, want breaking=ERROR risky=off", printed.Policy.FailOnCategory)
+	}
+	if got := printed.GetRequiredRules(); len(got) != 1 || got[0] != "input-removed" {
+		t.Errorf("policy.required_rules = %v, want [input-removed]", got)
+	}
+	if printed.IsRuleEnabled("input-default-changed") {
+		t.Error("rules.input-default-changed should stay disabled")
+	}
+	if len(printed.Overrides) != 1 || printed.Overrides[0].Path != "environments/**" || len(printed.Overrides[0].Rules) != 2 {
+		t.Errorf("overrides = %+v, want two rules for environments/**", printed.Overrides)
+	} else if sev := printed.Overrides[0].Rules[0].Severity; sev == nil || *sev != "WARNING" {
+		t.Errorf("override input-removed severity = %v, want WARNING", sev)
+	}
+	if pc := printed.GetPluginConfig("azurerm"); pc == nil || pc.Version != "0.1.0" {
+		t.Errorf("plugin.azurerm = %+v, want version 0.1.0", pc)
+	}
+	if printed.GetDeprecationMarker() != `remove in v?(\d+)` || printed.GetModuleVersion() != "2.0" {
+		t.Errorf("deprecation = %+v, want the marker and module version", printed.Deprecation)
+	}
+	if printed.GetSimilarityThreshold() != DefaultSimilarityThreshold {
+		t.Errorf("rename_detection.similarity_threshold = %v, want the default", printed.GetSimilarityThreshold())
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	cfg := Default()
+	cfg.Output.Format = "sarif"
+
+	var buf bytes.Buffer
+	if err := cfg.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON error: %v", err)
+	}
+
+	var doc struct {
+		Version int `json:"version"`
+		Output  struct {
+			Format string `json:"format"`
+		} `json:"output"`
+		Policy struct {
+			FailOn string `json:"fail_on"`
+		} `json:"policy"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if doc.Version != 1 || doc.Output.Format != "sarif" || doc.Policy.FailOn != "ERROR" {
+		t.Errorf("unexpected JSON config: %s", buf.String())
+	}
+	if strings.Contains(buf.String(), `"rules"`) {
+		t.Errorf("expected no rules without rule blocks: %s", buf.String())
+	}
+}
+
+func TestWriteJSON_Overrides(t *testing.T) {
+	cfg := Default()
+	severity := "ERROR"
+	cfg.Overrides = []*OverrideConfig{{
+		Path:  "modules/**",
+		Rules: []*RuleConfig{{ID: "input-type-changed", Severity: &severity}},
+	}}
+
+	var buf bytes.Buffer
+	if err := cfg.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON error: %v", err)
+	}
+
+	var doc struct {
+		Override map[string]struct {
+			Rules map[string]struct {
+				Severity string `json:"severity"`
+			} `json:"rules"`
+		} `json:"override"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if got := doc.Override["modules/**"].Rules["input-type-changed"].Severity; got != "ERROR" {
+		t.Errorf("override severity = %q, want ERROR in %s", got, buf.String())
+	}
+}
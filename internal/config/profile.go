@@ -0,0 +1,197 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// DefaultProfile is the profile applied when no profile is selected
+const DefaultProfile = "default"
+
+// ProfileConfig is a named set of policy and rule settings that is merged
+// over the base configuration when selected with --profile
+type ProfileConfig struct {
+	Name   string               `hcl:"name,label"`
+	Policy *ProfilePolicyConfig `hcl:"policy,block"`
+	Rules  []*RuleConfig        `hcl:"rules,block"`
+}
+
+// ProfilePolicyConfig defines the policy settings a profile overrides.
+// Attributes left unset keep the base configuration's value.
+type ProfilePolicyConfig struct {
+	FailOnExpr            hcl.Expression `hcl:"fail_on,optional"`
+	TreatWarningsAsErrors *bool          `hcl:"treat_warnings_as_errors,optional"`
+	TreatRiskyAsErrors    *bool          `hcl:"treat_risky_as_errors,optional"`
+	EscalateRequired      *bool          `hcl:"escalate_required,optional"`
+	EscalateFanOut        *int           `hcl:"escalate_fan_out,optional"`
+	RequiredRules         []string       `hcl:"required_rules,optional"`
+	InterfaceOnly         *bool          `hcl:"interface_only,optional"`
+
+	// FailOn and FailOnCategory are decoded from FailOnExpr, as in PolicyConfig
+	FailOn         string
+	FailOnCategory map[string]string
+}
+
+// LoadProfile loads configuration like Load and merges the named profile
+// over it. An empty profile selects the "default" profile if the
+// configuration defines one, and the base configuration otherwise.
+func LoadProfile(configPath, oldDir, profile string) (*Config, error) {
+	cfg, err := Load(configPath, oldDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile == "" {
+		if cfg.GetProfile(DefaultProfile) == nil {
+			return cfg, nil
+		}
+		profile = DefaultProfile
+	}
+
+	if err := cfg.ApplyProfile(profile); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// GetProfile returns the profile with the given name, or nil if not defined
+func (c *Config) GetProfile(name string) *ProfileConfig {
+	for _, p := range c.Profiles {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// ProfileNames returns the names of the defined profiles, sorted
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for _, p := range c.Profiles {
+		names = append(names, p.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ApplyProfile merges the named profile over the configuration. Policy
+// attributes set in the profile replace the base values, and rules blocks
+// replace the enabled and severity settings of the same rule.
+func (c *Config) ApplyProfile(name string) error {
+	p := c.GetProfile(name)
+	if p == nil {
+		if len(c.Profiles) == 0 {
+			return fmt.Errorf("profile %q not found: the configuration defines no profiles", name)
+		}
+		return fmt.Errorf("profile %q not found (available: %s)", name, strings.Join(c.ProfileNames(), ", "))
+	}
+
+	if p.Policy != nil {
+		if c.Policy == nil {
+			c.Policy = Default().Policy
+		}
+		mergeProfilePolicy(c.Policy, p.Policy)
+	}
+
+	for _, rule := range p.Rules {
+		base := c.findRuleConfig(rule.ID)
+		if base == nil {
+			c.Rules = append(c.Rules, &RuleConfig{ID: rule.ID, Enabled: rule.Enabled, Severity: rule.Severity})
+			continue
+		}
+		if rule.Enabled != nil {
+			base.Enabled = rule.Enabled
+		}
+		if rule.Severity != nil {
+			base.Severity = rule.Severity
+		}
+	}
+
+	c.profile = name
+	return nil
+}
+
+// Profile returns the name of the applied profile, or empty if none was
+func (c *Config) Profile() string {
+	return c.profile
+}
+
+// findRuleConfig returns the rules block for a rule, matching IDs and
+// names, so a profile can refer to a rule differently than the base config
+func (c *Config) findRuleConfig(ruleIDOrName string) *RuleConfig {
+	validator := getValidator()
+	id, ok := validator.ResolveToID(ruleIDOrName)
+	for _, rc := range c.Rules {
+		if rc.ID == ruleIDOrName {
+			return rc
+		}
+		if other, otherOK := validator.ResolveToID(rc.ID); ok && otherOK && other == id {
+			return rc
+		}
+	}
+	return nil
+}
+
+// mergeProfilePolicy overrides the base policy with the attributes set in
+// the profile's policy block
+func mergeProfilePolicy(base *PolicyConfig, p *ProfilePolicyConfig) {
+	if p.FailOnCategory != nil {
+		base.FailOnCategory = p.FailOnCategory
+	} else if p.FailOn != "" {
+		base.FailOn = p.FailOn
+		base.FailOnCategory = nil
+	}
+	if p.TreatWarningsAsErrors != nil {
+		base.TreatWarningsAsErrors = *p.TreatWarningsAsErrors
+	}
+	if p.TreatRiskyAsErrors != nil {
+		base.TreatRiskyAsErrors = *p.TreatRiskyAsErrors
+	}
+	if p.EscalateRequired != nil {
+		base.EscalateRequired = *p.EscalateRequired
+	}
+	if p.EscalateFanOut != nil {
+		base.EscalateFanOut = *p.EscalateFanOut
+	}
+	if p.RequiredRules != nil {
+		base.RequiredRules = p.RequiredRules
+	}
+	if p.InterfaceOnly != nil {
+		base.InterfaceOnly = *p.InterfaceOnly
+	}
+}
+
+// profileProblems checks profile names are unique and validates each
+// profile's settings like the base configuration's
+func profileProblems(cfg *Config) []Problem {
+	var problems []Problem
+	seen := make(map[string]bool)
+	for _, p := range cfg.Profiles {
+		key := fmt.Sprintf("profile %q", p.Name)
+		if seen[p.Name] {
+			problems = append(problems, Problem{Key: key, Message: fmt.Sprintf("duplicate profile: %s", p.Name)})
+			continue
+		}
+		seen[p.Name] = true
+
+		// The version is the base configuration's, which is checked on its own
+		check := &Config{Version: 1, Rules: p.Rules}
+		if p.Policy != nil {
+			check.Policy = &PolicyConfig{
+				FailOn:         p.Policy.FailOn,
+				FailOnCategory: p.Policy.FailOnCategory,
+				RequiredRules:  p.Policy.RequiredRules,
+			}
+		}
+		for _, problem := range Problems(check) {
+			problems = append(problems, Problem{
+				Key:     key + " " + problem.Key,
+				Message: fmt.Sprintf("profile %q: %s", p.Name, problem.Message),
+			})
+		}
+	}
+	return problems
+}
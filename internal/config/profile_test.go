@@ -0,0 +1,179 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+const profileConfig = `
+version = 1
+
+policy {
+  fail_on = "ERROR"
+}
+
+rules "input-default-changed" {
+  severity = "NOTICE"
+}
+
+profile "strict" {
+  policy {
+    fail_on           = "WARNING"
+    escalate_required = true
+  }
+
+  rules "input-default-changed" {
+    severity = "ERROR"
+  }
+
+  rules "required-input-added" {
+    enabled = false
+  }
+}
+
+profile "advisory" {
+  policy {
+    fail_on = {
+      breaking = "ERROR"
+      risky    = "off"
+    }
+  }
+}
+`
+
+func writeProfileConfig(t *testing.T, content string) string {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), ".tfbreak.hcl")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return configPath
+}
+
+func TestLoadProfile_None(t *testing.T) {
+	cfg, err := LoadProfile(writeProfileConfig(t, profileConfig), "", "")
+	if err != nil {
+		t.Fatalf("LoadProfile() error = %v", err)
+	}
+
+	if cfg.Profile() != "" {
+		t.Errorf("Profile() = %q, want none", cfg.Profile())
+	}
+	if cfg.Policy.FailOn != "ERROR" {
+		t.Errorf("fail_on = %s, want ERROR", cfg.Policy.FailOn)
+	}
+	if got := cfg.GetRuleSeverity("input-default-changed", types.SeverityWarning); got != types.SeverityNotice {
+		t.Errorf("input-default-changed severity = %v, want NOTICE", got)
+	}
+	if !cfg.IsRuleEnabled("required-input-added") {
+		t.Error("expected required-input-added to be enabled")
+	}
+}
+
+func TestLoadProfile_Strict(t *testing.T) {
+	cfg, err := LoadProfile(writeProfileConfig(t, profileConfig), "", "strict")
+	if err != nil {
+		t.Fatalf("LoadProfile() error = %v", err)
+	}
+
+	if cfg.Profile() != "strict" {
+		t.Errorf("Profile() = %q, want strict", cfg.Profile())
+	}
+	if cfg.Policy.FailOn != "WARNING" {
+		t.Errorf("fail_on = %s, want WARNING", cfg.Policy.FailOn)
+	}
+	if !cfg.IsEscalateRequiredEnabled() {
+		t.Error("expected escalate_required from the profile")
+	}
+	// The profile overrides the base severity of the same rule
+	if got := cfg.GetRuleSeverity("input-default-changed", types.SeverityWarning); got != types.SeverityError {
+		t.Errorf("input-default-changed severity = %v, want ERROR", got)
+	}
+	if len(cfg.Rules) != 2 {
+		t.Errorf("expected input-default-changed to be merged rather than added, got %d rules blocks", len(cfg.Rules))
+	}
+	if cfg.IsRuleEnabled("required-input-added") {
+		t.Error("expected required-input-added to be disabled by the profile")
+	}
+}
+
+func TestLoadProfile_FailOnCategories(t *testing.T) {
+	cfg, err := LoadProfile(writeProfileConfig(t, profileConfig), "", "advisory")
+	if err != nil {
+		t.Fatalf("LoadProfile() error = %v", err)
+	}
+
+	thresholds, err := cfg.GetFailOnCategories()
+	if err != nil {
+		t.Fatalf("GetFailOnCategories failed: %v", err)
+	}
+	if _, ok := thresholds[types.CategoryRisky]; ok {
+		t.Error("expected risky to be off")
+	}
+	if sev := thresholds[types.CategoryBreaking]; sev != types.SeverityError {
+		t.Errorf("breaking threshold = %v, want ERROR", sev)
+	}
+}
+
+func TestLoadProfile_Default(t *testing.T) {
+	content := profileConfig + `
+profile "default" {
+  policy {
+    fail_on = "NOTICE"
+  }
+}
+`
+	configPath := writeProfileConfig(t, content)
+
+	cfg, err := LoadProfile(configPath, "", "")
+	if err != nil {
+		t.Fatalf("LoadProfile() error = %v", err)
+	}
+	if cfg.Profile() != DefaultProfile || cfg.Policy.FailOn != "NOTICE" {
+		t.Errorf("profile = %q, fail_on = %s, want default profile with NOTICE", cfg.Profile(), cfg.Policy.FailOn)
+	}
+
+	// Selecting another profile replaces the default one
+	cfg, err = LoadProfile(configPath, "", "strict")
+	if err != nil {
+		t.Fatalf("LoadProfile() error = %v", err)
+	}
+	if cfg.Policy.FailOn != "WARNING" {
+		t.Errorf("fail_on = %s, want WARNING", cfg.Policy.FailOn)
+	}
+}
+
+func TestLoadProfile_NotFound(t *testing.T) {
+	_, err := LoadProfile(writeProfileConfig(t, profileConfig), "", "release")
+	if err == nil || !strings.Contains(err.Error(), "available: advisory, strict") {
+		t.Errorf("expected error listing profiles, got %v", err)
+	}
+
+	// Without a config file there are no profiles to select
+	t.Chdir(t.TempDir())
+	if _, err := LoadProfile("", "", "strict"); err == nil {
+		t.Error("expected error without a config file")
+	}
+}
+
+func TestLoadProfile_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown rule":     "profile \"strict\" {\n  rules \"XX999\" {\n    enabled = false\n  }\n}\n",
+		"invalid fail_on":  "profile \"strict\" {\n  policy {\n    fail_on = \"FATAL\"\n  }\n}\n",
+		"duplicate name":   "profile \"strict\" {\n}\nprofile \"strict\" {\n}\n",
+		"invalid severity": "profile \"strict\" {\n  rules \"required-input-added\" {\n    severity = \"HIGH\"\n  }\n}\n",
+	}
+
+	for name, profile := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Profiles are validated even when not selected
+			if _, err := Load(writeProfileConfig(t, "version = 1\n"+profile), ""); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
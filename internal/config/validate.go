@@ -2,6 +2,11 @@ package config
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
 
 	"github.com/jokarl/tfbreak-core/internal/types"
 )
@@ -54,30 +59,44 @@ func (f fallbackValidator) ResolveToID(nameOrID string) (string, bool) {
 // ValidRuleNames maps rule names to IDs (fallback when no validator is set)
 // Only rule names are accepted - legacy rule codes (BC001, etc.) are not supported
 var ValidRuleNames = map[string]string{
-	"required-input-added":          "BC001",
-	"input-removed":                 "BC002",
-	"input-renamed":                 "BC003",
-	"input-type-changed":            "BC004",
-	"input-default-removed":         "BC005",
-	"output-removed":                "BC009",
-	"output-renamed":                "BC010",
-	"resource-removed-no-moved":     "BC100",
-	"module-removed-no-moved":       "BC101",
-	"invalid-moved-block":           "BC102",
-	"conflicting-moved":             "BC103",
-	"input-renamed-optional":        "RC003",
-	"input-default-changed":         "RC006",
-	"input-nullable-changed":        "RC007",
-	"input-sensitive-changed":       "RC008",
-	"output-sensitive-changed":      "RC011",
-	"validation-added":              "RC012",
-	"validation-value-removed":      "RC013",
-	"terraform-version-constrained": "BC200",
-	"provider-version-constrained":  "BC201",
-	"module-source-changed":         "RC300",
-	"module-version-changed":        "RC301",
+	"required-input-added":           "BC001",
+	"input-removed":                  "BC002",
+	"input-renamed":                  "BC003",
+	"input-type-changed":             "BC004",
+	"input-default-removed":          "BC005",
+	"input-object-attribute-changed": "BC006",
+	"output-removed":                 "BC009",
+	"output-renamed":                 "BC010",
+	"resource-removed-no-moved":      "BC100",
+	"module-removed-no-moved":        "BC101",
+	"invalid-moved-block":            "BC102",
+	"conflicting-moved":              "BC103",
+	"moved-from-still-exists":        "BC104",
+	"input-renamed-optional":         "RC003",
+	"input-default-changed":          "RC006",
+	"input-nullable-changed":         "RC007",
+	"input-sensitive-changed":        "RC008",
+	"input-optional-default-changed": "RC009",
+	"output-sensitive-changed":       "RC011",
+	"validation-added":               "RC012",
+	"validation-value-removed":       "RC013",
+	"output-sensitive-added":         "RC014",
+	"input-deprecated":               "RC015",
+	"validation-removed":             "RC016",
+	"input-removal-overdue":          "RC017",
+	"terraform-version-constrained":  "BC200",
+	"provider-version-constrained":   "BC201",
+	"provider-hashes-changed":        "RC202",
+	"provider-source-changed":        "BC203",
+	"provider-version-narrowed":      "RC204",
+	"module-source-changed":          "RC300",
+	"module-version-changed":         "RC301",
 }
 
+// moduleVersionPattern matches the module versions the deprecation marker
+// rule can compare: one to three numeric parts, optionally prefixed with "v"
+var moduleVersionPattern = regexp.MustCompile(`^v?\d+(\.\d+){0,2}$`)
+
 // getValidator returns the current rule validator
 func getValidator() RuleValidator {
 	if defaultValidator != nil {
@@ -86,20 +105,61 @@ func getValidator() RuleValidator {
 	return fallbackValidator{}
 }
 
-// Validate validates the configuration
+// Problem is an invalid setting in a configuration
+type Problem struct {
+	// Key is the setting as it is written in the config file, e.g.
+	// "policy fail_on" or `rules "input-removed" severity`
+	Key string
+
+	// Message describes what is wrong with the setting
+	Message string
+}
+
+func (p Problem) Error() string {
+	return p.Message
+}
+
+// Validate validates the configuration and returns its first problem
 func Validate(cfg *Config) error {
+	if problems := Problems(cfg); len(problems) > 0 {
+		return problems[0]
+	}
+	return nil
+}
+
+// Problems validates the configuration and returns every problem with it
+func Problems(cfg *Config) []Problem {
+	var problems []Problem
+	add := func(key, format string, args ...interface{}) {
+		problems = append(problems, Problem{Key: key, Message: fmt.Sprintf(format, args...)})
+	}
+
 	// Version check
 	if cfg.Version != 1 {
-		return fmt.Errorf("unsupported config version: %d (only version 1 is supported)", cfg.Version)
+		add("version", "unsupported config version: %d (only version 1 is supported)", cfg.Version)
+	}
+
+	// Validate path filter globs
+	if cfg.Paths != nil {
+		for i, pattern := range cfg.Paths.Include {
+			if !doublestar.ValidatePattern(pattern) {
+				add(fmt.Sprintf("paths include[%d]", i), "invalid include pattern: %s (must be a glob pattern)", pattern)
+			}
+		}
+		for i, pattern := range cfg.Paths.Exclude {
+			if !doublestar.ValidatePattern(pattern) {
+				add(fmt.Sprintf("paths exclude[%d]", i), "invalid exclude pattern: %s (must be a glob pattern)", pattern)
+			}
+		}
 	}
 
 	// Validate output format
 	if cfg.Output != nil && cfg.Output.Format != "" {
 		switch cfg.Output.Format {
-		case "text", "json", "compact", "checkstyle", "junit", "sarif":
+		case "text", "json", "compact", "checkstyle", "junit", "sarif", "ndjson", "jsonl", "rdjson", "gitlab", "markdown":
 			// valid
 		default:
-			return fmt.Errorf("invalid output format: %s (must be 'text', 'json', 'compact', 'checkstyle', 'junit', or 'sarif')", cfg.Output.Format)
+			add("output format", "invalid output format: %s (must be 'text', 'json', 'compact', 'checkstyle', 'junit', 'sarif', 'ndjson', 'jsonl', 'rdjson', 'gitlab', or 'markdown')", cfg.Output.Format)
 		}
 	}
 
@@ -109,14 +169,43 @@ func Validate(cfg *Config) error {
 		case "auto", "always", "never":
 			// valid
 		default:
-			return fmt.Errorf("invalid color mode: %s (must be 'auto', 'always', or 'never')", cfg.Output.Color)
+			add("output color", "invalid color mode: %s (must be 'auto', 'always', or 'never')", cfg.Output.Color)
 		}
 	}
 
 	// Validate policy fail_on
 	if cfg.Policy != nil && cfg.Policy.FailOn != "" {
 		if _, err := types.ParseSeverity(cfg.Policy.FailOn); err != nil {
-			return fmt.Errorf("invalid fail_on severity: %s (must be 'ERROR', 'WARNING', or 'NOTICE')", cfg.Policy.FailOn)
+			add("policy fail_on", "invalid fail_on severity: %s (must be 'ERROR', 'WARNING', 'DEPRECATION', or 'NOTICE')", cfg.Policy.FailOn)
+		}
+	}
+
+	// Validate the fan-out escalation threshold
+	if cfg.Policy != nil && cfg.Policy.EscalateFanOut < 0 {
+		add("policy escalate_fan_out", "invalid escalate_fan_out: %d (must be 0 to disable, or a number of outputs)", cfg.Policy.EscalateFanOut)
+	}
+
+	// Validate per-category fail_on thresholds
+	if cfg.Policy != nil {
+		names := make([]string, 0, len(cfg.Policy.FailOnCategory))
+		for name := range cfg.Policy.FailOnCategory {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			value := cfg.Policy.FailOnCategory[name]
+			key := "policy fail_on." + name
+			if _, err := types.ParseCategory(name); err != nil {
+				add(key, "invalid fail_on category: %s (must be 'breaking', 'risky', or 'advisory')", name)
+				continue
+			}
+			if strings.EqualFold(value, FailOff) {
+				continue
+			}
+			if _, err := types.ParseSeverity(value); err != nil {
+				add(key, "invalid fail_on severity for %s: %s (must be 'ERROR', 'WARNING', 'DEPRECATION', 'NOTICE', or 'off')", name, value)
+			}
 		}
 	}
 
@@ -124,7 +213,29 @@ func Validate(cfg *Config) error {
 	if cfg.RenameDetection != nil && cfg.RenameDetection.SimilarityThreshold != nil {
 		threshold := *cfg.RenameDetection.SimilarityThreshold
 		if threshold < 0.0 || threshold > 1.0 {
-			return fmt.Errorf("invalid similarity_threshold: %f (must be between 0.0 and 1.0)", threshold)
+			add("rename_detection similarity_threshold", "invalid similarity_threshold: %f (must be between 0.0 and 1.0)", threshold)
+		}
+	}
+
+	// Validate the deprecation marker and module version
+	if cfg.Deprecation != nil {
+		if marker := cfg.Deprecation.Marker; marker != "" {
+			if re, err := regexp.Compile(marker); err != nil {
+				add("deprecation marker", "invalid deprecation marker: %s (%v)", marker, err)
+			} else if re.NumSubexp() == 0 {
+				add("deprecation marker", "invalid deprecation marker: %s (must have a capture group for the removal version)", marker)
+			}
+		}
+		if version := cfg.Deprecation.ModuleVersion; version != "" && !moduleVersionPattern.MatchString(version) {
+			add("deprecation module_version", "invalid module_version: %s (must be a version like 1.2.3)", version)
+		}
+	}
+
+	// Validate the pull request ref template
+	if cfg.Git != nil && cfg.Git.PRRefTemplate != "" {
+		template := cfg.Git.PRRefTemplate
+		if !strings.HasPrefix(template, "refs/") || strings.Count(template, "%d") != 1 {
+			add("git pr_ref_template", "invalid pr_ref_template: %s (must start with 'refs/' and contain %%d exactly once)", template)
 		}
 	}
 
@@ -132,13 +243,42 @@ func Validate(cfg *Config) error {
 
 	// Validate rule configurations
 	for _, rule := range cfg.Rules {
+		key := fmt.Sprintf("rules %q", rule.ID)
 		if _, ok := validator.ResolveToID(rule.ID); !ok {
-			return fmt.Errorf("unknown rule: %s", rule.ID)
+			add(key, "unknown rule: %s", rule.ID)
 		}
 
 		if rule.Severity != nil {
 			if _, err := types.ParseSeverity(*rule.Severity); err != nil {
-				return fmt.Errorf("invalid severity for rule %s: %s", rule.ID, *rule.Severity)
+				add(key+" severity", "invalid severity for rule %s: %s", rule.ID, *rule.Severity)
+			}
+		}
+	}
+
+	// Validate per-path overrides like the top-level rules blocks
+	for _, o := range cfg.Overrides {
+		key := fmt.Sprintf("override %q", o.Path)
+		if !doublestar.ValidatePattern(o.Path) {
+			add(key, "invalid override path: %s (must be a glob pattern)", o.Path)
+		}
+		for _, rule := range o.Rules {
+			ruleKey := fmt.Sprintf("%s rules %q", key, rule.ID)
+			if _, ok := validator.ResolveToID(rule.ID); !ok {
+				add(ruleKey, "unknown rule in override %q: %s", o.Path, rule.ID)
+			}
+			if rule.Severity != nil {
+				if _, err := types.ParseSeverity(*rule.Severity); err != nil {
+					add(ruleKey+" severity", "invalid severity for rule %s in override %q: %s", rule.ID, o.Path, *rule.Severity)
+				}
+			}
+		}
+	}
+
+	// Validate policy required_rules
+	if cfg.Policy != nil {
+		for i, ruleSpec := range cfg.Policy.RequiredRules {
+			if _, ok := validator.ResolveToID(ruleSpec); !ok {
+				add(fmt.Sprintf("policy required_rules[%d]", i), "unknown rule in required_rules: %s", ruleSpec)
 			}
 		}
 	}
@@ -146,20 +286,20 @@ func Validate(cfg *Config) error {
 	// Validate annotation allow_rule_ids and deny_rule_ids
 	// Only rule names are accepted (e.g., required-input-added)
 	if cfg.Annotations != nil {
-		for _, ruleSpec := range cfg.Annotations.AllowRuleIDs {
+		for i, ruleSpec := range cfg.Annotations.AllowRuleIDs {
 			if _, ok := validator.ResolveToID(ruleSpec); !ok {
-				return fmt.Errorf("unknown rule in allow_rule_ids: %s", ruleSpec)
+				add(fmt.Sprintf("annotations allow_rule_ids[%d]", i), "unknown rule in allow_rule_ids: %s", ruleSpec)
 			}
 		}
 
-		for _, ruleSpec := range cfg.Annotations.DenyRuleIDs {
+		for i, ruleSpec := range cfg.Annotations.DenyRuleIDs {
 			if _, ok := validator.ResolveToID(ruleSpec); !ok {
-				return fmt.Errorf("unknown rule in deny_rule_ids: %s", ruleSpec)
+				add(fmt.Sprintf("annotations deny_rule_ids[%d]", i), "unknown rule in deny_rule_ids: %s", ruleSpec)
 			}
 		}
 	}
 
-	return nil
+	return append(problems, profileProblems(cfg)...)
 }
 
 // ValidateRuleID checks if a rule ID or name is valid
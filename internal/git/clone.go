@@ -1,8 +1,10 @@
 package git
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
 )
 
 // Clone represents a shallow clone that will be cleaned up.
@@ -25,39 +27,55 @@ type Clone struct {
 // by calling Remove() when done.
 //
 // This uses --depth 1 --single-branch for efficiency, downloading only the
-// necessary data for the specified ref.
-func ShallowClone(url, ref string) (*Clone, error) {
+// necessary data for the specified ref. Refs outside refs/heads and
+// refs/tags, such as pull request refs, are fetched into an empty repository
+// instead, since clone cannot check them out. If TFBREAK_GIT_CACHE is set, the ref
+// is checked out from a local mirror of the remote kept in that directory
+// instead, which makes repeated comparisons against the same remote cheap.
+//
+// The clone is abandoned, and its directory removed, when ctx is done.
+func ShallowClone(ctx context.Context, url, ref string) (*Clone, error) {
 	// Pre-flight: validate ref exists remotely (fast failure without cloning)
-	sha, fullRef, err := ResolveRemoteRef(url, ref)
+	sha, fullRef, err := ResolveRemoteRef(ctx, url, ref)
 	if err != nil {
 		return nil, err
 	}
 
+	if cacheDir := os.Getenv(CacheDirEnv); cacheDir != "" {
+		return cloneFromMirror(ctx, cacheDir, url, ref, sha)
+	}
+
 	// Create temp directory for clone
 	tmpDir, err := os.MkdirTemp("", "tfbreak-clone-")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create temp directory: %w", err)
 	}
 
-	// Shallow clone with minimal data
-	// --depth 1: only fetch the single commit
-	// --branch: specify the ref to clone
-	// --single-branch: don't fetch other branches
-	_, err = Run([]string{
-		"clone",
-		"--depth", "1",
-		"--branch", ref,
-		"--single-branch",
-		url,
-		tmpDir,
-	}, nil)
+	if isCloneableRef(fullRef) {
+		// Shallow clone with minimal data
+		// --depth 1: only fetch the single commit
+		// --branch: specify the ref to clone
+		// --single-branch: don't fetch other branches
+		_, err = RunContext(ctx, []string{
+			"clone",
+			"--depth", "1",
+			"--branch", ref,
+			"--single-branch",
+			url,
+			tmpDir,
+		}, nil)
+	} else {
+		err = fetchShallow(ctx, url, fullRef, tmpDir)
+	}
 	if err != nil {
 		os.RemoveAll(tmpDir) // Clean up temp dir on failure
-		return nil, fmt.Errorf("failed to clone %s at %s: %w", url, ref, err)
+		return nil, fmt.Errorf("failed to clone %s at %s: %w", RedactURL(url), ref, err)
 	}
 
-	// Use the SHA from ls-remote since shallow clone might not have full ref info
-	_ = fullRef // fullRef available if needed
+	if err := ensureLFSContent(tmpDir); err != nil {
+		os.RemoveAll(tmpDir)
+		return nil, err
+	}
 
 	return &Clone{
 		Path: tmpDir,
@@ -67,6 +85,27 @@ func ShallowClone(url, ref string) (*Clone, error) {
 	}, nil
 }
 
+// isCloneableRef reports whether "git clone --branch" accepts fullRef.
+// Only branches and tags qualify; refs in other namespaces, such as pull
+// request refs, have to be fetched explicitly.
+func isCloneableRef(fullRef string) bool {
+	return strings.HasPrefix(fullRef, "refs/heads/") || strings.HasPrefix(fullRef, "refs/tags/")
+}
+
+// fetchShallow checks out a single commit of fullRef from url into dir by
+// initializing an empty repository and fetching just that ref.
+func fetchShallow(ctx context.Context, url, fullRef, dir string) error {
+	if _, err := Run([]string{"init", "--quiet", dir}, nil); err != nil {
+		return err
+	}
+	opts := &RunOptions{Dir: dir}
+	if _, err := RunContext(ctx, []string{"fetch", "--depth", "1", "--quiet", "--no-tags", url, fullRef}, opts); err != nil {
+		return err
+	}
+	_, err := Run([]string{"checkout", "--detach", "--quiet", "FETCH_HEAD"}, opts)
+	return err
+}
+
 // Remove cleans up the clone by removing the directory.
 func (c *Clone) Remove() error {
 	if c.Path == "" {
@@ -84,15 +123,15 @@ func (c *Clone) Remove() error {
 // CloneForComparison creates two shallow clones for comparing two refs.
 // Both clones are returned, and the caller is responsible for calling Remove() on both.
 // If creating either clone fails, any created clone is cleaned up before returning.
-func CloneForComparison(url, baseRef, headRef string) (baseClone, headClone *Clone, err error) {
+func CloneForComparison(ctx context.Context, url, baseRef, headRef string) (baseClone, headClone *Clone, err error) {
 	// Clone base ref first
-	baseClone, err = ShallowClone(url, baseRef)
+	baseClone, err = ShallowClone(ctx, url, baseRef)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to clone base ref %q: %w", baseRef, err)
 	}
 
 	// Clone head ref
-	headClone, err = ShallowClone(url, headRef)
+	headClone, err = ShallowClone(ctx, url, headRef)
 	if err != nil {
 		// Clean up base clone on failure
 		baseClone.Remove()
@@ -0,0 +1,69 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Commit is a commit as listed by CommitsBetween
+type Commit struct {
+	// SHA is the full commit SHA
+	SHA string
+
+	// Author is the author's name
+	Author string
+
+	// Date is the author date
+	Date time.Time
+
+	// Subject is the first line of the commit message
+	Subject string
+}
+
+// commitLogFormat is the git log format parsed by parseCommitLog: one line
+// per commit with the fields separated by the ASCII unit separator
+const commitLogFormat = "--format=%H%x1f%an%x1f%aI%x1f%s"
+
+// CommitsBetween returns the commits reachable from head but not from base
+// (the range "base..head"), newest first, as listed by "git log". The
+// result is empty if head is base or one of its ancestors. Returns
+// *ErrNoMergeBase if the refs share no history, since the range would then
+// hold all of head's history.
+func CommitsBetween(dir, base, head string) ([]Commit, error) {
+	// Also reports invalid refs before they reach git log
+	if _, err := MergeBase(dir, base, head); err != nil {
+		return nil, err
+	}
+
+	out, err := Run([]string{"log", commitLogFormat, base + ".." + head, "--"}, &RunOptions{Dir: dir})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits between %q and %q: %w", base, head, err)
+	}
+	return parseCommitLog(out)
+}
+
+// parseCommitLog parses the output of git log with commitLogFormat
+func parseCommitLog(out string) ([]Commit, error) {
+	commits := []Commit{}
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.SplitN(line, "\x1f", 4)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("unexpected git log output: %q", line)
+		}
+		date, err := time.Parse(time.RFC3339, fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("unexpected commit date %q: %w", fields[2], err)
+		}
+		commits = append(commits, Commit{
+			SHA:     fields[0],
+			Author:  fields[1],
+			Date:    date,
+			Subject: fields[3],
+		})
+	}
+	return commits, nil
+}
@@ -0,0 +1,113 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+)
+
+func TestCommitsBetween(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	dir := t.TempDir()
+	setupTestRepo(t, dir)
+	runGit(t, dir, "branch", "-M", "main")
+	runGit(t, dir, "tag", "base")
+	runGit(t, dir, "commit", "--allow-empty", "-m", "First change")
+	runGit(t, dir, "commit", "--allow-empty", "-m", "Second change")
+	runGit(t, dir, "commit", "--allow-empty", "-m", "Third change: with | separators")
+
+	commits, err := CommitsBetween(dir, "base", "main")
+	if err != nil {
+		t.Fatalf("CommitsBetween() error = %v", err)
+	}
+
+	want := []string{"Third change: with | separators", "Second change", "First change"}
+	if len(commits) != len(want) {
+		t.Fatalf("CommitsBetween() returned %d commits, want %d", len(commits), len(want))
+	}
+	head, err := ResolveRef(dir, "main")
+	if err != nil {
+		t.Fatalf("ResolveRef() error = %v", err)
+	}
+	if commits[0].SHA != head {
+		t.Errorf("commits[0].SHA = %q, want HEAD %q", commits[0].SHA, head)
+	}
+	for i, c := range commits {
+		if c.Subject != want[i] {
+			t.Errorf("commits[%d].Subject = %q, want %q", i, c.Subject, want[i])
+		}
+		if len(c.SHA) != 40 {
+			t.Errorf("commits[%d].SHA = %q, want a full SHA", i, c.SHA)
+		}
+		if c.Author != "Test User" {
+			t.Errorf("commits[%d].Author = %q, want %q", i, c.Author, "Test User")
+		}
+		if c.Date.IsZero() {
+			t.Errorf("commits[%d].Date is zero", i)
+		}
+	}
+}
+
+func TestCommitsBetween_EmptyRange(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	dir := t.TempDir()
+	setupTestRepo(t, dir)
+	runGit(t, dir, "tag", "old")
+	runGit(t, dir, "commit", "--allow-empty", "-m", "Newer commit")
+
+	tests := []struct {
+		name       string
+		base, head string
+	}{
+		{"same ref", "HEAD", "HEAD"},
+		{"head is an ancestor of base", "HEAD", "old"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commits, err := CommitsBetween(dir, tt.base, tt.head)
+			if err != nil {
+				t.Fatalf("CommitsBetween() error = %v", err)
+			}
+			if commits == nil || len(commits) != 0 {
+				t.Errorf("CommitsBetween() = %+v, want an empty slice", commits)
+			}
+		})
+	}
+}
+
+func TestCommitsBetween_NoCommonAncestor(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	dir := t.TempDir()
+	setupTestRepo(t, dir)
+	runGit(t, dir, "branch", "original")
+	runGit(t, dir, "checkout", "-q", "--orphan", "unrelated")
+	runGit(t, dir, "commit", "--allow-empty", "-m", "Unrelated root")
+
+	_, err := CommitsBetween(dir, "original", "unrelated")
+	var noMergeBase *ErrNoMergeBase
+	if !errors.As(err, &noMergeBase) {
+		t.Fatalf("CommitsBetween() error = %v, want *ErrNoMergeBase", err)
+	}
+}
+
+func TestCommitsBetween_InvalidRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	dir := t.TempDir()
+	setupTestRepo(t, dir)
+
+	if _, err := CommitsBetween(dir, "does-not-exist", "HEAD"); err == nil {
+		t.Error("CommitsBetween() expected error for an invalid ref")
+	}
+}
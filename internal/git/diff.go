@@ -0,0 +1,49 @@
+package git
+
+import "strings"
+
+// Diff returns the files that changed on headRef since it diverged from
+// baseRef, as "git diff base...head" reports them: the comparison starts
+// at the merge base of the two refs, so commits made on baseRef after
+// headRef branched off are not included. This matches what a pull request
+// from headRef into baseRef changes.
+//
+// Paths are relative to the repository root and use forward slashes, even
+// when repoDir is a subdirectory. Renames are reported as a deletion of the
+// old path and an addition of the new one, so both show up. If nothing
+// changed, an empty slice is returned.
+func Diff(repoDir, baseRef, headRef string) ([]string, error) {
+	return diffNames(repoDir, baseRef+"..."+headRef)
+}
+
+// DiffDirect returns the files that differ between baseRef and headRef, as
+// "git diff base..head" reports them: the two commits are compared as they
+// are, so changes made on baseRef after headRef branched off are included.
+// Paths are reported as by Diff.
+func DiffDirect(repoDir, baseRef, headRef string) ([]string, error) {
+	return diffNames(repoDir, baseRef+".."+headRef)
+}
+
+// diffNames lists the files changed in a revision range, running git from
+// the repository root so paths do not depend on repoDir
+func diffNames(repoDir, revRange string) ([]string, error) {
+	root, err := FindGitRoot(repoDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// -z keeps paths with unusual characters unquoted; the trailing "--"
+	// makes git read the range as revisions, never as a path
+	out, err := Run([]string{"diff", "--name-only", "-z", "--no-renames", revRange, "--"}, &RunOptions{Dir: root})
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{}
+	for _, path := range strings.Split(out, "\x00") {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
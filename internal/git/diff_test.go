@@ -0,0 +1,125 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// setupDiffRepo creates a repository where the "feature" branch changes
+// modules/a and renames modules/b, while main changes modules/c after the
+// branch point
+func setupDiffRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	setupTestRepo(t, dir)
+
+	for _, module := range []string{"a", "b", "c"} {
+		writeDiffFile(t, filepath.Join(dir, "modules", module, "main.tf"), "# "+module+"\n")
+	}
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-m", "Add modules")
+	runGit(t, dir, "branch", "-M", "main")
+
+	runGit(t, dir, "checkout", "-q", "-b", "feature")
+	writeDiffFile(t, filepath.Join(dir, "modules", "a", "main.tf"), "# a changed\n")
+	runGit(t, dir, "mv", "modules/b", "modules/renamed")
+	runGit(t, dir, "commit", "-am", "Change a, rename b")
+
+	runGit(t, dir, "checkout", "-q", "main")
+	writeDiffFile(t, filepath.Join(dir, "modules", "c", "main.tf"), "# c changed\n")
+	runGit(t, dir, "commit", "-am", "Change c")
+	return dir
+}
+
+func writeDiffFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+	dir := setupDiffRepo(t)
+
+	// Three-dot: only what the feature branch changed since it branched off
+	got, err := Diff(dir, "main", "feature")
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	want := []string{"modules/a/main.tf", "modules/b/main.tf", "modules/renamed/main.tf"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %v, want %v", got, want)
+	}
+
+	// Two-dot: main's later change to modules/c differs too
+	got, err = DiffDirect(dir, "main", "feature")
+	if err != nil {
+		t.Fatalf("DiffDirect() error = %v", err)
+	}
+	want = []string{"modules/a/main.tf", "modules/b/main.tf", "modules/c/main.tf", "modules/renamed/main.tf"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffDirect() = %v, want %v", got, want)
+	}
+}
+
+func TestDiff_FromSubdirectory(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+	dir := setupDiffRepo(t)
+
+	// Paths stay relative to the repository root
+	got, err := Diff(filepath.Join(dir, "modules", "c"), "main", "feature")
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if len(got) != 3 || got[0] != "modules/a/main.tf" {
+		t.Errorf("Diff() from subdirectory = %v, want root-relative paths", got)
+	}
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+	dir := setupDiffRepo(t)
+
+	got, err := Diff(dir, "feature", "feature")
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if got == nil || len(got) != 0 {
+		t.Errorf("Diff() = %#v, want an empty slice", got)
+	}
+}
+
+func TestDiff_Errors(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+	dir := setupDiffRepo(t)
+
+	_, err := Diff(dir, "main", "does-not-exist")
+	var gitErr *GitError
+	if !errors.As(err, &gitErr) || gitErr.Stderr == "" {
+		t.Errorf("Diff() with unknown ref error = %v, want *GitError with stderr", err)
+	}
+	if !IsNotFound(err) {
+		t.Errorf("IsNotFound(%v) = false, want true", err)
+	}
+
+	var notRepo *ErrNotARepository
+	if _, err := Diff(t.TempDir(), "main", "feature"); !errors.As(err, &notRepo) {
+		t.Errorf("Diff() outside a repository error = %v, want *ErrNotARepository", err)
+	}
+}
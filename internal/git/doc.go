@@ -28,5 +28,5 @@
 //	exists, err := git.RefExists("/path/to/repo", "main")
 //
 //	// Check if a ref exists in a remote repository
-//	exists, err := git.RemoteRefExists("https://github.com/org/repo", "v1.0.0")
+//	exists, err := git.RemoteRefExists(ctx, "https://github.com/org/repo", "v1.0.0")
 package git
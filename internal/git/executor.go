@@ -2,9 +2,12 @@ package git
 
 import (
 	"bytes"
+	"context"
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
+	"time"
 )
 
 // RunOptions configures how a git command is executed.
@@ -18,23 +21,72 @@ type RunOptions struct {
 	Env []string
 }
 
+var (
+	trustedDirsMu sync.Mutex
+	trustedDirs   []string
+)
+
+// TrustDirectory marks a repository as safe for all subsequent git commands
+// run by this process, as if it were listed in git's safe.directory setting.
+// The user's git configuration is not modified.
+func TrustDirectory(dir string) {
+	trustedDirsMu.Lock()
+	defer trustedDirsMu.Unlock()
+	for _, d := range trustedDirs {
+		if d == dir {
+			return
+		}
+	}
+	trustedDirs = append(trustedDirs, dir)
+}
+
+// commandArgs returns the full argument list for a git command, passing
+// trusted directories as command-line safe.directory settings.
+func commandArgs(args []string) []string {
+	trustedDirsMu.Lock()
+	defer trustedDirsMu.Unlock()
+	if len(trustedDirs) == 0 {
+		return args
+	}
+
+	full := make([]string, 0, len(trustedDirs)*2+len(args))
+	for _, d := range trustedDirs {
+		full = append(full, "-c", "safe.directory="+d)
+	}
+	return append(full, args...)
+}
+
 // Available returns true if git is installed and in PATH.
 func Available() bool {
 	_, err := exec.LookPath("git")
 	return err == nil
 }
 
+// waitDelay bounds how long a killed git command may keep its output open.
+// Helpers started by git, such as ssh or git-remote-https, inherit the
+// pipes and can otherwise keep a cancelled command from returning.
+const waitDelay = 2 * time.Second
+
 // Run executes a git command and returns the stdout output.
-// If the command fails, a *GitError is returned with stderr context.
+// If the command fails, a *GitError is returned with stderr context, or an
+// *ErrDubiousOwnership wrapping it if git refused to use the repository.
 func Run(args []string, opts *RunOptions) (string, error) {
+	return RunContext(context.Background(), args, opts)
+}
+
+// RunContext is like Run, but kills the git command when ctx is done. The
+// error is then an *ErrCanceled wrapping ctx.Err(), so callers can check
+// for context.DeadlineExceeded with errors.Is.
+func RunContext(ctx context.Context, args []string, opts *RunOptions) (string, error) {
 	if !Available() {
 		return "", ErrGitNotFound
 	}
 
 	var stdout, stderr bytes.Buffer
-	cmd := exec.Command("git", args...)
+	cmd := exec.CommandContext(ctx, "git", commandArgs(args)...)
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
+	cmd.WaitDelay = waitDelay
 
 	// Set working directory if specified
 	if opts != nil && opts.Dir != "" {
@@ -47,17 +99,22 @@ func Run(args []string, opts *RunOptions) (string, error) {
 		cmd.Env = append(cmd.Env, opts.Env...)
 	}
 
+	start := time.Now()
 	err := cmd.Run()
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", &ErrCanceled{Command: redactArgs(args), Elapsed: time.Since(start), Err: ctxErr}
+		}
 		exitCode := 1
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			exitCode = exitErr.ExitCode()
 		}
-		return "", &GitError{
-			Command:  args,
+		// Credentials in remote URLs must not end up in error messages or logs
+		return "", classifyError(&GitError{
+			Command:  redactArgs(args),
 			ExitCode: exitCode,
-			Stderr:   stderr.String(),
-		}
+			Stderr:   RedactURL(stderr.String()),
+		})
 	}
 
 	return strings.TrimSpace(stdout.String()), nil
@@ -0,0 +1,86 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// fullSHARe matches a full SHA-1 or SHA-256 object name, the only kind of
+// commit a server can be asked for directly
+var fullSHARe = regexp.MustCompile(`^[0-9a-f]{40}([0-9a-f]{24})?$`)
+
+// FetchRef fetches ref from remote into the repository at dir, one commit
+// deep, so that a ref missing from a shallow clone resolves afterwards. Tags
+// and branches are stored under their own names, a remote-tracking name such
+// as "origin/main" as that remote-tracking branch, and a full SHA only as the
+// commit. Returns *ErrRemoteNotConfigured if dir has no such remote, and
+// *ErrRefNotFound if the remote has no such ref. The fetch is abandoned when
+// ctx is done.
+func FetchRef(ctx context.Context, dir, remote, ref string) error {
+	if _, err := Run([]string{"remote", "get-url", remote}, &RunOptions{Dir: dir}); err != nil {
+		var gitErr *GitError
+		if errors.As(err, &gitErr) && strings.Contains(gitErr.Stderr, "No such remote") {
+			return &ErrRemoteNotConfigured{Remote: remote, Dir: dir}
+		}
+		return err
+	}
+
+	refspec, err := fetchRefspec(ctx, dir, remote, ref)
+	if err != nil {
+		return err
+	}
+
+	_, err = RunContext(ctx, []string{"fetch", "--quiet", "--no-tags", "--depth=1", remote, refspec}, &RunOptions{Dir: dir})
+	if err != nil {
+		var gitErr *GitError
+		if errors.As(err, &gitErr) && strings.Contains(strings.ToLower(gitErr.Stderr), "couldn't find remote ref") {
+			return &ErrRefNotFound{Ref: ref, Remote: remote}
+		}
+		return fmt.Errorf("failed to fetch %q from %s: %w", ref, remote, err)
+	}
+	return nil
+}
+
+// fetchRefspec returns the refspec that fetches ref from remote into the
+// local ref it resolves as. A short name is looked up on the remote, with a
+// tag taking precedence over a branch of the same name, as in rev-parse.
+func fetchRefspec(ctx context.Context, dir, remote, ref string) (string, error) {
+	// Revision suffixes select an ancestor, which is fetched along with
+	// the named commit only if the history is deep enough
+	name := ref
+	if i := strings.IndexAny(name, "~^"); i >= 0 {
+		name = name[:i]
+	}
+
+	switch {
+	case strings.HasPrefix(name, "refs/"):
+		return "+" + name + ":" + name, nil
+	case fullSHARe.MatchString(name):
+		return name, nil
+	case strings.HasPrefix(name, remote+"/"):
+		branch := strings.TrimPrefix(name, remote+"/")
+		return "+refs/heads/" + branch + ":refs/remotes/" + name, nil
+	}
+
+	tagRef, branchRef := "refs/tags/"+name, "refs/heads/"+name
+	out, err := RunContext(ctx, []string{"ls-remote", remote, tagRef, branchRef}, &RunOptions{Dir: dir})
+	if err != nil {
+		return "", fmt.Errorf("failed to look up %q in %s: %w", ref, remote, err)
+	}
+
+	found := make(map[string]bool)
+	for _, line := range splitLines(out) {
+		if fields := strings.Fields(line); len(fields) == 2 {
+			found[fields[1]] = true
+		}
+	}
+	for _, fullRef := range []string{tagRef, branchRef} {
+		if found[fullRef] {
+			return "+" + fullRef + ":" + fullRef, nil
+		}
+	}
+	return "", &ErrRefNotFound{Ref: ref, Remote: remote}
+}
@@ -0,0 +1,109 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// setupShallowClone creates a remote with a tag "v1.0.0" on its first commit,
+// a branch "feature" and a second commit on the default branch, and returns
+// a clone of the default branch one commit deep without tags
+func setupShallowClone(t *testing.T) (cloneDir, tagSHA string) {
+	t.Helper()
+
+	remoteDir := t.TempDir()
+	setupBareRepo(t, remoteDir)
+
+	localDir := t.TempDir()
+	setupTestRepo(t, localDir)
+	createTag(t, localDir, "v1.0.0")
+	tagSHA = getHeadSHA(t, localDir)
+	runGit(t, localDir, "branch", "feature")
+	if err := os.WriteFile(filepath.Join(localDir, "main.tf"), []byte("variable \"x\" {}\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	runGit(t, localDir, "add", "main.tf")
+	runGit(t, localDir, "commit", "-m", "Second commit")
+	addRemoteAndPush(t, localDir, remoteDir)
+	runGit(t, localDir, "push", "origin", "feature", "v1.0.0")
+
+	cloneDir = t.TempDir()
+	runGit(t, cloneDir, "clone", "--quiet", "--depth=1", "--no-tags", "file://"+remoteDir, ".")
+	return cloneDir, tagSHA
+}
+
+func TestFetchRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	tests := []struct {
+		ref string
+	}{
+		{"v1.0.0"},
+		{"feature"},
+		{"origin/feature"},
+		{"refs/tags/v1.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			cloneDir, tagSHA := setupShallowClone(t)
+
+			if _, err := ResolveRef(cloneDir, tt.ref); err == nil {
+				t.Fatalf("expected %q to be missing from the shallow clone", tt.ref)
+			}
+
+			if err := FetchRef(context.Background(), cloneDir, "origin", tt.ref); err != nil {
+				t.Fatalf("FetchRef failed: %v", err)
+			}
+
+			sha, err := ResolveRef(cloneDir, tt.ref)
+			if err != nil {
+				t.Fatalf("ResolveRef after fetch failed: %v", err)
+			}
+			if sha != tagSHA {
+				t.Errorf("ResolveRef(%q) = %s, want %s", tt.ref, sha, tagSHA)
+			}
+		})
+	}
+}
+
+func TestFetchRef_NotFound(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	cloneDir, _ := setupShallowClone(t)
+
+	err := FetchRef(context.Background(), cloneDir, "origin", "v9.9.9")
+	var notFound *ErrRefNotFound
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected ErrRefNotFound, got %v", err)
+	}
+	if notFound.Remote != "origin" {
+		t.Errorf("Remote = %q, want origin", notFound.Remote)
+	}
+}
+
+func TestFetchRef_RemoteNotConfigured(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	dir := t.TempDir()
+	setupTestRepo(t, dir)
+
+	err := FetchRef(context.Background(), dir, "origin", "main")
+	var notConfigured *ErrRemoteNotConfigured
+	if !errors.As(err, &notConfigured) {
+		t.Fatalf("expected ErrRemoteNotConfigured, got %v", err)
+	}
+	if notConfigured.Remote != "origin" {
+		t.Errorf("Remote = %q, want origin", notConfigured.Remote)
+	}
+}
@@ -0,0 +1,103 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// lfsPointerPrefix starts every Git LFS pointer file
+const lfsPointerPrefix = "version https://git-lfs"
+
+// lfsPointerMaxSize is the size limit for pointer files set by the Git LFS
+// specification. Larger files are never pointers.
+const lfsPointerMaxSize = 1024
+
+// IsLFSPointer reports whether data is the content of a Git LFS pointer file
+// rather than the file it stands for
+func IsLFSPointer(data []byte) bool {
+	return len(data) < lfsPointerMaxSize && bytes.HasPrefix(data, []byte(lfsPointerPrefix))
+}
+
+// FindLFSPointers returns the Terraform files (.tf and .tf.json) under dir
+// that are Git LFS pointers, as slash-separated paths relative to dir
+func FindLFSPointers(dir string) ([]string, error) {
+	var pointers []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && (d.Name() == ".git" || d.Name() == ".terraform") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ".tf") && !strings.HasSuffix(d.Name(), ".tf.json") {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.Size() >= lfsPointerMaxSize {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if IsLFSPointer(data) {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			pointers = append(pointers, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s for Git LFS pointers: %w", dir, err)
+	}
+	return pointers, nil
+}
+
+// LFSAvailable checks if Git LFS is installed
+func LFSAvailable() bool {
+	_, err := Run([]string{"lfs", "version"}, nil)
+	return err == nil
+}
+
+// ensureLFSContent replaces Git LFS pointers among the Terraform files of a
+// checkout with their content. Checkouts normally do this through the LFS
+// smudge filter, which is missing when Git LFS is not installed or was
+// skipped. Loading the pointers instead would report an empty module.
+func ensureLFSContent(dir string) error {
+	pointers, err := FindLFSPointers(dir)
+	if err != nil || len(pointers) == 0 {
+		return err
+	}
+
+	if !LFSAvailable() {
+		return &ErrLFSPointers{Files: pointers}
+	}
+
+	// Fetches the objects if needed and checks out their content
+	_, err = Run([]string{"lfs", "pull", "--include", strings.Join(pointers, ",")}, &RunOptions{Dir: dir})
+	if err != nil {
+		return &ErrLFSPointers{Files: pointers, Err: err}
+	}
+
+	remaining, err := FindLFSPointers(dir)
+	if err != nil {
+		return err
+	}
+	if len(remaining) > 0 {
+		return &ErrLFSPointers{Files: remaining, Err: fmt.Errorf("git lfs pull left the files as pointers")}
+	}
+	return nil
+}
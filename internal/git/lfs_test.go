@@ -0,0 +1,112 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testLFSPointer is a pointer file as Git LFS writes it
+const testLFSPointer = `version https://git-lfs.github.com/spec/v1
+oid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393
+size 12345
+`
+
+func TestIsLFSPointer(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want bool
+	}{
+		{"pointer", testLFSPointer, true},
+		{"terraform", "variable \"name\" {}\n", false},
+		{"empty", "", false},
+		{"mentions lfs later", "# version https://git-lfs.github.com/spec/v1\n", false},
+		{"too large", testLFSPointer + strings.Repeat("#", lfsPointerMaxSize), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsLFSPointer([]byte(tt.data)); got != tt.want {
+				t.Errorf("IsLFSPointer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindLFSPointers(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.tf":                   testLFSPointer,
+		"variables.tf":              "variable \"name\" {}\n",
+		"modules/vpc/outputs.tf":    testLFSPointer,
+		"modules/vpc/main.tf.json":  testLFSPointer,
+		"README.md":                 testLFSPointer,
+		".terraform/modules/x/a.tf": testLFSPointer,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pointers, err := FindLFSPointers(dir)
+	if err != nil {
+		t.Fatalf("FindLFSPointers() error = %v", err)
+	}
+
+	want := []string{"main.tf", "modules/vpc/main.tf.json", "modules/vpc/outputs.tf"}
+	if strings.Join(pointers, ",") != strings.Join(want, ",") {
+		t.Errorf("FindLFSPointers() = %v, want %v", pointers, want)
+	}
+}
+
+func TestCreateWorktree_LFSPointer(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	// Commit a pointer file as a regular file, which is what a checkout
+	// without the LFS smudge filter produces
+	repoDir := t.TempDir()
+	setupTestRepo(t, repoDir)
+	if err := os.WriteFile(filepath.Join(repoDir, "main.tf"), []byte(testLFSPointer), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repoDir, "add", "main.tf")
+	runGit(t, repoDir, "commit", "-m", "Add LFS pointer")
+
+	wt, err := CreateWorktree(repoDir, "HEAD")
+	if err == nil {
+		wt.Remove()
+		t.Fatal("expected error for a checkout with LFS pointers")
+	}
+
+	// Whether or not Git LFS is installed, the object does not exist
+	var lfsErr *ErrLFSPointers
+	if !errors.As(err, &lfsErr) {
+		t.Fatalf("expected *ErrLFSPointers, got %T: %v", err, err)
+	}
+	if len(lfsErr.Files) != 1 || lfsErr.Files[0] != "main.tf" {
+		t.Errorf("Files = %v, want [main.tf]", lfsErr.Files)
+	}
+	if lfsErr.Err == nil && !strings.Contains(err.Error(), "git lfs install") {
+		t.Errorf("error should explain how to install Git LFS, got: %v", err)
+	}
+
+	// The worktree must have been cleaned up
+	worktrees, err := WorktreeList(repoDir)
+	if err != nil {
+		t.Fatalf("WorktreeList() error = %v", err)
+	}
+	if len(worktrees) != 1 {
+		t.Errorf("expected only the main worktree to remain, got %v", worktrees)
+	}
+}
@@ -0,0 +1,25 @@
+package git
+
+import (
+	"errors"
+	"strings"
+)
+
+// MergeBase returns the SHA of the best common ancestor of refA and refB, as
+// found by "git merge-base". This is the commit a three-dot comparison
+// (refA...refB) starts from. Returns *ErrNoMergeBase if the refs share no
+// history.
+func MergeBase(repoDir, refA, refB string) (string, error) {
+	out, err := Run([]string{"merge-base", refA, refB}, &RunOptions{Dir: repoDir})
+	if err != nil {
+		// git merge-base exits 1 without output when there is no common
+		// ancestor; invalid refs fail with exit 128 and an error message
+		var gitErr *GitError
+		if errors.As(err, &gitErr) && gitErr.ExitCode == 1 && strings.TrimSpace(gitErr.Stderr) == "" {
+			shallow, _ := IsShallowClone(repoDir)
+			return "", &ErrNoMergeBase{RefA: refA, RefB: refB, IsShallow: shallow}
+		}
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
@@ -0,0 +1,78 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestMergeBase(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	dir := t.TempDir()
+	setupTestRepo(t, dir)
+	runGit(t, dir, "branch", "-M", "main")
+	forkPoint, err := ResolveRef(dir, "HEAD")
+	if err != nil {
+		t.Fatalf("ResolveRef() error = %v", err)
+	}
+
+	// Both branches move on after the feature branch is created
+	runGit(t, dir, "checkout", "-q", "-b", "feature")
+	runGit(t, dir, "commit", "--allow-empty", "-m", "Feature commit")
+	runGit(t, dir, "checkout", "-q", "main")
+	runGit(t, dir, "commit", "--allow-empty", "-m", "Main commit")
+
+	sha, err := MergeBase(dir, "main", "feature")
+	if err != nil {
+		t.Fatalf("MergeBase() error = %v", err)
+	}
+	if sha != forkPoint {
+		t.Errorf("MergeBase() = %q, want fork point %q", sha, forkPoint)
+	}
+}
+
+func TestMergeBase_NoCommonAncestor(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	dir := t.TempDir()
+	setupTestRepo(t, dir)
+	runGit(t, dir, "branch", "original")
+	runGit(t, dir, "checkout", "-q", "--orphan", "unrelated")
+	runGit(t, dir, "commit", "--allow-empty", "-m", "Unrelated root")
+
+	_, err := MergeBase(dir, "original", "unrelated")
+	var noMergeBase *ErrNoMergeBase
+	if !errors.As(err, &noMergeBase) {
+		t.Fatalf("MergeBase() error = %v, want *ErrNoMergeBase", err)
+	}
+	if noMergeBase.IsShallow {
+		t.Error("IsShallow = true, want false for a full repository")
+	}
+	if !strings.Contains(err.Error(), "have no common ancestor") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+}
+
+func TestMergeBase_InvalidRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	dir := t.TempDir()
+	setupTestRepo(t, dir)
+
+	_, err := MergeBase(dir, "HEAD", "does-not-exist")
+	if err == nil {
+		t.Fatal("MergeBase() with an invalid ref should return error")
+	}
+	var noMergeBase *ErrNoMergeBase
+	if errors.As(err, &noMergeBase) {
+		t.Errorf("MergeBase() with an invalid ref error = %v, want a git error", err)
+	}
+}
@@ -0,0 +1,209 @@
+package git
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CacheDirEnv is the environment variable that enables the local mirror cache
+// for remote clones. When set, ShallowClone keeps a mirror of each remote
+// under this directory and checks refs out from it instead of cloning anew.
+const CacheDirEnv = "TFBREAK_GIT_CACHE"
+
+const (
+	// mirrorLockTimeout is how long to wait for another process holding a mirror lock
+	mirrorLockTimeout = 5 * time.Minute
+
+	// mirrorLockStale is the age after which a lock is assumed to be left
+	// behind by a crashed process and is broken
+	mirrorLockStale = 30 * time.Minute
+
+	// mirrorLockPoll is the interval between attempts to acquire a mirror lock
+	mirrorLockPoll = 100 * time.Millisecond
+)
+
+// Environment variables that pass the credentials of a remote URL to
+// credentialHelper
+const (
+	credentialUsernameEnv = "TFBREAK_GIT_USERNAME"
+	credentialPasswordEnv = "TFBREAK_GIT_PASSWORD"
+)
+
+// credentialHelper answers git's credential requests from the credential
+// environment variables
+const credentialHelper = `!f() { test "$1" = get || return 0; ` +
+	`echo "username=$` + credentialUsernameEnv + `"; echo "password=$` + credentialPasswordEnv + `"; }; f`
+
+// mirrorMu serializes mirror access within this process; the lock file
+// serializes it across processes.
+var mirrorMu sync.Mutex
+
+// mirrorPath returns the mirror directory for url under cacheDir
+func mirrorPath(cacheDir, url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(cacheDir, hex.EncodeToString(sum[:8])+".git")
+}
+
+// cloneFromMirror checks out sha from a local mirror of url under cacheDir.
+// The mirror is created with "git clone --mirror" on first use and refreshed
+// with "git fetch" afterwards. The checkout borrows objects from the mirror
+// while cloning and then copies them, so it stays valid after the mirror is
+// fetched into again, and is removed like any other Clone.
+func cloneFromMirror(ctx context.Context, cacheDir, url, ref, sha string) (*Clone, error) {
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create git cache directory: %w", err)
+	}
+
+	// The mirror is keyed and configured without credentials, so they are
+	// not written to the cache
+	remote, credentials := splitCredentials(url)
+	mirror := mirrorPath(cacheDir, remote)
+	unlock, err := lockMirror(mirror)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if err := updateMirror(ctx, mirror, url, remote, credentials); err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "tfbreak-clone-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+
+	// --reference borrows objects from the mirror during the clone, and
+	// --dissociate copies them afterwards, so a later fetch or gc of the
+	// mirror cannot remove objects the checkout still needs
+	_, err = Run([]string{"clone", "--reference", mirror, "--dissociate", "--no-checkout", "--quiet", mirror, tmpDir}, nil)
+	if err == nil {
+		_, err = Run([]string{"checkout", "--detach", "--quiet", sha}, &RunOptions{Dir: tmpDir})
+	}
+	if err != nil {
+		os.RemoveAll(tmpDir) // Clean up temp dir on failure
+		return nil, fmt.Errorf("failed to check out %s at %s from cache: %w", RedactURL(url), ref, err)
+	}
+
+	if err := ensureLFSContent(tmpDir); err != nil {
+		os.RemoveAll(tmpDir)
+		return nil, err
+	}
+
+	return &Clone{
+		Path: tmpDir,
+		URL:  url,
+		Ref:  ref,
+		SHA:  sha,
+	}, nil
+}
+
+// splitCredentials removes the credentials from an http(s) URL. It returns
+// the URL without them and the git arguments and environment that supply
+// them through credentialHelper instead, replacing any configured helpers.
+// Other URLs are returned unchanged, with nil options.
+func splitCredentials(rawURL string) (string, *credentialOptions) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.User == nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return rawURL, nil
+	}
+
+	password, _ := u.User.Password()
+	credentials := &credentialOptions{
+		args: []string{"-c", "credential.helper=", "-c", "credential.helper=" + credentialHelper},
+		env: []string{
+			credentialUsernameEnv + "=" + u.User.Username(),
+			credentialPasswordEnv + "=" + password,
+		},
+	}
+	u.User = nil
+	return u.String(), credentials
+}
+
+// credentialOptions supply the credentials removed from a remote URL to the
+// git commands that contact the remote
+type credentialOptions struct {
+	args []string
+	env  []string
+}
+
+// run runs a git command that contacts the remote with the credentials
+func (c *credentialOptions) run(ctx context.Context, args []string, dir string) error {
+	opts := &RunOptions{Dir: dir}
+	if c != nil {
+		args = append(slices.Clone(c.args), args...)
+		opts.Env = c.env
+	}
+	_, err := RunContext(ctx, args, opts)
+	return err
+}
+
+// updateMirror creates the mirror of remote if it does not exist, or
+// fetches into it otherwise. A mirror left half-created by an interrupted
+// clone is replaced. url is the remote as given, for error messages.
+func updateMirror(ctx context.Context, mirror, url, remote string, credentials *credentialOptions) error {
+	if _, err := os.Stat(filepath.Join(mirror, "HEAD")); err == nil {
+		// Mirrors created with credentials in the URL are cleaned up as well
+		err := RunSilent([]string{"remote", "set-url", "origin", remote}, &RunOptions{Dir: mirror})
+		if err == nil {
+			err = credentials.run(ctx, []string{"fetch", "--prune", "--quiet", "origin"}, mirror)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update cached mirror of %s: %w", RedactURL(url), err)
+		}
+		return nil
+	}
+
+	_ = os.RemoveAll(mirror)
+	if err := credentials.run(ctx, []string{"clone", "--mirror", "--quiet", remote, mirror}, ""); err != nil {
+		_ = os.RemoveAll(mirror)
+		return fmt.Errorf("failed to create cached mirror of %s: %w", RedactURL(url), err)
+	}
+	return nil
+}
+
+// lockMirror acquires exclusive access to a mirror. It returns a function
+// that releases the lock.
+func lockMirror(mirror string) (func(), error) {
+	mirrorMu.Lock()
+
+	lockFile := mirror + ".lock"
+	deadline := time.Now().Add(mirrorLockTimeout)
+	for {
+		f, err := os.OpenFile(lockFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_, _ = f.WriteString(strconv.Itoa(os.Getpid()))
+			f.Close()
+			return func() {
+				_ = os.Remove(lockFile)
+				mirrorMu.Unlock()
+			}, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			mirrorMu.Unlock()
+			return nil, fmt.Errorf("failed to lock git cache: %w", err)
+		}
+
+		// Break locks left behind by a process that died while holding them
+		if info, statErr := os.Stat(lockFile); statErr == nil && time.Since(info.ModTime()) > mirrorLockStale {
+			_ = os.Remove(lockFile)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			mirrorMu.Unlock()
+			return nil, fmt.Errorf("timed out waiting for git cache lock %s", lockFile)
+		}
+		time.Sleep(mirrorLockPoll)
+	}
+}
@@ -0,0 +1,40 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pullRequestRefPrefix is the local namespace fetched pull request refs are
+// stored under, so they never collide with branches or tags
+const pullRequestRefPrefix = "refs/tfbreak/pr/"
+
+// PullRequestRef expands a pull request ref template with number. GitHub
+// publishes pull requests as "refs/pull/%d/merge" and "refs/pull/%d/head",
+// GitLab as "refs/merge-requests/%d/head".
+func PullRequestRef(template string, number int) string {
+	return strings.Replace(template, "%d", strconv.Itoa(number), 1)
+}
+
+// FetchPullRequest fetches the ref for pull request number from remote into
+// the repository at dir and returns the local ref it was stored as. The ref
+// is stored under refs/tfbreak/pr/ and overwritten on every fetch, because
+// providers move pull request refs when the branch is pushed to. The fetch
+// is abandoned when ctx is done.
+func FetchPullRequest(ctx context.Context, dir, remote, template string, number int) (string, error) {
+	ref := PullRequestRef(template, number)
+	localRef := pullRequestRefPrefix + strconv.Itoa(number)
+
+	_, err := RunContext(ctx, []string{"fetch", "--quiet", "--no-tags", remote, "+" + ref + ":" + localRef}, &RunOptions{Dir: dir})
+	if err != nil {
+		var gitErr *GitError
+		if errors.As(err, &gitErr) && strings.Contains(strings.ToLower(gitErr.Stderr), "couldn't find remote ref") {
+			return "", &ErrRefNotFound{Ref: ref, Remote: remote}
+		}
+		return "", fmt.Errorf("failed to fetch pull request %d (%s) from %s: %w", number, ref, RedactURL(remote), err)
+	}
+	return localRef, nil
+}
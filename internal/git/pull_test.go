@@ -0,0 +1,116 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupPullRequestRemote creates a bare repository with a main branch and a
+// pull request published as refs/pull/1/merge, the way GitHub does. It
+// returns the bare repository and the pull request commit.
+func setupPullRequestRemote(t *testing.T) (remoteDir, prSHA string) {
+	t.Helper()
+
+	remoteDir = t.TempDir()
+	setupBareRepo(t, remoteDir)
+
+	localDir := t.TempDir()
+	setupTestRepo(t, localDir)
+	addRemoteAndPush(t, localDir, remoteDir)
+
+	if err := os.WriteFile(filepath.Join(localDir, "main.tf"), []byte("variable \"x\" {}\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	runGit(t, localDir, "add", "main.tf")
+	runGit(t, localDir, "commit", "-m", "Pull request change")
+	runGit(t, localDir, "push", "origin", "HEAD:refs/pull/1/merge")
+
+	return remoteDir, getHeadSHA(t, localDir)
+}
+
+func TestPullRequestRef(t *testing.T) {
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{"refs/pull/%d/merge", "refs/pull/42/merge"},
+		{"refs/merge-requests/%d/head", "refs/merge-requests/42/head"},
+	}
+
+	for _, tt := range tests {
+		if got := PullRequestRef(tt.template, 42); got != tt.want {
+			t.Errorf("PullRequestRef(%q, 42) = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+}
+
+func TestFetchPullRequest(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	remoteDir, prSHA := setupPullRequestRemote(t)
+
+	// A fresh clone only has branches, not pull request refs
+	cloneDir := t.TempDir()
+	runGit(t, cloneDir, "clone", "--quiet", remoteDir, ".")
+
+	localRef, err := FetchPullRequest(context.Background(), cloneDir, "origin", "refs/pull/%d/merge", 1)
+	if err != nil {
+		t.Fatalf("FetchPullRequest failed: %v", err)
+	}
+	if localRef != "refs/tfbreak/pr/1" {
+		t.Errorf("expected local ref refs/tfbreak/pr/1, got %s", localRef)
+	}
+
+	sha, err := ResolveRef(cloneDir, localRef)
+	if err != nil {
+		t.Fatalf("ResolveRef failed: %v", err)
+	}
+	if sha != prSHA {
+		t.Errorf("expected %s, got %s", prSHA, sha)
+	}
+}
+
+func TestFetchPullRequest_NotFound(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	remoteDir, _ := setupPullRequestRemote(t)
+	cloneDir := t.TempDir()
+	runGit(t, cloneDir, "clone", "--quiet", remoteDir, ".")
+
+	_, err := FetchPullRequest(context.Background(), cloneDir, "origin", "refs/pull/%d/merge", 2)
+	if !IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "refs/pull/2/merge") {
+		t.Errorf("expected error to name the ref, got %v", err)
+	}
+}
+
+func TestShallowClone_PullRequestRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	remoteDir, prSHA := setupPullRequestRemote(t)
+
+	clone, err := ShallowClone(context.Background(), remoteDir, "refs/pull/1/merge")
+	if err != nil {
+		t.Fatalf("ShallowClone failed: %v", err)
+	}
+	defer clone.Remove()
+
+	if clone.SHA != prSHA {
+		t.Errorf("expected SHA %s, got %s", prSHA, clone.SHA)
+	}
+	if _, err := os.Stat(filepath.Join(clone.Path, "main.tf")); err != nil {
+		t.Errorf("expected main.tf in clone: %v", err)
+	}
+}
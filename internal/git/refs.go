@@ -1,9 +1,15 @@
 package git
 
 import (
+	"context"
 	"fmt"
+	"strings"
 )
 
+// shortRefPrefixes are the namespaces a short ref name can resolve into,
+// in the order git tries them
+var shortRefPrefixes = []string{"refs/tags/", "refs/heads/", "refs/remotes/"}
+
 // RefExists checks if a ref exists in a local repository.
 // Returns true if the ref exists, false if it doesn't, or an error if the check fails.
 func RefExists(dir, ref string) (bool, error) {
@@ -18,10 +24,25 @@ func RefExists(dir, ref string) (bool, error) {
 }
 
 // ResolveRef resolves a ref to its commit SHA in a local repository.
+// A short name that matches more than one kind of ref, such as both a branch
+// and a tag, returns *ErrAmbiguousRef instead of letting git pick one;
+// fully-qualified refs like "refs/tags/release" are never ambiguous. In a
+// repository without any commits, a missing ref returns *ErrUnbornHead, and
+// a missing stash entry such as "stash@{0}" returns *ErrNoStash.
 func ResolveRef(dir, ref string) (string, error) {
+	if err := checkStashEntry(dir, ref); err != nil {
+		return "", err
+	}
+	if candidates, err := refCandidates(dir, ref); err == nil && len(candidates) > 1 {
+		return "", &ErrAmbiguousRef{Ref: ref, Candidates: candidates}
+	}
+
 	sha, err := Run([]string{"rev-parse", ref}, &RunOptions{Dir: dir})
 	if err != nil {
 		if IsNotFound(err) {
+			if unbornErr := CheckUnbornHead(dir); unbornErr != nil {
+				return "", unbornErr
+			}
 			isShallow, _ := IsShallowClone(dir)
 			return "", &ErrRefNotFound{Ref: ref, IsShallow: isShallow}
 		}
@@ -30,27 +51,105 @@ func ResolveRef(dir, ref string) (string, error) {
 	return sha, nil
 }
 
+// IsFullyQualifiedRef reports whether ref names a commit without relying on
+// how git resolves short names: a full commit SHA, or a ref in the refs/
+// namespace such as "refs/heads/main". Revision suffixes such as "~1" or
+// "^1" may follow either.
+func IsFullyQualifiedRef(ref string) bool {
+	name := ref
+	if i := strings.IndexAny(name, "~^@:"); i >= 0 {
+		name = name[:i]
+	}
+	return (strings.HasPrefix(name, "refs/") && len(name) > len("refs/")) || isFullSHA(name)
+}
+
+// CheckFullyQualifiedRef returns *ErrUnqualifiedRef if ref is not fully
+// qualified (see IsFullyQualifiedRef). If dir is a repository, the error
+// lists the refs a short name would resolve to there.
+func CheckFullyQualifiedRef(dir, ref string) error {
+	if IsFullyQualifiedRef(ref) {
+		return nil
+	}
+	err := &ErrUnqualifiedRef{Ref: ref}
+	if dir != "" {
+		err.Candidates, _ = refCandidates(dir, ref)
+	}
+	return err
+}
+
+// isFullSHA reports whether s is a full SHA-1 or SHA-256 object name
+func isFullSHA(s string) bool {
+	if len(s) != 40 && len(s) != 64 {
+		return false
+	}
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// refCandidates returns the fully-qualified refs a short ref name matches.
+// Revision suffixes such as "~1" or "^{commit}" are ignored, so "release~1"
+// matches the same refs as "release". Fully-qualified refs, HEAD, and
+// anything that is not a plain name return no candidates.
+func refCandidates(dir, ref string) ([]string, error) {
+	name := ref
+	if i := strings.IndexAny(name, "~^@:"); i >= 0 {
+		name = name[:i]
+	}
+	if name == "" || name == "HEAD" || strings.HasPrefix(name, "refs/") {
+		return nil, nil
+	}
+
+	patterns := make([]string, len(shortRefPrefixes))
+	for i, prefix := range shortRefPrefixes {
+		patterns[i] = prefix + name
+	}
+
+	args := append([]string{"for-each-ref", "--format=%(refname)"}, patterns...)
+	out, err := Run(args, &RunOptions{Dir: dir})
+	if err != nil {
+		return nil, err
+	}
+
+	// for-each-ref also lists refs below a pattern (refs/heads/release/x),
+	// so keep only exact matches
+	var candidates []string
+	for _, line := range splitLines(out) {
+		for _, pattern := range patterns {
+			if line == pattern {
+				candidates = append(candidates, line)
+			}
+		}
+	}
+	return candidates, nil
+}
+
 // RemoteRefExists checks if a ref exists in a remote repository without cloning.
 // This uses git ls-remote which only fetches ref information, not content.
-func RemoteRefExists(url, ref string) (bool, error) {
+// The request is abandoned when ctx is done.
+func RemoteRefExists(ctx context.Context, url, ref string) (bool, error) {
 	// git ls-remote --exit-code returns exit code 2 if ref not found
-	_, err := Run([]string{"ls-remote", "--exit-code", url, ref}, nil)
+	_, err := RunContext(ctx, []string{"ls-remote", "--exit-code", url, ref}, nil)
 	if err != nil {
 		if IsNotFound(err) {
 			return false, nil
 		}
-		return false, fmt.Errorf("failed to check remote ref %q in %q: %w", ref, url, err)
+		return false, fmt.Errorf("failed to check remote ref %q in %q: %w", ref, RedactURL(url), err)
 	}
 	return true, nil
 }
 
 // ResolveRemoteRef resolves a ref to its commit SHA in a remote repository.
 // Returns the SHA and the full ref name (e.g., "refs/tags/v1.0.0").
-func ResolveRemoteRef(url, ref string) (sha string, fullRef string, err error) {
+// The request is abandoned when ctx is done.
+func ResolveRemoteRef(ctx context.Context, url, ref string) (sha string, fullRef string, err error) {
 	// git ls-remote returns lines like:
 	// abc123def456... refs/heads/main
 	// abc123def456... refs/tags/v1.0.0
-	out, err := Run([]string{"ls-remote", url, ref}, nil)
+	out, err := RunContext(ctx, []string{"ls-remote", url, ref}, nil)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to resolve remote ref %q: %w", ref, err)
 	}
@@ -71,11 +170,12 @@ func ResolveRemoteRef(url, ref string) (sha string, fullRef string, err error) {
 
 // ListRemoteRefs lists all refs in a remote repository.
 // If pattern is provided, only matching refs are returned.
-func ListRemoteRefs(url string, patterns ...string) (map[string]string, error) {
+// The request is abandoned when ctx is done.
+func ListRemoteRefs(ctx context.Context, url string, patterns ...string) (map[string]string, error) {
 	args := []string{"ls-remote", url}
 	args = append(args, patterns...)
 
-	out, err := Run(args, nil)
+	out, err := RunContext(ctx, args, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list remote refs: %w", err)
 	}
@@ -1,9 +1,12 @@
 package git
 
 import (
+	"context"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -165,7 +168,7 @@ func TestRemoteRefExists_Branch(t *testing.T) {
 
 	// Test against a well-known public repository
 	// Using a stable ref that should always exist
-	exists, err := RemoteRefExists("https://github.com/git/git.git", "refs/heads/master")
+	exists, err := RemoteRefExists(context.Background(), "https://github.com/git/git.git", "refs/heads/master")
 	if err != nil {
 		// Network errors are acceptable in test environments
 		if IsAuthError(err) {
@@ -191,7 +194,7 @@ func TestRemoteRefExists_NotFound(t *testing.T) {
 	}
 
 	// Test against a well-known public repository with a non-existent ref
-	exists, err := RemoteRefExists("https://github.com/git/git.git", "refs/heads/nonexistent-branch-xyz-12345")
+	exists, err := RemoteRefExists(context.Background(), "https://github.com/git/git.git", "refs/heads/nonexistent-branch-xyz-12345")
 	if err != nil {
 		// Network errors are acceptable in test environments
 		if IsAuthError(err) {
@@ -217,7 +220,7 @@ func TestRemoteRefExists_Tag(t *testing.T) {
 	}
 
 	// Test against a well-known public repository with a tag
-	exists, err := RemoteRefExists("https://github.com/git/git.git", "refs/tags/v2.0.0")
+	exists, err := RemoteRefExists(context.Background(), "https://github.com/git/git.git", "refs/tags/v2.0.0")
 	if err != nil {
 		if IsAuthError(err) {
 			t.Skip("authentication error, skipping")
@@ -237,7 +240,7 @@ func TestRemoteRefExists_InvalidURL(t *testing.T) {
 	}
 
 	// Test with an invalid URL
-	_, err := RemoteRefExists("not-a-valid-url", "refs/heads/main")
+	_, err := RemoteRefExists(context.Background(), "not-a-valid-url", "refs/heads/main")
 	if err == nil {
 		t.Error("RemoteRefExists with invalid URL should return error")
 	}
@@ -258,10 +261,10 @@ func TestRemoteRefExists_LocalRepo(t *testing.T) {
 	addRemoteAndPush(t, localDir, remoteDir)
 
 	// Now test RemoteRefExists against the local "remote"
-	exists, err := RemoteRefExists(remoteDir, "refs/heads/main")
+	exists, err := RemoteRefExists(context.Background(), remoteDir, "refs/heads/main")
 	if err != nil && !IsNotFound(err) {
 		// Some git configs might use master instead of main
-		exists, err = RemoteRefExists(remoteDir, "refs/heads/master")
+		exists, err = RemoteRefExists(context.Background(), remoteDir, "refs/heads/master")
 	}
 
 	// Either main or master should exist
@@ -339,6 +342,66 @@ func TestResolveRef_NotFound(t *testing.T) {
 	}
 }
 
+func TestResolveRef_AmbiguousBranchAndTag(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	// Create a branch and a tag named "release" on different commits
+	repoDir := t.TempDir()
+	setupTestRepo(t, repoDir)
+	createTag(t, repoDir, "release")
+	tagSHA := getHeadSHA(t, repoDir)
+	runGit(t, repoDir, "commit", "--allow-empty", "-m", "Second commit")
+	runGit(t, repoDir, "branch", "release")
+	branchSHA := getHeadSHA(t, repoDir)
+
+	for _, ref := range []string{"release", "release~0"} {
+		_, err := ResolveRef(repoDir, ref)
+		var ambiguousErr *ErrAmbiguousRef
+		if !errors.As(err, &ambiguousErr) {
+			t.Fatalf("ResolveRef(%q) error = %v, want *ErrAmbiguousRef", ref, err)
+		}
+		want := []string{"refs/heads/release", "refs/tags/release"}
+		if !reflect.DeepEqual(ambiguousErr.Candidates, want) {
+			t.Errorf("Candidates = %v, want %v", ambiguousErr.Candidates, want)
+		}
+		if !IsAmbiguousRef(err) {
+			t.Error("IsAmbiguousRef() = false, want true")
+		}
+	}
+
+	// Fully-qualified refs pick one explicitly
+	for ref, want := range map[string]string{
+		"refs/tags/release":  tagSHA,
+		"refs/heads/release": branchSHA,
+	} {
+		sha, err := ResolveRef(repoDir, ref)
+		if err != nil {
+			t.Fatalf("ResolveRef(%q) error = %v", ref, err)
+		}
+		if sha != want {
+			t.Errorf("ResolveRef(%q) = %q, want %q", ref, sha, want)
+		}
+	}
+}
+
+func TestResolveRef_NestedBranchNotAmbiguous(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	// A tag "release" and a branch "release/1.x" do not collide
+	repoDir := t.TempDir()
+	setupTestRepo(t, repoDir)
+	createTag(t, repoDir, "release")
+	runGit(t, repoDir, "branch", "release/1.x")
+
+	if _, err := ResolveRef(repoDir, "release"); err != nil {
+		t.Errorf("ResolveRef() error = %v", err)
+	}
+}
+
 func TestResolveRef_NotGitRepo(t *testing.T) {
 	if _, err := exec.LookPath("git"); err != nil {
 		t.Skip("git is not installed, skipping test")
@@ -444,9 +507,9 @@ func TestListRemoteRefs_LocalRepo(t *testing.T) {
 	runGit(t, localDir, "push", "origin", "--tags")
 
 	// List all refs
-	refs, err := ListRemoteRefs(remoteDir)
+	refs, err := ListRemoteRefs(context.Background(), remoteDir)
 	if err != nil {
-		t.Fatalf("ListRemoteRefs() error = %v", err)
+		t.Fatalf("ListRemoteRefs(context.Background()) error = %v", err)
 	}
 
 	// Should have at least some refs
@@ -484,9 +547,9 @@ func TestListRemoteRefs_WithPattern(t *testing.T) {
 	runGit(t, localDir, "push", "origin", "--tags")
 
 	// List only tags
-	refs, err := ListRemoteRefs(remoteDir, "refs/tags/*")
+	refs, err := ListRemoteRefs(context.Background(), remoteDir, "refs/tags/*")
 	if err != nil {
-		t.Fatalf("ListRemoteRefs() error = %v", err)
+		t.Fatalf("ListRemoteRefs(context.Background()) error = %v", err)
 	}
 
 	// All refs should be tags
@@ -555,3 +618,67 @@ func TestSplitLines(t *testing.T) {
 		})
 	}
 }
+
+func TestIsFullyQualifiedRef(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want bool
+	}{
+		{"refs/heads/main", true},
+		{"refs/tags/v1.2.0", true},
+		{"refs/heads/main~1", true},
+		{"refs/pull/42/merge^1", true},
+		{"0123456789abcdef0123456789abcdef01234567", true},
+		{"0123456789ABCDEF0123456789ABCDEF01234567^1", true},
+		{"0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", true},
+		{"main", false},
+		{"v1.2.0", false},
+		{"HEAD", false},
+		{"HEAD~1", false},
+		{"origin/main", false},
+		{"heads/main", false},
+		{"refs/", false},
+		{"0123456", false},
+		{"0123456789abcdef0123456789abcdef0123456g", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsFullyQualifiedRef(tt.ref); got != tt.want {
+			t.Errorf("IsFullyQualifiedRef(%q) = %v, want %v", tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestCheckFullyQualifiedRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	setupTestRepo(t, repoDir)
+	createTag(t, repoDir, "release")
+	runGit(t, repoDir, "branch", "release")
+
+	if err := CheckFullyQualifiedRef(repoDir, "refs/tags/release"); err != nil {
+		t.Errorf("CheckFullyQualifiedRef(refs/tags/release) error = %v", err)
+	}
+
+	err := CheckFullyQualifiedRef(repoDir, "release")
+	var unqualifiedErr *ErrUnqualifiedRef
+	if !errors.As(err, &unqualifiedErr) {
+		t.Fatalf("CheckFullyQualifiedRef(release) error = %v, want *ErrUnqualifiedRef", err)
+	}
+	want := []string{"refs/heads/release", "refs/tags/release"}
+	if !reflect.DeepEqual(unqualifiedErr.Candidates, want) {
+		t.Errorf("Candidates = %v, want %v", unqualifiedErr.Candidates, want)
+	}
+
+	// Without a repository there is nothing to suggest
+	err = CheckFullyQualifiedRef("", "main")
+	if !errors.As(err, &unqualifiedErr) || len(unqualifiedErr.Candidates) != 0 {
+		t.Fatalf("CheckFullyQualifiedRef(main) error = %v, want *ErrUnqualifiedRef without candidates", err)
+	}
+	if !strings.Contains(err.Error(), "'refs/heads/main'") {
+		t.Errorf("expected the error to suggest refs/heads/main, got %q", err.Error())
+	}
+}
@@ -3,10 +3,13 @@ package git
 import (
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // FindGitRoot finds the root directory of the git repository containing dir.
 // Returns the path to the repository root, or an error if dir is not in a git repository.
+// If git refuses to use the repository because of its ownership, the
+// *ErrDubiousOwnership is returned instead of *ErrNotARepository.
 func FindGitRoot(dir string) (string, error) {
 	// Resolve to absolute path
 	absDir, err := filepath.Abs(dir)
@@ -17,6 +20,9 @@ func FindGitRoot(dir string) (string, error) {
 	// Use git rev-parse to find the root
 	root, err := Run([]string{"rev-parse", "--show-toplevel"}, &RunOptions{Dir: absDir})
 	if err != nil {
+		if IsDubiousOwnership(err) {
+			return "", err
+		}
 		return "", &ErrNotARepository{Dir: dir}
 	}
 
@@ -24,11 +30,93 @@ func FindGitRoot(dir string) (string, error) {
 }
 
 // IsGitRepository returns true if dir is inside a git repository.
+//
+// To avoid starting a git process in hot paths such as recursive discovery,
+// it first looks for a .git directory, or a .git file pointing to an
+// existing one (as in worktrees and submodules), in dir and its parents.
+// git itself is only asked when GIT_DIR is set or a .git file cannot be
+// followed. The fast path does not check repository ownership; use
+// FindGitRoot to find out whether git will actually use the repository.
 func IsGitRepository(dir string) bool {
+	if os.Getenv("GIT_DIR") == "" {
+		switch findDotGit(dir) {
+		case dotGitNone:
+			return false
+		case dotGitDir, dotGitFile:
+			return true
+		}
+	}
+
 	_, err := FindGitRoot(dir)
 	return err == nil
 }
 
+// dotGitKind is what findDotGit found
+type dotGitKind int
+
+const (
+	// dotGitNone means no .git was found in the directory or its parents
+	dotGitNone dotGitKind = iota
+	// dotGitDir is a .git directory
+	dotGitDir
+	// dotGitFile is a .git file whose gitdir exists
+	dotGitFile
+	// dotGitUnknown is a .git entry that could not be followed; only git
+	// can tell whether it is a repository
+	dotGitUnknown
+)
+
+// findDotGit walks up from dir to the filesystem root looking for the
+// nearest .git entry, without running git
+func findDotGit(dir string) dotGitKind {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return dotGitUnknown
+	}
+
+	for {
+		path := filepath.Join(absDir, ".git")
+		info, err := os.Stat(path)
+		switch {
+		case err == nil && info.IsDir():
+			return dotGitDir
+		case err == nil:
+			return followGitFile(path)
+		case !os.IsNotExist(err):
+			return dotGitUnknown
+		}
+
+		parent := filepath.Dir(absDir)
+		if parent == absDir {
+			return dotGitNone
+		}
+		absDir = parent
+	}
+}
+
+// followGitFile reads a .git file of the form "gitdir: <path>", as written
+// for linked worktrees and submodules, and checks that the directory it
+// points to exists. Relative paths are relative to the .git file.
+func followGitFile(path string) dotGitKind {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return dotGitUnknown
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return dotGitUnknown
+	}
+
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(path), gitDir)
+	}
+	if info, err := os.Stat(gitDir); err != nil || !info.IsDir() {
+		return dotGitUnknown
+	}
+	return dotGitFile
+}
+
 // IsShallowClone returns true if the repository at dir is a shallow clone.
 // A shallow clone has a .git/shallow file.
 func IsShallowClone(dir string) (bool, error) {
@@ -49,6 +137,28 @@ func IsShallowClone(dir string) (bool, error) {
 	return true, nil
 }
 
+// CheckUnbornHead returns *ErrUnbornHead if the repository at dir has no
+// commits at all: HEAD names a branch that does not exist yet, and there are
+// no other refs. An orphan branch in a repository with other branches is not
+// reported. Returns nil if HEAD resolves or dir is not a repository.
+func CheckUnbornHead(dir string) error {
+	if _, err := Run([]string{"rev-parse", "--verify", "--quiet", "HEAD"}, &RunOptions{Dir: dir}); err == nil {
+		return nil
+	}
+
+	// A detached HEAD always points at a commit, so HEAD must be symbolic
+	branch, err := Run([]string{"symbolic-ref", "--quiet", "--short", "HEAD"}, &RunOptions{Dir: dir})
+	if err != nil {
+		return nil
+	}
+
+	refs, err := Run([]string{"for-each-ref", "--count=1", "--format=%(refname)"}, &RunOptions{Dir: dir})
+	if err != nil || refs != "" {
+		return nil
+	}
+	return &ErrUnbornHead{Dir: dir, Branch: branch}
+}
+
 // getGitDir returns the path to the .git directory for a repository.
 func getGitDir(dir string) (string, error) {
 	absDir, err := filepath.Abs(dir)
@@ -59,6 +169,9 @@ func getGitDir(dir string) (string, error) {
 	// git rev-parse --git-dir returns the path to .git
 	gitDir, err := Run([]string{"rev-parse", "--git-dir"}, &RunOptions{Dir: absDir})
 	if err != nil {
+		if IsDubiousOwnership(err) {
+			return "", err
+		}
 		return "", &ErrNotARepository{Dir: dir}
 	}
 
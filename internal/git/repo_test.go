@@ -1,6 +1,7 @@
 package git
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -127,6 +128,50 @@ func TestIsShallowClone_Subdirectory(t *testing.T) {
 	}
 }
 
+func TestCheckUnbornHead_EmptyRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	runGitCmd(t, repoDir, "init", "-b", "main")
+
+	err := CheckUnbornHead(repoDir)
+	var unborn *ErrUnbornHead
+	if !errors.As(err, &unborn) {
+		t.Fatalf("CheckUnbornHead() = %v, want ErrUnbornHead", err)
+	}
+	if unborn.Branch != "main" {
+		t.Errorf("Branch = %q, want main", unborn.Branch)
+	}
+
+	// Resolving any ref explains the empty repository instead of the ref
+	if _, err := ResolveRef(repoDir, "HEAD"); !errors.As(err, &unborn) {
+		t.Errorf("ResolveRef(HEAD) = %v, want ErrUnbornHead", err)
+	}
+	if _, err := CreateWorktree(repoDir, "main"); !errors.As(err, &unborn) {
+		t.Errorf("CreateWorktree(main) = %v, want ErrUnbornHead", err)
+	}
+}
+
+func TestCheckUnbornHead_WithCommits(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	setupFullTestRepo(t, repoDir)
+	if err := CheckUnbornHead(repoDir); err != nil {
+		t.Errorf("CheckUnbornHead() = %v, want nil", err)
+	}
+
+	// An orphan branch has no commits, but the repository does
+	runGitCmd(t, repoDir, "checkout", "--orphan", "fresh")
+	if err := CheckUnbornHead(repoDir); err != nil {
+		t.Errorf("CheckUnbornHead() on orphan branch = %v, want nil", err)
+	}
+}
+
 func TestFindGitRoot_Found(t *testing.T) {
 	if _, err := exec.LookPath("git"); err != nil {
 		t.Skip("git is not installed, skipping test")
@@ -406,6 +451,90 @@ func TestIsGitRepository_Subdirectory(t *testing.T) {
 	}
 }
 
+func TestIsGitRepository_WorktreeGitFile(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	setupFullTestRepo(t, repoDir)
+	worktreeDir := filepath.Join(t.TempDir(), "wt")
+	runGitCmd(t, repoDir, "worktree", "add", "--detach", worktreeDir)
+
+	// A linked worktree has a .git file, not a directory
+	if info, err := os.Stat(filepath.Join(worktreeDir, ".git")); err != nil || info.IsDir() {
+		t.Fatalf("expected a .git file in the worktree: %v", err)
+	}
+	if got := findDotGit(worktreeDir); got != dotGitFile {
+		t.Errorf("findDotGit() = %v, want dotGitFile", got)
+	}
+	if !IsGitRepository(worktreeDir) {
+		t.Error("IsGitRepository() = false, want true for a linked worktree")
+	}
+}
+
+func TestIsGitRepository_SubmoduleStyleGitFile(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".git", "modules", "sub"), 0755); err != nil {
+		t.Fatalf("failed to create git dir: %v", err)
+	}
+	subDir := filepath.Join(root, "sub", "nested")
+	if err := os.MkdirAll(subDir, 0755); err != nil {
+		t.Fatalf("failed to create submodule: %v", err)
+	}
+
+	// Submodules point to their git dir with a relative path
+	gitFile := filepath.Join(root, "sub", ".git")
+	if err := os.WriteFile(gitFile, []byte("gitdir: ../.git/modules/sub\n"), 0644); err != nil {
+		t.Fatalf("failed to write .git file: %v", err)
+	}
+
+	if got := findDotGit(subDir); got != dotGitFile {
+		t.Errorf("findDotGit() = %v, want dotGitFile", got)
+	}
+	if !IsGitRepository(subDir) {
+		t.Error("IsGitRepository() = false, want true inside a submodule")
+	}
+}
+
+func TestIsGitRepository_DanglingGitFile(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	// A .git file pointing nowhere is left to git, which rejects it
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".git"), []byte("gitdir: /does/not/exist\n"), 0644); err != nil {
+		t.Fatalf("failed to write .git file: %v", err)
+	}
+
+	if got := findDotGit(dir); got != dotGitUnknown {
+		t.Errorf("findDotGit() = %v, want dotGitUnknown", got)
+	}
+	if IsGitRepository(dir) {
+		t.Error("IsGitRepository() = true, want false for a dangling .git file")
+	}
+}
+
+func TestIsGitRepository_GitDirEnv(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	setupFullTestRepo(t, repoDir)
+
+	// With GIT_DIR set, git finds the repository from anywhere
+	dir := t.TempDir()
+	if findDotGit(dir) != dotGitNone {
+		t.Fatal("expected no .git in the test directory")
+	}
+	t.Setenv("GIT_DIR", filepath.Join(repoDir, ".git"))
+	if !IsGitRepository(dir) {
+		t.Error("IsGitRepository() = false, want true with GIT_DIR set")
+	}
+}
+
 func TestGetCurrentBranch(t *testing.T) {
 	if _, err := exec.LookPath("git"); err != nil {
 		t.Skip("git is not installed, skipping test")
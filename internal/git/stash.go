@@ -0,0 +1,51 @@
+package git
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// stashRefPattern matches a stash entry ref such as "stash@{0}"
+var stashRefPattern = regexp.MustCompile(`^(?:refs/)?stash@\{(\d+)\}$`)
+
+// IsStashRef reports whether ref names a stash entry, such as "stash@{0}"
+func IsStashRef(ref string) bool {
+	return stashRefPattern.MatchString(ref)
+}
+
+// checkStashEntry returns *ErrNoStash if ref is a stash entry that does not
+// exist in the repository at dir. Git reports a missing entry with a reflog
+// error rather than as an unknown revision, so it is checked up front.
+func checkStashEntry(dir, ref string) error {
+	m := stashRefPattern.FindStringSubmatch(ref)
+	if m == nil {
+		return nil
+	}
+	index, err := strconv.Atoi(m[1])
+	if err != nil {
+		return &ErrNoStash{Ref: ref}
+	}
+
+	out, err := Run([]string{"stash", "list", "--format=%H"}, &RunOptions{Dir: dir})
+	if err != nil {
+		return err
+	}
+	if count := len(splitLines(out)); index >= count {
+		return &ErrNoStash{Ref: ref, Count: count}
+	}
+	return nil
+}
+
+// checkoutStashUntracked writes the untracked files saved in the stash entry
+// sha, if it was created with "git stash --include-untracked", into the
+// worktree at dir. The entry's own tree only holds tracked files; untracked
+// ones are kept in its third parent.
+func checkoutStashUntracked(dir, sha string) error {
+	untracked := sha + "^3"
+	exists, err := RefExists(dir, untracked)
+	if err != nil || !exists {
+		return err
+	}
+	_, err = Run([]string{"checkout", untracked, "--", "."}, &RunOptions{Dir: dir})
+	return err
+}
@@ -0,0 +1,134 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsStashRef(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want bool
+	}{
+		{"stash@{0}", true},
+		{"stash@{12}", true},
+		{"refs/stash@{1}", true},
+		{"stash", false},
+		{"stash@{0}~1", false},
+		{"stash@{-1}", false},
+		{"main@{0}", false},
+		{"HEAD", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsStashRef(tt.ref); got != tt.want {
+			t.Errorf("IsStashRef(%q) = %v, want %v", tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestResolveRef_Stash(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	setupTestRepo(t, repoDir)
+
+	// No stash yet
+	_, err := ResolveRef(repoDir, "stash@{0}")
+	var stashErr *ErrNoStash
+	if !errors.As(err, &stashErr) || stashErr.Count != 0 {
+		t.Fatalf("ResolveRef() error = %v, want *ErrNoStash for an empty stash", err)
+	}
+	if !strings.Contains(err.Error(), "the stash is empty") {
+		t.Errorf("error should say the stash is empty, got: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("# Stashed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repoDir, "stash", "push")
+
+	sha, err := ResolveRef(repoDir, "stash@{0}")
+	if err != nil {
+		t.Fatalf("ResolveRef() error = %v", err)
+	}
+	if len(sha) < 40 {
+		t.Errorf("ResolveRef() = %q, want a commit SHA", sha)
+	}
+
+	_, err = ResolveRef(repoDir, "stash@{1}")
+	if !errors.As(err, &stashErr) || stashErr.Count != 1 {
+		t.Fatalf("ResolveRef() error = %v, want *ErrNoStash with 1 entry", err)
+	}
+	if !strings.Contains(err.Error(), "the last entry is 'stash@{0}'") {
+		t.Errorf("error should name the last entry, got: %v", err)
+	}
+}
+
+func TestCreateWorktree_Stash(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	setupTestRepo(t, repoDir)
+
+	// Stash a change to a tracked file and a new untracked file
+	if err := os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("# Stashed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repoDir, "untracked.tf"), []byte(`variable "a" {}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repoDir, "stash", "push", "--include-untracked")
+
+	for _, create := range []struct {
+		name string
+		fn   func() (*Worktree, error)
+	}{
+		{"full", func() (*Worktree, error) { return CreateWorktree(repoDir, "stash@{0}") }},
+		{"sparse", func() (*Worktree, error) { return CreateSparseWorktree(repoDir, "stash@{0}", "modules") }},
+	} {
+		t.Run(create.name, func(t *testing.T) {
+			wt, err := create.fn()
+			if err != nil {
+				t.Fatalf("creating worktree error = %v", err)
+			}
+			defer wt.Remove()
+
+			if wt.SparsePath != "" {
+				t.Errorf("SparsePath = %q, want a full checkout for a stash entry", wt.SparsePath)
+			}
+			readme, err := os.ReadFile(filepath.Join(wt.Path, "README.md"))
+			if err != nil || string(readme) != "# Stashed\n" {
+				t.Errorf("README.md = %q (error %v), want the stashed content", readme, err)
+			}
+			if _, err := os.Stat(filepath.Join(wt.Path, "untracked.tf")); err != nil {
+				t.Errorf("untracked.tf should be checked out from the stash: %v", err)
+			}
+		})
+	}
+
+	// The repository's own working tree is left alone
+	if _, err := os.Stat(filepath.Join(repoDir, "untracked.tf")); !os.IsNotExist(err) {
+		t.Errorf("untracked.tf should not be restored in the repository, stat error = %v", err)
+	}
+}
+
+func TestErrNoStash(t *testing.T) {
+	err := &ErrNoStash{Ref: "stash@{0}"}
+	if !strings.Contains(err.Error(), "git stash push") {
+		t.Errorf("error should suggest stashing, got: %v", err)
+	}
+
+	err = &ErrNoStash{Ref: "stash@{3}", Count: 2}
+	if !strings.Contains(err.Error(), "'stash@{1}'") {
+		t.Errorf("error should name the last entry, got: %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package git
+
+import (
+	"errors"
+	"strings"
+)
+
+// LastReachableTag returns the name of the most recent tag reachable from
+// HEAD, as found by "git describe --tags --abbrev=0". Lightweight tags count
+// as well as annotated ones. Returns *ErrNoTags if there is none.
+func LastReachableTag(dir string) (string, error) {
+	out, err := Run([]string{"describe", "--tags", "--abbrev=0", "HEAD"}, &RunOptions{Dir: dir})
+	if err != nil {
+		var gitErr *GitError
+		if errors.As(err, &gitErr) && isNoTagsStderr(gitErr.Stderr) {
+			shallow, _ := IsShallowClone(dir)
+			return "", &ErrNoTags{Dir: dir, IsShallow: shallow}
+		}
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// isNoTagsStderr reports whether git describe failed because there is no
+// tag at all, or none reachable from the commit
+func isNoTagsStderr(stderr string) bool {
+	return strings.Contains(stderr, "No names found") || strings.Contains(stderr, "No tags can describe")
+}
@@ -0,0 +1,59 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+)
+
+func TestLastReachableTag(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	dir := t.TempDir()
+	setupTestRepo(t, dir)
+	createTag(t, dir, "v1.0.0")
+	runGit(t, dir, "commit", "--allow-empty", "-m", "Second commit")
+	runGit(t, dir, "tag", "-a", "v1.1.0", "-m", "Release 1.1.0")
+
+	// A newer tag on another branch is not reachable from HEAD
+	runGit(t, dir, "checkout", "-q", "-b", "feature")
+	runGit(t, dir, "commit", "--allow-empty", "-m", "Feature commit")
+	createTag(t, dir, "v2.0.0-rc1")
+	runGit(t, dir, "checkout", "-q", "-")
+	runGit(t, dir, "commit", "--allow-empty", "-m", "Unreleased commit")
+
+	tag, err := LastReachableTag(dir)
+	if err != nil {
+		t.Fatalf("LastReachableTag() error = %v", err)
+	}
+	if tag != "v1.1.0" {
+		t.Errorf("LastReachableTag() = %q, want %q", tag, "v1.1.0")
+	}
+}
+
+func TestLastReachableTag_NoTags(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	dir := t.TempDir()
+	setupTestRepo(t, dir)
+
+	_, err := LastReachableTag(dir)
+	var noTags *ErrNoTags
+	if !errors.As(err, &noTags) {
+		t.Fatalf("LastReachableTag() error = %v, want *ErrNoTags", err)
+	}
+
+	// Tags that exist but are not reachable are the same as none
+	runGit(t, dir, "checkout", "-q", "-b", "feature")
+	runGit(t, dir, "commit", "--allow-empty", "-m", "Feature commit")
+	createTag(t, dir, "v1.0.0")
+	runGit(t, dir, "checkout", "-q", "-")
+
+	if _, err := LastReachableTag(dir); !errors.As(err, &noTags) {
+		t.Errorf("LastReachableTag() with only unreachable tags error = %v, want *ErrNoTags", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"sync"
 )
 
 // Version represents a parsed git version.
@@ -30,6 +31,39 @@ func (v *Version) AtLeast(major, minor int) bool {
 	return false
 }
 
+// Capabilities describes optional git features that tfbreak uses when the
+// installed git supports them.
+type Capabilities struct {
+	// SparseCheckout is true if cone-mode sparse checkouts can be used to
+	// check out a single directory of a linked worktree. tfbreak relies on
+	// the sparse-checkout behavior of git 2.36 and later.
+	SparseCheckout bool
+}
+
+// Capabilities returns the optional features supported by this version.
+func (v *Version) Capabilities() Capabilities {
+	return Capabilities{
+		SparseCheckout: v.AtLeast(2, 36),
+	}
+}
+
+var (
+	capabilitiesOnce sync.Once
+	capabilities     Capabilities
+)
+
+// GetCapabilities returns the optional features supported by the installed
+// git. It is detected once per process; if the version cannot be determined,
+// no optional features are reported.
+func GetCapabilities() Capabilities {
+	capabilitiesOnce.Do(func() {
+		if v, err := GetVersion(); err == nil {
+			capabilities = v.Capabilities()
+		}
+	})
+	return capabilities
+}
+
 // versionRegex matches git version strings like:
 // - "git version 2.39.0"
 // - "git version 2.39.0 (Apple Git-143)"
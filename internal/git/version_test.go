@@ -408,3 +408,26 @@ type VersionTooOldError struct {
 func (e *VersionTooOldError) Error() string {
 	return "git version too old"
 }
+
+func TestVersion_Capabilities(t *testing.T) {
+	tests := []struct {
+		input  string
+		sparse bool
+	}{
+		{"git version 2.25.1", false},
+		{"git version 2.35.8", false},
+		{"git version 2.36.0", true},
+		{"git version 2.39.5", true},
+		{"git version 3.0.0", true},
+	}
+
+	for _, tt := range tests {
+		v, err := ParseVersion(tt.input)
+		if err != nil {
+			t.Fatalf("ParseVersion(%q) error = %v", tt.input, err)
+		}
+		if got := v.Capabilities().SparseCheckout; got != tt.sparse {
+			t.Errorf("%s: SparseCheckout = %v, want %v", tt.input, got, tt.sparse)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // Worktree represents a git worktree that will be cleaned up.
@@ -19,12 +20,40 @@ type Worktree struct {
 
 	// SHA is the resolved commit SHA.
 	SHA string
+
+	// SparsePath is the only directory checked out besides the files at the
+	// repository root, or empty if the whole tree was checked out.
+	SparsePath string
 }
 
 // CreateWorktree creates a detached worktree at the specified ref.
 // The worktree is created in a temporary directory and should be cleaned up
-// by calling Remove() when done.
+// by calling Remove() when done. A stash entry such as "stash@{0}" is checked
+// out as it was stashed, including any untracked files it saved.
 func CreateWorktree(repoDir, ref string) (*Worktree, error) {
+	return createWorktree(repoDir, ref, "")
+}
+
+// CreateSparseWorktree creates a detached worktree at the specified ref in
+// which only path, a directory relative to the repository root, is checked
+// out. This avoids materializing a large repository when only one module in
+// it is compared.
+//
+// If git does not support sparse checkouts in worktrees (see Capabilities),
+// or path is empty, the root, or outside the repository, the whole tree is
+// checked out instead, as it is for stash entries. Check
+// SparsePath on the returned worktree to see which one happened.
+func CreateSparseWorktree(repoDir, ref, path string) (*Worktree, error) {
+	path = filepath.Clean(path)
+	if path == "." || !filepath.IsLocal(path) || !GetCapabilities().SparseCheckout || IsStashRef(ref) {
+		return createWorktree(repoDir, ref, "")
+	}
+	return createWorktree(repoDir, ref, filepath.ToSlash(path))
+}
+
+// createWorktree creates a detached worktree, checking out only sparsePath
+// if it is not empty
+func createWorktree(repoDir, ref, sparsePath string) (*Worktree, error) {
 	// Pre-flight: validate we're in a git repository
 	repoRoot, err := FindGitRoot(repoDir)
 	if err != nil {
@@ -43,25 +72,126 @@ func CreateWorktree(repoDir, ref string) (*Worktree, error) {
 		return nil, fmt.Errorf("failed to create temp directory: %w", err)
 	}
 
-	// Create detached worktree
-	// Using --detach prevents accidental commits in the worktree
-	_, err = Run([]string{"worktree", "add", "--detach", tmpDir, ref}, &RunOptions{Dir: repoRoot})
+	// Create detached worktree at the resolved SHA.
+	// Using --detach with a commit SHA never creates or moves a branch
+	// (no DWIM branch creation from remote-tracking refs), works for
+	// arbitrary commits, and prevents accidental commits in the worktree.
+	// A sparse worktree starts out empty and is checked out below, once
+	// the sparse patterns are in place.
+	args := []string{"worktree", "add", "--detach"}
+	if sparsePath != "" {
+		args = append(args, "--no-checkout")
+	}
+	_, err = Run(append(args, tmpDir, sha), &RunOptions{Dir: repoRoot})
 	if err != nil {
 		os.RemoveAll(tmpDir) // Clean up temp dir on failure
 		return nil, fmt.Errorf("failed to create worktree at %q: %w", ref, err)
 	}
 
-	return &Worktree{
-		Path:    tmpDir,
-		RepoDir: repoRoot,
-		Ref:     ref,
-		SHA:     sha,
-	}, nil
+	wt := &Worktree{
+		Path:       tmpDir,
+		RepoDir:    repoRoot,
+		Ref:        ref,
+		SHA:        sha,
+		SparsePath: sparsePath,
+	}
+
+	if sparsePath != "" {
+		if err := checkoutSparse(tmpDir, sha, sparsePath); err != nil {
+			wt.Remove()
+			return nil, fmt.Errorf("failed to check out %q at %q: %w", sparsePath, ref, err)
+		}
+	}
+
+	if IsStashRef(ref) {
+		if err := checkoutStashUntracked(tmpDir, sha); err != nil {
+			wt.Remove()
+			return nil, fmt.Errorf("failed to check out untracked files of %q: %w", ref, err)
+		}
+	}
+
+	if err := ensureLFSContent(tmpDir); err != nil {
+		wt.Remove()
+		return nil, err
+	}
+
+	return wt, nil
+}
+
+// sparseConfig enables cone-mode sparse checkout for a single git command.
+// `git sparse-checkout` would store these settings in the worktree's own
+// config, which turns on extensions.worktreeConfig in the shared repository
+// config and changes it for the user's main checkout. Passing them on the
+// command line leaves every config file untouched.
+var sparseConfig = []string{"-c", "core.sparseCheckout=true", "-c", "core.sparseCheckoutCone=true"}
+
+// checkoutSparse restricts a worktree created with --no-checkout to path in
+// cone mode and checks out sha. The patterns are written to the worktree's
+// own gitdir, so the main checkout is left as it is.
+func checkoutSparse(dir, sha, path string) error {
+	if err := writeSparsePatterns(dir, conePatterns(path)); err != nil {
+		return err
+	}
+	_, err := Run(append(sparseConfig, "checkout", "--quiet", "--detach", sha), &RunOptions{Dir: dir})
+	return err
+}
+
+// conePatterns returns the cone-mode sparse-checkout patterns that include
+// the files at the repository root and everything below path
+func conePatterns(path string) []string {
+	patterns := []string{"/*", "!/*/"}
+	parts := strings.Split(path, "/")
+	for i := range parts {
+		prefix := "/" + strings.Join(parts[:i+1], "/") + "/"
+		patterns = append(patterns, prefix)
+		if i < len(parts)-1 {
+			patterns = append(patterns, "!"+prefix+"*/")
+		}
+	}
+	return patterns
+}
+
+// writeSparsePatterns writes the sparse-checkout patterns of the worktree
+// at dir to info/sparse-checkout in its gitdir
+func writeSparsePatterns(dir string, patterns []string) error {
+	gitDir, err := Run([]string{"rev-parse", "--absolute-git-dir"}, &RunOptions{Dir: dir})
+	if err != nil {
+		return err
+	}
+	infoDir := filepath.Join(strings.TrimSpace(gitDir), "info")
+	if err := os.MkdirAll(infoDir, 0755); err != nil {
+		return fmt.Errorf("failed to write sparse-checkout patterns: %w", err)
+	}
+	content := strings.Join(patterns, "\n") + "\n"
+	if err := os.WriteFile(filepath.Join(infoDir, "sparse-checkout"), []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write sparse-checkout patterns: %w", err)
+	}
+	return nil
+}
+
+// DisableSparse checks out the whole tree in a sparse worktree. It does
+// nothing if the worktree is not sparse.
+func (w *Worktree) DisableSparse() error {
+	if w.SparsePath == "" {
+		return nil
+	}
+	// Widening the patterns to the whole tree and updating the index clears
+	// the skip-worktree bits and checks out the missing files
+	if err := writeSparsePatterns(w.Path, []string{"/*"}); err != nil {
+		return fmt.Errorf("failed to disable sparse checkout: %w", err)
+	}
+	if _, err := Run(append(sparseConfig, "read-tree", "-mu", "HEAD"), &RunOptions{Dir: w.Path}); err != nil {
+		return fmt.Errorf("failed to disable sparse checkout: %w", err)
+	}
+	w.SparsePath = ""
+	return ensureLFSContent(w.Path)
 }
 
 // Remove cleans up the worktree.
-// It removes the worktree from git's tracking and deletes the directory.
-// Errors during removal are logged but not returned to ensure cleanup completes.
+// It removes the worktree from git's tracking, deletes the directory, and
+// prunes the worktree's admin files under .git/worktrees.
+// Cleanup always runs to completion; an error is returned only if the
+// directory could not be deleted.
 func (w *Worktree) Remove() error {
 	if w.Path == "" {
 		return nil
@@ -69,20 +199,24 @@ func (w *Worktree) Remove() error {
 
 	// First try to remove via git worktree remove
 	// Use --force in case there are untracked files
+	var removeErr error
 	_, err := Run([]string{"worktree", "remove", "--force", w.Path}, &RunOptions{Dir: w.RepoDir})
 	if err != nil {
 		// If git worktree remove fails, manually clean up
 		// This can happen if the worktree was already partially deleted
-		_ = os.RemoveAll(w.Path)
-
-		// Also try to prune any stale worktree entries
-		_, _ = Run([]string{"worktree", "prune"}, &RunOptions{Dir: w.RepoDir})
+		if err := os.RemoveAll(w.Path); err != nil {
+			removeErr = fmt.Errorf("failed to remove worktree for %q: %w", w.Ref, err)
+		}
 	}
 
+	// Always prune so no stale .git/worktrees/<name> entry is left behind,
+	// even if the directory was deleted out from under git
+	_, _ = Run([]string{"worktree", "prune"}, &RunOptions{Dir: w.RepoDir})
+
 	// Clear the path to prevent double-cleanup
 	w.Path = ""
 
-	return nil
+	return removeErr
 }
 
 // WorktreeList returns a list of all worktrees for the repository.
@@ -0,0 +1,104 @@
+package git
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+)
+
+// WorktreePool creates worktrees of one repository on demand and shares them
+// for the rest of a run, so that comparing many modules against the same ref
+// checks it out only once. It is safe for concurrent use.
+type WorktreePool struct {
+	repoDir string
+
+	mu      sync.Mutex
+	entries map[poolKey]*poolEntry
+	order   []*poolEntry
+}
+
+// poolKey identifies a worktree by ref and sparse path; an empty sparse
+// path is a full checkout
+type poolKey struct {
+	ref        string
+	sparsePath string
+}
+
+// poolEntry holds the outcome of creating one worktree. The worktree is
+// created once, and a failure is returned to every caller.
+type poolEntry struct {
+	once     sync.Once
+	worktree *Worktree
+	err      error
+}
+
+// NewWorktreePool returns an empty pool for the repository at repoDir.
+func NewWorktreePool(repoDir string) *WorktreePool {
+	return &WorktreePool{
+		repoDir: repoDir,
+		entries: make(map[poolKey]*poolEntry),
+	}
+}
+
+// Get returns the worktree with the whole tree checked out at ref, creating
+// it on first use.
+func (p *WorktreePool) Get(ref string) (*Worktree, error) {
+	return p.get(poolKey{ref: ref}, func() (*Worktree, error) {
+		return CreateWorktree(p.repoDir, ref)
+	})
+}
+
+// GetSparse returns the worktree at ref in which only path is checked out,
+// creating it with CreateSparseWorktree on first use. An empty path or the
+// root shares the full checkout returned by Get.
+func (p *WorktreePool) GetSparse(ref, path string) (*Worktree, error) {
+	key := poolKey{ref: ref, sparsePath: filepath.Clean(path)}
+	if key.sparsePath == "." {
+		key.sparsePath = ""
+	}
+	return p.get(key, func() (*Worktree, error) {
+		return CreateSparseWorktree(p.repoDir, ref, path)
+	})
+}
+
+// get returns the worktree for key, calling create if no other caller has.
+// Callers asking for the same key while it is created wait for the result.
+func (p *WorktreePool) get(key poolKey, create func() (*Worktree, error)) (*Worktree, error) {
+	p.mu.Lock()
+	entry, ok := p.entries[key]
+	if !ok {
+		entry = &poolEntry{}
+		p.entries[key] = entry
+		p.order = append(p.order, entry)
+	}
+	p.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.worktree, entry.err = create()
+	})
+	return entry.worktree, entry.err
+}
+
+// Cleanup removes every worktree the pool created, in creation order. A
+// failed removal does not stop the others; all failures are returned
+// joined. The pool is empty afterwards and can be reused.
+func (p *WorktreePool) Cleanup() error {
+	p.mu.Lock()
+	order := p.order
+	p.entries = make(map[poolKey]*poolEntry)
+	p.order = nil
+	p.mu.Unlock()
+
+	var errs []error
+	for _, entry := range order {
+		// Wait for a worktree still being created
+		entry.once.Do(func() {})
+		if entry.worktree == nil {
+			continue
+		}
+		if err := entry.worktree.Remove(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,126 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"sync"
+	"testing"
+)
+
+func TestWorktreePool_SharesWorktreePerRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	setupFullTestRepoWithHistory(t, repoDir)
+
+	pool := NewWorktreePool(repoDir)
+	defer pool.Cleanup()
+
+	// Concurrent requests for one ref get the same worktree
+	var wg sync.WaitGroup
+	worktrees := make([]*Worktree, 8)
+	for i := range worktrees {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wt, err := pool.Get("HEAD~1")
+			if err != nil {
+				t.Errorf("Get() error = %v", err)
+			}
+			worktrees[i] = wt
+		}()
+	}
+	wg.Wait()
+	for _, wt := range worktrees[1:] {
+		if wt != worktrees[0] {
+			t.Fatal("Get() returned different worktrees for the same ref")
+		}
+	}
+
+	// The root as sparse path is the full checkout
+	if wt, err := pool.GetSparse("HEAD~1", ""); err != nil || wt != worktrees[0] {
+		t.Errorf("GetSparse(root) = %v, %v, want the full checkout", wt, err)
+	}
+
+	other, err := pool.Get("HEAD")
+	if err != nil {
+		t.Fatalf("Get(HEAD) error = %v", err)
+	}
+	if other == worktrees[0] {
+		t.Error("Get() returned the same worktree for different refs")
+	}
+
+	list, err := WorktreeList(repoDir)
+	if err != nil {
+		t.Fatalf("WorktreeList() error = %v", err)
+	}
+	// The main checkout plus one worktree per ref
+	if len(list) != 3 {
+		t.Errorf("got %d worktrees, want 3: %v", len(list), list)
+	}
+}
+
+func TestWorktreePool_CleanupRemovesAll(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	setupFullTestRepoWithHistory(t, repoDir)
+
+	pool := NewWorktreePool(repoDir)
+	first, err := pool.Get("HEAD~2")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	second, err := pool.Get("HEAD")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	firstPath, secondPath := first.Path, second.Path
+
+	// git cannot remove a worktree whose directory is gone; the next one
+	// is removed regardless
+	if err := os.RemoveAll(firstPath); err != nil {
+		t.Fatalf("failed to delete worktree: %v", err)
+	}
+
+	if err := pool.Cleanup(); err != nil {
+		t.Errorf("Cleanup() error = %v", err)
+	}
+	if _, err := os.Stat(secondPath); !os.IsNotExist(err) {
+		t.Errorf("worktree %s still exists after Cleanup()", secondPath)
+	}
+	list, err := WorktreeList(repoDir)
+	if err != nil {
+		t.Fatalf("WorktreeList() error = %v", err)
+	}
+	if len(list) != 1 {
+		t.Errorf("got %d worktrees after Cleanup(), want only the main checkout: %v", len(list), list)
+	}
+
+	// Cleanup leaves the pool empty
+	if err := pool.Cleanup(); err != nil {
+		t.Errorf("second Cleanup() error = %v", err)
+	}
+}
+
+func TestWorktreePool_FailureIsShared(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	setupFullTestRepo(t, repoDir)
+
+	pool := NewWorktreePool(repoDir)
+	defer pool.Cleanup()
+
+	for i := 0; i < 2; i++ {
+		if _, err := pool.Get("no-such-ref"); err == nil {
+			t.Fatal("Get() = nil error, want error for a missing ref")
+		}
+	}
+}
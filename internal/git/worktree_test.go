@@ -0,0 +1,246 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestCreateWorktree_Detached(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	setupFullTestRepoWithHistory(t, repoDir)
+
+	branchesBefore, err := Run([]string{"branch", "--list"}, &RunOptions{Dir: repoDir})
+	if err != nil {
+		t.Fatalf("git branch failed: %v", err)
+	}
+
+	// Use an arbitrary commit SHA rather than a branch name
+	sha, err := ResolveRef(repoDir, "HEAD~2")
+	if err != nil {
+		t.Fatalf("ResolveRef() error = %v", err)
+	}
+
+	wt, err := CreateWorktree(repoDir, sha)
+	if err != nil {
+		t.Fatalf("CreateWorktree() error = %v", err)
+	}
+	defer wt.Remove()
+
+	if wt.SHA != sha {
+		t.Errorf("Worktree.SHA = %q, want %q", wt.SHA, sha)
+	}
+
+	// HEAD in the worktree must be detached
+	if _, err := Run([]string{"symbolic-ref", "-q", "HEAD"}, &RunOptions{Dir: wt.Path}); err == nil {
+		t.Error("worktree HEAD is a symbolic ref, want detached")
+	}
+
+	// No branches should have been created
+	branchesAfter, err := Run([]string{"branch", "--list"}, &RunOptions{Dir: repoDir})
+	if err != nil {
+		t.Fatalf("git branch failed: %v", err)
+	}
+	if branchesAfter != branchesBefore {
+		t.Errorf("branches changed after CreateWorktree:\nbefore: %s\nafter: %s", branchesBefore, branchesAfter)
+	}
+}
+
+func TestCreateWorktree_UniquePaths(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	setupFullTestRepo(t, repoDir)
+
+	wt1, err := CreateWorktree(repoDir, "HEAD")
+	if err != nil {
+		t.Fatalf("CreateWorktree() error = %v", err)
+	}
+	defer wt1.Remove()
+
+	wt2, err := CreateWorktree(repoDir, "HEAD")
+	if err != nil {
+		t.Fatalf("CreateWorktree() error = %v", err)
+	}
+	defer wt2.Remove()
+
+	if wt1.Path == wt2.Path {
+		t.Errorf("worktrees share path %q", wt1.Path)
+	}
+}
+
+func TestWorktreeRemove_PrunesAdminFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	setupFullTestRepo(t, repoDir)
+
+	wt, err := CreateWorktree(repoDir, "HEAD")
+	if err != nil {
+		t.Fatalf("CreateWorktree() error = %v", err)
+	}
+	path := wt.Path
+	adminDir := filepath.Join(repoDir, ".git", "worktrees", filepath.Base(path))
+
+	if _, err := os.Stat(adminDir); err != nil {
+		t.Fatalf("expected admin dir %s to exist: %v", adminDir, err)
+	}
+
+	if err := wt.Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("worktree directory %s still exists", path)
+	}
+	assertNoWorktreeAdminEntries(t, repoDir)
+}
+
+func TestWorktreeRemove_DirectoryAlreadyDeleted(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	setupFullTestRepo(t, repoDir)
+
+	wt, err := CreateWorktree(repoDir, "HEAD")
+	if err != nil {
+		t.Fatalf("CreateWorktree() error = %v", err)
+	}
+
+	// Simulate the directory being removed out from under git
+	if err := os.RemoveAll(wt.Path); err != nil {
+		t.Fatalf("failed to remove worktree dir: %v", err)
+	}
+
+	if err := wt.Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+
+	assertNoWorktreeAdminEntries(t, repoDir)
+}
+
+func assertNoWorktreeAdminEntries(t *testing.T, repoDir string) {
+	t.Helper()
+
+	entries, err := os.ReadDir(filepath.Join(repoDir, ".git", "worktrees"))
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatalf("failed to read .git/worktrees: %v", err)
+	}
+	for _, e := range entries {
+		t.Errorf("leftover worktree admin entry: .git/worktrees/%s", e.Name())
+	}
+}
+
+// setupModulesRepo creates a repository with two modules and a root file
+func setupModulesRepo(t *testing.T, dir string) {
+	t.Helper()
+
+	runGitCmd(t, dir, "init")
+	runGitCmd(t, dir, "config", "user.email", "test@test.com")
+	runGitCmd(t, dir, "config", "user.name", "Test User")
+	for _, name := range []string{"README.md", "modules/vpc/main.tf", "modules/db/main.tf"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("# "+name+"\n"), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+	}
+	runGitCmd(t, dir, "add", ".")
+	runGitCmd(t, dir, "commit", "-m", "Add modules")
+}
+
+func TestCreateSparseWorktree_OnlyRequestedSubdir(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+	if !GetCapabilities().SparseCheckout {
+		t.Skip("git does not support sparse checkouts in worktrees, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	setupModulesRepo(t, repoDir)
+	configPath := filepath.Join(repoDir, ".git", "config")
+	config, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wt, err := CreateSparseWorktree(repoDir, "HEAD", "modules/vpc")
+	if err != nil {
+		t.Fatalf("CreateSparseWorktree() error = %v", err)
+	}
+	defer wt.Remove()
+
+	if wt.SparsePath != "modules/vpc" {
+		t.Errorf("Worktree.SparsePath = %q, want modules/vpc", wt.SparsePath)
+	}
+	if _, err := os.Stat(filepath.Join(wt.Path, "modules", "vpc", "main.tf")); err != nil {
+		t.Errorf("requested module missing from worktree: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(wt.Path, "modules", "db")); !os.IsNotExist(err) {
+		t.Errorf("expected modules/db to be left out of the worktree, stat error = %v", err)
+	}
+
+	// The main checkout must stay complete and clean
+	if _, err := os.Stat(filepath.Join(repoDir, "modules", "db", "main.tf")); err != nil {
+		t.Errorf("main checkout lost modules/db: %v", err)
+	}
+	status, err := Run([]string{"status", "--porcelain"}, &RunOptions{Dir: repoDir})
+	if err != nil {
+		t.Fatalf("git status failed: %v", err)
+	}
+	if status != "" {
+		t.Errorf("main checkout is dirty after CreateSparseWorktree:\n%s", status)
+	}
+
+	if err := wt.DisableSparse(); err != nil {
+		t.Fatalf("DisableSparse() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(wt.Path, "modules", "db", "main.tf")); err != nil {
+		t.Errorf("expected the full tree after DisableSparse: %v", err)
+	}
+
+	// Sparse settings must not leak into the shared repository config
+	if err := wt.Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if after, err := os.ReadFile(configPath); err != nil || string(after) != string(config) {
+		t.Errorf(".git/config changed:\nbefore:\n%s\nafter:\n%s", config, after)
+	}
+}
+
+func TestCreateSparseWorktree_FullCheckoutForRoot(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed, skipping test")
+	}
+
+	repoDir := t.TempDir()
+	setupModulesRepo(t, repoDir)
+
+	for _, path := range []string{"", ".", "../outside"} {
+		wt, err := CreateSparseWorktree(repoDir, "HEAD", path)
+		if err != nil {
+			t.Fatalf("CreateSparseWorktree(%q) error = %v", path, err)
+		}
+		if wt.SparsePath != "" {
+			t.Errorf("CreateSparseWorktree(%q).SparsePath = %q, want full checkout", path, wt.SparsePath)
+		}
+		if _, err := os.Stat(filepath.Join(wt.Path, "modules", "db", "main.tf")); err != nil {
+			t.Errorf("CreateSparseWorktree(%q) did not check out the full tree: %v", path, err)
+		}
+		wt.Remove()
+	}
+}
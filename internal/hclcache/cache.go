@@ -0,0 +1,112 @@
+// Package hclcache caches parsed HCL files so that each file is parsed once
+// per check, and the result shared between the loader's direct-HCL passes
+// (nullable, validation, moved blocks) and the plugin runner.
+package hclcache
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+// Default is the cache shared by the loader and plugin packages
+var Default = New()
+
+// Cache holds parsed HCL files keyed by absolute path.
+// A cached entry is re-parsed if the file's size or modification time changes.
+// Cached files must be treated as read-only by callers.
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+// entry is a parse result along with the file state it was parsed from
+type entry struct {
+	file    *hcl.File
+	diags   hcl.Diagnostics
+	size    int64
+	modTime time.Time
+}
+
+// New creates an empty Cache
+func New() *Cache {
+	return &Cache{
+		entries: make(map[string]*entry),
+	}
+}
+
+// ParseFile returns the parsed HCL file at path, parsing it only if it is not
+// cached or has changed on disk since it was cached. Parse diagnostics are
+// cached along with the file.
+func (c *Cache) ParseFile(path string) (*hcl.File, hcl.Diagnostics) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fileDiagnostics(path, err)
+	}
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		c.Invalidate(absPath)
+		return nil, fileDiagnostics(absPath, err)
+	}
+
+	c.mu.Lock()
+	e, ok := c.entries[absPath]
+	c.mu.Unlock()
+	if ok && e.size == info.Size() && e.modTime.Equal(info.ModTime()) {
+		return e.file, e.diags
+	}
+
+	// Parse without holding the lock so that other files can be parsed
+	// concurrently. Two callers may parse the same file at once; either
+	// result is valid, so the last one stored wins.
+	// A fresh parser per file, since hclparse.Parser keeps its own
+	// unbounded cache keyed by filename
+	file, diags := hclparse.NewParser().ParseHCLFile(absPath)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[absPath] = &entry{
+		file:    file,
+		diags:   diags,
+		size:    info.Size(),
+		modTime: info.ModTime(),
+	}
+	return file, diags
+}
+
+// Invalidate removes a file from the cache
+func (c *Cache) Invalidate(path string) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, absPath)
+}
+
+// Len returns the number of cached files
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// fileDiagnostics wraps a file access error in HCL diagnostics, matching the
+// form returned by hclparse
+func fileDiagnostics(path string, err error) hcl.Diagnostics {
+	return hcl.Diagnostics{
+		{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to read file",
+			Detail:   fmt.Sprintf("The configuration file %q could not be read: %s.", path, err),
+		},
+	}
+}
@@ -0,0 +1,223 @@
+package hclcache
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+func writeFile(t testing.TB, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseFile_CachesByAbsolutePath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.tf")
+	writeFile(t, path, `variable "a" {}`)
+
+	cache := New()
+	first, diags := cache.ParseFile(path)
+	if diags.HasErrors() {
+		t.Fatalf("ParseFile() diags = %v", diags)
+	}
+
+	// A relative path to the same file hits the same entry
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rel, err := filepath.Rel(wd, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := cache.ParseFile(rel)
+
+	if first != second {
+		t.Error("expected the cached file to be returned")
+	}
+	if cache.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", cache.Len())
+	}
+}
+
+func TestParseFile_Concurrent(t *testing.T) {
+	dir := t.TempDir()
+	var paths []string
+	for i := 0; i < 8; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("f%d.tf", i))
+		writeFile(t, path, fmt.Sprintf(`variable "v%d" {}`, i))
+		paths = append(paths, path)
+	}
+
+	cache := New()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		for _, path := range paths {
+			wg.Add(1)
+			go func(path string) {
+				defer wg.Done()
+				if _, diags := cache.ParseFile(path); diags.HasErrors() {
+					t.Errorf("ParseFile(%s) diags = %v", path, diags)
+				}
+			}(path)
+		}
+	}
+	wg.Wait()
+
+	if cache.Len() != len(paths) {
+		t.Errorf("Len() = %d, want %d", cache.Len(), len(paths))
+	}
+}
+
+func TestParseFile_InvalidatesOnChange(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.tf")
+	writeFile(t, path, `variable "a" {}`)
+
+	cache := New()
+	first, _ := cache.ParseFile(path)
+
+	writeFile(t, path, `variable "a" {}
+variable "b" {}`)
+	// Ensure the modification time changes even on coarse-grained filesystems
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+
+	second, _ := cache.ParseFile(path)
+	if first == second {
+		t.Fatal("expected the file to be re-parsed after it changed")
+	}
+	if string(second.Bytes) != `variable "a" {}
+variable "b" {}` {
+		t.Errorf("re-parsed file has stale content: %q", second.Bytes)
+	}
+}
+
+func TestParseFile_Invalidate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.tf")
+	writeFile(t, path, `variable "a" {}`)
+
+	cache := New()
+	first, _ := cache.ParseFile(path)
+	cache.Invalidate(path)
+	if cache.Len() != 0 {
+		t.Errorf("Len() = %d after Invalidate, want 0", cache.Len())
+	}
+
+	second, _ := cache.ParseFile(path)
+	if first == second {
+		t.Error("expected the file to be re-parsed after Invalidate")
+	}
+}
+
+func TestParseFile_Errors(t *testing.T) {
+	dir := t.TempDir()
+	cache := New()
+
+	if _, diags := cache.ParseFile(filepath.Join(dir, "missing.tf")); !diags.HasErrors() {
+		t.Error("expected diagnostics for a missing file")
+	}
+
+	path := filepath.Join(dir, "broken.tf")
+	writeFile(t, path, `variable "a" {`)
+	if _, diags := cache.ParseFile(path); !diags.HasErrors() {
+		t.Error("expected diagnostics for invalid HCL")
+	}
+	// Parse errors are cached along with the file
+	if _, diags := cache.ParseFile(path); !diags.HasErrors() {
+		t.Error("expected cached diagnostics for invalid HCL")
+	}
+
+	// A deleted file is dropped from the cache
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, diags := cache.ParseFile(path); !diags.HasErrors() {
+		t.Error("expected diagnostics for a deleted file")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", cache.Len())
+	}
+}
+
+// passesPerCheck is the number of times each file is parsed during a check
+// without the cache: the loader's nullable, validation, and moved-block
+// passes, plus plugin.LoadHCLFiles
+const passesPerCheck = 4
+
+// benchmarkModule writes a module with many variables and resources
+func benchmarkModule(b *testing.B) []string {
+	b.Helper()
+	dir := b.TempDir()
+	var paths []string
+	for i := 0; i < 20; i++ {
+		var content string
+		for j := 0; j < 50; j++ {
+			content += fmt.Sprintf(`
+variable "var_%d_%d" {
+  type     = string
+  default  = "value"
+  nullable = false
+
+  validation {
+    condition     = length(var.var_%d_%d) > 0
+    error_message = "must not be empty"
+  }
+}
+
+resource "null_resource" "res_%d_%d" {
+  triggers = {
+    value = var.var_%d_%d
+  }
+}
+`, i, j, i, j, i, j, i, j)
+		}
+		path := filepath.Join(dir, fmt.Sprintf("file_%d.tf", i))
+		writeFile(b, path, content)
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+func BenchmarkCheckParsing_Uncached(b *testing.B) {
+	paths := benchmarkModule(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for pass := 0; pass < passesPerCheck; pass++ {
+			parser := hclparse.NewParser()
+			for _, path := range paths {
+				if _, diags := parser.ParseHCLFile(path); diags.HasErrors() {
+					b.Fatal(diags)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkCheckParsing_Cached(b *testing.B) {
+	paths := benchmarkModule(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache := New()
+		for pass := 0; pass < passesPerCheck; pass++ {
+			for _, path := range paths {
+				if _, diags := cache.ParseFile(path); diags.HasErrors() {
+					b.Fatal(diags)
+				}
+			}
+		}
+	}
+}
@@ -31,6 +31,11 @@ func TestScenario_RC006_DefaultChanged(t *testing.T) {
 	runScenario(t, "rc006_default_changed", []string{"RC006"})
 }
 
+func TestScenario_RC006_DefaultEquivalent(t *testing.T) {
+	// Defaults written differently but with the same value must not fire
+	runScenario(t, "rc006_default_equivalent", []string{})
+}
+
 func TestScenario_BC009_OutputRemoved(t *testing.T) {
 	runScenario(t, "bc009_output_removed", []string{"BC009"})
 }
@@ -53,6 +58,40 @@ func TestScenario_BC103_NonexistentTarget(t *testing.T) {
 	runScenario(t, "bc103_nonexistent_target", []string{"BC103"})
 }
 
+func TestScenario_BC104_MovedFromStillExists(t *testing.T) {
+	runScenario(t, "bc104_moved_from_still_exists", []string{"BC104"})
+}
+
+func TestScenario_RC202_ProviderHashesChanged(t *testing.T) {
+	// RC202 is off by default
+	runScenario(t, "rc202_provider_hashes_changed", []string{})
+
+	baseDir := getTestdataDir()
+	oldDir := filepath.Join(baseDir, "rc202_provider_hashes_changed", "old")
+	newDir := filepath.Join(baseDir, "rc202_provider_hashes_changed", "new")
+
+	oldSnap, err := loader.Load(oldDir)
+	if err != nil {
+		t.Fatalf("failed to load old config: %v", err)
+	}
+	newSnap, err := loader.Load(newDir)
+	if err != nil {
+		t.Fatalf("failed to load new config: %v", err)
+	}
+	for _, snap := range []*types.ModuleSnapshot{oldSnap, newSnap} {
+		if err := loader.LoadProviderLocks(snap); err != nil {
+			t.Fatalf("failed to load provider locks: %v", err)
+		}
+	}
+
+	engine := rules.NewDefaultEngine()
+	engine.EnableRule("RC202")
+	result := engine.Check(oldDir, newDir, oldSnap, newSnap, types.SeverityError)
+	if len(result.Findings) != 1 || result.Findings[0].RuleID != "RC202" {
+		t.Errorf("expected a single RC202 finding, got %+v", result.Findings)
+	}
+}
+
 func TestScenario_NoChanges(t *testing.T) {
 	runScenario(t, "no_changes", []string{})
 }
@@ -70,6 +109,16 @@ func TestScenario_RC007_NullableChanged(t *testing.T) {
 	runScenario(t, "rc007_nullable_changed", []string{"RC007"})
 }
 
+func TestScenario_BC006_OptionalAttributeRequired(t *testing.T) {
+	// The attribute change is reported by BC006 instead of BC004
+	runScenario(t, "bc006_optional_attribute_required", []string{"BC006"})
+}
+
+func TestScenario_RC009_OptionalDefaultChanged(t *testing.T) {
+	// Only optional() defaults changed, so BC004 must not fire
+	runScenario(t, "rc009_optional_default_changed", []string{"RC009"})
+}
+
 func TestScenario_RC008_SensitiveChanged(t *testing.T) {
 	runScenario(t, "rc008_sensitive_changed", []string{"RC008"})
 }
@@ -78,6 +127,10 @@ func TestScenario_RC011_OutputSensitiveChanged(t *testing.T) {
 	runScenario(t, "rc011_output_sensitive_changed", []string{"RC011"})
 }
 
+func TestScenario_RC014_OutputSensitiveAdded(t *testing.T) {
+	runScenario(t, "rc014_output_sensitive_added", []string{"RC014"})
+}
+
 func TestScenario_BC200_VersionAdded(t *testing.T) {
 	runScenario(t, "bc200_version_added", []string{"BC200"})
 }
@@ -86,6 +139,11 @@ func TestScenario_BC201_ProviderRemoved(t *testing.T) {
 	runScenario(t, "bc201_provider_removed", []string{"BC201"})
 }
 
+func TestScenario_BC203_ProviderSourceChanged(t *testing.T) {
+	// Only the source changed, so BC201 must not fire
+	runScenario(t, "bc203_provider_source_changed", []string{"BC203"})
+}
+
 func TestScenario_RC012_ValidationAdded(t *testing.T) {
 	runScenario(t, "rc012_validation_added", []string{"RC012"})
 }
@@ -94,6 +152,38 @@ func TestScenario_RC013_ValidationValueRemoved(t *testing.T) {
 	runScenario(t, "rc013_validation_value_removed", []string{"RC013"})
 }
 
+func TestScenario_RC016_ValidationRemoved(t *testing.T) {
+	runScenario(t, "rc016_validation_removed", []string{"RC016"})
+}
+
+func TestScenario_ReformattedOnly(t *testing.T) {
+	baseDir := getTestdataDir()
+	oldDir := filepath.Join(baseDir, "reformatted_only", "old")
+	newDir := filepath.Join(baseDir, "reformatted_only", "new")
+
+	oldSnap, err := loader.Load(oldDir)
+	if err != nil {
+		t.Fatalf("failed to load old config: %v", err)
+	}
+	newSnap, err := loader.Load(newDir)
+	if err != nil {
+		t.Fatalf("failed to load new config: %v", err)
+	}
+
+	// Reformatting types, defaults, and validations yields no findings
+	engine := rules.NewDefaultEngine()
+	result := engine.CheckWithOptions(oldDir, newDir, oldSnap, newSnap, types.SeverityError, rules.CheckOptions{IgnoreWhitespace: true})
+	for _, f := range result.Findings {
+		t.Errorf("unexpected finding: [%s] %s", f.RuleID, f.Message)
+	}
+
+	// Without normalization, the reformatted types are reported
+	result = engine.CheckWithOptions(oldDir, newDir, oldSnap, newSnap, types.SeverityError, rules.CheckOptions{})
+	if len(result.Findings) == 0 || result.Findings[0].RuleID != "BC004" {
+		t.Errorf("expected BC004 findings without normalization, got %+v", result.Findings)
+	}
+}
+
 func TestScenario_BC003_InputRenamed(t *testing.T) {
 	// BC003 requires rename detection to be enabled
 	runScenarioWithRenameDetection(t, "bc003_input_renamed", []string{"BC003"}, 0.70)
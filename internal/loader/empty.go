@@ -0,0 +1,54 @@
+package loader
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// ErrEmptyModule reports a module directory that has no Terraform files, or
+// whose files declare nothing to compare. Either usually means the check was
+// pointed at the wrong directory.
+type ErrEmptyModule struct {
+	Dir string
+
+	// NoFiles is true if Dir contains no .tf or .tf.json files, and false
+	// if it has some but they declare nothing
+	NoFiles bool
+}
+
+func (e *ErrEmptyModule) Error() string {
+	if e.NoFiles {
+		return fmt.Sprintf("no Terraform files (.tf or .tf.json) in '%s'", e.Dir)
+	}
+	return fmt.Sprintf("the Terraform files in '%s' declare no variables, outputs, resources, module calls, or version constraints", e.Dir)
+}
+
+// CheckEmpty returns an ErrEmptyModule for each of the old and new modules
+// whose directory has no Terraform files. If both have files but neither
+// declares anything, it returns one for the new module instead. A single
+// empty side is a real change, such as a module whose contents were all
+// removed, and is left to the rules.
+func CheckEmpty(old, new *types.ModuleSnapshot) []*ErrEmptyModule {
+	var errs []*ErrEmptyModule
+	for _, snap := range []*types.ModuleSnapshot{old, new} {
+		if !hasTerraformFiles(snap.Path) {
+			errs = append(errs, &ErrEmptyModule{Dir: snap.Path, NoFiles: true})
+		}
+	}
+	if len(errs) == 0 && old.IsEmpty() && new.IsEmpty() {
+		errs = append(errs, &ErrEmptyModule{Dir: new.Path})
+	}
+	return errs
+}
+
+// hasTerraformFiles reports whether path is a directory with Terraform files,
+// or a single file loaded with LoadFile
+func hasTerraformFiles(path string) bool {
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		return true
+	}
+	return tfconfig.IsModuleDir(path)
+}
@@ -0,0 +1,66 @@
+package loader
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckEmpty(t *testing.T) {
+	noFiles := t.TempDir()
+	writeFile(t, filepath.Join(noFiles, "README.md"), "# not a module\n")
+
+	commentsOnly := t.TempDir()
+	writeFile(t, filepath.Join(commentsOnly, "main.tf"), "# TODO: add variables\n")
+
+	commentsOnly2 := t.TempDir()
+	writeFile(t, filepath.Join(commentsOnly2, "main.tf"), "/* nothing yet */\n")
+
+	module := t.TempDir()
+	writeFile(t, filepath.Join(module, "variables.tf"), "variable \"region\" {}\n")
+
+	tests := []struct {
+		name        string
+		old, new    string
+		wantDirs    []string
+		wantNoFiles []bool
+	}{
+		{"both modules declare something", module, module, nil, nil},
+		{"new directory without Terraform files", module, noFiles, []string{noFiles}, []bool{true}},
+		{"both without Terraform files", noFiles, noFiles, []string{noFiles, noFiles}, []bool{true, true}},
+		{"both declare nothing", commentsOnly, commentsOnly2, []string{commentsOnly2}, []bool{false}},
+		// Removing everything from a module is a change for the rules to report
+		{"only the new module declares nothing", module, commentsOnly, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old, err := Load(tt.old)
+			if err != nil {
+				t.Fatalf("Load(old) error = %v", err)
+			}
+			new, err := Load(tt.new)
+			if err != nil {
+				t.Fatalf("Load(new) error = %v", err)
+			}
+
+			errs := CheckEmpty(old, new)
+			if len(errs) != len(tt.wantDirs) {
+				t.Fatalf("CheckEmpty() = %v, want %d errors", errs, len(tt.wantDirs))
+			}
+			for i, err := range errs {
+				if err.Dir != tt.wantDirs[i] || err.NoFiles != tt.wantNoFiles[i] {
+					t.Errorf("error %d = %+v, want Dir %q, NoFiles %v", i, err, tt.wantDirs[i], tt.wantNoFiles[i])
+				}
+			}
+		})
+	}
+}
+
+func TestErrEmptyModule_Error(t *testing.T) {
+	if got := (&ErrEmptyModule{Dir: "mod", NoFiles: true}).Error(); got != "no Terraform files (.tf or .tf.json) in 'mod'" {
+		t.Errorf("Error() = %q", got)
+	}
+	if got := (&ErrEmptyModule{Dir: "mod"}).Error(); got != "the Terraform files in 'mod' declare no variables, outputs, resources, module calls, or version constraints" {
+		t.Errorf("Error() = %q", got)
+	}
+}
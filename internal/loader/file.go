@@ -0,0 +1,127 @@
+package loader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// LoadFile loads the declarations in a single Terraform file as if the file
+// were a module on its own. Other files in its directory and the dependency
+// lock file are ignored, and declaration ranges use the path as given.
+func LoadFile(path string) (*types.ModuleSnapshot, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("file does not exist: %s", path)
+		}
+		return nil, fmt.Errorf("failed to access file: %w", err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("path is a directory, not a file: %s", path)
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return LoadSource(path, src)
+}
+
+// LoadSource loads a snapshot from the contents of a single Terraform file,
+// parsed in memory without touching the filesystem. The filename is used in
+// declaration ranges, and a .tf.json suffix selects JSON syntax.
+func LoadSource(filename string, src []byte) (*types.ModuleSnapshot, error) {
+	return loadSources(filename, map[string][]byte{filename: src})
+}
+
+// LoadSources loads a snapshot from the contents of the files of a module in
+// dir, parsed in memory like LoadSource. files maps filenames relative to dir
+// to their contents.
+func LoadSources(dir string, files map[string][]byte) (*types.ModuleSnapshot, error) {
+	named := make(map[string][]byte, len(files))
+	for name, src := range files {
+		named[filepath.Join(dir, name)] = src
+	}
+	return loadSources(dir, named)
+}
+
+// loadSources parses files, keyed by the filename to use in declaration
+// ranges, in name order into a snapshot of the module at path
+func loadSources(path string, files map[string][]byte) (*types.ModuleSnapshot, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parser := hclparse.NewParser()
+	module := tfconfig.NewModule(path)
+	nullableMap := make(NullableMap)
+	validationMap := make(ValidationMap)
+	references := newReferenceGraph()
+	var movedBlocks []*types.MovedBlock
+
+	for _, filename := range names {
+		var file *hcl.File
+		var diags hcl.Diagnostics
+		isJSON := strings.HasSuffix(filename, ".tf.json")
+		if isJSON {
+			file, diags = parser.ParseJSON(files[filename], filename)
+		} else {
+			file, diags = parser.ParseHCL(files[filename], filename)
+		}
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("failed to load file: %s", diags.Error())
+		}
+
+		if diags := tfconfig.LoadModuleFromFile(file, module); diags.HasErrors() {
+			return nil, fmt.Errorf("failed to load file: %s", diags.Error())
+		}
+
+		// Like Load, only native syntax files are parsed for the attributes
+		// terraform-config-inspect does not support
+		if isJSON {
+			continue
+		}
+
+		nullables, err := nullablesFromHCL(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse nullable attributes: %w", err)
+		}
+		for name, nullable := range nullables {
+			nullableMap[name] = nullable
+		}
+
+		validations, err := validationsFromHCL(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse validation blocks: %w", err)
+		}
+		for name, v := range validations {
+			validationMap[name] = v
+		}
+
+		if err := references.addFile(file); err != nil {
+			return nil, fmt.Errorf("failed to parse output references: %w", err)
+		}
+
+		moved, err := movedBlocksFromHCL(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse moved blocks: %w", err)
+		}
+		movedBlocks = append(movedBlocks, moved...)
+	}
+
+	snapshot := buildSnapshot(path, module, nullableMap, validationMap)
+	applyOutputReferences(snapshot, references.variableReferences())
+	snapshot.MovedBlocks = append(snapshot.MovedBlocks, movedBlocks...)
+
+	return snapshot, nil
+}
@@ -0,0 +1,157 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadFile_SingleFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "variables.tf")
+	src := `variable "name" {
+  type     = string
+  nullable = false
+
+  validation {
+    condition     = length(var.name) > 0
+    error_message = "Name must not be empty."
+  }
+}
+
+output "id" {
+  value = "x"
+}
+
+moved {
+  from = aws_s3_bucket.old
+  to   = aws_s3_bucket.new
+}
+`
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Other files in the directory are not part of the snapshot
+	if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`variable "other" {}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	snap, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+
+	if len(snap.Variables) != 1 || len(snap.Outputs) != 1 || len(snap.MovedBlocks) != 1 {
+		t.Fatalf("expected 1 variable, output, and moved block, got %d, %d, %d",
+			len(snap.Variables), len(snap.Outputs), len(snap.MovedBlocks))
+	}
+
+	v := snap.Variables["name"]
+	if v == nil {
+		t.Fatal("variable name not found")
+	}
+	if v.DeclRange.Filename != path || v.DeclRange.Line != 1 {
+		t.Errorf("DeclRange = %s:%d, want %s:1", v.DeclRange.Filename, v.DeclRange.Line, path)
+	}
+	if v.Nullable == nil || *v.Nullable {
+		t.Errorf("Nullable = %v, want false", v.Nullable)
+	}
+	if v.ValidationCount != 1 {
+		t.Errorf("ValidationCount = %d, want 1", v.ValidationCount)
+	}
+}
+
+func TestLoadSource_RelativeFilename(t *testing.T) {
+	snap, err := LoadSource("old/variables.tf", []byte(`variable "region" {
+  default = "us-east-1"
+}
+`))
+	if err != nil {
+		t.Fatalf("LoadSource() error = %v", err)
+	}
+
+	v := snap.Variables["region"]
+	if v == nil {
+		t.Fatal("variable region not found")
+	}
+	if v.DeclRange.Filename != "old/variables.tf" {
+		t.Errorf("Filename = %q, want old/variables.tf", v.DeclRange.Filename)
+	}
+	if v.Required {
+		t.Error("Required = true, want false for a variable with a default")
+	}
+}
+
+func TestLoadSource_JSON(t *testing.T) {
+	snap, err := LoadSource("variables.tf.json", []byte(`{"variable": {"region": {"type": "string"}}}`))
+	if err != nil {
+		t.Fatalf("LoadSource() error = %v", err)
+	}
+	if v := snap.Variables["region"]; v == nil || !v.Required {
+		t.Errorf("expected required variable region, got %+v", v)
+	}
+}
+
+func TestLoadSources(t *testing.T) {
+	snap, err := LoadSources("module", map[string][]byte{
+		"variables.tf": []byte(`variable "name" {
+  type     = string
+  nullable = false
+}
+`),
+		"outputs.tf": []byte(`output "id" {
+  value = local.id
+}
+`),
+		"locals.tf": []byte(`locals {
+  id = "${var.name}-id"
+}
+`),
+		"moved.tf": []byte(`moved {
+  from = aws_s3_bucket.old
+  to   = aws_s3_bucket.new
+}
+`),
+	})
+	if err != nil {
+		t.Fatalf("LoadSources() error = %v", err)
+	}
+
+	if snap.Path != "module" {
+		t.Errorf("Path = %q, want module", snap.Path)
+	}
+	v := snap.Variables["name"]
+	if v == nil {
+		t.Fatal("variable name not found")
+	}
+	if v.DeclRange.Filename != filepath.Join("module", "variables.tf") {
+		t.Errorf("Filename = %q, want module/variables.tf", v.DeclRange.Filename)
+	}
+	if v.Nullable == nil || *v.Nullable {
+		t.Error("expected nullable = false")
+	}
+	if o := snap.Outputs["id"]; o == nil || len(o.VariableRefs) != 1 || o.VariableRefs[0] != "name" {
+		t.Errorf("expected output id to depend on name through a local in another file, got %+v", o)
+	}
+	if len(snap.MovedBlocks) != 1 {
+		t.Errorf("expected 1 moved block, got %d", len(snap.MovedBlocks))
+	}
+}
+
+func TestLoadFile_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := LoadFile(filepath.Join(dir, "missing.tf")); err == nil {
+		t.Error("LoadFile() on a missing file should return error")
+	}
+	if _, err := LoadFile(dir); err == nil {
+		t.Error("LoadFile() on a directory should return error")
+	}
+
+	invalid := filepath.Join(dir, "invalid.tf")
+	if err := os.WriteFile(invalid, []byte(`variable "x" {`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFile(invalid); err == nil {
+		t.Error("LoadFile() on invalid HCL should return error")
+	}
+}
@@ -36,9 +36,6 @@ func Load(dir string) (*types.ModuleSnapshot, error) {
 		return nil, fmt.Errorf("failed to load module: %s", diags.Error())
 	}
 
-	// Create snapshot
-	snapshot := types.NewModuleSnapshot(absDir)
-
 	// Parse nullable attributes (not supported by terraform-config-inspect)
 	nullableMap, err := parseNullableAttributes(absDir)
 	if err != nil {
@@ -51,6 +48,30 @@ func Load(dir string) (*types.ModuleSnapshot, error) {
 		return nil, fmt.Errorf("failed to parse validation blocks: %w", err)
 	}
 
+	snapshot := buildSnapshot(absDir, module, nullableMap, validationMap)
+
+	// Parse the variables output values depend on (not supported by terraform-config-inspect)
+	references, err := parseOutputReferences(absDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse output references: %w", err)
+	}
+	applyOutputReferences(snapshot, references)
+
+	// Parse moved blocks (not supported by terraform-config-inspect)
+	movedBlocks, err := parseMovedBlocks(absDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse moved blocks: %w", err)
+	}
+	snapshot.MovedBlocks = movedBlocks
+
+	return snapshot, nil
+}
+
+// buildSnapshot converts a module loaded by terraform-config-inspect into a
+// snapshot, merging in the variable attributes parsed directly from HCL
+func buildSnapshot(path string, module *tfconfig.Module, nullableMap NullableMap, validationMap ValidationMap) *types.ModuleSnapshot {
+	snapshot := types.NewModuleSnapshot(path)
+
 	// Extract variables
 	for name, v := range module.Variables {
 		varSig := convertVariable(v)
@@ -94,18 +115,11 @@ func Load(dir string) (*types.ModuleSnapshot, error) {
 		}
 	}
 
-	// Parse moved blocks (not supported by terraform-config-inspect)
-	movedBlocks, err := parseMovedBlocks(absDir)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse moved blocks: %w", err)
-	}
-	snapshot.MovedBlocks = movedBlocks
-
-	return snapshot, nil
+	return snapshot
 }
 
 func convertVariable(v *tfconfig.Variable) *types.VariableSignature {
-	return &types.VariableSignature{
+	sig := &types.VariableSignature{
 		Name:        v.Name,
 		Type:        v.Type,
 		Default:     v.Default,
@@ -117,6 +131,19 @@ func convertVariable(v *tfconfig.Variable) *types.VariableSignature {
 			Line:     v.Pos.Line,
 		},
 	}
+
+	// terraform-config-inspect leaves Type empty when there is no type argument
+	sig.TypeExplicit = v.Type != ""
+
+	// Extract optional() attribute defaults (not supported by terraform-config-inspect)
+	if info := parseTypeExpr(v.Type); info != nil {
+		sig.TypeConstraint = info.Constraint
+		if len(info.OptionalDefaults) > 0 {
+			sig.OptionalDefaults = info.OptionalDefaults
+		}
+	}
+
+	return sig
 }
 
 func convertOutput(o *tfconfig.Output) *types.OutputSignature {
@@ -0,0 +1,112 @@
+package loader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/jokarl/tfbreak-core/internal/hclcache"
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// LockFilename is the name of Terraform's dependency lock file
+const LockFilename = ".terraform.lock.hcl"
+
+// lockFileSchema defines the schema for the top level of a lock file
+var lockFileSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type:       "provider",
+			LabelNames: []string{"address"},
+		},
+	},
+}
+
+// lockProviderSchema defines the schema for the content of a provider block
+var lockProviderSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "version"},
+		{Name: "constraints"},
+		{Name: "hashes"},
+	},
+}
+
+// LoadProviderLocks adds the entries of the module's dependency lock file, if
+// present, to the snapshot. Only RC202 reads them, so Load leaves them out.
+func LoadProviderLocks(snapshot *types.ModuleSnapshot) error {
+	locks, err := parseLockFile(snapshot.Path)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", LockFilename, err)
+	}
+	for addr, lock := range locks {
+		snapshot.ProviderLocks[addr] = lock
+	}
+	return nil
+}
+
+// parseLockFile parses the provider entries from the lock file in the given
+// directory. A missing lock file yields no entries.
+func parseLockFile(dir string) (map[string]*types.ProviderLock, error) {
+	filePath := filepath.Join(dir, LockFilename)
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	file, diags := hclcache.Default.ParseFile(filePath)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("HCL parse error: %s", diags.Error())
+	}
+
+	content, _, diags := file.Body.PartialContent(lockFileSchema)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to extract provider blocks: %s", diags.Error())
+	}
+
+	locks := make(map[string]*types.ProviderLock)
+	for _, block := range content.Blocks {
+		lock, err := parseLockProvider(block)
+		if err != nil {
+			return nil, fmt.Errorf("invalid provider block at %s:%d: %w",
+				block.DefRange.Filename, block.DefRange.Start.Line, err)
+		}
+		locks[lock.Address] = lock
+	}
+
+	return locks, nil
+}
+
+// parseLockProvider parses a single provider block from a lock file
+func parseLockProvider(block *hcl.Block) (*types.ProviderLock, error) {
+	content, diags := block.Body.Content(lockProviderSchema)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("invalid provider block content: %s", diags.Error())
+	}
+
+	lock := &types.ProviderLock{
+		Address: block.Labels[0],
+		DeclRange: types.FileRange{
+			Filename: block.DefRange.Filename,
+			Line:     block.DefRange.Start.Line,
+		},
+	}
+
+	if attr, ok := content.Attributes["version"]; ok {
+		if diags := gohcl.DecodeExpression(attr.Expr, nil, &lock.Version); diags.HasErrors() {
+			return nil, fmt.Errorf("invalid 'version': %s", diags.Error())
+		}
+	}
+	if attr, ok := content.Attributes["constraints"]; ok {
+		if diags := gohcl.DecodeExpression(attr.Expr, nil, &lock.Constraints); diags.HasErrors() {
+			return nil, fmt.Errorf("invalid 'constraints': %s", diags.Error())
+		}
+	}
+	if attr, ok := content.Attributes["hashes"]; ok {
+		if diags := gohcl.DecodeExpression(attr.Expr, nil, &lock.Hashes); diags.HasErrors() {
+			return nil, fmt.Errorf("invalid 'hashes': %s", diags.Error())
+		}
+	}
+
+	return lock, nil
+}
@@ -0,0 +1,105 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseLockFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "main.tf"), `variable "name" {}`)
+	writeFile(t, filepath.Join(dir, LockFilename), `
+provider "registry.terraform.io/hashicorp/aws" {
+  version     = "5.31.0"
+  constraints = ">= 5.0.0"
+  hashes = [
+    "h1:abc=",
+    "zh:def",
+  ]
+}
+
+provider "registry.terraform.io/hashicorp/random" {
+  version = "3.6.0"
+}
+`)
+
+	snap, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(snap.ProviderLocks) != 0 {
+		t.Fatalf("Load() should leave out provider locks, got %d", len(snap.ProviderLocks))
+	}
+	if err := LoadProviderLocks(snap); err != nil {
+		t.Fatalf("LoadProviderLocks() error = %v", err)
+	}
+
+	if len(snap.ProviderLocks) != 2 {
+		t.Fatalf("expected 2 provider locks, got %d", len(snap.ProviderLocks))
+	}
+
+	aws := snap.ProviderLocks["registry.terraform.io/hashicorp/aws"]
+	if aws == nil {
+		t.Fatal("missing aws provider lock")
+	}
+	if aws.Version != "5.31.0" {
+		t.Errorf("Version = %q, want %q", aws.Version, "5.31.0")
+	}
+	if aws.Constraints != ">= 5.0.0" {
+		t.Errorf("Constraints = %q, want %q", aws.Constraints, ">= 5.0.0")
+	}
+	if len(aws.Hashes) != 2 || aws.Hashes[0] != "h1:abc=" {
+		t.Errorf("Hashes = %v, want [h1:abc= zh:def]", aws.Hashes)
+	}
+	if aws.DeclRange.Line != 2 {
+		t.Errorf("DeclRange.Line = %d, want 2", aws.DeclRange.Line)
+	}
+
+	random := snap.ProviderLocks["registry.terraform.io/hashicorp/random"]
+	if random == nil || len(random.Hashes) != 0 {
+		t.Errorf("random provider lock = %+v, want no hashes", random)
+	}
+}
+
+func TestParseLockFile_Missing(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "main.tf"), `variable "name" {}`)
+
+	snap, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if err := LoadProviderLocks(snap); err != nil {
+		t.Fatalf("LoadProviderLocks() error = %v", err)
+	}
+	if len(snap.ProviderLocks) != 0 {
+		t.Errorf("expected no provider locks, got %d", len(snap.ProviderLocks))
+	}
+}
+
+func TestParseLockFile_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "main.tf"), `variable "name" {}`)
+	writeFile(t, filepath.Join(dir, LockFilename), `
+provider "registry.terraform.io/hashicorp/aws" {
+  hashes = "not-a-list"
+}
+`)
+
+	// Load does not read the lock file, so only LoadProviderLocks fails
+	snap, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if err := LoadProviderLocks(snap); err == nil {
+		t.Error("expected error for invalid hashes attribute")
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
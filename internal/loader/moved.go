@@ -7,7 +7,8 @@ import (
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
-	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/jokarl/tfbreak-core/internal/hclcache"
 	"github.com/jokarl/tfbreak-core/internal/types"
 )
 
@@ -38,8 +39,6 @@ func parseMovedBlocks(dir string) ([]*types.MovedBlock, error) {
 		return nil, fmt.Errorf("failed to read directory: %w", err)
 	}
 
-	parser := hclparse.NewParser()
-
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
@@ -49,7 +48,7 @@ func parseMovedBlocks(dir string) ([]*types.MovedBlock, error) {
 		}
 
 		filePath := filepath.Join(dir, entry.Name())
-		blocks, err := parseMovedBlocksFromFile(parser, filePath)
+		blocks, err := parseMovedBlocksFromFile(filePath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse %s: %w", filePath, err)
 		}
@@ -60,12 +59,16 @@ func parseMovedBlocks(dir string) ([]*types.MovedBlock, error) {
 }
 
 // parseMovedBlocksFromFile parses moved blocks from a single .tf file
-func parseMovedBlocksFromFile(parser *hclparse.Parser, filePath string) ([]*types.MovedBlock, error) {
-	file, diags := parser.ParseHCLFile(filePath)
+func parseMovedBlocksFromFile(filePath string) ([]*types.MovedBlock, error) {
+	file, diags := hclcache.Default.ParseFile(filePath)
 	if diags.HasErrors() {
 		return nil, fmt.Errorf("HCL parse error: %s", diags.Error())
 	}
+	return movedBlocksFromHCL(file)
+}
 
+// movedBlocksFromHCL parses moved blocks from a parsed file
+func movedBlocksFromHCL(file *hcl.File) ([]*types.MovedBlock, error) {
 	content, _, diags := file.Body.PartialContent(movedBlockSchema)
 	if diags.HasErrors() {
 		return nil, fmt.Errorf("failed to extract moved blocks: %s", diags.Error())
@@ -119,15 +122,30 @@ func parseMovedBlock(block *hcl.Block) (*types.MovedBlock, error) {
 	}
 
 	return &types.MovedBlock{
-		From: fromAddr,
-		To:   toAddr,
-		DeclRange: types.FileRange{
-			Filename: block.DefRange.Filename,
-			Line:     block.DefRange.Start.Line,
-		},
+		From:      fromAddr,
+		To:        toAddr,
+		DeclRange: blockRange(block),
 	}, nil
 }
 
+// blockRange returns the source range of a whole block, from the start of
+// its type keyword to its closing brace. Findings are reported at the first
+// line, so an annotation on the line above the block applies to them.
+func blockRange(block *hcl.Block) types.FileRange {
+	rng := block.DefRange
+	if body, ok := block.Body.(*hclsyntax.Body); ok {
+		rng = hcl.RangeBetween(block.DefRange, body.SrcRange)
+	}
+
+	return types.FileRange{
+		Filename:  rng.Filename,
+		Line:      rng.Start.Line,
+		Column:    rng.Start.Column,
+		EndLine:   rng.End.Line,
+		EndColumn: rng.End.Column,
+	}
+}
+
 // extractTraversalAddress extracts a resource or module address from an HCL expression
 func extractTraversalAddress(expr hcl.Expression) (string, error) {
 	traversal, diags := hcl.AbsTraversalForExpr(expr)
@@ -3,6 +3,8 @@ package loader
 import (
 	"path/filepath"
 	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
 )
 
 func TestParseMovedBlocks(t *testing.T) {
@@ -46,3 +48,24 @@ func TestParseMovedBlocksEmpty(t *testing.T) {
 		t.Errorf("expected 0 moved blocks, got %d", len(snap.MovedBlocks))
 	}
 }
+
+func TestParseMovedBlocksRange(t *testing.T) {
+	dir := filepath.Join(getTestdataDir(), "with_moved")
+	snap, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	// The second block spans lines 10-13 of main.tf, from "moved" to "}"
+	got := snap.MovedBlocks[1].DeclRange
+	want := types.FileRange{
+		Filename:  filepath.Join(dir, "main.tf"),
+		Line:      10,
+		Column:    1,
+		EndLine:   13,
+		EndColumn: 2,
+	}
+	if got != want {
+		t.Errorf("DeclRange = %+v, want %+v", got, want)
+	}
+}
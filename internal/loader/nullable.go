@@ -7,7 +7,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
-	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/jokarl/tfbreak-core/internal/hclcache"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -43,8 +43,6 @@ func parseNullableAttributes(dir string) (NullableMap, error) {
 		return nil, fmt.Errorf("failed to read directory: %w", err)
 	}
 
-	parser := hclparse.NewParser()
-
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
@@ -54,7 +52,7 @@ func parseNullableAttributes(dir string) (NullableMap, error) {
 		}
 
 		filePath := filepath.Join(dir, entry.Name())
-		fileNullables, err := parseNullableFromFile(parser, filePath)
+		fileNullables, err := parseNullableFromFile(filePath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse %s: %w", filePath, err)
 		}
@@ -69,12 +67,16 @@ func parseNullableAttributes(dir string) (NullableMap, error) {
 }
 
 // parseNullableFromFile parses nullable attributes from variable blocks in a single file
-func parseNullableFromFile(parser *hclparse.Parser, filePath string) (NullableMap, error) {
-	file, diags := parser.ParseHCLFile(filePath)
+func parseNullableFromFile(filePath string) (NullableMap, error) {
+	file, diags := hclcache.Default.ParseFile(filePath)
 	if diags.HasErrors() {
 		return nil, fmt.Errorf("HCL parse error: %s", diags.Error())
 	}
+	return nullablesFromHCL(file)
+}
 
+// nullablesFromHCL parses nullable attributes from variable blocks in a parsed file
+func nullablesFromHCL(file *hcl.File) (NullableMap, error) {
 	content, _, diags := file.Body.PartialContent(variableBlockSchema)
 	if diags.HasErrors() {
 		return nil, fmt.Errorf("failed to extract variable blocks: %s", diags.Error())
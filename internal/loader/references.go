@@ -0,0 +1,198 @@
+package loader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/jokarl/tfbreak-core/internal/hclcache"
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// referenceBlockSchema defines the schema for extracting the blocks whose
+// expressions are followed to find what outputs depend on
+var referenceBlockSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type:       "output",
+			LabelNames: []string{"name"},
+		},
+		{
+			Type: "locals",
+		},
+	},
+}
+
+// outputValueSchema defines the schema for extracting value from an output block
+var outputValueSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "value", Required: false},
+	},
+}
+
+// VariableReferenceMap maps output names to the sorted names of the input
+// variables their values depend on, directly or through locals
+type VariableReferenceMap map[string][]string
+
+// referenceGraph holds the variables and locals that each output value and
+// each local refers to directly. Locals may be defined in any file of a
+// module, so references are only resolved once all files are read.
+type referenceGraph struct {
+	outputs map[string]*directReferences
+	locals  map[string]*directReferences
+}
+
+// directReferences are the var.* and local.* names an expression refers to
+type directReferences struct {
+	variables map[string]bool
+	locals    map[string]bool
+}
+
+func newReferenceGraph() *referenceGraph {
+	return &referenceGraph{
+		outputs: make(map[string]*directReferences),
+		locals:  make(map[string]*directReferences),
+	}
+}
+
+// parseOutputReferences parses the output values and locals in the given
+// directory and returns the variables each output depends on
+func parseOutputReferences(dir string) (VariableReferenceMap, error) {
+	graph := newReferenceGraph()
+
+	// Find all .tf files
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if !strings.HasSuffix(entry.Name(), ".tf") {
+			continue
+		}
+
+		filePath := filepath.Join(dir, entry.Name())
+		file, diags := hclcache.Default.ParseFile(filePath)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("failed to parse %s: HCL parse error: %s", filePath, diags.Error())
+		}
+		if err := graph.addFile(file); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filePath, err)
+		}
+	}
+
+	return graph.variableReferences(), nil
+}
+
+// addFile records the direct references of the outputs and locals in file
+func (g *referenceGraph) addFile(file *hcl.File) error {
+	content, _, diags := file.Body.PartialContent(referenceBlockSchema)
+	if diags.HasErrors() {
+		return fmt.Errorf("failed to extract output and locals blocks: %s", diags.Error())
+	}
+
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case "output":
+			if len(block.Labels) < 1 {
+				continue
+			}
+			outputContent, _, diags := block.Body.PartialContent(outputValueSchema)
+			if diags.HasErrors() {
+				continue
+			}
+			if attr, ok := outputContent.Attributes["value"]; ok {
+				g.outputs[block.Labels[0]] = referencesOf(attr.Expr)
+			}
+		case "locals":
+			attrs, diags := block.Body.JustAttributes()
+			if diags.HasErrors() {
+				continue
+			}
+			for name, attr := range attrs {
+				g.locals[name] = referencesOf(attr.Expr)
+			}
+		}
+	}
+
+	return nil
+}
+
+// referencesOf collects the variables and locals expr refers to
+func referencesOf(expr hcl.Expression) *directReferences {
+	refs := &directReferences{
+		variables: make(map[string]bool),
+		locals:    make(map[string]bool),
+	}
+	for _, traversal := range expr.Variables() {
+		if len(traversal) < 2 {
+			continue
+		}
+		attr, ok := traversal[1].(hcl.TraverseAttr)
+		if !ok {
+			continue
+		}
+		switch traversal.RootName() {
+		case "var":
+			refs.variables[attr.Name] = true
+		case "local":
+			refs.locals[attr.Name] = true
+		}
+	}
+	return refs
+}
+
+// variableReferences resolves each output's references through locals into
+// the set of variables it depends on. Outputs that depend on no variable
+// are left out.
+func (g *referenceGraph) variableReferences() VariableReferenceMap {
+	result := make(VariableReferenceMap)
+	for name, refs := range g.outputs {
+		variables := make(map[string]bool)
+		g.collectVariables(refs, variables, make(map[string]bool))
+		if len(variables) == 0 {
+			continue
+		}
+
+		names := make([]string, 0, len(variables))
+		for v := range variables {
+			names = append(names, v)
+		}
+		sort.Strings(names)
+		result[name] = names
+	}
+	return result
+}
+
+// collectVariables adds the variables refs depends on to variables,
+// following locals. Visited locals are skipped, so cycles terminate.
+func (g *referenceGraph) collectVariables(refs *directReferences, variables, visited map[string]bool) {
+	for v := range refs.variables {
+		variables[v] = true
+	}
+	for local := range refs.locals {
+		if visited[local] {
+			continue
+		}
+		visited[local] = true
+		if localRefs, ok := g.locals[local]; ok {
+			g.collectVariables(localRefs, variables, visited)
+		}
+	}
+}
+
+// applyOutputReferences records the variables each output depends on in
+// the snapshot's output signatures
+func applyOutputReferences(snapshot *types.ModuleSnapshot, references VariableReferenceMap) {
+	for name, variables := range references {
+		if o, ok := snapshot.Outputs[name]; ok {
+			o.VariableRefs = variables
+		}
+	}
+}
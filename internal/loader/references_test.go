@@ -0,0 +1,98 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoad_OutputVariableRefs(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"variables.tf": `
+variable "name" {}
+variable "tags" {}
+variable "unused" {}
+`,
+		// Locals are resolved across files, through chains, and cycles end
+		"locals.tf": `
+locals {
+  prefix    = "${var.name}-"
+  full_name = "${local.prefix}${var.name}"
+  loop_a    = local.loop_b
+  loop_b    = [local.loop_a, var.tags]
+}
+`,
+		"outputs.tf": `
+output "name" {
+  value = var.name
+}
+
+output "full_name" {
+  value = local.full_name
+}
+
+output "summary" {
+  value = { name = local.prefix, tags = var.tags["env"] }
+}
+
+output "looped" {
+  value = local.loop_a
+}
+
+output "constant" {
+  value = "fixed"
+}
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	snap, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	want := map[string][]string{
+		"name":      {"name"},
+		"full_name": {"name"},
+		"summary":   {"name", "tags"},
+		"looped":    {"tags"},
+		"constant":  nil,
+	}
+	for name, refs := range want {
+		o, ok := snap.Outputs[name]
+		if !ok {
+			t.Fatalf("output %q not loaded", name)
+		}
+		if !reflect.DeepEqual(o.VariableRefs, refs) {
+			t.Errorf("output %q VariableRefs = %v, want %v", name, o.VariableRefs, refs)
+		}
+	}
+}
+
+func TestLoadSource_OutputVariableRefs(t *testing.T) {
+	src := `
+variable "name" {}
+
+locals {
+  upper = upper(var.name)
+}
+
+output "upper" {
+  value = local.upper
+}
+`
+	snap, err := LoadSource("main.tf", []byte(src))
+	if err != nil {
+		t.Fatalf("LoadSource() error = %v", err)
+	}
+	if got := snap.Outputs["upper"].VariableRefs; !reflect.DeepEqual(got, []string{"name"}) {
+		t.Errorf("VariableRefs = %v, want [name]", got)
+	}
+}
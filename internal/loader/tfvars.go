@@ -0,0 +1,75 @@
+package loader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jokarl/tfbreak-core/internal/hclcache"
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// TFVarsSet maps variable names to the location where a tfvars file assigns them
+type TFVarsSet map[string]types.FileRange
+
+// LoadTFVars reads the variable assignments from every .tfvars file in dir,
+// including terraform.tfvars and *.auto.tfvars. Values are not evaluated,
+// only the assigned names are recorded. When several files assign the same
+// variable, the first file in lexical order wins.
+func LoadTFVars(dir string) (TFVarsSet, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tfvars directory: %w", err)
+	}
+
+	vars := make(TFVarsSet)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".tfvars") {
+			continue
+		}
+
+		filePath := filepath.Join(dir, entry.Name())
+		file, diags := hclcache.Default.ParseFile(filePath)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("failed to parse %s: %s", filePath, diags.Error())
+		}
+
+		attrs, diags := file.Body.JustAttributes()
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("failed to parse %s: %s", filePath, diags.Error())
+		}
+
+		for name, attr := range attrs {
+			if _, ok := vars[name]; ok {
+				continue
+			}
+			vars[name] = types.FileRange{
+				Filename: attr.NameRange.Filename,
+				Line:     attr.NameRange.Start.Line,
+			}
+		}
+	}
+
+	return vars, nil
+}
+
+// UncoveredVariables returns the required variables of snap that vars does
+// not assign, sorted by name
+func UncoveredVariables(snap *types.ModuleSnapshot, vars TFVarsSet) []*types.VariableSignature {
+	var missing []*types.VariableSignature
+	for name, v := range snap.Variables {
+		if !v.Required {
+			continue
+		}
+		if _, ok := vars[name]; !ok {
+			missing = append(missing, v)
+		}
+	}
+
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i].Name < missing[j].Name
+	})
+	return missing
+}
@@ -0,0 +1,101 @@
+package loader
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadTFVars(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "terraform.tfvars"), `
+name   = "example"
+region = "eu-west-1"
+`)
+	writeFile(t, filepath.Join(dir, "extra.auto.tfvars"), `
+tags = { team = "platform" }
+name = "overridden"
+`)
+	writeFile(t, filepath.Join(dir, "main.tf"), `variable "ignored" {}`)
+
+	vars, err := LoadTFVars(dir)
+	if err != nil {
+		t.Fatalf("LoadTFVars() error = %v", err)
+	}
+
+	for _, name := range []string{"name", "region", "tags"} {
+		if _, ok := vars[name]; !ok {
+			t.Errorf("expected %q to be assigned", name)
+		}
+	}
+	if _, ok := vars["ignored"]; ok {
+		t.Error(".tf files must not be read as tfvars")
+	}
+
+	// extra.auto.tfvars sorts before terraform.tfvars
+	if got := filepath.Base(vars["name"].Filename); got != "extra.auto.tfvars" {
+		t.Errorf("name assigned in %s, want extra.auto.tfvars", got)
+	}
+	if vars["tags"].Line != 2 {
+		t.Errorf("tags line = %d, want 2", vars["tags"].Line)
+	}
+}
+
+func TestLoadTFVars_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "terraform.tfvars"), `name = `)
+
+	if _, err := LoadTFVars(dir); err == nil {
+		t.Error("expected error for invalid tfvars file")
+	}
+}
+
+func TestUncoveredVariables(t *testing.T) {
+	moduleDir := t.TempDir()
+	writeFile(t, filepath.Join(moduleDir, "variables.tf"), `
+variable "name" {
+  type = string
+}
+
+variable "region" {
+  type = string
+}
+
+variable "tags" {
+  type    = map(string)
+  default = {}
+}
+
+variable "vpc_id" {
+  type = string
+}
+`)
+	varsDir := t.TempDir()
+	writeFile(t, filepath.Join(varsDir, "test.tfvars"), `
+name = "example"
+tags = { team = "platform" }
+`)
+
+	snap, err := Load(moduleDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	vars, err := LoadTFVars(varsDir)
+	if err != nil {
+		t.Fatalf("LoadTFVars() error = %v", err)
+	}
+
+	missing := UncoveredVariables(snap, vars)
+	var names []string
+	for _, v := range missing {
+		names = append(names, v.Name)
+	}
+	if len(names) != 2 || names[0] != "region" || names[1] != "vpc_id" {
+		t.Errorf("UncoveredVariables() = %v, want [region vpc_id]", names)
+	}
+
+	vars["region"] = vars["name"]
+	vars["vpc_id"] = vars["name"]
+	if missing := UncoveredVariables(snap, vars); len(missing) != 0 {
+		t.Errorf("expected all required variables covered, got %d missing", len(missing))
+	}
+}
@@ -0,0 +1,173 @@
+package loader
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// typeExprInfo holds the details extracted from a variable type expression
+// that terraform-config-inspect does not expose
+type typeExprInfo struct {
+	// Constraint is the type expression with optional() defaults stripped
+	Constraint string
+
+	// OptionalDefaults maps attribute paths to their optional() default values
+	OptionalDefaults map[string]interface{}
+}
+
+// parseTypeExpr parses a variable type expression (as returned by
+// terraform-config-inspect) and extracts optional() attribute defaults.
+// Returns nil if the expression is empty or cannot be parsed.
+func parseTypeExpr(src string) *typeExprInfo {
+	if src == "" {
+		return nil
+	}
+
+	expr, diags := hclsyntax.ParseExpression([]byte(src), "type", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil
+	}
+
+	w := &typeExprWalker{
+		defaults: make(map[string]interface{}),
+	}
+	w.walk(expr, "")
+
+	return &typeExprInfo{
+		Constraint:       stripRanges(src, w.strip),
+		OptionalDefaults: w.defaults,
+	}
+}
+
+// typeExprWalker walks a type expression collecting optional() defaults
+type typeExprWalker struct {
+	defaults map[string]interface{}
+
+	// strip holds the byte ranges of optional() default arguments
+	strip []hcl.Range
+}
+
+func (w *typeExprWalker) walk(expr hclsyntax.Expression, path string) {
+	switch e := expr.(type) {
+	case *hclsyntax.FunctionCallExpr:
+		switch e.Name {
+		case "object":
+			if len(e.Args) != 1 {
+				return
+			}
+			obj, ok := e.Args[0].(*hclsyntax.ObjectConsExpr)
+			if !ok {
+				return
+			}
+			for _, item := range obj.Items {
+				key, ok := objectKeyName(item.KeyExpr)
+				if !ok {
+					continue
+				}
+				w.walkAttribute(item.ValueExpr, joinTypePath(path, key))
+			}
+		case "list", "set", "map":
+			if len(e.Args) == 1 {
+				w.walk(e.Args[0], path+"[*]")
+			}
+		case "tuple":
+			if len(e.Args) != 1 {
+				return
+			}
+			tuple, ok := e.Args[0].(*hclsyntax.TupleConsExpr)
+			if !ok {
+				return
+			}
+			for i, elem := range tuple.Exprs {
+				w.walk(elem, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	}
+}
+
+// walkAttribute handles an object attribute, which may be wrapped in optional()
+func (w *typeExprWalker) walkAttribute(expr hclsyntax.Expression, path string) {
+	call, ok := expr.(*hclsyntax.FunctionCallExpr)
+	if !ok || call.Name != "optional" || len(call.Args) == 0 {
+		w.walk(expr, path)
+		return
+	}
+
+	var def interface{}
+	if len(call.Args) == 2 {
+		def = evalDefault(call.Args[1])
+		w.strip = append(w.strip, hcl.Range{
+			Start: call.Args[0].Range().End,
+			End:   call.Args[1].Range().End,
+		})
+	}
+	w.defaults[path] = def
+
+	w.walk(call.Args[0], path)
+}
+
+// objectKeyName returns the attribute name of an object type key
+func objectKeyName(expr hclsyntax.Expression) (string, bool) {
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() || val.Type() != cty.String || val.IsNull() {
+		return "", false
+	}
+	return val.AsString(), true
+}
+
+// evalDefault evaluates an optional() default to a JSON-compatible value.
+// Returns nil if the default is null or cannot be evaluated as a constant.
+func evalDefault(expr hclsyntax.Expression) interface{} {
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() {
+		return nil
+	}
+
+	data, err := ctyjson.Marshal(val, val.Type())
+	if err != nil {
+		return nil
+	}
+
+	var result interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil
+	}
+	return result
+}
+
+// joinTypePath appends an attribute name to a type path
+func joinTypePath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// stripRanges removes the given byte ranges from src
+func stripRanges(src string, ranges []hcl.Range) string {
+	if len(ranges) == 0 {
+		return src
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start.Byte < ranges[j].Start.Byte
+	})
+
+	var out []byte
+	pos := 0
+	for _, r := range ranges {
+		if r.Start.Byte < pos || r.End.Byte > len(src) {
+			continue
+		}
+		out = append(out, src[pos:r.Start.Byte]...)
+		pos = r.End.Byte
+	}
+	out = append(out, src[pos:]...)
+	return string(out)
+}
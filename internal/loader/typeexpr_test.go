@@ -0,0 +1,155 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseTypeExpr_OptionalDefaults(t *testing.T) {
+	src := `object({
+    name     = string
+    tier     = optional(string, "standard")
+    replicas = optional(number)
+    network  = optional(object({
+      cidr = optional(string, "10.0.0.0/16")
+    }), {})
+    rules = list(object({
+      port = optional(number, 443)
+    }))
+  })`
+
+	info := parseTypeExpr(src)
+	if info == nil {
+		t.Fatal("parseTypeExpr returned nil")
+	}
+
+	tests := []struct {
+		path string
+		want interface{}
+	}{
+		{"tier", "standard"},
+		{"replicas", nil},
+		{"network.cidr", "10.0.0.0/16"},
+		{"rules[*].port", float64(443)},
+	}
+	for _, tt := range tests {
+		got, ok := info.OptionalDefaults[tt.path]
+		if !ok {
+			t.Errorf("missing optional attribute %q", tt.path)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("OptionalDefaults[%q] = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+
+	network, ok := info.OptionalDefaults["network"].(map[string]interface{})
+	if !ok || len(network) != 0 {
+		t.Errorf("OptionalDefaults[\"network\"] = %v, want empty object", info.OptionalDefaults["network"])
+	}
+
+	if _, ok := info.OptionalDefaults["name"]; ok {
+		t.Error("required attribute \"name\" should not be recorded")
+	}
+}
+
+func TestParseTypeExpr_ConstraintIgnoresDefaults(t *testing.T) {
+	a := parseTypeExpr(`object({ tier = optional(string, "standard") })`)
+	b := parseTypeExpr(`object({ tier = optional(string, "premium") })`)
+	if a == nil || b == nil {
+		t.Fatal("parseTypeExpr returned nil")
+	}
+	if a.Constraint != b.Constraint {
+		t.Errorf("constraints differ: %q vs %q", a.Constraint, b.Constraint)
+	}
+	if a.Constraint != `object({ tier = optional(string) })` {
+		t.Errorf("Constraint = %q", a.Constraint)
+	}
+}
+
+func TestParseTypeExpr_Simple(t *testing.T) {
+	info := parseTypeExpr("list(string)")
+	if info == nil {
+		t.Fatal("parseTypeExpr returned nil")
+	}
+	if info.Constraint != "list(string)" {
+		t.Errorf("Constraint = %q, want %q", info.Constraint, "list(string)")
+	}
+	if len(info.OptionalDefaults) != 0 {
+		t.Errorf("expected no optional defaults, got %v", info.OptionalDefaults)
+	}
+
+	if parseTypeExpr("") != nil {
+		t.Error("expected nil for empty type")
+	}
+}
+
+func TestLoad_WithOptionalDefaults(t *testing.T) {
+	dir := t.TempDir()
+
+	tfContent := `
+variable "settings" {
+  type = object({
+    tier = optional(string, "standard")
+  })
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "variables.tf"), []byte(tfContent), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	snap, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	v := snap.Variables["settings"]
+	if v == nil {
+		t.Fatal("variable \"settings\" not loaded")
+	}
+	if got := v.OptionalDefaults["tier"]; got != "standard" {
+		t.Errorf("OptionalDefaults[\"tier\"] = %v, want %q", got, "standard")
+	}
+}
+
+func TestLoad_TypeExplicit(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "variables.tf"), `variable "omitted" {}
+
+variable "explicit_any" {
+  type = any
+}
+
+variable "typed" {
+  type = string
+}
+`)
+	writeFile(t, filepath.Join(dir, "json.tf.json"), `{"variable": {"json_omitted": {}, "json_any": {"type": "any"}}}`)
+
+	snap, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		typ      string
+		explicit bool
+	}{
+		{"omitted", "", false},
+		{"explicit_any", "any", true},
+		{"typed", "string", true},
+		{"json_omitted", "", false},
+		{"json_any", "any", true},
+	}
+	for _, tt := range tests {
+		v := snap.Variables[tt.name]
+		if v == nil {
+			t.Fatalf("variable %q not loaded", tt.name)
+		}
+		if v.Type != tt.typ || v.TypeExplicit != tt.explicit {
+			t.Errorf("%s: Type = %q, TypeExplicit = %v, want %q, %v", tt.name, v.Type, v.TypeExplicit, tt.typ, tt.explicit)
+		}
+	}
+}
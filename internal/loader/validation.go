@@ -5,11 +5,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
-	"github.com/hashicorp/hcl/v2/hclparse"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/jokarl/tfbreak-core/internal/hclcache"
 	"github.com/jokarl/tfbreak-core/internal/types"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -38,8 +39,6 @@ func parseValidationBlocks(dir string) (ValidationMap, error) {
 		return nil, fmt.Errorf("failed to read directory: %w", err)
 	}
 
-	parser := hclparse.NewParser()
-
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
@@ -49,7 +48,7 @@ func parseValidationBlocks(dir string) (ValidationMap, error) {
 		}
 
 		filePath := filepath.Join(dir, entry.Name())
-		fileValidations, err := parseValidationsFromFile(parser, filePath)
+		fileValidations, err := parseValidationsFromFile(filePath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse %s: %w", filePath, err)
 		}
@@ -64,22 +63,24 @@ func parseValidationBlocks(dir string) (ValidationMap, error) {
 }
 
 // parseValidationsFromFile parses validation blocks from variable blocks in a single file
-func parseValidationsFromFile(parser *hclparse.Parser, filePath string) (ValidationMap, error) {
-	file, diags := parser.ParseHCLFile(filePath)
+func parseValidationsFromFile(filePath string) (ValidationMap, error) {
+	file, diags := hclcache.Default.ParseFile(filePath)
 	if diags.HasErrors() {
 		return nil, fmt.Errorf("HCL parse error: %s", diags.Error())
 	}
+	return validationsFromHCL(file)
+}
 
+// validationsFromHCL parses validation blocks from variable blocks in a parsed file
+func validationsFromHCL(file *hcl.File) (ValidationMap, error) {
 	content, _, diags := file.Body.PartialContent(variableWithValidationSchema)
 	if diags.HasErrors() {
 		return nil, fmt.Errorf("failed to extract variable blocks: %s", diags.Error())
 	}
 
-	// Read file content for extracting raw expressions
-	fileContent, err := os.ReadFile(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
-	}
+	// Use the parsed source for extracting raw expressions, so ranges
+	// always line up with the cached parse
+	fileContent := file.Bytes
 
 	result := make(ValidationMap)
 
@@ -140,6 +141,7 @@ func parseValidationBlock(block *hclsyntax.Block, fileContent []byte) (types.Val
 		case "condition":
 			// Extract the raw condition expression from source
 			validation.Condition = extractExpressionSource(attr.Expr, fileContent)
+			validation.ReferencedVariables = referencedVariables(attr.Expr)
 		case "error_message":
 			// Try to evaluate error_message as a string literal
 			val, diags := attr.Expr.Value(nil)
@@ -155,6 +157,27 @@ func parseValidationBlock(block *hclsyntax.Block, fileContent []byte) (types.Val
 	return validation, nil
 }
 
+// referencedVariables returns the names of the variables an expression
+// references as var.<name>, sorted and without duplicates, or nil if it
+// references none
+func referencedVariables(expr hcl.Expression) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() != "var" || len(traversal) < 2 {
+			continue
+		}
+		attr, ok := traversal[1].(hcl.TraverseAttr)
+		if !ok || seen[attr.Name] {
+			continue
+		}
+		seen[attr.Name] = true
+		names = append(names, attr.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // extractExpressionSource extracts the raw source code of an expression
 func extractExpressionSource(expr hcl.Expression, fileContent []byte) string {
 	rng := expr.Range()
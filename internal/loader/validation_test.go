@@ -3,6 +3,7 @@ package loader
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -176,3 +177,70 @@ variable "name" {
 		t.Errorf("expected 0 Validations for 'name', got %d", len(nameVar.Validations))
 	}
 }
+
+func TestParseValidationBlocks_ReferencedVariables(t *testing.T) {
+	dir := t.TempDir()
+
+	tfContent := `
+variable "min_size" {
+  type = number
+}
+
+variable "max_size" {
+  type = number
+
+  validation {
+    condition     = var.max_size >= var.min_size && var.max_size + var.min_size <= var.limits.total
+    error_message = "max_size must be at least min_size and within the total limit."
+  }
+
+  validation {
+    condition     = alltrue([for zone in var.zones : var.max_size >= length(zone)])
+    error_message = "max_size must cover every zone."
+  }
+
+  validation {
+    condition     = var.max_size > 0
+    error_message = "max_size must be positive."
+  }
+}
+
+variable "name" {
+  type = string
+
+  validation {
+    condition     = length(local.prefix) > 0
+    error_message = "Only references to variables are recorded."
+  }
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "variables.tf"), []byte(tfContent), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	validationMap, err := parseValidationBlocks(dir)
+	if err != nil {
+		t.Fatalf("parseValidationBlocks failed: %v", err)
+	}
+
+	tests := []struct {
+		variable string
+		index    int
+		want     []string
+	}{
+		{"max_size", 0, []string{"limits", "max_size", "min_size"}},
+		{"max_size", 1, []string{"max_size", "zones"}},
+		{"max_size", 2, []string{"max_size"}},
+		{"name", 0, nil},
+	}
+	for _, tt := range tests {
+		validations := validationMap[tt.variable]
+		if len(validations) <= tt.index {
+			t.Fatalf("expected validation %d for %q, got %d validations", tt.index, tt.variable, len(validations))
+		}
+		got := validations[tt.index].ReferencedVariables
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s validation %d: ReferencedVariables = %v, want %v", tt.variable, tt.index, got, tt.want)
+		}
+	}
+}
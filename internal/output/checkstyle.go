@@ -110,7 +110,7 @@ func mapToCheckstyleSeverity(s types.Severity) string {
 		return "error"
 	case types.SeverityWarning:
 		return "warning"
-	case types.SeverityNotice:
+	case types.SeverityDeprecation, types.SeverityNotice:
 		return "info"
 	default:
 		return "info"
@@ -0,0 +1,106 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// GitLabRenderer renders output as a GitLab Code Quality report, which
+// GitLab shows as inline annotations on merge requests
+type GitLabRenderer struct {
+	// SourceRoots are directories treated as the repository root. Files
+	// under any of them are reported relative to it, as GitLab expects.
+	// See SARIFRenderer.SourceRoots.
+	SourceRoots []string
+
+	// Compact writes the report on a single line instead of indenting it
+	Compact bool
+}
+
+// gitlabIssue is a single Code Quality issue
+type gitlabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitlabLocation `json:"location"`
+}
+
+// gitlabLocation is the file and line an issue is reported on
+type gitlabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitlabLines `json:"lines"`
+}
+
+// gitlabLines holds the line an issue starts on
+type gitlabLines struct {
+	Begin int `json:"begin"`
+}
+
+// Render writes the check result as a GitLab Code Quality report
+func (r *GitLabRenderer) Render(w io.Writer, result *types.CheckResult) error {
+	issues := []gitlabIssue{}
+
+	for _, f := range result.Findings {
+		if f.Ignored {
+			continue
+		}
+
+		location := gitlabLocation{Path: "<unknown>"}
+		if loc := findingLocation(f); loc != nil {
+			location.Path = loc.Filename
+			if rel, ok := relativePath(r.SourceRoots, loc.Filename); ok {
+				location.Path = rel
+			}
+			location.Lines.Begin = loc.Line
+		}
+
+		issues = append(issues, gitlabIssue{
+			Description: f.Message,
+			CheckName:   f.RuleID,
+			Fingerprint: gitlabFingerprint(f, location),
+			Severity:    mapToGitLabSeverity(f.Severity),
+			Location:    location,
+		})
+	}
+
+	return newJSONEncoder(w, r.Compact).Encode(issues)
+}
+
+// findingLocation returns the new location of a finding, falling back to
+// the old one for things that were removed
+func findingLocation(f *types.Finding) *types.FileRange {
+	if f.NewLocation != nil {
+		return f.NewLocation
+	}
+	return f.OldLocation
+}
+
+// gitlabFingerprint hashes the rule ID, file, and line, so GitLab matches
+// the same finding across pipelines instead of reporting it as new. The
+// message, with whitespace collapsed, tells apart findings of one rule on
+// the same line, which GitLab would otherwise merge into one annotation.
+func gitlabFingerprint(f *types.Finding, loc gitlabLocation) string {
+	message := strings.Join(strings.Fields(f.Message), " ")
+	sum := sha256.Sum256([]byte(strings.Join([]string{f.RuleID, loc.Path, strconv.Itoa(loc.Lines.Begin), message}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// mapToGitLabSeverity maps tfbreak severity to Code Quality severity.
+// Breaking changes are critical and risky changes major; deprecations and
+// notices are minor.
+func mapToGitLabSeverity(s types.Severity) string {
+	switch s {
+	case types.SeverityError:
+		return "critical"
+	case types.SeverityWarning:
+		return "major"
+	default:
+		return "minor"
+	}
+}
@@ -0,0 +1,175 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// codeQualityIssue mirrors the fields of GitLab's Code Quality report that
+// tfbreak writes, independently of the renderer's own types
+type codeQualityIssue struct {
+	Description string `json:"description"`
+	CheckName   string `json:"check_name"`
+	Fingerprint string `json:"fingerprint"`
+	Severity    string `json:"severity"`
+	Location    struct {
+		Path  string `json:"path"`
+		Lines struct {
+			Begin int `json:"begin"`
+		} `json:"lines"`
+	} `json:"location"`
+}
+
+func renderGitLab(t *testing.T, r *GitLabRenderer, result *types.CheckResult) []codeQualityIssue {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := r.Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	var issues []codeQualityIssue
+	if err := json.Unmarshal(buf.Bytes(), &issues); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, buf.String())
+	}
+	return issues
+}
+
+func TestGitLabRenderer(t *testing.T) {
+	result := &types.CheckResult{
+		Findings: []*types.Finding{
+			{
+				RuleID:      "BC001",
+				Severity:    types.SeverityError,
+				Message:     `New required variable "foo" has no default`,
+				NewLocation: &types.FileRange{Filename: "variables.tf", Line: 3},
+			},
+			{
+				RuleID:      "RC006",
+				Severity:    types.SeverityWarning,
+				Message:     `Default value changed for "bar"`,
+				OldLocation: &types.FileRange{Filename: "old.tf", Line: 8},
+			},
+			{
+				RuleID:   "RC014",
+				Severity: types.SeverityNotice,
+				Message:  "Output became sensitive",
+			},
+		},
+	}
+
+	issues := renderGitLab(t, &GitLabRenderer{}, result)
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 issues, got %d", len(issues))
+	}
+
+	issue := issues[0]
+	if issue.Description != `New required variable "foo" has no default` || issue.CheckName != "BC001" || issue.Severity != "critical" {
+		t.Errorf("unexpected first issue: %+v", issue)
+	}
+	if issue.Location.Path != "variables.tf" || issue.Location.Lines.Begin != 3 {
+		t.Errorf("unexpected location: %+v", issue.Location)
+	}
+
+	// Risky changes are major and fall back to the old location
+	issue = issues[1]
+	if issue.Severity != "major" || issue.Location.Path != "old.tf" || issue.Location.Lines.Begin != 8 {
+		t.Errorf("unexpected second issue: %+v", issue)
+	}
+
+	if issues[2].Severity != "minor" || issues[2].Location.Path != "<unknown>" {
+		t.Errorf("unexpected third issue: %+v", issues[2])
+	}
+
+	seen := make(map[string]bool)
+	for _, issue := range issues {
+		if issue.Fingerprint == "" || seen[issue.Fingerprint] {
+			t.Errorf("expected a unique fingerprint, got %q", issue.Fingerprint)
+		}
+		seen[issue.Fingerprint] = true
+	}
+}
+
+func TestGitLabRenderer_StableFingerprint(t *testing.T) {
+	finding := func(message, filename string, line int) *types.CheckResult {
+		return &types.CheckResult{
+			Findings: []*types.Finding{
+				{
+					RuleID:      "BC001",
+					Severity:    types.SeverityError,
+					Message:     message,
+					NewLocation: &types.FileRange{Filename: filename, Line: line},
+				},
+			},
+		}
+	}
+
+	// Worktrees are checked out to a different temporary directory on each
+	// run, so the fingerprint must only depend on the repository path
+	first := renderGitLab(t, &GitLabRenderer{SourceRoots: []string{"/tmp/run1"}}, finding("first", "/tmp/run1/variables.tf", 3))
+	second := renderGitLab(t, &GitLabRenderer{SourceRoots: []string{"/tmp/run2"}}, finding("first", "/tmp/run2/variables.tf", 3))
+	if first[0].Location.Path != "variables.tf" {
+		t.Errorf("path = %q, want variables.tf", first[0].Location.Path)
+	}
+	if first[0].Fingerprint != second[0].Fingerprint {
+		t.Errorf("fingerprint changed across runs: %q != %q", first[0].Fingerprint, second[0].Fingerprint)
+	}
+
+	moved := renderGitLab(t, &GitLabRenderer{}, finding("first", "variables.tf", 4))
+	if moved[0].Fingerprint == first[0].Fingerprint {
+		t.Errorf("expected a different fingerprint for a different line")
+	}
+}
+
+func TestGitLabRenderer_SameLineFingerprints(t *testing.T) {
+	// RC016 reports a changed and a removed condition of one variable on
+	// the same line
+	result := &types.CheckResult{
+		Findings: []*types.Finding{
+			{
+				RuleID:      "RC016",
+				Severity:    types.SeverityWarning,
+				Message:     `Variable "env": validation condition changed`,
+				NewLocation: &types.FileRange{Filename: "variables.tf", Line: 3},
+			},
+			{
+				RuleID:      "RC016",
+				Severity:    types.SeverityNotice,
+				Message:     `Variable "env": 1 of 2 validation conditions removed`,
+				NewLocation: &types.FileRange{Filename: "variables.tf", Line: 3},
+			},
+		},
+	}
+
+	issues := renderGitLab(t, &GitLabRenderer{}, result)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d", len(issues))
+	}
+	if issues[0].Fingerprint == issues[1].Fingerprint {
+		t.Errorf("expected distinct fingerprints for findings on the same line, both were %q", issues[0].Fingerprint)
+	}
+
+	// Reflowing the message does not change the fingerprint
+	result.Findings[1].Message = "Variable \"env\":\n  1 of 2 validation conditions removed"
+	if reflowed := renderGitLab(t, &GitLabRenderer{}, result); reflowed[1].Fingerprint != issues[1].Fingerprint {
+		t.Errorf("fingerprint changed with message whitespace: %q != %q", reflowed[1].Fingerprint, issues[1].Fingerprint)
+	}
+}
+
+func TestGitLabRenderer_SkipsIgnored(t *testing.T) {
+	result := &types.CheckResult{
+		Findings: []*types.Finding{
+			{RuleID: "BC001", Severity: types.SeverityError, Message: "ignored", Ignored: true},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (&GitLabRenderer{Compact: true}).Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if got := buf.String(); got != "[]\n" {
+		t.Errorf("unexpected output: %s", got)
+	}
+}
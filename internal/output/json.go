@@ -8,7 +8,10 @@ import (
 )
 
 // JSONRenderer renders output in JSON format
-type JSONRenderer struct{}
+type JSONRenderer struct {
+	// Compact writes the document on a single line instead of indenting it
+	Compact bool
+}
 
 // jsonOutput is the structure for JSON output
 type jsonOutput struct {
@@ -19,6 +22,14 @@ type jsonOutput struct {
 	Summary  types.Summary   `json:"summary"`
 	Result   string          `json:"result"`
 	FailOn   string          `json:"fail_on"`
+
+	FailOnCategory map[types.Category]types.Severity `json:"fail_on_category,omitempty"`
+	Modules        []string                          `json:"modules,omitempty"`
+	OldRef         string                            `json:"old_ref,omitempty"`
+	NewRef         string                            `json:"new_ref,omitempty"`
+	Annotations    *types.AnnotationStats            `json:"annotations,omitempty"`
+	Warnings       []types.ResultWarning             `json:"warnings,omitempty"`
+	TimedOut       bool                              `json:"timed_out,omitempty"`
 }
 
 // Render writes the check result in JSON format
@@ -31,9 +42,25 @@ func (r *JSONRenderer) Render(w io.Writer, result *types.CheckResult) error {
 		Summary:  result.Summary,
 		Result:   result.Result,
 		FailOn:   result.FailOn.String(),
+
+		FailOnCategory: result.FailOnCategory,
+		Modules:        result.Modules,
+		OldRef:         result.OldRef,
+		NewRef:         result.NewRef,
+		Annotations:    result.Annotations,
+		Warnings:       result.Warnings,
+		TimedOut:       result.TimedOut,
 	}
 
+	return newJSONEncoder(w, r.Compact).Encode(output)
+}
+
+// newJSONEncoder returns an encoder that indents its output with two spaces,
+// or writes each value on a single line if compact is set
+func newJSONEncoder(w io.Writer, compact bool) *json.Encoder {
 	encoder := json.NewEncoder(w)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(output)
+	if !compact {
+		encoder.SetIndent("", "  ")
+	}
+	return encoder
 }
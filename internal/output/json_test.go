@@ -215,3 +215,166 @@ func TestJSONRendererEmpty(t *testing.T) {
 		t.Errorf("result = %v, want PASS", output["result"])
 	}
 }
+
+func TestJSONRenderer_Module(t *testing.T) {
+	result := &types.CheckResult{
+		Modules: []string{".", "modules/vpc"},
+		Findings: []*types.Finding{
+			{
+				RuleID:   "BC002",
+				RuleName: "input-removed",
+				Severity: types.SeverityError,
+				Message:  "Variable \"region\" was removed",
+				Module:   "modules/vpc",
+			},
+		},
+	}
+
+	renderer := &JSONRenderer{}
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	var output struct {
+		Modules  []string `json:"modules"`
+		Findings []struct {
+			Module string `json:"module"`
+		} `json:"findings"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	if len(output.Modules) != 2 {
+		t.Errorf("modules = %v, want 2 entries", output.Modules)
+	}
+	if output.Findings[0].Module != "modules/vpc" {
+		t.Errorf("findings[0].module = %q, want %q", output.Findings[0].Module, "modules/vpc")
+	}
+}
+
+func TestJSONRenderer_Annotations(t *testing.T) {
+	render := func(result *types.CheckResult) map[string]json.RawMessage {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := (&JSONRenderer{}).Render(&buf, result); err != nil {
+			t.Fatalf("Render error: %v", err)
+		}
+		var output map[string]json.RawMessage
+		if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+			t.Fatalf("Invalid JSON: %v", err)
+		}
+		return output
+	}
+
+	output := render(&types.CheckResult{
+		Annotations: &types.AnnotationStats{Parsed: 3, Matched: 1, Unmatched: 1, Expired: 1},
+	})
+	var stats types.AnnotationStats
+	if err := json.Unmarshal(output["annotations"], &stats); err != nil {
+		t.Fatalf("Invalid annotations object: %v", err)
+	}
+	if stats != (types.AnnotationStats{Parsed: 3, Matched: 1, Unmatched: 1, Expired: 1}) {
+		t.Errorf("annotations = %+v", stats)
+	}
+
+	// Left out when annotations were not processed
+	if _, ok := render(&types.CheckResult{})["annotations"]; ok {
+		t.Error("expected no annotations object without annotation processing")
+	}
+}
+
+func TestJSONRenderer_Warnings(t *testing.T) {
+	render := func(result *types.CheckResult) map[string]json.RawMessage {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := (&JSONRenderer{}).Render(&buf, result); err != nil {
+			t.Fatalf("Render error: %v", err)
+		}
+		var output map[string]json.RawMessage
+		if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+			t.Fatalf("Invalid JSON: %v", err)
+		}
+		return output
+	}
+
+	result := &types.CheckResult{}
+	result.AddWarning(types.WarningSourcePlugin, "plugin azurerm failed to start")
+	result.Warnings = append(result.Warnings, types.ResultWarning{
+		Source:   types.WarningSourceLoader,
+		Message:  "no Terraform files (.tf or .tf.json) in '/new'",
+		Location: &types.FileRange{Filename: "/new"},
+	})
+
+	var warnings []map[string]any
+	if err := json.Unmarshal(render(result)["warnings"], &warnings); err != nil {
+		t.Fatalf("Invalid warnings array: %v", err)
+	}
+	if len(warnings) != 2 {
+		t.Fatalf("expected 2 warnings, got %d", len(warnings))
+	}
+	if warnings[0]["source"] != "plugin" || warnings[0]["message"] != "plugin azurerm failed to start" {
+		t.Errorf("unexpected first warning: %v", warnings[0])
+	}
+	if _, ok := warnings[0]["location"]; ok {
+		t.Errorf("expected no location without one, got %v", warnings[0])
+	}
+	if location, ok := warnings[1]["location"].(map[string]any); !ok || location["filename"] != "/new" {
+		t.Errorf("unexpected second warning location: %v", warnings[1])
+	}
+
+	// Left out when the run had no warnings
+	if _, ok := render(&types.CheckResult{})["warnings"]; ok {
+		t.Error("expected no warnings array without warnings")
+	}
+}
+
+func TestJSONRenderer_SummaryBreakdown(t *testing.T) {
+	result := types.NewCheckResult("/old", "/new", types.SeverityError)
+	result.AddFinding(types.NewFinding("BC001", "required-input-added", types.SeverityError, "a"))
+	result.AddFinding(types.NewFinding("BC001", "required-input-added", types.SeverityError, "b"))
+	result.AddFinding(types.NewFinding("RC006", "input-default-changed", types.SeverityWarning, "c"))
+	result.Compute()
+
+	renderer := &JSONRenderer{}
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	var output struct {
+		Summary struct {
+			ByRule     map[string]int `json:"by_rule"`
+			ByCategory map[string]int `json:"by_category"`
+		} `json:"summary"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	if output.Summary.ByRule["BC001"] != 2 || output.Summary.ByRule["RC006"] != 1 {
+		t.Errorf("summary.by_rule = %v, want BC001:2 RC006:1", output.Summary.ByRule)
+	}
+	if output.Summary.ByCategory["breaking"] != 2 || output.Summary.ByCategory["risky"] != 1 {
+		t.Errorf("summary.by_category = %v, want breaking:2 risky:1", output.Summary.ByCategory)
+	}
+}
+
+func TestJSONRenderer_HideIgnored(t *testing.T) {
+	output := renderHidingIgnored(t, FormatJSON, hideIgnoredTestResult())
+
+	var parsed jsonOutput
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if len(parsed.Findings) != 1 || parsed.Findings[0].RuleID != "BC001" {
+		t.Errorf("expected only the reported finding, got %+v", parsed.Findings)
+	}
+	if parsed.Summary.Ignored != 1 || parsed.Summary.Error != 1 {
+		t.Errorf("expected summary to count the hidden finding, got %+v", parsed.Summary)
+	}
+	if parsed.Result != "FAIL" {
+		t.Errorf("Result = %s, want FAIL", parsed.Result)
+	}
+}
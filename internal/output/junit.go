@@ -21,6 +21,7 @@ type junitTestSuites struct {
 	Tests      int              `xml:"tests,attr"`
 	Failures   int              `xml:"failures,attr"`
 	Errors     int              `xml:"errors,attr"`
+	Skipped    int              `xml:"skipped,attr,omitempty"`
 	Time       float64          `xml:"time,attr"`
 	TestSuites []junitTestSuite `xml:"testsuite"`
 }
@@ -58,22 +59,65 @@ type junitSkipped struct {
 	Message string `xml:"message,attr,omitempty"`
 }
 
-// Render writes the check result in JUnit XML format
+// Render writes the check result in JUnit XML format.
+// Test suites are grouped by rule, or by module when the result covers
+// multiple modules (recursive mode).
 func (r *JUnitRenderer) Render(w io.Writer, result *types.CheckResult) error {
+	timestamp := time.Now().Format(time.RFC3339)
+
+	var testSuites []junitTestSuite
+	if len(result.Modules) > 0 {
+		testSuites = r.buildModuleSuites(result, timestamp)
+	} else {
+		testSuites = r.buildRuleSuites(result, timestamp)
+	}
+
+	// If no findings, create a passing test
+	if len(testSuites) == 0 {
+		testSuites = append(testSuites, r.buildPassingSuite("tfbreak", timestamp))
+	}
+
+	// Skipped counts every ignored finding, including any left out of
+	// the test suites by --hide-ignored
+	output := junitTestSuites{
+		Name:       "tfbreak",
+		Skipped:    result.Summary.Ignored,
+		Time:       0,
+		TestSuites: testSuites,
+	}
+	for _, suite := range testSuites {
+		output.Tests += suite.Tests
+		for _, tc := range suite.TestCases {
+			if tc.Failure == nil {
+				continue
+			}
+			if tc.Failure.Type == types.SeverityError.String() {
+				output.Errors++
+			} else {
+				output.Failures++
+			}
+		}
+	}
+
+	// Write XML header
+	if _, err := w.Write([]byte(xml.Header)); err != nil {
+		return err
+	}
+
+	// Encode XML
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return encoder.Encode(output)
+}
+
+// buildRuleSuites creates one test suite per rule that has findings
+func (r *JUnitRenderer) buildRuleSuites(result *types.CheckResult, timestamp string) []junitTestSuite {
 	// Group findings by rule ID to create test suites per rule
 	ruleFindings := make(map[string][]*types.Finding)
 	for _, f := range result.Findings {
 		ruleFindings[f.RuleID] = append(ruleFindings[f.RuleID], f)
 	}
 
-	// Build test suites
-	var testSuites []junitTestSuite
-	totalTests := 0
-	totalFailures := 0
-	totalErrors := 0
-
-	timestamp := time.Now().Format(time.RFC3339)
-
 	// Sort rule IDs for deterministic output
 	ruleIDs := make([]string, 0, len(ruleFindings))
 	for ruleID := range ruleFindings {
@@ -81,88 +125,110 @@ func (r *JUnitRenderer) Render(w io.Writer, result *types.CheckResult) error {
 	}
 	sort.Strings(ruleIDs)
 
+	testSuites := make([]junitTestSuite, 0, len(ruleIDs))
 	for _, ruleID := range ruleIDs {
-		findings := ruleFindings[ruleID]
-		suite := junitTestSuite{
-			Name:      fmt.Sprintf("tfbreak.%s", ruleID),
-			Timestamp: timestamp,
-			Time:      0,
-		}
-
-		for _, f := range findings {
-			testCase := junitTestCase{
-				Name:      r.buildTestCaseName(f),
-				Classname: fmt.Sprintf("tfbreak.%s", f.RuleID),
-				Time:      0,
-			}
-
-			if f.Ignored {
-				// Ignored findings are skipped tests
-				testCase.Skipped = &junitSkipped{
-					Message: f.IgnoreReason,
-				}
-				suite.Skipped++
-			} else {
-				// Non-ignored findings are failures
-				testCase.Failure = &junitFailure{
-					Message: f.Message,
-					Type:    f.Severity.String(),
-					Content: r.buildFailureContent(f),
-				}
-				suite.Failures++
-				if f.Severity == types.SeverityError {
-					totalErrors++
-				} else {
-					totalFailures++
-				}
-			}
+		testSuites = append(testSuites, r.buildSuite(fmt.Sprintf("tfbreak.%s", ruleID), ruleFindings[ruleID], timestamp))
+	}
+	return testSuites
+}
 
-			suite.TestCases = append(suite.TestCases, testCase)
-			suite.Tests++
-			totalTests++
+// buildModuleSuites creates one test suite per checked module, named by the
+// module path. Test cases within a suite are grouped by rule. Modules without
+// findings get a single passing test case.
+func (r *JUnitRenderer) buildModuleSuites(result *types.CheckResult, timestamp string) []junitTestSuite {
+	moduleFindings := make(map[string][]*types.Finding)
+	modules := make([]string, 0, len(result.Modules))
+	for _, module := range result.Modules {
+		if _, ok := moduleFindings[module]; !ok {
+			moduleFindings[module] = nil
+			modules = append(modules, module)
 		}
-
-		testSuites = append(testSuites, suite)
 	}
+	for _, f := range result.Findings {
+		if _, ok := moduleFindings[f.Module]; !ok {
+			modules = append(modules, f.Module)
+		}
+		moduleFindings[f.Module] = append(moduleFindings[f.Module], f)
+	}
+	sort.Strings(modules)
 
-	// If no findings, create a passing test
-	if len(testSuites) == 0 {
-		testSuites = append(testSuites, junitTestSuite{
-			Name:      "tfbreak",
-			Tests:     1,
-			Timestamp: timestamp,
-			TestCases: []junitTestCase{
-				{
-					Name:      "Breaking change detection",
-					Classname: "tfbreak",
-					Time:      0,
-				},
-			},
+	testSuites := make([]junitTestSuite, 0, len(modules))
+	for _, module := range modules {
+		findings := moduleFindings[module]
+		if len(findings) == 0 {
+			testSuites = append(testSuites, r.buildPassingSuite(module, timestamp))
+			continue
+		}
+
+		// Group test cases by rule within the module
+		sort.SliceStable(findings, func(i, j int) bool {
+			return findings[i].RuleID < findings[j].RuleID
 		})
-		totalTests = 1
+		testSuites = append(testSuites, r.buildSuite(module, findings, timestamp))
 	}
+	return testSuites
+}
 
-	output := junitTestSuites{
-		Name:       "tfbreak",
-		Tests:      totalTests,
-		Failures:   totalFailures,
-		Errors:     totalErrors,
-		Time:       0,
-		TestSuites: testSuites,
+// buildSuite creates a test suite with one test case per finding
+func (r *JUnitRenderer) buildSuite(name string, findings []*types.Finding, timestamp string) junitTestSuite {
+	suite := junitTestSuite{
+		Name:      name,
+		Timestamp: timestamp,
+		Time:      0,
 	}
 
-	// Write XML header
-	if _, err := w.Write([]byte(xml.Header)); err != nil {
-		return err
+	for _, f := range findings {
+		testCase := junitTestCase{
+			Name:      r.buildTestCaseName(f),
+			Classname: fmt.Sprintf("tfbreak.%s", f.RuleID),
+			Time:      0,
+		}
+
+		if f.Ignored {
+			// Ignored findings are skipped tests
+			testCase.Skipped = &junitSkipped{
+				Message: f.IgnoreReason,
+			}
+			suite.Skipped++
+		} else {
+			// Non-ignored findings are failures
+			testCase.Failure = &junitFailure{
+				Message: f.Message,
+				Type:    f.Severity.String(),
+				Content: r.buildFailureContent(f),
+			}
+			suite.Failures++
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
 	}
 
-	// Encode XML
-	encoder := xml.NewEncoder(w)
-	encoder.Indent("", "  ")
-	return encoder.Encode(output)
+	return suite
+}
+
+// buildPassingSuite creates a suite with a single passing test case
+func (r *JUnitRenderer) buildPassingSuite(name, timestamp string) junitTestSuite {
+	return junitTestSuite{
+		Name:      name,
+		Tests:     1,
+		Timestamp: timestamp,
+		TestCases: []junitTestCase{
+			{
+				Name:      "Breaking change detection",
+				Classname: "tfbreak",
+				Time:      0,
+			},
+		},
+	}
 }
 
-// buildTestCaseName creates a descriptive name for the test case
+// buildTestCaseName creates a descriptive name for the test case.
+// The finding fingerprint, which covers the module and message, is appended
+// to the location so that findings of the same rule get distinct,
+// re-identifiable names that CI systems will not collapse into a single
+// test. Only findings of the same rule with the same module, file, line,
+// and message share a name.
 func (r *JUnitRenderer) buildTestCaseName(f *types.Finding) string {
 	location := ""
 	if f.NewLocation != nil {
@@ -172,9 +238,9 @@ func (r *JUnitRenderer) buildTestCaseName(f *types.Finding) string {
 	}
 
 	if location != "" {
-		return fmt.Sprintf("%s at %s", f.RuleName, location)
+		return fmt.Sprintf("%s at %s [%s]", f.RuleName, location, f.Fingerprint())
 	}
-	return f.RuleName
+	return fmt.Sprintf("%s [%s]", f.RuleName, f.Fingerprint())
 }
 
 // buildFailureContent creates the content for the failure element
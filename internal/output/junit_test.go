@@ -369,3 +369,209 @@ func TestJUnitRenderer_DeterministicSuiteOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestJUnitRenderer_SameRuleUniqueNames(t *testing.T) {
+	result := &types.CheckResult{
+		OldPath: "/old",
+		NewPath: "/new",
+		Findings: []*types.Finding{
+			{
+				RuleID:      "BC002",
+				RuleName:    "input-removed",
+				Severity:    types.SeverityError,
+				Message:     "Variable \"foo\" was removed",
+				OldLocation: &types.FileRange{Filename: "variables.tf", Line: 1},
+			},
+			{
+				RuleID:      "BC002",
+				RuleName:    "input-removed",
+				Severity:    types.SeverityError,
+				Message:     "Variable \"bar\" was removed",
+				OldLocation: &types.FileRange{Filename: "variables.tf", Line: 1},
+			},
+		},
+		Result: "FAIL",
+		FailOn: types.SeverityError,
+	}
+
+	render := func() junitTestSuite {
+		var buf bytes.Buffer
+		if err := (&JUnitRenderer{}).Render(&buf, result); err != nil {
+			t.Fatalf("Render error: %v", err)
+		}
+		var testSuites junitTestSuites
+		if err := xml.Unmarshal(buf.Bytes(), &testSuites); err != nil {
+			t.Fatalf("Invalid XML: %v", err)
+		}
+		if len(testSuites.TestSuites) != 1 {
+			t.Fatalf("expected 1 suite grouped by rule, got %d", len(testSuites.TestSuites))
+		}
+		return testSuites.TestSuites[0]
+	}
+
+	suite := render()
+	if len(suite.TestCases) != 2 {
+		t.Fatalf("expected 2 test cases, got %d", len(suite.TestCases))
+	}
+	first, second := suite.TestCases[0].Name, suite.TestCases[1].Name
+	if first == second {
+		t.Errorf("expected distinct test case names, both were %q", first)
+	}
+	if !strings.HasPrefix(first, "input-removed at variables.tf:1 [") {
+		t.Errorf("unexpected test case name %q", first)
+	}
+
+	// Names must be stable across renders
+	again := render()
+	if again.TestCases[0].Name != first || again.TestCases[1].Name != second {
+		t.Error("expected test case names to be stable across renders")
+	}
+}
+
+func TestJUnitRenderer_TestCaseNameCollisions(t *testing.T) {
+	finding := func(module string, line int) *types.Finding {
+		return &types.Finding{
+			RuleID:      "RC006",
+			RuleName:    "input-default-changed",
+			Severity:    types.SeverityWarning,
+			Message:     "Variable \"cidr\" default changed",
+			Module:      module,
+			NewLocation: &types.FileRange{Filename: "variables.tf", Line: line},
+		}
+	}
+	renderer := &JUnitRenderer{}
+	name := renderer.buildTestCaseName(finding("modules/vpc", 3))
+
+	// The same message differs by line or by module
+	if other := renderer.buildTestCaseName(finding("modules/vpc", 9)); other == name {
+		t.Errorf("expected distinct names for different lines, both were %q", name)
+	}
+	if other := renderer.buildTestCaseName(finding("modules/eks", 3)); other == name {
+		t.Errorf("expected distinct names for different modules, both were %q", name)
+	}
+
+	// Identical findings share a name
+	if other := renderer.buildTestCaseName(finding("modules/vpc", 3)); other != name {
+		t.Errorf("expected identical findings to share a name, got %q and %q", name, other)
+	}
+}
+
+func TestJUnitRenderer_GroupsByModule(t *testing.T) {
+	result := &types.CheckResult{
+		OldPath: "/old",
+		NewPath: "/new",
+		Modules: []string{".", "modules/vpc", "modules/eks"},
+		Findings: []*types.Finding{
+			{
+				RuleID:   "RC006",
+				RuleName: "input-default-changed",
+				Severity: types.SeverityWarning,
+				Message:  "Variable \"cidr\" default changed",
+				Module:   "modules/vpc",
+			},
+			{
+				RuleID:   "BC002",
+				RuleName: "input-removed",
+				Severity: types.SeverityError,
+				Message:  "Variable \"region\" was removed",
+				Module:   "modules/vpc",
+			},
+			{
+				RuleID:   "BC002",
+				RuleName: "input-removed",
+				Severity: types.SeverityError,
+				Message:  "Variable \"name\" was removed",
+				Module:   ".",
+			},
+		},
+		Result: "FAIL",
+		FailOn: types.SeverityError,
+	}
+
+	renderer := &JUnitRenderer{}
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	var testSuites junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &testSuites); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+
+	if len(testSuites.TestSuites) != 3 {
+		t.Fatalf("expected one suite per module, got %d", len(testSuites.TestSuites))
+	}
+
+	suites := make(map[string]junitTestSuite)
+	var names []string
+	for _, suite := range testSuites.TestSuites {
+		suites[suite.Name] = suite
+		names = append(names, suite.Name)
+	}
+	if strings.Join(names, ",") != ".,modules/eks,modules/vpc" {
+		t.Errorf("suite order = %v, want sorted module paths", names)
+	}
+
+	vpc := suites["modules/vpc"]
+	if vpc.Tests != 2 || vpc.Failures != 2 {
+		t.Errorf("modules/vpc: tests=%d failures=%d, want 2/2", vpc.Tests, vpc.Failures)
+	}
+	// Test cases are grouped by rule within the module
+	if vpc.TestCases[0].Classname != "tfbreak.BC002" || vpc.TestCases[1].Classname != "tfbreak.RC006" {
+		t.Errorf("modules/vpc test cases not grouped by rule: %s, %s", vpc.TestCases[0].Classname, vpc.TestCases[1].Classname)
+	}
+
+	// Modules without findings get a passing test case
+	eks := suites["modules/eks"]
+	if eks.Tests != 1 || eks.Failures != 0 || eks.TestCases[0].Failure != nil {
+		t.Errorf("modules/eks: expected a single passing test, got %+v", eks)
+	}
+
+	if testSuites.Tests != 4 || testSuites.Errors != 2 || testSuites.Failures != 1 {
+		t.Errorf("totals: tests=%d errors=%d failures=%d, want 4/2/1", testSuites.Tests, testSuites.Errors, testSuites.Failures)
+	}
+}
+
+func TestJUnitRenderer_NoModulesGroupsByRule(t *testing.T) {
+	result := &types.CheckResult{
+		Findings: []*types.Finding{
+			{RuleID: "BC002", RuleName: "input-removed", Severity: types.SeverityError, Message: "a"},
+			{RuleID: "RC006", RuleName: "input-default-changed", Severity: types.SeverityWarning, Message: "b"},
+		},
+	}
+
+	renderer := &JUnitRenderer{}
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	var testSuites junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &testSuites); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+	if len(testSuites.TestSuites) != 2 || testSuites.TestSuites[0].Name != "tfbreak.BC002" {
+		t.Errorf("expected rule suites for single-module results, got %+v", testSuites.TestSuites)
+	}
+}
+
+func TestJUnitRenderer_HideIgnored(t *testing.T) {
+	output := renderHidingIgnored(t, FormatJUnit, hideIgnoredTestResult())
+
+	var testSuites junitTestSuites
+	if err := xml.Unmarshal([]byte(output), &testSuites); err != nil {
+		t.Fatalf("Invalid XML: %v", err)
+	}
+	if testSuites.Tests != 1 || testSuites.Errors != 1 {
+		t.Errorf("tests = %d, errors = %d, want 1 and 1", testSuites.Tests, testSuites.Errors)
+	}
+	if testSuites.Skipped != 1 {
+		t.Errorf("skipped = %d, want the hidden finding counted", testSuites.Skipped)
+	}
+	for _, suite := range testSuites.TestSuites {
+		if suite.Skipped != 0 || strings.Contains(suite.Name, "BC002") {
+			t.Errorf("expected no test cases for the ignored finding, got suite %+v", suite)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// MarkdownRenderer renders output as GitHub-flavored Markdown, for bots
+// that post the result as a pull request comment
+type MarkdownRenderer struct {
+	// ShowIDs renders the short ID of each finding after its rule name
+	ShowIDs bool
+
+	// SourceRoots are directories treated as the repository root. Files
+	// under any of them are shown relative to it, so files read from
+	// temporary checkouts appear under their repository paths.
+	// See SARIFRenderer.SourceRoots.
+	SourceRoots []string
+}
+
+// markdownFileGroup holds the findings reported on one file, or on no
+// file if filename is empty
+type markdownFileGroup struct {
+	filename string
+	findings []*types.Finding
+}
+
+// markdownNoLocation heads the group of findings without a source location
+const markdownNoLocation = "Other findings"
+
+// Render writes the check result as Markdown
+func (r *MarkdownRenderer) Render(w io.Writer, result *types.CheckResult) error {
+	fmt.Fprintf(w, "## tfbreak: %s\n\n", result.Result)
+	fmt.Fprintf(w, "Comparing %s -> %s\n\n", markdownCode(result.OldPath), markdownCode(result.NewPath))
+
+	if result.Result == "PASS" {
+		fmt.Fprintln(w, "No breaking changes detected.")
+	} else {
+		fmt.Fprintln(w, "**Breaking changes detected.**")
+	}
+
+	if result.TimedOut {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "> **Note:** the run timed out, so these results are incomplete.")
+	}
+
+	if len(result.Findings) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(w)
+	r.renderSummary(w, result.Summary)
+
+	for _, group := range r.groupFindingsByFile(result.Findings) {
+		heading := markdownNoLocation
+		if group.filename != "" {
+			heading = markdownCode(group.filename)
+		}
+		fmt.Fprintf(w, "\n### %s\n\n", heading)
+		for _, f := range group.findings {
+			r.renderFinding(w, f)
+		}
+	}
+
+	return nil
+}
+
+// renderSummary writes the counts by severity as a table
+func (r *MarkdownRenderer) renderSummary(w io.Writer, s types.Summary) {
+	fmt.Fprintln(w, "| Severity | Count |")
+	fmt.Fprintln(w, "| --- | ---: |")
+	fmt.Fprintf(w, "| %s | %d |\n", types.SeverityError, s.Error)
+	fmt.Fprintf(w, "| %s | %d |\n", types.SeverityWarning, s.Warning)
+	fmt.Fprintf(w, "| %s | %d |\n", types.SeverityDeprecation, s.Deprecation)
+	fmt.Fprintf(w, "| %s | %d |\n", types.SeverityNotice, s.Notice)
+	if s.Ignored > 0 {
+		fmt.Fprintf(w, "| Ignored | %d |\n", s.Ignored)
+	}
+}
+
+// renderFinding writes a finding as a list item, with its remediation
+// folded into a collapsible block
+func (r *MarkdownRenderer) renderFinding(w io.Writer, f *types.Finding) {
+	rule := markdownCode(f.RuleID)
+	if f.HelpURL != "" {
+		rule = fmt.Sprintf("[%s](%s)", f.RuleID, f.HelpURL)
+	}
+
+	fmt.Fprintf(w, "- **%s** %s", f.Severity, rule)
+	if f.RuleName != "" {
+		fmt.Fprintf(w, " %s", markdownCode(f.RuleName))
+	}
+	if r.ShowIDs {
+		fmt.Fprintf(w, " [%s]", markdownCode(f.ShortID()))
+	}
+	if loc := findingLocation(f); loc != nil {
+		fmt.Fprintf(w, " at %s", markdownCode(fmt.Sprintf("%s:%d", r.displayPath(loc.Filename), loc.Line)))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "  %s\n", escapeMarkdown(f.Message))
+
+	if f.Ignored {
+		if f.IgnoreReason != "" {
+			fmt.Fprintf(w, "  _Ignored: %s_\n", escapeMarkdown(f.IgnoreReason))
+		} else {
+			fmt.Fprintln(w, "  _Ignored_")
+		}
+	}
+
+	if f.Remediation != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "  <details><summary>Remediation</summary>")
+		fmt.Fprintln(w)
+		for _, line := range strings.Split(f.Remediation, "\n") {
+			if line == "" {
+				fmt.Fprintln(w)
+				continue
+			}
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "  </details>")
+		fmt.Fprintln(w)
+	}
+}
+
+// groupFindingsByFile groups findings by the file they are reported on,
+// with files sorted alphabetically and findings by line, so the output
+// diffs cleanly between runs. Findings without a location come last.
+func (r *MarkdownRenderer) groupFindingsByFile(findings []*types.Finding) []markdownFileGroup {
+	byFile := make(map[string][]*types.Finding)
+	var unlocated []*types.Finding
+	for _, f := range findings {
+		if loc := findingLocation(f); loc != nil {
+			filename := r.displayPath(loc.Filename)
+			byFile[filename] = append(byFile[filename], f)
+		} else {
+			unlocated = append(unlocated, f)
+		}
+	}
+
+	files := make([]string, 0, len(byFile))
+	for filename := range byFile {
+		files = append(files, filename)
+	}
+	sort.Strings(files)
+
+	groups := make([]markdownFileGroup, 0, len(files)+1)
+	for _, filename := range files {
+		group := byFile[filename]
+		sort.SliceStable(group, func(i, j int) bool {
+			return findingLocation(group[i]).Line < findingLocation(group[j]).Line
+		})
+		groups = append(groups, markdownFileGroup{filename: filename, findings: group})
+	}
+	if len(unlocated) > 0 {
+		groups = append(groups, markdownFileGroup{findings: unlocated})
+	}
+	return groups
+}
+
+// displayPath returns filename relative to the first source root containing
+// it, or unchanged if there is none
+func (r *MarkdownRenderer) displayPath(filename string) string {
+	if rel, ok := relativePath(r.SourceRoots, filename); ok {
+		return rel
+	}
+	return filename
+}
+
+// markdownCode formats s as inline code, using a longer fence if s
+// contains backticks
+func markdownCode(s string) string {
+	fence := "`"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		return fence + " " + s + " " + fence
+	}
+	return fence + s + fence
+}
+
+// markdownEscaper escapes characters that Markdown or HTML would interpret
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"<", "&lt;",
+	">", "&gt;",
+	"|", `\|`,
+)
+
+// escapeMarkdown escapes s for use as Markdown text
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
@@ -0,0 +1,171 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+func renderMarkdown(t *testing.T, result *types.CheckResult) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := (&MarkdownRenderer{}).Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	return buf.String()
+}
+
+func TestMarkdownRenderer_Pass(t *testing.T) {
+	result := types.NewCheckResult("./old", "./new", types.SeverityError)
+	result.Result = "PASS"
+
+	want := "## tfbreak: PASS\n\nComparing `./old` -> `./new`\n\nNo breaking changes detected.\n"
+	if got := renderMarkdown(t, result); got != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMarkdownRenderer_GroupsFindingsByFile(t *testing.T) {
+	result := types.NewCheckResult("./old", "./new", types.SeverityError)
+	result.Findings = []*types.Finding{
+		{
+			RuleID:      "RC006",
+			RuleName:    "input-default-changed",
+			Severity:    types.SeverityWarning,
+			Message:     `Default value changed for "my_var"`,
+			NewLocation: &types.FileRange{Filename: "variables.tf", Line: 12},
+		},
+		{
+			RuleID:      "BC002",
+			RuleName:    "output-removed",
+			Severity:    types.SeverityError,
+			Message:     `Output "id" was removed`,
+			OldLocation: &types.FileRange{Filename: "outputs.tf", Line: 4},
+			HelpURL:     "https://example.com/rules/BC002",
+		},
+		{
+			RuleID:      "BC001",
+			RuleName:    "required-input-added",
+			Severity:    types.SeverityError,
+			Message:     `New required variable "foo" has no default`,
+			NewLocation: &types.FileRange{Filename: "variables.tf", Line: 3},
+			Remediation: "Add a default:\n\n  default = null",
+		},
+		{
+			RuleID:   "RC014",
+			Severity: types.SeverityNotice,
+			Message:  "Output became sensitive",
+		},
+	}
+	result.Summary = types.Summary{Error: 2, Warning: 1, Notice: 1, Total: 4}
+	result.Result = "FAIL"
+
+	got := renderMarkdown(t, result)
+
+	for _, want := range []string{
+		"**Breaking changes detected.**",
+		"| ERROR | 2 |\n| WARNING | 1 |\n| DEPRECATION | 0 |\n| NOTICE | 1 |\n",
+		"- **ERROR** [BC002](https://example.com/rules/BC002) `output-removed` at `outputs.tf:4`\n",
+		"  Default value changed for \"my\\_var\"\n",
+		"  <details><summary>Remediation</summary>\n\n  Add a default:\n\n    default = null\n\n  </details>\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "| Ignored |") {
+		t.Errorf("expected no ignored row without ignored findings, got:\n%s", got)
+	}
+
+	// Files are sorted, findings within a file by line, and findings
+	// without a location come last
+	order := []string{"### `outputs.tf`", "### `variables.tf`", "`variables.tf:3`", "`variables.tf:12`", "### Other findings", "RC014"}
+	last := -1
+	for _, s := range order {
+		idx := strings.Index(got, s)
+		if idx <= last {
+			t.Fatalf("expected %q after the previous section, got:\n%s", s, got)
+		}
+		last = idx
+	}
+}
+
+func TestMarkdownRenderer_Ignored(t *testing.T) {
+	result := types.NewCheckResult("./old", "./new", types.SeverityError)
+	result.Findings = []*types.Finding{
+		{
+			RuleID:       "BC001",
+			Severity:     types.SeverityError,
+			Message:      "ignored",
+			NewLocation:  &types.FileRange{Filename: "main.tf", Line: 1},
+			Ignored:      true,
+			IgnoreReason: "planned <breaking> change",
+		},
+	}
+	result.Summary = types.Summary{Ignored: 1, Total: 1}
+	result.Result = "PASS"
+
+	got := renderMarkdown(t, result)
+	for _, want := range []string{"No breaking changes detected.", "| Ignored | 1 |", "_Ignored: planned &lt;breaking&gt; change_"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestMarkdownRenderer_ShowIDs(t *testing.T) {
+	result := types.NewCheckResult("./old", "./new", types.SeverityError)
+	result.Findings = []*types.Finding{
+		types.NewFinding("BC002", "input-removed", types.SeverityError, `Variable "foo" was removed`).
+			WithOldLocation(&types.FileRange{Filename: "variables.tf", Line: 3}),
+	}
+	result.Result = "FAIL"
+
+	id := result.Findings[0].ShortID()
+	var buf bytes.Buffer
+	if err := (&MarkdownRenderer{ShowIDs: true}).Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if want := "- **ERROR** `BC002` `input-removed` [`" + id + "`] at `variables.tf:3`\n"; !strings.Contains(buf.String(), want) {
+		t.Errorf("expected output to contain %q, got:\n%s", want, buf.String())
+	}
+
+	if got := renderMarkdown(t, result); strings.Contains(got, id) {
+		t.Errorf("expected no short ID without ShowIDs, got:\n%s", got)
+	}
+}
+
+func TestMarkdownRenderer_RelativePaths(t *testing.T) {
+	// With --base, the old files are read from a temporary worktree and the
+	// new ones from the repository itself
+	result := types.NewCheckResult("/tmp/tfbreak-wt-1234", "/repo", types.SeverityError)
+	result.Findings = []*types.Finding{
+		types.NewFinding("BC002", "input-removed", types.SeverityError, `Variable "foo" was removed`).
+			WithOldLocation(&types.FileRange{Filename: "/tmp/tfbreak-wt-1234/modules/vpc/variables.tf", Line: 3}),
+		types.NewFinding("BC001", "required-input-added", types.SeverityError, `New required variable "bar" has no default`).
+			WithNewLocation(&types.FileRange{Filename: "/repo/modules/vpc/variables.tf", Line: 7}),
+	}
+	result.Result = "FAIL"
+
+	var buf bytes.Buffer
+	renderer := &MarkdownRenderer{SourceRoots: []string{"/repo", "/tmp/tfbreak-wt-1234"}}
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	got := buf.String()
+
+	// Both findings are grouped under the repository path
+	if n := strings.Count(got, "### `modules/vpc/variables.tf`"); n != 1 {
+		t.Errorf("expected a single heading for the repository path, got %d:\n%s", n, got)
+	}
+	for _, want := range []string{"at `modules/vpc/variables.tf:3`", "at `modules/vpc/variables.tf:7`"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+	if findings := got[strings.Index(got, "###"):]; strings.Contains(findings, "tfbreak-wt-") {
+		t.Errorf("expected no worktree paths in the findings, got:\n%s", got)
+	}
+}
@@ -0,0 +1,69 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// NDJSONRenderer renders output as newline-delimited JSON (also known as
+// JSON Lines): one object per finding followed by a final summary object.
+// The result is rendered once the check is complete; the format lets
+// consumers read findings line by line rather than parse a single document.
+type NDJSONRenderer struct{}
+
+// ndjsonFinding is a single finding line
+type ndjsonFinding struct {
+	Type string `json:"type"`
+	*types.Finding
+}
+
+// ndjsonSummary is the final line of the output
+type ndjsonSummary struct {
+	Type    string        `json:"type"`
+	Version string        `json:"version"`
+	OldPath string        `json:"old_path"`
+	NewPath string        `json:"new_path"`
+	Summary types.Summary `json:"summary"`
+	Result  string        `json:"result"`
+	FailOn  string        `json:"fail_on"`
+
+	FailOnCategory map[types.Category]types.Severity `json:"fail_on_category,omitempty"`
+	Modules        []string                          `json:"modules,omitempty"`
+	OldRef         string                            `json:"old_ref,omitempty"`
+	NewRef         string                            `json:"new_ref,omitempty"`
+	Warnings       []types.ResultWarning             `json:"warnings,omitempty"`
+	TimedOut       bool                              `json:"timed_out,omitempty"`
+}
+
+// Render writes the check result in NDJSON format
+func (r *NDJSONRenderer) Render(w io.Writer, result *types.CheckResult) error {
+	encoder := json.NewEncoder(w)
+	for _, f := range result.Findings {
+		if err := encoder.Encode(ndjsonFinding{Type: "finding", Finding: f}); err != nil {
+			return err
+		}
+	}
+	return encodeNDJSONSummary(encoder, result)
+}
+
+// encodeNDJSONSummary writes the summary line for result
+func encodeNDJSONSummary(encoder *json.Encoder, result *types.CheckResult) error {
+	return encoder.Encode(ndjsonSummary{
+		Type:    "summary",
+		Version: "1.0",
+		OldPath: result.OldPath,
+		NewPath: result.NewPath,
+		Summary: result.Summary,
+		Result:  result.Result,
+		FailOn:  result.FailOn.String(),
+
+		FailOnCategory: result.FailOnCategory,
+		Modules:        result.Modules,
+		OldRef:         result.OldRef,
+		NewRef:         result.NewRef,
+		Warnings:       result.Warnings,
+		TimedOut:       result.TimedOut,
+	})
+}
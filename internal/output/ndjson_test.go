@@ -0,0 +1,155 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+func ndjsonTestResult() *types.CheckResult {
+	return &types.CheckResult{
+		OldPath: "/old",
+		NewPath: "/new",
+		Findings: []*types.Finding{
+			{
+				RuleID:   "BC001",
+				RuleName: "required-input-added",
+				Severity: types.SeverityError,
+				Message:  "New required variable \"foo\" has no default",
+			},
+			{
+				RuleID:   "RC006",
+				RuleName: "input-default-changed",
+				Severity: types.SeverityWarning,
+				Message:  "Default value changed for \"bar\"",
+			},
+		},
+		Summary: types.Summary{Error: 1, Warning: 1, Total: 2},
+		Result:  "FAIL",
+		FailOn:  types.SeverityError,
+	}
+}
+
+// parseNDJSON decodes every line of the output, failing on invalid JSON
+func parseNDJSON(t *testing.T, output string) []map[string]any {
+	t.Helper()
+	var objects []map[string]any
+	for i, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		var obj map[string]any
+		if err := json.Unmarshal([]byte(line), &obj); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v\n%s", i+1, err, line)
+		}
+		objects = append(objects, obj)
+	}
+	return objects
+}
+
+func TestNDJSONRenderer(t *testing.T) {
+	renderer := &NDJSONRenderer{}
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, ndjsonTestResult()); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	objects := parseNDJSON(t, buf.String())
+	if len(objects) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(objects))
+	}
+
+	for i, obj := range objects[:2] {
+		if obj["type"] != "finding" {
+			t.Errorf("line %d type = %v, want finding", i+1, obj["type"])
+		}
+	}
+	if objects[0]["rule_id"] != "BC001" || objects[1]["rule_id"] != "RC006" {
+		t.Errorf("findings out of order: %v, %v", objects[0]["rule_id"], objects[1]["rule_id"])
+	}
+
+	summary := objects[2]
+	if summary["type"] != "summary" {
+		t.Errorf("last line type = %v, want summary", summary["type"])
+	}
+	if summary["result"] != "FAIL" {
+		t.Errorf("summary result = %v, want FAIL", summary["result"])
+	}
+	if counts, ok := summary["summary"].(map[string]any); !ok || counts["total"] != float64(2) {
+		t.Errorf("summary counts = %v, want total 2", summary["summary"])
+	}
+}
+
+func TestNDJSONRenderer_NoFindings(t *testing.T) {
+	result := &types.CheckResult{Result: "PASS", FailOn: types.SeverityError}
+
+	renderer := &NDJSONRenderer{}
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	objects := parseNDJSON(t, buf.String())
+	if len(objects) != 1 || objects[0]["type"] != "summary" {
+		t.Errorf("expected a single summary line, got %v", objects)
+	}
+}
+
+func TestNDJSONRenderer_HideIgnored(t *testing.T) {
+	output := renderHidingIgnored(t, FormatNDJSON, hideIgnoredTestResult())
+
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one finding line and a summary line, got %d:\n%s", len(lines), output)
+	}
+	if strings.Contains(output, "BC002") {
+		t.Errorf("expected ignored finding to be hidden, got:\n%s", output)
+	}
+
+	var summary ndjsonSummary
+	if err := json.Unmarshal([]byte(lines[1]), &summary); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if summary.Summary.Ignored != 1 {
+		t.Errorf("expected summary to count the hidden finding, got %+v", summary.Summary)
+	}
+}
+
+// lineWriter records each write separately
+type lineWriter struct {
+	writes []string
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.writes = append(w.writes, string(p))
+	return len(p), nil
+}
+
+func TestNDJSONRenderer_WritesEachLine(t *testing.T) {
+	result := ndjsonTestResult()
+	result.AddWarning(types.WarningSourceLoader, "skipped module modules/new: not found in old directory")
+
+	w := &lineWriter{}
+	renderer := NewRenderer(FormatJSONL, false)
+	if err := renderer.Render(w, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	// Nothing is buffered: every finding and the summary is its own write
+	if len(w.writes) != 3 {
+		t.Fatalf("expected 3 writes, got %d: %q", len(w.writes), w.writes)
+	}
+	for i, line := range w.writes {
+		if strings.Count(line, "\n") != 1 || !strings.HasSuffix(line, "\n") {
+			t.Errorf("write %d is not a single line: %q", i+1, line)
+		}
+	}
+
+	summary := parseNDJSON(t, w.writes[2])[0]
+	if summary["type"] != "summary" {
+		t.Errorf("last line type = %v, want summary", summary["type"])
+	}
+	if warnings, ok := summary["warnings"].([]any); !ok || len(warnings) != 1 {
+		t.Errorf("summary warnings = %v, want the skipped module warning", summary["warnings"])
+	}
+}
@@ -0,0 +1,121 @@
+package output
+
+import (
+	"io"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// RDJSONRenderer renders output in Reviewdog Diagnostic Format (rdjson),
+// which reviewdog turns into pull request comments on any CI provider
+type RDJSONRenderer struct {
+	// Compact writes the document on a single line instead of indenting it
+	Compact bool
+}
+
+// rdjsonResult is the root DiagnosticResult object
+type rdjsonResult struct {
+	Source      rdjsonSource       `json:"source"`
+	Diagnostics []rdjsonDiagnostic `json:"diagnostics"`
+}
+
+// rdjsonSource names the tool that produced the diagnostics
+type rdjsonSource struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// rdjsonDiagnostic is a single finding
+type rdjsonDiagnostic struct {
+	Message  string          `json:"message"`
+	Location *rdjsonLocation `json:"location,omitempty"`
+	Severity string          `json:"severity"`
+	Code     rdjsonCode      `json:"code"`
+}
+
+// rdjsonLocation is a file and the range within it
+type rdjsonLocation struct {
+	Path  string       `json:"path"`
+	Range *rdjsonRange `json:"range,omitempty"`
+}
+
+// rdjsonRange spans from start to end; end is optional
+type rdjsonRange struct {
+	Start rdjsonPosition  `json:"start"`
+	End   *rdjsonPosition `json:"end,omitempty"`
+}
+
+// rdjsonPosition is a 1-based line and column
+type rdjsonPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column,omitempty"`
+}
+
+// rdjsonCode identifies the rule, linking to its documentation
+type rdjsonCode struct {
+	Value string `json:"value"`
+	URL   string `json:"url,omitempty"`
+}
+
+// Render writes the check result in rdjson format
+func (r *RDJSONRenderer) Render(w io.Writer, result *types.CheckResult) error {
+	output := rdjsonResult{
+		Source: rdjsonSource{
+			Name: "tfbreak",
+			URL:  "https://github.com/jokarl/tfbreak-core",
+		},
+		Diagnostics: []rdjsonDiagnostic{},
+	}
+
+	for _, f := range result.Findings {
+		if f.Ignored {
+			continue
+		}
+
+		diagnostic := rdjsonDiagnostic{
+			Message:  f.Message,
+			Severity: mapToRDJSONSeverity(f.Severity),
+			Code:     rdjsonCode{Value: f.RuleID, URL: f.HelpURL},
+		}
+
+		if f.NewLocation != nil {
+			diagnostic.Location = rdjsonLocationFor(f.NewLocation)
+		} else if f.OldLocation != nil {
+			diagnostic.Location = rdjsonLocationFor(f.OldLocation)
+		}
+
+		output.Diagnostics = append(output.Diagnostics, diagnostic)
+	}
+
+	return newJSONEncoder(w, r.Compact).Encode(output)
+}
+
+// rdjsonLocationFor converts a file range, leaving out the range if the
+// line is unknown and the end if it is not recorded
+func rdjsonLocationFor(loc *types.FileRange) *rdjsonLocation {
+	location := &rdjsonLocation{Path: loc.Filename}
+	if loc.Line == 0 {
+		return location
+	}
+
+	location.Range = &rdjsonRange{
+		Start: rdjsonPosition{Line: loc.Line, Column: loc.Column},
+	}
+	if loc.EndLine != 0 {
+		location.Range.End = &rdjsonPosition{Line: loc.EndLine, Column: loc.EndColumn}
+	}
+	return location
+}
+
+// mapToRDJSONSeverity maps tfbreak severity to rdjson severity.
+// Risky changes (WARNING) stay warnings; notices become INFO.
+func mapToRDJSONSeverity(s types.Severity) string {
+	switch s {
+	case types.SeverityError:
+		return "ERROR"
+	case types.SeverityWarning:
+		return "WARNING"
+	default:
+		return "INFO"
+	}
+}
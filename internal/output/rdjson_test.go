@@ -0,0 +1,128 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// reviewdogResult mirrors the parts of reviewdog's DiagnosticResult schema
+// that tfbreak writes, independently of the renderer's own types
+type reviewdogResult struct {
+	Source struct {
+		Name string `json:"name"`
+	} `json:"source"`
+	Diagnostics []struct {
+		Message  string `json:"message"`
+		Severity string `json:"severity"`
+		Location struct {
+			Path  string `json:"path"`
+			Range struct {
+				Start struct {
+					Line   int `json:"line"`
+					Column int `json:"column"`
+				} `json:"start"`
+				End *struct {
+					Line   int `json:"line"`
+					Column int `json:"column"`
+				} `json:"end"`
+			} `json:"range"`
+		} `json:"location"`
+		Code struct {
+			Value string `json:"value"`
+			URL   string `json:"url"`
+		} `json:"code"`
+	} `json:"diagnostics"`
+}
+
+func renderRDJSON(t *testing.T, result *types.CheckResult) reviewdogResult {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := (&RDJSONRenderer{}).Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	var rd reviewdogResult
+	if err := json.Unmarshal(buf.Bytes(), &rd); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, buf.String())
+	}
+	return rd
+}
+
+func TestRDJSONRenderer(t *testing.T) {
+	result := &types.CheckResult{
+		Findings: []*types.Finding{
+			{
+				RuleID:   "BC001",
+				Severity: types.SeverityError,
+				Message:  `New required variable "foo" has no default`,
+				NewLocation: &types.FileRange{
+					Filename: "variables.tf", Line: 3, Column: 1, EndLine: 5, EndColumn: 2,
+				},
+				HelpURL: "https://example.com/rules/BC001",
+			},
+			{
+				RuleID:      "RC006",
+				Severity:    types.SeverityWarning,
+				Message:     `Default value changed for "bar"`,
+				OldLocation: &types.FileRange{Filename: "old.tf", Line: 8},
+			},
+			{
+				RuleID:   "RC014",
+				Severity: types.SeverityNotice,
+				Message:  "Output became sensitive",
+			},
+		},
+	}
+
+	rd := renderRDJSON(t, result)
+
+	if rd.Source.Name != "tfbreak" {
+		t.Errorf("source.name = %q, want tfbreak", rd.Source.Name)
+	}
+	if len(rd.Diagnostics) != 3 {
+		t.Fatalf("expected 3 diagnostics, got %d", len(rd.Diagnostics))
+	}
+
+	d := rd.Diagnostics[0]
+	if d.Severity != "ERROR" || d.Code.Value != "BC001" || d.Code.URL != "https://example.com/rules/BC001" {
+		t.Errorf("unexpected first diagnostic: %+v", d)
+	}
+	if d.Location.Path != "variables.tf" || d.Location.Range.Start.Line != 3 || d.Location.Range.Start.Column != 1 {
+		t.Errorf("unexpected location: %+v", d.Location)
+	}
+	if d.Location.Range.End == nil || d.Location.Range.End.Line != 5 || d.Location.Range.End.Column != 2 {
+		t.Errorf("unexpected range end: %+v", d.Location.Range.End)
+	}
+
+	// Risky changes are warnings and fall back to the old location
+	d = rd.Diagnostics[1]
+	if d.Severity != "WARNING" || d.Location.Path != "old.tf" || d.Location.Range.Start.Line != 8 {
+		t.Errorf("unexpected second diagnostic: %+v", d)
+	}
+	if d.Location.Range.End != nil {
+		t.Errorf("expected no range end without an end line, got %+v", d.Location.Range.End)
+	}
+
+	if rd.Diagnostics[2].Severity != "INFO" {
+		t.Errorf("notice severity = %q, want INFO", rd.Diagnostics[2].Severity)
+	}
+}
+
+func TestRDJSONRenderer_SkipsIgnored(t *testing.T) {
+	result := &types.CheckResult{
+		Findings: []*types.Finding{
+			{RuleID: "BC001", Severity: types.SeverityError, Message: "ignored", Ignored: true},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (&RDJSONRenderer{Compact: true}).Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if got := buf.String(); got != `{"source":{"name":"tfbreak","url":"https://github.com/jokarl/tfbreak-core"},"diagnostics":[]}`+"\n" {
+		t.Errorf("unexpected output: %s", got)
+	}
+}
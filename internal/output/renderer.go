@@ -22,6 +22,13 @@ const (
 	FormatCheckstyle Format = "checkstyle"
 	FormatJUnit      Format = "junit"
 	FormatSARIF      Format = "sarif"
+	FormatNDJSON     Format = "ndjson"
+	FormatRDJSON     Format = "rdjson"
+	FormatGitLab     Format = "gitlab"
+	FormatMarkdown   Format = "markdown"
+
+	// FormatJSONL is the JSON Lines name for the NDJSON format
+	FormatJSONL Format = "jsonl"
 )
 
 // ValidFormats returns all valid output format names
@@ -33,6 +40,11 @@ func ValidFormats() []string {
 		string(FormatCheckstyle),
 		string(FormatJUnit),
 		string(FormatSARIF),
+		string(FormatNDJSON),
+		string(FormatRDJSON),
+		string(FormatGitLab),
+		string(FormatMarkdown),
+		string(FormatJSONL),
 	}
 }
 
@@ -46,11 +58,80 @@ func IsValidFormat(format string) bool {
 	return false
 }
 
+// Extension returns the file extension (without dot) used when writing
+// this format to a file
+func (f Format) Extension() string {
+	switch f {
+	case FormatJSON, FormatRDJSON, FormatGitLab:
+		return "json"
+	case FormatCheckstyle, FormatJUnit:
+		return "xml"
+	case FormatSARIF:
+		return "sarif"
+	case FormatNDJSON:
+		return "ndjson"
+	case FormatJSONL:
+		return "jsonl"
+	case FormatMarkdown:
+		return "md"
+	default:
+		return "txt"
+	}
+}
+
+// Options configures renderer behavior beyond the output format
+type Options struct {
+	// ColorEnabled enables ANSI colors in human-readable formats
+	ColorEnabled bool
+
+	// Verbose includes additional finding details (e.g., rename confidence)
+	Verbose bool
+
+	// IncludeRemediation shows the help URL of findings that have no
+	// remediation text, such as plugin findings, in text output
+	IncludeRemediation bool
+
+	// ShowIDs shows the short ID of each finding in text and Markdown
+	// output. See types.Finding.ShortID.
+	ShowIDs bool
+
+	// SourceRoots makes SARIF, GitLab, and Markdown locations relative to
+	// the repository root. See SARIFRenderer.SourceRoots.
+	SourceRoots []string
+
+	// CompactJSON writes JSON, SARIF, rdjson, and GitLab documents on a single
+	// line instead of indenting them. NDJSON is always written one object per
+	// line.
+	CompactJSON bool
+
+	// SARIFCategory sets the SARIF run's analysis category.
+	// See SARIFRenderer.Category.
+	SARIFCategory string
+
+	// HideIgnored leaves ignored findings out of the rendered output. The
+	// summary still counts them, and the result is unaffected.
+	HideIgnored bool
+}
+
 // NewRenderer creates a renderer for the given format
 func NewRenderer(format Format, colorEnabled bool) Renderer {
+	return NewRendererWithOptions(format, Options{ColorEnabled: colorEnabled})
+}
+
+// NewRendererWithOptions creates a renderer for the given format with additional options
+func NewRendererWithOptions(format Format, opts Options) Renderer {
+	renderer := newRenderer(format, opts)
+	if opts.HideIgnored {
+		return &hideIgnoredRenderer{Renderer: renderer}
+	}
+	return renderer
+}
+
+// newRenderer creates the renderer for format
+func newRenderer(format Format, opts Options) Renderer {
 	switch format {
 	case FormatJSON:
-		return &JSONRenderer{}
+		return &JSONRenderer{Compact: opts.CompactJSON}
 	case FormatCompact:
 		return &CompactRenderer{}
 	case FormatCheckstyle:
@@ -58,8 +139,34 @@ func NewRenderer(format Format, colorEnabled bool) Renderer {
 	case FormatJUnit:
 		return &JUnitRenderer{}
 	case FormatSARIF:
-		return &SARIFRenderer{}
+		return &SARIFRenderer{SourceRoots: opts.SourceRoots, Compact: opts.CompactJSON, Category: opts.SARIFCategory}
+	case FormatNDJSON, FormatJSONL:
+		return &NDJSONRenderer{}
+	case FormatRDJSON:
+		return &RDJSONRenderer{Compact: opts.CompactJSON}
+	case FormatGitLab:
+		return &GitLabRenderer{SourceRoots: opts.SourceRoots, Compact: opts.CompactJSON}
+	case FormatMarkdown:
+		return &MarkdownRenderer{ShowIDs: opts.ShowIDs, SourceRoots: opts.SourceRoots}
 	default:
-		return &TextRenderer{ColorEnabled: colorEnabled}
+		return &TextRenderer{ColorEnabled: opts.ColorEnabled, Verbose: opts.Verbose, IncludeRemediation: opts.IncludeRemediation, ShowIDs: opts.ShowIDs}
+	}
+}
+
+// hideIgnoredRenderer renders a result without its ignored findings. The
+// summary is passed through unchanged so ignored findings are still counted.
+type hideIgnoredRenderer struct {
+	Renderer
+}
+
+// Render writes the result with ignored findings removed
+func (r *hideIgnoredRenderer) Render(w io.Writer, result *types.CheckResult) error {
+	visible := *result
+	visible.Findings = make([]*types.Finding, 0, len(result.Findings))
+	for _, f := range result.Findings {
+		if !f.Ignored {
+			visible.Findings = append(visible.Findings, f)
+		}
 	}
+	return r.Renderer.Render(w, &visible)
 }
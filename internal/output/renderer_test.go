@@ -1,7 +1,13 @@
 package output
 
 import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
 )
 
 func TestNewRenderer(t *testing.T) {
@@ -15,6 +21,11 @@ func TestNewRenderer(t *testing.T) {
 		{FormatCheckstyle, "*output.CheckstyleRenderer"},
 		{FormatJUnit, "*output.JUnitRenderer"},
 		{FormatSARIF, "*output.SARIFRenderer"},
+		{FormatNDJSON, "*output.NDJSONRenderer"},
+		{FormatRDJSON, "*output.RDJSONRenderer"},
+		{FormatGitLab, "*output.GitLabRenderer"},
+		{FormatMarkdown, "*output.MarkdownRenderer"},
+		{FormatJSONL, "*output.NDJSONRenderer"},
 		{"unknown", "*output.TextRenderer"}, // Default
 		{"", "*output.TextRenderer"},        // Empty defaults to text
 	}
@@ -33,7 +44,7 @@ func TestNewRenderer(t *testing.T) {
 func TestValidFormats(t *testing.T) {
 	formats := ValidFormats()
 
-	expected := []string{"text", "json", "compact", "checkstyle", "junit", "sarif"}
+	expected := []string{"text", "json", "compact", "checkstyle", "junit", "sarif", "ndjson", "rdjson", "gitlab", "markdown", "jsonl"}
 	if len(formats) != len(expected) {
 		t.Errorf("ValidFormats() returned %d formats, want %d", len(formats), len(expected))
 	}
@@ -63,6 +74,11 @@ func TestIsValidFormat(t *testing.T) {
 		{"checkstyle", true},
 		{"junit", true},
 		{"sarif", true},
+		{"ndjson", true},
+		{"rdjson", true},
+		{"gitlab", true},
+		{"markdown", true},
+		{"jsonl", true},
 		{"unknown", false},
 		{"", false},
 		{"TEXT", false}, // Case sensitive
@@ -91,7 +107,126 @@ func getTypeName(r Renderer) string {
 		return "*output.JUnitRenderer"
 	case *SARIFRenderer:
 		return "*output.SARIFRenderer"
+	case *NDJSONRenderer:
+		return "*output.NDJSONRenderer"
+	case *RDJSONRenderer:
+		return "*output.RDJSONRenderer"
+	case *GitLabRenderer:
+		return "*output.GitLabRenderer"
+	case *MarkdownRenderer:
+		return "*output.MarkdownRenderer"
 	default:
 		return "unknown"
 	}
 }
+
+func TestFormatExtension(t *testing.T) {
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{FormatText, "txt"},
+		{FormatJSON, "json"},
+		{FormatCompact, "txt"},
+		{FormatCheckstyle, "xml"},
+		{FormatJUnit, "xml"},
+		{FormatSARIF, "sarif"},
+		{FormatNDJSON, "ndjson"},
+		{FormatRDJSON, "json"},
+		{FormatGitLab, "json"},
+		{FormatMarkdown, "md"},
+		{FormatJSONL, "jsonl"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.format.Extension(); got != tt.want {
+			t.Errorf("Format(%q).Extension() = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestNewRendererWithOptions_CompactJSON(t *testing.T) {
+	result := &types.CheckResult{
+		OldPath: "/old",
+		NewPath: "/new",
+		Findings: []*types.Finding{
+			types.NewFinding("BC001", "required-input-added", types.SeverityError, "New required variable \"foo\" has no default").
+				WithNewLocation(&types.FileRange{Filename: "variables.tf", Line: 10}),
+		},
+		Result: "FAIL",
+		FailOn: types.SeverityError,
+	}
+	result.Compute()
+
+	for _, format := range []Format{FormatJSON, FormatSARIF} {
+		t.Run(string(format), func(t *testing.T) {
+			var compact, indented bytes.Buffer
+			if err := NewRendererWithOptions(format, Options{CompactJSON: true}).Render(&compact, result); err != nil {
+				t.Fatalf("Render error: %v", err)
+			}
+			if err := NewRendererWithOptions(format, Options{}).Render(&indented, result); err != nil {
+				t.Fatalf("Render error: %v", err)
+			}
+
+			// Compact output is a single line terminated by the encoder's newline
+			if n := strings.Count(compact.String(), "\n"); n != 1 {
+				t.Errorf("compact output has %d newlines, want 1:\n%s", n, compact.String())
+			}
+			if !strings.Contains(indented.String(), "{\n  \"") {
+				t.Errorf("expected indented output, got:\n%s", indented.String())
+			}
+
+			// Both are the same document
+			var a, b any
+			if err := json.Unmarshal(compact.Bytes(), &a); err != nil {
+				t.Fatalf("compact output is not valid JSON: %v", err)
+			}
+			if err := json.Unmarshal(indented.Bytes(), &b); err != nil {
+				t.Fatalf("indented output is not valid JSON: %v", err)
+			}
+			if !reflect.DeepEqual(a, b) {
+				t.Error("compact and indented output differ")
+			}
+		})
+	}
+}
+
+// hideIgnoredTestResult returns a result with one reported and one ignored finding
+func hideIgnoredTestResult() *types.CheckResult {
+	result := &types.CheckResult{
+		OldPath: "/old",
+		NewPath: "/new",
+		Findings: []*types.Finding{
+			types.NewFinding("BC001", "required-input-added", types.SeverityError, "New required variable \"foo\" has no default").
+				WithNewLocation(&types.FileRange{Filename: "variables.tf", Line: 10}),
+			types.NewFinding("BC002", "input-removed", types.SeverityError, "Variable \"bar\" was removed").
+				WithOldLocation(&types.FileRange{Filename: "variables.tf", Line: 20}),
+		},
+		FailOn: types.SeverityError,
+	}
+	result.Findings[1].Ignored = true
+	result.Findings[1].IgnoreReason = "planned removal"
+	result.Compute()
+	return result
+}
+
+func renderHidingIgnored(t *testing.T, format Format, result *types.CheckResult) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := NewRendererWithOptions(format, Options{HideIgnored: true}).Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	return buf.String()
+}
+
+func TestNewRendererWithOptions_HideIgnoredLeavesResultUnchanged(t *testing.T) {
+	result := hideIgnoredTestResult()
+	renderHidingIgnored(t, FormatJSON, result)
+
+	if len(result.Findings) != 2 {
+		t.Errorf("rendering changed the result's findings: got %d, want 2", len(result.Findings))
+	}
+	if result.Result != "FAIL" || result.Summary.Ignored != 1 {
+		t.Errorf("rendering changed the result: %s, %+v", result.Result, result.Summary)
+	}
+}
@@ -1,16 +1,34 @@
 package output
 
 import (
-	"encoding/json"
 	"io"
+	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/jokarl/tfbreak-core/internal/types"
 )
 
 // SARIFRenderer renders output in SARIF (Static Analysis Results Interchange Format) JSON
 // SARIF is a standardized format for static analysis tools, supported by GitHub, Azure DevOps, etc.
-type SARIFRenderer struct{}
+type SARIFRenderer struct {
+	// SourceRoots are directories treated as the repository root. Files
+	// under any of them are emitted as URIs relative to the SRCROOT base
+	// ID, which is recorded as the first root. Additional roots cover
+	// checkouts of the same repository, such as temporary git worktrees.
+	SourceRoots []string
+
+	// Compact writes the log on a single line instead of indenting it
+	Compact bool
+
+	// Category is written as the run's automationDetails.id, so code
+	// scanning keeps runs with different categories apart instead of
+	// replacing one with the other. Empty leaves automationDetails out.
+	Category string
+}
+
+// sarifSrcRoot is the uriBaseId used for repository-relative URIs
+const sarifSrcRoot = "SRCROOT"
 
 // sarifLog is the root SARIF structure (version 2.1.0)
 type sarifLog struct {
@@ -23,6 +41,38 @@ type sarifLog struct {
 type sarifRun struct {
 	Tool    sarifTool     `json:"tool"`
 	Results []sarifResult `json:"results"`
+
+	// OriginalURIBaseIDs maps uriBaseId names to their absolute locations
+	OriginalURIBaseIDs map[string]sarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+
+	// AutomationDetails identifies the run's analysis category
+	AutomationDetails *sarifAutomationDetails `json:"automationDetails,omitempty"`
+
+	// Invocations holds the run's warnings as tool execution notifications
+	Invocations []sarifInvocation `json:"invocations,omitempty"`
+}
+
+// sarifInvocation describes how the tool ran
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+// sarifNotification is a problem the tool ran into that is not a result
+type sarifNotification struct {
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+
+	// Properties holds the warning's "source", and "module" in recursive mode
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// sarifAutomationDetails identifies a run. The id has the form
+// "category/runId"; code scanning treats everything up to the last slash
+// as the category.
+type sarifAutomationDetails struct {
+	ID string `json:"id"`
 }
 
 // sarifTool describes the analysis tool
@@ -58,6 +108,21 @@ type sarifResult struct {
 	Level     string           `json:"level"`
 	Message   sarifMessage     `json:"message"`
 	Locations []sarifLocation  `json:"locations,omitempty"`
+
+	// RelatedLocations are other locations the result refers to
+	RelatedLocations []sarifLocation `json:"relatedLocations,omitempty"`
+
+	// Properties is the SARIF property bag; holds "module" in recursive mode
+	// and "severity" for deprecations, which have no SARIF level of their own
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// setProperty adds a value to the result's property bag
+func (r *sarifResult) setProperty(key, value string) {
+	if r.Properties == nil {
+		r.Properties = make(map[string]string)
+	}
+	r.Properties[key] = value
 }
 
 // sarifMessage is a message with text
@@ -67,6 +132,8 @@ type sarifMessage struct {
 
 // sarifLocation describes where a result was found
 type sarifLocation struct {
+	// ID identifies a related location within its result
+	ID               int                   `json:"id,omitempty"`
 	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
 }
 
@@ -78,7 +145,8 @@ type sarifPhysicalLocation struct {
 
 // sarifArtifactLocation describes the artifact (file)
 type sarifArtifactLocation struct {
-	URI string `json:"uri"`
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
 }
 
 // sarifRegion describes a region within a file
@@ -118,7 +186,7 @@ func (r *SARIFRenderer) Render(w io.Writer, result *types.CheckResult) error {
 			DefaultConfig: sarifDefaultConfig{
 				Level: mapToSARIFLevel(f.Severity),
 			},
-			HelpURI: "https://github.com/jokarl/tfbreak-core/blob/main/docs/rules.md#" + f.RuleID,
+			HelpURI: f.HelpURL,
 		})
 	}
 
@@ -137,39 +205,24 @@ func (r *SARIFRenderer) Render(w io.Writer, result *types.CheckResult) error {
 			},
 		}
 
+		if f.Module != "" {
+			sarifResult.setProperty("module", f.Module)
+		}
+		if f.Severity == types.SeverityDeprecation {
+			sarifResult.setProperty("severity", f.Severity.String())
+		}
+
 		// Add location if available
 		if f.NewLocation != nil {
-			sarifResult.Locations = []sarifLocation{
-				{
-					PhysicalLocation: sarifPhysicalLocation{
-						ArtifactLocation: sarifArtifactLocation{
-							URI: f.NewLocation.Filename,
-						},
-						Region: &sarifRegion{
-							StartLine:   f.NewLocation.Line,
-							StartColumn: f.NewLocation.Column,
-							EndLine:     f.NewLocation.EndLine,
-							EndColumn:   f.NewLocation.EndColumn,
-						},
-					},
-				},
-			}
+			sarifResult.Locations = []sarifLocation{r.location(f.NewLocation)}
 		} else if f.OldLocation != nil {
-			sarifResult.Locations = []sarifLocation{
-				{
-					PhysicalLocation: sarifPhysicalLocation{
-						ArtifactLocation: sarifArtifactLocation{
-							URI: f.OldLocation.Filename,
-						},
-						Region: &sarifRegion{
-							StartLine:   f.OldLocation.Line,
-							StartColumn: f.OldLocation.Column,
-							EndLine:     f.OldLocation.EndLine,
-							EndColumn:   f.OldLocation.EndColumn,
-						},
-					},
-				},
-			}
+			sarifResult.Locations = []sarifLocation{r.location(f.OldLocation)}
+		}
+
+		for i := range f.RelatedLocations {
+			related := r.location(&f.RelatedLocations[i])
+			related.ID = i + 1
+			sarifResult.RelatedLocations = append(sarifResult.RelatedLocations, related)
 		}
 
 		results = append(results, sarifResult)
@@ -190,13 +243,124 @@ func (r *SARIFRenderer) Render(w io.Writer, result *types.CheckResult) error {
 					},
 				},
 				Results: results,
+
+				OriginalURIBaseIDs: r.originalURIBaseIDs(),
+				AutomationDetails:  r.automationDetails(),
+				Invocations:        r.invocations(result),
+			},
+		},
+	}
+
+	return newJSONEncoder(w, r.Compact).Encode(log)
+}
+
+// invocations records warnings as notifications of a single invocation,
+// which did not succeed if it timed out, or returns nil if there are none
+func (r *SARIFRenderer) invocations(result *types.CheckResult) []sarifInvocation {
+	if len(result.Warnings) == 0 {
+		return nil
+	}
+
+	invocation := sarifInvocation{ExecutionSuccessful: !result.TimedOut}
+	for _, w := range result.Warnings {
+		notification := sarifNotification{
+			Level:      "warning",
+			Message:    sarifMessage{Text: w.Message},
+			Properties: map[string]string{"source": w.Source},
+		}
+		if w.Module != "" {
+			notification.Properties["module"] = w.Module
+		}
+		if w.Location != nil {
+			location := r.location(w.Location)
+			// A warning about a whole file or directory has no region
+			if w.Location.Line == 0 {
+				location.PhysicalLocation.Region = nil
+			}
+			notification.Locations = []sarifLocation{location}
+		}
+		invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, notification)
+	}
+	return []sarifInvocation{invocation}
+}
+
+// location builds a SARIF location for a file range
+func (r *SARIFRenderer) location(loc *types.FileRange) sarifLocation {
+	artifact := sarifArtifactLocation{URI: loc.Filename}
+	if rel, ok := r.relativeURI(loc.Filename); ok {
+		artifact = sarifArtifactLocation{URI: rel, URIBaseID: sarifSrcRoot}
+	}
+
+	return sarifLocation{
+		PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: artifact,
+			Region: &sarifRegion{
+				StartLine:   loc.Line,
+				StartColumn: loc.Column,
+				EndLine:     loc.EndLine,
+				EndColumn:   loc.EndColumn,
 			},
 		},
 	}
+}
+
+// relativeURI returns filename relative to the first source root containing
+// it, as a forward-slash URI
+func (r *SARIFRenderer) relativeURI(filename string) (string, bool) {
+	return relativePath(r.SourceRoots, filename)
+}
+
+// relativePath returns filename relative to the first of roots containing
+// it, as a forward-slash path. The symlink-resolved path is tried as well
+// since git reports resolved roots.
+func relativePath(roots []string, filename string) (string, bool) {
+	if len(roots) == 0 || !filepath.IsAbs(filename) {
+		return "", false
+	}
+
+	candidates := []string{filename}
+	if resolved, err := filepath.EvalSymlinks(filename); err == nil && resolved != filename {
+		candidates = append(candidates, resolved)
+	}
+
+	for _, root := range roots {
+		for _, path := range candidates {
+			rel, err := filepath.Rel(root, path)
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				continue
+			}
+			return filepath.ToSlash(rel), true
+		}
+	}
+	return "", false
+}
 
-	encoder := json.NewEncoder(w)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(log)
+// automationDetails returns the run's automationDetails for Category, or nil
+// if no category is set. The id ends in a slash, leaving the run ID empty,
+// so the whole category is kept even if it contains slashes.
+func (r *SARIFRenderer) automationDetails() *sarifAutomationDetails {
+	if r.Category == "" {
+		return nil
+	}
+	return &sarifAutomationDetails{ID: strings.TrimSuffix(r.Category, "/") + "/"}
+}
+
+// originalURIBaseIDs returns the SRCROOT base ID for the first source root
+func (r *SARIFRenderer) originalURIBaseIDs() map[string]sarifArtifactLocation {
+	if len(r.SourceRoots) == 0 {
+		return nil
+	}
+
+	uri := filepath.ToSlash(r.SourceRoots[0])
+	if !strings.HasPrefix(uri, "/") {
+		uri = "/" + uri // Windows drive paths
+	}
+	if !strings.HasSuffix(uri, "/") {
+		uri += "/"
+	}
+	return map[string]sarifArtifactLocation{
+		sarifSrcRoot: {URI: "file://" + uri},
+	}
 }
 
 // mapToSARIFLevel maps tfbreak severity to SARIF level
@@ -206,7 +370,7 @@ func mapToSARIFLevel(s types.Severity) string {
 		return "error"
 	case types.SeverityWarning:
 		return "warning"
-	case types.SeverityNotice:
+	case types.SeverityDeprecation, types.SeverityNotice:
 		return "note"
 	default:
 		return "none"
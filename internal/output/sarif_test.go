@@ -3,6 +3,8 @@ package output
 import (
 	"bytes"
 	"encoding/json"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/jokarl/tfbreak-core/internal/types"
@@ -219,6 +221,34 @@ func TestSARIFRenderer_SeverityMapping(t *testing.T) {
 	}
 }
 
+func TestSARIFRenderer_DeprecationProperty(t *testing.T) {
+	result := &types.CheckResult{
+		Findings: []*types.Finding{
+			{RuleID: "RC015", RuleName: "input-deprecated", Severity: types.SeverityDeprecation, Message: "deprecated"},
+			{RuleID: "RC014", RuleName: "output-sensitive-added", Severity: types.SeverityNotice, Message: "notice"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (&SARIFRenderer{}).Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	var sarif sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &sarif); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	// Both are notes; only the deprecation is marked in the property bag
+	results := sarif.Runs[0].Results
+	if results[0].Level != "note" || results[0].Properties["severity"] != "DEPRECATION" {
+		t.Errorf("unexpected deprecation result: %+v", results[0])
+	}
+	if results[1].Properties != nil {
+		t.Errorf("expected no properties on the notice, got %v", results[1].Properties)
+	}
+}
+
 func TestSARIFRenderer_OldLocation(t *testing.T) {
 	result := &types.CheckResult{
 		OldPath: "/old",
@@ -341,6 +371,7 @@ func TestMapToSARIFLevel_AllCases(t *testing.T) {
 	}{
 		{types.SeverityError, "error"},
 		{types.SeverityWarning, "warning"},
+		{types.SeverityDeprecation, "note"},
 		{types.SeverityNotice, "note"},
 		{types.Severity(-1), "none"},  // Unknown defaults to none
 		{types.Severity(99), "none"},  // Unknown defaults to none
@@ -422,3 +453,337 @@ func TestSARIFRenderer_DeterministicRuleOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestSARIFRenderer_ModuleProperty(t *testing.T) {
+	result := &types.CheckResult{
+		Findings: []*types.Finding{
+			{
+				RuleID:   "BC002",
+				RuleName: "input-removed",
+				Severity: types.SeverityError,
+				Message:  "Variable \"region\" was removed",
+				Module:   "modules/vpc",
+			},
+			{
+				RuleID:   "BC002",
+				RuleName: "input-removed",
+				Severity: types.SeverityError,
+				Message:  "Variable \"name\" was removed",
+			},
+		},
+	}
+
+	renderer := &SARIFRenderer{}
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	var sarif sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &sarif); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	results := sarif.Runs[0].Results
+	if got := results[0].Properties["module"]; got != "modules/vpc" {
+		t.Errorf("properties.module = %q, want %q", got, "modules/vpc")
+	}
+	if results[1].Properties != nil {
+		t.Errorf("expected no properties for a single-module finding, got %v", results[1].Properties)
+	}
+}
+
+func TestSARIFRenderer_Notifications(t *testing.T) {
+	result := &types.CheckResult{
+		Warnings: []types.ResultWarning{
+			{Source: types.WarningSourceLoader, Message: "skipped module modules/new: not found in old directory", Module: "modules/new"},
+			{Source: types.WarningSourceLoader, Message: "no Terraform files", Location: &types.FileRange{Filename: "/repo/empty"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (&SARIFRenderer{}).Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	var sarif sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &sarif); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	invocations := sarif.Runs[0].Invocations
+	if len(invocations) != 1 || !invocations[0].ExecutionSuccessful {
+		t.Fatalf("expected one successful invocation, got %+v", invocations)
+	}
+	notifications := invocations[0].ToolExecutionNotifications
+	if len(notifications) != 2 {
+		t.Fatalf("expected 2 notifications, got %d", len(notifications))
+	}
+
+	first := notifications[0]
+	if first.Level != "warning" || first.Message.Text != "skipped module modules/new: not found in old directory" {
+		t.Errorf("unexpected first notification: %+v", first)
+	}
+	if first.Properties["source"] != "loader" || first.Properties["module"] != "modules/new" {
+		t.Errorf("properties = %v, want source and module", first.Properties)
+	}
+	if first.Locations != nil {
+		t.Errorf("expected no locations, got %+v", first.Locations)
+	}
+
+	// A warning about a directory has a location without a region
+	second := notifications[1]
+	if len(second.Locations) != 1 || second.Locations[0].PhysicalLocation.ArtifactLocation.URI != "/repo/empty" {
+		t.Fatalf("unexpected locations: %+v", second.Locations)
+	}
+	if second.Locations[0].PhysicalLocation.Region != nil {
+		t.Errorf("expected no region, got %+v", second.Locations[0].PhysicalLocation.Region)
+	}
+}
+
+func TestSARIFRenderer_NoNotifications(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (&SARIFRenderer{}).Render(&buf, &types.CheckResult{}); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if strings.Contains(buf.String(), "invocations") {
+		t.Errorf("expected no invocations without warnings, got %s", buf.String())
+	}
+}
+
+func TestSARIFRenderer_TimedOut(t *testing.T) {
+	result := &types.CheckResult{TimedOut: true}
+	result.AddWarning(types.WarningSourceTimeout, "--timeout of 1m0s expired after checking 1 of 2 modules")
+
+	var buf bytes.Buffer
+	if err := (&SARIFRenderer{}).Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	var sarif sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &sarif); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	invocations := sarif.Runs[0].Invocations
+	if len(invocations) != 1 || invocations[0].ExecutionSuccessful {
+		t.Fatalf("expected one unsuccessful invocation, got %+v", invocations)
+	}
+	if notifications := invocations[0].ToolExecutionNotifications; len(notifications) != 1 || notifications[0].Properties["source"] != "timeout" {
+		t.Errorf("unexpected notifications: %+v", notifications)
+	}
+}
+
+func TestSARIFRenderer_AutomationDetails(t *testing.T) {
+	tests := []struct {
+		category string
+		wantID   string
+	}{
+		{"", ""},
+		{"tfbreak", "tfbreak/"},
+		{"tfbreak/modules/vpc", "tfbreak/modules/vpc/"},
+		{"tfbreak/", "tfbreak/"},
+	}
+
+	for _, tt := range tests {
+		renderer := &SARIFRenderer{Category: tt.category}
+		var buf bytes.Buffer
+		if err := renderer.Render(&buf, &types.CheckResult{}); err != nil {
+			t.Fatalf("Render error: %v", err)
+		}
+
+		var sarif sarifLog
+		if err := json.Unmarshal(buf.Bytes(), &sarif); err != nil {
+			t.Fatalf("Invalid JSON: %v", err)
+		}
+
+		details := sarif.Runs[0].AutomationDetails
+		if tt.wantID == "" {
+			if details != nil {
+				t.Errorf("Category %q: expected no automationDetails, got %+v", tt.category, details)
+			}
+			continue
+		}
+		if details == nil || details.ID != tt.wantID {
+			t.Errorf("Category %q: automationDetails = %+v, want id %q", tt.category, details, tt.wantID)
+		}
+	}
+}
+
+func TestSARIFRenderer_HelpURI(t *testing.T) {
+	result := &types.CheckResult{
+		Findings: []*types.Finding{
+			{
+				RuleID:   "BC002",
+				RuleName: "input-removed",
+				Severity: types.SeverityError,
+				Message:  "Variable \"region\" was removed",
+				HelpURL:  "https://docs.example.com/rules/BC002",
+			},
+			{
+				RuleID:   "PLUGIN001",
+				RuleName: "plugin-rule",
+				Severity: types.SeverityWarning,
+				Message:  "Plugin finding",
+			},
+		},
+	}
+
+	renderer := &SARIFRenderer{}
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	var sarif sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &sarif); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	rules := sarif.Runs[0].Tool.Driver.Rules
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules))
+	}
+	if rules[0].HelpURI != "https://docs.example.com/rules/BC002" {
+		t.Errorf("BC002 helpUri = %q, want the finding's help URL", rules[0].HelpURI)
+	}
+	if rules[1].HelpURI != "" {
+		t.Errorf("plugin rule helpUri = %q, want empty", rules[1].HelpURI)
+	}
+}
+
+func TestSARIFRenderer_SourceRoots(t *testing.T) {
+	root := t.TempDir()
+	worktree := t.TempDir()
+
+	result := &types.CheckResult{
+		Findings: []*types.Finding{
+			{
+				RuleID:      "BC001",
+				RuleName:    "required-input-added",
+				Severity:    types.SeverityError,
+				Message:     "New required variable \"foo\" has no default",
+				NewLocation: &types.FileRange{Filename: filepath.Join(root, "modules", "vpc", "variables.tf"), Line: 3},
+			},
+			{
+				RuleID:      "BC002",
+				RuleName:    "input-removed",
+				Severity:    types.SeverityError,
+				Message:     "Variable \"bar\" was removed",
+				OldLocation: &types.FileRange{Filename: filepath.Join(worktree, "modules", "vpc", "variables.tf"), Line: 7},
+			},
+			{
+				RuleID:      "BC009",
+				RuleName:    "output-removed",
+				Severity:    types.SeverityError,
+				Message:     "Output \"baz\" was removed",
+				OldLocation: &types.FileRange{Filename: "/elsewhere/outputs.tf", Line: 1},
+			},
+		},
+	}
+
+	renderer := &SARIFRenderer{SourceRoots: []string{root, worktree}}
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	var sarif sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &sarif); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	run := sarif.Runs[0]
+	base, ok := run.OriginalURIBaseIDs["SRCROOT"]
+	if !ok {
+		t.Fatal("missing SRCROOT in originalUriBaseIds")
+	}
+	if !strings.HasPrefix(base.URI, "file://") || !strings.HasSuffix(base.URI, "/") {
+		t.Errorf("SRCROOT uri = %q, want a file:// URI ending in /", base.URI)
+	}
+
+	for i, want := range []sarifArtifactLocation{
+		{URI: "modules/vpc/variables.tf", URIBaseID: "SRCROOT"},
+		{URI: "modules/vpc/variables.tf", URIBaseID: "SRCROOT"},
+		{URI: "/elsewhere/outputs.tf"},
+	} {
+		got := run.Results[i].Locations[0].PhysicalLocation.ArtifactLocation
+		if got != want {
+			t.Errorf("result %d artifactLocation = %+v, want %+v", i, got, want)
+		}
+	}
+}
+
+func TestSARIFRenderer_NoSourceRoots(t *testing.T) {
+	result := &types.CheckResult{
+		Findings: []*types.Finding{
+			{
+				RuleID:      "BC001",
+				RuleName:    "required-input-added",
+				Severity:    types.SeverityError,
+				Message:     "New required variable \"foo\" has no default",
+				NewLocation: &types.FileRange{Filename: "/tmp/new/variables.tf", Line: 3},
+			},
+		},
+	}
+
+	renderer := &SARIFRenderer{}
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	if strings.Contains(buf.String(), "uriBaseId") || strings.Contains(buf.String(), "originalUriBaseIds") {
+		t.Errorf("expected no uriBaseId without source roots:\n%s", buf.String())
+	}
+}
+
+func TestSARIFRenderer_RelatedLocations(t *testing.T) {
+	oldLoc := &types.FileRange{Filename: "/old/variables.tf", Line: 3, Column: 1}
+	newLoc := &types.FileRange{Filename: "/new/variables.tf", Line: 7, Column: 1}
+	result := &types.CheckResult{
+		OldPath: "/old",
+		NewPath: "/new",
+		Findings: []*types.Finding{
+			types.NewFinding("BC003", "input-renamed", types.SeverityError, `Variable "name" was renamed to "bucket_name"`).
+				WithOldLocation(oldLoc).
+				WithNewLocation(newLoc).
+				WithRelatedLocation(oldLoc),
+		},
+		Result: "FAIL",
+		FailOn: types.SeverityError,
+	}
+
+	renderer := &SARIFRenderer{SourceRoots: []string{"/old", "/new"}}
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+
+	var sarif sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &sarif); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	res := sarif.Runs[0].Results[0]
+	if len(res.Locations) != 1 || res.Locations[0].PhysicalLocation.Region.StartLine != 7 {
+		t.Errorf("expected the new declaration as the primary location, got %+v", res.Locations)
+	}
+	if len(res.RelatedLocations) != 1 {
+		t.Fatalf("expected 1 related location, got %d", len(res.RelatedLocations))
+	}
+
+	related := res.RelatedLocations[0]
+	if related.ID != 1 {
+		t.Errorf("expected related location id 1, got %d", related.ID)
+	}
+	if related.PhysicalLocation.ArtifactLocation.URI != "variables.tf" {
+		t.Errorf("expected related URI variables.tf, got %s", related.PhysicalLocation.ArtifactLocation.URI)
+	}
+	if related.PhysicalLocation.Region.StartLine != 3 {
+		t.Errorf("expected related location on line 3, got %d", related.PhysicalLocation.Region.StartLine)
+	}
+	if !strings.Contains(buf.String(), `"relatedLocations"`) {
+		t.Error("expected relatedLocations in SARIF output")
+	}
+}
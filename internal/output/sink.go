@@ -0,0 +1,89 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SinkOpener opens the destination named by the part of an output URI after
+// "scheme://"
+type SinkOpener func(target string) (io.WriteCloser, error)
+
+var (
+	sinksMu sync.RWMutex
+	sinks   = map[string]SinkOpener{
+		"file": openFileSink,
+	}
+)
+
+// RegisterSink makes an output destination available as scheme://target.
+// Registering a scheme again replaces its opener.
+func RegisterSink(scheme string, open SinkOpener) {
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+	sinks[scheme] = open
+}
+
+// SinkSchemes returns the registered output URI schemes, sorted
+func SinkSchemes() []string {
+	sinksMu.RLock()
+	defer sinksMu.RUnlock()
+
+	schemes := make([]string, 0, len(sinks))
+	for scheme := range sinks {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+	return schemes
+}
+
+// OpenSink resolves an output destination to a writer. An empty destination
+// or "-" is stdout, "scheme://target" is opened by the sink registered for
+// the scheme, and anything else is a file path. Closing the returned writer
+// closes the destination, except for stdout, which stays open.
+func OpenSink(dest string) (io.WriteCloser, error) {
+	if dest == "" || dest == "-" {
+		return stdoutSink{os.Stdout}, nil
+	}
+
+	scheme, target, ok := strings.Cut(dest, "://")
+	if !ok {
+		return openFileSink(dest)
+	}
+
+	sinksMu.RLock()
+	open, exists := sinks[scheme]
+	sinksMu.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("unsupported output scheme %q (supported: %s, or - for stdout)", scheme, strings.Join(SinkSchemes(), ", "))
+	}
+	return open(target)
+}
+
+// openFileSink creates or truncates the file at path. For file:// URIs, the
+// path is everything after the scheme, so file:///tmp/report.json is an
+// absolute path and file://report.json is relative.
+func openFileSink(path string) (io.WriteCloser, error) {
+	if path == "" {
+		return nil, fmt.Errorf("output file path is empty")
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	return f, nil
+}
+
+// stdoutSink writes to stdout without closing it. It embeds the *os.File so
+// callers can still check whether output goes to a terminal.
+type stdoutSink struct {
+	*os.File
+}
+
+func (stdoutSink) Close() error {
+	return nil
+}
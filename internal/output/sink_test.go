@@ -0,0 +1,129 @@
+package output
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOpenSink_Stdout(t *testing.T) {
+	for _, dest := range []string{"", "-"} {
+		w, err := OpenSink(dest)
+		if err != nil {
+			t.Fatalf("OpenSink(%q) error = %v", dest, err)
+		}
+		s, ok := w.(stdoutSink)
+		if !ok || s.File != os.Stdout {
+			t.Errorf("OpenSink(%q) = %T, want stdout", dest, w)
+		}
+		if err := w.Close(); err != nil {
+			t.Errorf("Close() error = %v", err)
+		}
+	}
+
+	// Closing the sink must leave stdout usable
+	if _, err := os.Stdout.Stat(); err != nil {
+		t.Errorf("stdout closed by sink: %v", err)
+	}
+}
+
+func TestOpenSink_File(t *testing.T) {
+	dir := t.TempDir()
+	tests := map[string]string{
+		"plain path": filepath.Join(dir, "plain.json"),
+		"file URI":   "file://" + filepath.Join(dir, "uri.json"),
+	}
+
+	for name, dest := range tests {
+		t.Run(name, func(t *testing.T) {
+			w, err := OpenSink(dest)
+			if err != nil {
+				t.Fatalf("OpenSink(%q) error = %v", dest, err)
+			}
+			if _, err := io.WriteString(w, "report"); err != nil {
+				t.Fatalf("write error = %v", err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			data, err := os.ReadFile(strings.TrimPrefix(dest, "file://"))
+			if err != nil {
+				t.Fatalf("failed to read output: %v", err)
+			}
+			if string(data) != "report" {
+				t.Errorf("file content = %q, want %q", data, "report")
+			}
+		})
+	}
+}
+
+func TestOpenSink_FileRelative(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	w, err := OpenSink("file://report.txt")
+	if err != nil {
+		t.Fatalf("OpenSink() error = %v", err)
+	}
+	w.Close()
+
+	if _, err := os.Stat("report.txt"); err != nil {
+		t.Errorf("expected report.txt in the working directory: %v", err)
+	}
+}
+
+func TestOpenSink_Errors(t *testing.T) {
+	for _, dest := range []string{"syslog://local0", "file://", filepath.Join(t.TempDir(), "missing", "report.json")} {
+		if w, err := OpenSink(dest); err == nil {
+			w.Close()
+			t.Errorf("OpenSink(%q) expected error", dest)
+		}
+	}
+
+	_, err := OpenSink("unknown://x")
+	if err == nil || !strings.Contains(err.Error(), "file") {
+		t.Errorf("expected error listing supported schemes, got %v", err)
+	}
+}
+
+// bufferSink is a WriteCloser for TestRegisterSink
+type bufferSink struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *bufferSink) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestRegisterSink(t *testing.T) {
+	var opened string
+	sink := &bufferSink{}
+	RegisterSink("test", func(target string) (io.WriteCloser, error) {
+		opened = target
+		return sink, nil
+	})
+	defer func() {
+		sinksMu.Lock()
+		delete(sinks, "test")
+		sinksMu.Unlock()
+	}()
+
+	w, err := OpenSink("test://findings")
+	if err != nil {
+		t.Fatalf("OpenSink() error = %v", err)
+	}
+	io.WriteString(w, "hello")
+	w.Close()
+
+	if opened != "findings" {
+		t.Errorf("opener target = %q, want %q", opened, "findings")
+	}
+	if sink.String() != "hello" || !sink.closed {
+		t.Errorf("sink content = %q, closed = %v", sink.String(), sink.closed)
+	}
+}
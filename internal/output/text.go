@@ -12,6 +12,16 @@ import (
 // TextRenderer renders output in human-readable text format
 type TextRenderer struct {
 	ColorEnabled bool
+
+	// Verbose renders additional finding details such as rename confidence
+	Verbose bool
+
+	// IncludeRemediation renders the help URL of findings without
+	// remediation text. Findings with remediation text always show it.
+	IncludeRemediation bool
+
+	// ShowIDs renders the short ID of each finding after its rule name
+	ShowIDs bool
 }
 
 // Render writes the check result in text format
@@ -44,7 +54,11 @@ func (r *TextRenderer) Render(w io.Writer, result *types.CheckResult) error {
 func (r *TextRenderer) renderFinding(w io.Writer, f *types.Finding) {
 	// Severity with color
 	severityStr := r.colorSeverity(f.Severity)
-	fmt.Fprintf(w, "%s  %s  %s\n", severityStr, f.RuleID, f.RuleName)
+	if r.ShowIDs {
+		fmt.Fprintf(w, "%s  %s  %s  [%s]\n", severityStr, f.RuleID, f.RuleName, f.ShortID())
+	} else {
+		fmt.Fprintf(w, "%s  %s  %s\n", severityStr, f.RuleID, f.RuleName)
+	}
 
 	// Location
 	if f.NewLocation != nil {
@@ -56,6 +70,13 @@ func (r *TextRenderer) renderFinding(w io.Writer, f *types.Finding) {
 	// Message
 	fmt.Fprintf(w, "  %s\n", f.Message)
 
+	// Rename confidence (verbose only)
+	if r.Verbose {
+		if confidence, ok := f.Metadata["confidence"]; ok {
+			fmt.Fprintf(w, "  Confidence: %s\n", confidence)
+		}
+	}
+
 	// Ignored status
 	if f.Ignored {
 		if f.IgnoreReason != "" {
@@ -70,6 +91,13 @@ func (r *TextRenderer) renderFinding(w io.Writer, f *types.Finding) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, "  Remediation:")
 		r.renderIndented(w, f.Remediation, "    ")
+		if f.HelpURL != "" {
+			fmt.Fprintf(w, "    (see %s)\n", f.HelpURL)
+		}
+	} else if r.IncludeRemediation && f.HelpURL != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "  Remediation:")
+		fmt.Fprintf(w, "    (see %s)\n", f.HelpURL)
 	}
 
 	fmt.Fprintln(w)
@@ -96,9 +124,15 @@ func (r *TextRenderer) renderSummary(w io.Writer, result *types.CheckResult) {
 			parts = append(parts, fmt.Sprintf("%d warning", result.Summary.Warning))
 		}
 	}
+	if result.Summary.Deprecation > 0 {
+		parts = append(parts, fmt.Sprintf("%d deprecation", result.Summary.Deprecation))
+	}
 	if result.Summary.Notice > 0 {
 		parts = append(parts, fmt.Sprintf("%d notice", result.Summary.Notice))
 	}
+	if result.Summary.Ignored > 0 && result.Summary.Warning == 0 {
+		parts = append(parts, fmt.Sprintf("%d ignored", result.Summary.Ignored))
+	}
 
 	if len(parts) == 0 {
 		parts = append(parts, "no issues found")
@@ -136,6 +170,8 @@ func (r *TextRenderer) colorSeverity(s types.Severity) string {
 		return color.New(color.FgRed, color.Bold).Sprint(str)
 	case types.SeverityWarning:
 		return color.New(color.FgYellow).Sprint(str)
+	case types.SeverityDeprecation:
+		return color.New(color.FgMagenta).Sprint(str)
 	case types.SeverityNotice:
 		return color.New(color.FgCyan).Sprint(str)
 	default:
@@ -87,6 +87,7 @@ func TestTextRenderer_WithRemediation(t *testing.T) {
 					Line:     10,
 				},
 				Remediation: "To fix this issue:\n1. Add a default value\n2. Update callers",
+				HelpURL:     "https://docs.example.com/rules/BC001",
 			},
 		},
 		Summary: types.Summary{
@@ -115,6 +116,11 @@ func TestTextRenderer_WithRemediation(t *testing.T) {
 	if !strings.Contains(output, "Add a default value") {
 		t.Error("output should contain remediation text")
 	}
+
+	// Check help URL follows the remediation
+	if !strings.Contains(output, "(see https://docs.example.com/rules/BC001)") {
+		t.Error("output should contain the rule's help URL")
+	}
 }
 
 func TestTextRenderer_NoRemediation(t *testing.T) {
@@ -157,6 +163,41 @@ func TestTextRenderer_NoRemediation(t *testing.T) {
 	}
 }
 
+func TestTextRenderer_IncludeRemediation_HelpURLOnly(t *testing.T) {
+	result := &types.CheckResult{
+		OldPath: "/old",
+		NewPath: "/new",
+		Findings: []*types.Finding{
+			{
+				RuleID:   "azurerm/azurerm_sku_changed",
+				RuleName: "azurerm_sku_changed",
+				Severity: types.SeverityWarning,
+				Message:  "SKU changed",
+				HelpURL:  "https://example.com/rules/azurerm_sku_changed",
+			},
+		},
+		Result: "PASS",
+		FailOn: types.SeverityError,
+	}
+
+	// Plugin findings carry a link but no remediation text
+	var buf bytes.Buffer
+	if err := (&TextRenderer{IncludeRemediation: true}).Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "  Remediation:\n    (see https://example.com/rules/azurerm_sku_changed)\n") {
+		t.Errorf("output should contain the help URL:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := (&TextRenderer{}).Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if strings.Contains(buf.String(), "(see ") {
+		t.Errorf("output should not contain the help URL without IncludeRemediation:\n%s", buf.String())
+	}
+}
+
 func TestTextRendererPass(t *testing.T) {
 	result := &types.CheckResult{
 		OldPath:  "/old",
@@ -183,3 +224,123 @@ func TestTextRendererPass(t *testing.T) {
 		t.Error("output should indicate no issues")
 	}
 }
+
+func TestTextRenderer_VerboseConfidence(t *testing.T) {
+	result := &types.CheckResult{
+		OldPath: "/old",
+		NewPath: "/new",
+		Findings: []*types.Finding{
+			{
+				RuleID:   "BC003",
+				RuleName: "input-renamed",
+				Severity: types.SeverityError,
+				Message:  "Variable \"api_key\" was renamed to \"api_key_v2\"",
+				Metadata: map[string]string{"confidence": "0.70"},
+			},
+		},
+		Result: "FAIL",
+		FailOn: types.SeverityError,
+	}
+
+	var buf bytes.Buffer
+	if err := (&TextRenderer{}).Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if strings.Contains(buf.String(), "Confidence:") {
+		t.Error("non-verbose output should not contain confidence")
+	}
+
+	buf.Reset()
+	if err := (&TextRenderer{Verbose: true}).Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Confidence: 0.70") {
+		t.Errorf("verbose output should contain confidence, got:\n%s", buf.String())
+	}
+}
+
+func TestTextRenderer_HideIgnored(t *testing.T) {
+	output := renderHidingIgnored(t, FormatText, hideIgnoredTestResult())
+
+	if strings.Contains(output, "BC002") || strings.Contains(output, "[IGNORED]") {
+		t.Errorf("expected ignored finding to be hidden, got:\n%s", output)
+	}
+	if !strings.Contains(output, "BC001") {
+		t.Errorf("expected reported finding in output, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Summary: 1 error, 1 ignored") {
+		t.Errorf("expected summary to count the hidden finding, got:\n%s", output)
+	}
+}
+
+func TestTextRenderer_Deprecation(t *testing.T) {
+	result := &types.CheckResult{
+		OldPath: "/old",
+		NewPath: "/new",
+		Findings: []*types.Finding{
+			types.NewFinding("RC015", "input-deprecated", types.SeverityDeprecation, `Variable "size" is now marked deprecated`),
+			types.NewFinding("RC014", "output-sensitive-added", types.SeverityNotice, `Output "token" is now sensitive`),
+		},
+		FailOn: types.SeverityError,
+	}
+	result.Compute()
+
+	var buf bytes.Buffer
+	if err := (&TextRenderer{}).Render(&buf, result); err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	output := buf.String()
+
+	if !strings.Contains(output, "DEPRECATION  RC015  input-deprecated") {
+		t.Errorf("expected deprecation finding, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Summary: 1 deprecation, 1 notice") {
+		t.Errorf("expected deprecations counted apart from notices, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Result: PASS") {
+		t.Errorf("expected deprecations to pass the default threshold, got:\n%s", output)
+	}
+}
+
+func TestTextRenderer_ShowIDs(t *testing.T) {
+	newResult := func(dir string) *types.CheckResult {
+		return &types.CheckResult{
+			OldPath: dir + "/old",
+			NewPath: dir + "/new",
+			Findings: []*types.Finding{
+				types.NewFinding("BC002", "input-removed", types.SeverityError, `Variable "foo" was removed`).
+					WithOldLocation(&types.FileRange{Filename: dir + "/old/variables.tf", Line: 3}),
+				types.NewFinding("BC002", "input-removed", types.SeverityError, `Variable "bar" was removed`).
+					WithOldLocation(&types.FileRange{Filename: dir + "/old/variables.tf", Line: 8}),
+			},
+			Result: "FAIL",
+			FailOn: types.SeverityError,
+		}
+	}
+	render := func(r *TextRenderer, result *types.CheckResult) string {
+		var buf bytes.Buffer
+		if err := r.Render(&buf, result); err != nil {
+			t.Fatalf("Render error: %v", err)
+		}
+		return buf.String()
+	}
+
+	first, rerun := newResult("/tmp/run-1"), newResult("/tmp/run-2")
+	output := render(&TextRenderer{ShowIDs: true}, first)
+	fooID, barID := first.Findings[0].ShortID(), first.Findings[1].ShortID()
+	if fooID == barID {
+		t.Errorf("distinct findings share short ID %q", fooID)
+	}
+	for _, id := range []string{fooID, barID} {
+		if !strings.Contains(output, "ERROR  BC002  input-removed  ["+id+"]\n") {
+			t.Errorf("output should contain short ID %q:\n%s", id, output)
+		}
+	}
+	if rerunOutput := render(&TextRenderer{ShowIDs: true}, rerun); !strings.Contains(rerunOutput, "["+fooID+"]") || !strings.Contains(rerunOutput, "["+barID+"]") {
+		t.Errorf("short IDs should be the same across runs:\n%s", rerunOutput)
+	}
+
+	if output := render(&TextRenderer{}, first); strings.Contains(output, fooID) {
+		t.Errorf("output should not contain short IDs without ShowIDs:\n%s", output)
+	}
+}
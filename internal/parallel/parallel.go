@@ -0,0 +1,47 @@
+// Package parallel runs independent work items on a bounded number of goroutines.
+package parallel
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Limit returns the number of workers for a parallelism setting: n itself
+// if it is positive, or GOMAXPROCS for 0 (auto)
+func Limit(n int) int {
+	if n > 0 {
+		return n
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// ForEach calls fn for each index in [0, count) on at most Limit(n)
+// goroutines and returns once all calls have finished. Callers that need
+// ordered results write them to index i of a slice. With a limit of 1, the
+// calls run sequentially in index order on the calling goroutine.
+func ForEach(n, count int, fn func(i int)) {
+	workers := min(Limit(n), count)
+	if workers <= 1 {
+		for i := 0; i < count; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
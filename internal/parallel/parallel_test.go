@@ -0,0 +1,63 @@
+package parallel
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimit(t *testing.T) {
+	if got := Limit(3); got != 3 {
+		t.Errorf("Limit(3) = %d, want 3", got)
+	}
+	if got, want := Limit(0), runtime.GOMAXPROCS(0); got != want {
+		t.Errorf("Limit(0) = %d, want GOMAXPROCS %d", got, want)
+	}
+}
+
+func TestForEach_CallsEachIndexOnce(t *testing.T) {
+	for _, n := range []int{0, 1, 4, 100} {
+		results := make([]int, 50)
+		ForEach(n, len(results), func(i int) {
+			results[i]++
+		})
+		for i, calls := range results {
+			if calls != 1 {
+				t.Errorf("n=%d: index %d called %d times, want 1", n, i, calls)
+			}
+		}
+	}
+}
+
+func TestForEach_Sequential(t *testing.T) {
+	var order []int
+	ForEach(1, 10, func(i int) {
+		// Appending without a lock is only safe when calls are sequential
+		order = append(order, i)
+	})
+	for i, got := range order {
+		if got != i {
+			t.Fatalf("call %d was for index %d, want in-order calls", i, got)
+		}
+	}
+}
+
+func TestForEach_BoundsConcurrency(t *testing.T) {
+	var running, peak atomic.Int32
+	var mu sync.Mutex
+	ForEach(3, 20, func(int) {
+		cur := running.Add(1)
+		mu.Lock()
+		if cur > peak.Load() {
+			peak.Store(cur)
+		}
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+	})
+	if got := peak.Load(); got > 3 {
+		t.Errorf("peak concurrency = %d, want at most 3", got)
+	}
+}
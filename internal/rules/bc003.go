@@ -81,7 +81,7 @@ func (r *BC003) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
 	// For each removed variable, try to find a matching added required variable
 	for oldName, oldVar := range removedVars {
 		match, similarity, found := FindBestMatch(oldName, addedRequiredVars, threshold)
-		if !found {
+		if !found || !meetsMinConfidence(similarity) {
 			continue
 		}
 
@@ -94,9 +94,11 @@ func (r *BC003) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
 			fmt.Sprintf("Variable %q was renamed to %q", oldName, match),
 		).WithOldLocation(&oldVar.DeclRange).
 			WithNewLocation(&newVar.DeclRange).
+			WithRelatedLocation(&oldVar.DeclRange).
 			WithDetail(fmt.Sprintf("Similarity: %.2f (threshold: %.2f)", similarity, threshold)).
 			WithMetadata("old_name", oldName).
-			WithMetadata("new_name", match)
+			WithMetadata("new_name", match).
+			WithMetadata("confidence", formatConfidence(similarity))
 
 		findings = append(findings, finding)
 
@@ -46,13 +46,13 @@ func TestBC003_RequiredRenameDetected(t *testing.T) {
 
 	old := &types.ModuleSnapshot{
 		Variables: map[string]*types.VariableSignature{
-			"api_key": {Name: "api_key", Default: nil},
+			"api_key": {Name: "api_key", Default: nil, DeclRange: types.FileRange{Filename: "variables.tf", Line: 3}},
 		},
 	}
 
 	new := &types.ModuleSnapshot{
 		Variables: map[string]*types.VariableSignature{
-			"api_key_v2": {Name: "api_key_v2", Default: nil},
+			"api_key_v2": {Name: "api_key_v2", Default: nil, DeclRange: types.FileRange{Filename: "variables.tf", Line: 9}},
 		},
 	}
 
@@ -75,6 +75,12 @@ func TestBC003_RequiredRenameDetected(t *testing.T) {
 	if f.Metadata["new_name"] != "api_key_v2" {
 		t.Errorf("Expected new_name 'api_key_v2', got %s", f.Metadata["new_name"])
 	}
+	if f.NewLocation == nil || f.NewLocation.Line != 9 {
+		t.Errorf("Expected new declaration as the location, got %+v", f.NewLocation)
+	}
+	if len(f.RelatedLocations) != 1 || f.RelatedLocations[0].Line != 3 {
+		t.Errorf("Expected old declaration as a related location, got %+v", f.RelatedLocations)
+	}
 }
 
 func TestBC003_OptionalNewVar_NoMatch(t *testing.T) {
@@ -246,3 +252,51 @@ func TestBC003_RuleMetadata(t *testing.T) {
 		t.Error("Expected non-nil documentation")
 	}
 }
+
+func TestBC003_MinConfidence_Boundary(t *testing.T) {
+	similarity := Similarity("api_key", "api_key_v2")
+
+	tests := []struct {
+		name          string
+		minConfidence float64
+		wantFindings  int
+	}{
+		{"below similarity", similarity - 0.01, 1},
+		{"equal to similarity", similarity, 1},
+		{"above similarity", similarity + 0.01, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetRenameDetectionSettings(&RenameDetectionSettings{
+				Enabled:             true,
+				SimilarityThreshold: 0.50,
+				MinConfidence:       tt.minConfidence,
+			})
+			defer SetRenameDetectionSettings(DefaultRenameDetectionSettings())
+
+			rule := &BC003{}
+
+			old := &types.ModuleSnapshot{
+				Variables: map[string]*types.VariableSignature{
+					"api_key": {Name: "api_key", Default: nil},
+				},
+			}
+
+			new := &types.ModuleSnapshot{
+				Variables: map[string]*types.VariableSignature{
+					"api_key_v2": {Name: "api_key_v2", Default: nil},
+				},
+			}
+
+			findings := rule.Evaluate(old, new)
+
+			if len(findings) != tt.wantFindings {
+				t.Fatalf("Expected %d findings, got %d", tt.wantFindings, len(findings))
+			}
+			if tt.wantFindings == 1 && findings[0].Metadata["confidence"] != "0.70" {
+				t.Errorf("Expected confidence '0.70', got %q", findings[0].Metadata["confidence"])
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package rules
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/jokarl/tfbreak-core/internal/types"
 )
@@ -63,15 +64,41 @@ func (r *BC004) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
 			continue
 		}
 
-		// Normalize types for comparison
-		oldType := normalizeType(oldVar.Type)
-		newType := normalizeType(newVar.Type)
+		// Normalize types for comparison. optional() defaults are ignored
+		// here; default changes are handled by RC009.
+		oldType := normalizeType(comparableType(oldVar))
+		newType := normalizeType(comparableType(newVar))
 
 		// Check if type actually changed
 		if oldType == newType {
 			continue
 		}
 
+		// A change of shape (primitive <-> collection, or between collection
+		// kinds) always breaks callers passing the old shape, so it must never
+		// be treated as a benign widening below
+		if oldKind, newKind := typeKind(oldType), typeKind(newType); isShapeChange(oldKind, newKind) {
+			finding := types.NewFinding(
+				r.ID(),
+				r.Name(),
+				r.DefaultSeverity(),
+				fmt.Sprintf("Variable %q type changed: %s -> %s", name,
+					formatVariableType(oldVar), formatVariableType(newVar)),
+			).WithDetail(fmt.Sprintf("The type changed shape from %s to %s; callers passing a %s value will fail", oldKind, newKind, oldKind)).
+				WithOldLocation(&oldVar.DeclRange).
+				WithNewLocation(&newVar.DeclRange).
+				WithMetadata("shape_change", oldKind+" -> "+newKind)
+
+			findings = append(findings, finding)
+			continue
+		}
+
+		// Changes to the attributes of object types, such as an attribute
+		// losing optional(), are handled by BC006
+		if oldTy, newTy := variableType(oldVar), variableType(newVar); !oldTy.Equals(newTy) && sameTypeIgnoringAttributes(oldTy, newTy) {
+			continue
+		}
+
 		// Check if this is a non-breaking change (any -> specific)
 		if isAnyType(oldType) && !isAnyType(newType) {
 			// Narrowing from any to specific type is safe
@@ -86,10 +113,18 @@ func (r *BC004) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
 			r.Name(),
 			r.DefaultSeverity(),
 			fmt.Sprintf("Variable %q type changed: %s -> %s", name,
-				formatType(oldType), formatType(newType)),
+				formatVariableType(oldVar), formatVariableType(newVar)),
 		).WithOldLocation(&oldVar.DeclRange).
 			WithNewLocation(&newVar.DeclRange)
 
+		if isAnyType(newType) {
+			if isImplicitAny(newVar) {
+				finding = finding.WithDetail("The type argument was removed, so the variable now accepts a value of any type")
+			} else {
+				finding = finding.WithDetail("The type was widened to any, so the variable now accepts a value of any type")
+			}
+		}
+
 		findings = append(findings, finding)
 	}
 
@@ -105,15 +140,57 @@ func normalizeType(t string) string {
 	return t
 }
 
+// comparableType returns the variable's type with optional() defaults stripped,
+// falling back to the raw type if the constraint was not extracted.
+func comparableType(v *types.VariableSignature) string {
+	if v.TypeConstraint != "" {
+		return v.TypeConstraint
+	}
+	return v.Type
+}
+
 // isAnyType checks if a type is "any" (accepts any value).
 func isAnyType(t string) bool {
 	return t == "any" || t == ""
 }
 
-// formatType formats a type for display.
-func formatType(t string) string {
-	if t == "" || t == "any" {
-		return "any"
+// typeKind returns the kind of a normalized type expression: "primitive"
+// for string, number, and bool, the constructor name for collection and
+// structural types (e.g., "list", "map", "object"), and "any" otherwise.
+func typeKind(t string) string {
+	switch t {
+	case "string", "number", "bool":
+		return "primitive"
 	}
-	return t
+	if i := strings.Index(t, "("); i > 0 {
+		switch kind := strings.TrimSpace(t[:i]); kind {
+		case "list", "set", "map", "object", "tuple":
+			return kind
+		}
+	}
+	return "any"
+}
+
+// isShapeChange reports whether a change between two type kinds changes the
+// shape of accepted values. Changes to or from any are not shape changes.
+func isShapeChange(oldKind, newKind string) bool {
+	return oldKind != newKind && oldKind != "any" && newKind != "any"
+}
+
+// formatVariableType formats a variable's type for display, telling an
+// omitted type ("implicit any") apart from type = any ("explicit any").
+func formatVariableType(v *types.VariableSignature) string {
+	if !isAnyType(v.Type) {
+		return v.Type
+	}
+	if isImplicitAny(v) {
+		return "implicit any"
+	}
+	return "explicit any"
+}
+
+// isImplicitAny reports whether a variable accepts any value because it
+// declares no type
+func isImplicitAny(v *types.VariableSignature) bool {
+	return v.Type == "" && !v.TypeExplicit
 }
@@ -145,6 +145,67 @@ func TestBC004_EmptyTypeAsAny_NonBreaking(t *testing.T) {
 	}
 }
 
+func TestBC004_WideningToAny_Message(t *testing.T) {
+	tests := []struct {
+		name        string
+		newVar      *types.VariableSignature
+		wantMessage string
+		wantDetail  string
+	}{
+		{
+			name:        "type omitted",
+			newVar:      &types.VariableSignature{Name: "my_var"},
+			wantMessage: `Variable "my_var" type changed: string -> implicit any`,
+			wantDetail:  "The type argument was removed, so the variable now accepts a value of any type",
+		},
+		{
+			name:        "explicit any",
+			newVar:      &types.VariableSignature{Name: "my_var", Type: "any", TypeExplicit: true},
+			wantMessage: `Variable "my_var" type changed: string -> explicit any`,
+			wantDetail:  "The type was widened to any, so the variable now accepts a value of any type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := types.NewModuleSnapshot("/old")
+			old.Variables["my_var"] = &types.VariableSignature{Name: "my_var", Type: "string", TypeExplicit: true}
+			new := types.NewModuleSnapshot("/new")
+			new.Variables["my_var"] = tt.newVar
+
+			findings := (&BC004{}).Evaluate(old, new)
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			if findings[0].Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", findings[0].Message, tt.wantMessage)
+			}
+			if findings[0].Detail != tt.wantDetail {
+				t.Errorf("Detail = %q, want %q", findings[0].Detail, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestFormatVariableType(t *testing.T) {
+	tests := []struct {
+		v    *types.VariableSignature
+		want string
+	}{
+		{&types.VariableSignature{Type: "list(string)", TypeExplicit: true}, "list(string)"},
+		{&types.VariableSignature{Type: "any", TypeExplicit: true}, "explicit any"},
+		{&types.VariableSignature{}, "implicit any"},
+		// Snapshots built without the loader may leave TypeExplicit unset
+		{&types.VariableSignature{Type: "any"}, "explicit any"},
+	}
+
+	for _, tt := range tests {
+		if got := formatVariableType(tt.v); got != tt.want {
+			t.Errorf("formatVariableType(%+v) = %q, want %q", tt.v, got, tt.want)
+		}
+	}
+}
+
 func TestBC004_EmptyToAny_NoChange(t *testing.T) {
 	rule := &BC004{}
 
@@ -221,10 +282,25 @@ func TestBC004_ObjectTypeChange(t *testing.T) {
 		},
 	}
 
-	findings := rule.Evaluate(old, new)
+	// Attribute changes are reported by BC006
+	if findings := rule.Evaluate(old, new); len(findings) != 0 {
+		t.Fatalf("expected 0 findings for object attribute change, got %d", len(findings))
+	}
+	if findings := (&BC006{}).Evaluate(old, new); len(findings) != 1 {
+		t.Fatalf("expected 1 BC006 finding for object attribute change, got %d", len(findings))
+	}
+}
 
-	if len(findings) != 1 {
-		t.Fatalf("expected 1 finding for object type change, got %d", len(findings))
+func TestBC004_ObjectAttributeTypeChange(t *testing.T) {
+	old := types.NewModuleSnapshot("/old")
+	old.Variables["config"] = &types.VariableSignature{Name: "config", Type: "object({name = string, tier = optional(string)})"}
+	new := types.NewModuleSnapshot("/new")
+	new.Variables["config"] = &types.VariableSignature{Name: "config", Type: "object({name = number, tier = string})"}
+
+	// An attribute type change is still a type change, even alongside an
+	// attribute losing optional()
+	if findings := (&BC004{}).Evaluate(old, new); len(findings) != 1 {
+		t.Fatalf("expected 1 finding for attribute type change, got %d", len(findings))
 	}
 }
 
@@ -291,3 +367,90 @@ func TestBC004_Documentation(t *testing.T) {
 		t.Error("Documentation Remediation should not be empty")
 	}
 }
+
+func TestBC004_IgnoresOptionalDefaultChange(t *testing.T) {
+	rule := &BC004{}
+
+	old := types.NewModuleSnapshot("/old")
+	old.Variables["settings"] = &types.VariableSignature{
+		Name:           "settings",
+		Type:           `object({ tier = optional(string, "standard") })`,
+		TypeConstraint: `object({ tier = optional(string) })`,
+	}
+	new := types.NewModuleSnapshot("/new")
+	new.Variables["settings"] = &types.VariableSignature{
+		Name:           "settings",
+		Type:           `object({ tier = optional(string, "premium") })`,
+		TypeConstraint: `object({ tier = optional(string) })`,
+	}
+
+	findings := rule.Evaluate(old, new)
+
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings for default-only change, got %d", len(findings))
+	}
+}
+
+func TestBC004_ShapeChange(t *testing.T) {
+	tests := []struct {
+		name      string
+		oldType   string
+		newType   string
+		wantShape string
+	}{
+		{"primitive to list", "string", "list(string)", "primitive -> list"},
+		{"primitive to map", "number", "map(number)", "primitive -> map"},
+		{"collection to primitive", "set(string)", "string", "set -> primitive"},
+		{"map to object", "map(string)", "object({name = string})", "map -> object"},
+		{"list to set", "list(string)", "set(string)", "list -> set"},
+		{"list to tuple", "list(string)", "tuple([string])", "list -> tuple"},
+		{"same kind", "list(string)", "list(number)", ""},
+		{"primitive change", "string", "number", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &BC004{}
+
+			old := types.NewModuleSnapshot("/old")
+			old.Variables["my_var"] = &types.VariableSignature{Name: "my_var", Type: tt.oldType}
+
+			new := types.NewModuleSnapshot("/new")
+			new.Variables["my_var"] = &types.VariableSignature{Name: "my_var", Type: tt.newType}
+
+			findings := rule.Evaluate(old, new)
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+
+			f := findings[0]
+			if f.Severity != types.SeverityError {
+				t.Errorf("Severity = %v, want %v", f.Severity, types.SeverityError)
+			}
+			if got := f.Metadata["shape_change"]; got != tt.wantShape {
+				t.Errorf("shape_change = %q, want %q", got, tt.wantShape)
+			}
+		})
+	}
+}
+
+func TestTypeKind(t *testing.T) {
+	tests := []struct {
+		typ  string
+		want string
+	}{
+		{"string", "primitive"},
+		{"bool", "primitive"},
+		{"list(string)", "list"},
+		{"map(object({a = string}))", "map"},
+		{"object({a = string})", "object"},
+		{"tuple([string, number])", "tuple"},
+		{"any", "any"},
+	}
+
+	for _, tt := range tests {
+		if got := typeKind(tt.typ); got != tt.want {
+			t.Errorf("typeKind(%q) = %q, want %q", tt.typ, got, tt.want)
+		}
+	}
+}
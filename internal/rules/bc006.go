@@ -0,0 +1,120 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// BC006 detects when attributes of a variable's object type are removed or
+// become required
+type BC006 struct{}
+
+func init() {
+	Register(&BC006{})
+}
+
+// ID returns the unique identifier for this rule.
+func (r *BC006) ID() string {
+	return "BC006"
+}
+
+// Name returns the human-readable name for this rule.
+func (r *BC006) Name() string {
+	return "input-object-attribute-changed"
+}
+
+// Description returns a description of what this rule detects.
+func (r *BC006) Description() string {
+	return "An attribute of a variable's object type was removed or is no longer optional, so callers passing the old object will fail"
+}
+
+// DefaultSeverity returns the default severity level for this rule.
+func (r *BC006) DefaultSeverity() types.Severity {
+	return types.SeverityError
+}
+
+// Documentation returns the documentation for this rule.
+func (r *BC006) Documentation() *RuleDoc {
+	return &RuleDoc{
+		ID:              r.ID(),
+		Name:            r.Name(),
+		DefaultSeverity: r.DefaultSeverity(),
+		Description:     r.Description(),
+		ExampleOld: `variable "settings" {
+  type = object({
+    name = string
+    tier = optional(string, "standard")
+  })
+}`,
+		ExampleNew: `variable "settings" {
+  type = object({
+    name = string
+    tier = string  # No longer optional!
+  })
+}`,
+		Remediation: `This is a BREAKING change because callers that pass the object as before
+will fail type conversion.
+
+The rule reports each changed attribute, including attributes of nested
+objects and of objects inside lists, sets, maps, and tuples:
+- An attribute lost optional() - callers that omit it now fail (ERROR)
+- A required attribute was added - callers that omit it now fail (ERROR)
+- An attribute was removed - callers setting it lose its effect (ERROR)
+- An attribute gained optional() - callers may now omit it (NOTICE)
+
+Adding an optional attribute is not reported. Changes to optional()
+defaults are reported as input-optional-default-changed, and other type
+changes as input-type-changed.
+
+To fix this issue, either:
+1. Keep the attribute optional, with a default that preserves the old behavior
+2. Update all callers to set the attribute
+3. Use an annotation if this is intentional:
+   # tfbreak:ignore input-object-attribute-changed # callers updated`,
+	}
+}
+
+// Evaluate checks for removed and newly required object type attributes.
+func (r *BC006) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
+	var findings []*types.Finding
+
+	for name, oldVar := range old.Variables {
+		newVar, exists := new.Variables[name]
+		if !exists {
+			// Variable was removed - handled by BC002
+			continue
+		}
+
+		for _, change := range diffObjectAttributes(variableType(oldVar), variableType(newVar)) {
+			severity := r.DefaultSeverity()
+			var message string
+			switch change.Kind {
+			case attributeRemoved:
+				message = fmt.Sprintf("Variable %q attribute %q was removed", name, change.Path)
+			case attributeAddedRequired:
+				message = fmt.Sprintf("Variable %q attribute %q was added as required", name, change.Path)
+			case attributeRequired:
+				message = fmt.Sprintf("Variable %q attribute %q is no longer optional", name, change.Path)
+			case attributeOptional:
+				// Loosening: callers setting the attribute keep working
+				severity = types.SeverityNotice
+				message = fmt.Sprintf("Variable %q attribute %q is now optional", name, change.Path)
+			}
+
+			finding := types.NewFinding(
+				r.ID(),
+				r.Name(),
+				severity,
+				message,
+			).WithOldLocation(&oldVar.DeclRange).
+				WithNewLocation(&newVar.DeclRange).
+				WithMetadata("attribute", change.Path).
+				WithMetadata("change", string(change.Kind))
+
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
@@ -0,0 +1,169 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+func TestBC006_Metadata(t *testing.T) {
+	r := &BC006{}
+
+	if r.ID() != "BC006" {
+		t.Errorf("expected ID 'BC006', got %q", r.ID())
+	}
+	if r.Name() != "input-object-attribute-changed" {
+		t.Errorf("expected Name 'input-object-attribute-changed', got %q", r.Name())
+	}
+	if r.DefaultSeverity() != types.SeverityError {
+		t.Errorf("expected severity BREAKING, got %v", r.DefaultSeverity())
+	}
+	doc := r.Documentation()
+	if doc == nil || doc.ExampleOld == "" || doc.ExampleNew == "" {
+		t.Error("expected Documentation with examples")
+	}
+	if _, ok := DefaultRegistry.Get("BC006"); !ok {
+		t.Error("expected BC006 to be registered")
+	}
+}
+
+func TestBC006_Evaluate(t *testing.T) {
+	type want struct {
+		message  string
+		severity types.Severity
+	}
+	tests := []struct {
+		name    string
+		oldType string
+		newType string
+		want    []want
+	}{
+		{
+			name:    "optional became required",
+			oldType: `object({name = optional(string)})`,
+			newType: `object({name = string})`,
+			want:    []want{{`Variable "settings" attribute "name" is no longer optional`, types.SeverityError}},
+		},
+		{
+			name:    "optional with default became required",
+			oldType: `object({tier = optional(string, "standard")})`,
+			newType: `object({tier = string})`,
+			want:    []want{{`Variable "settings" attribute "tier" is no longer optional`, types.SeverityError}},
+		},
+		{
+			name:    "required became optional with default",
+			oldType: `object({tier = string})`,
+			newType: `object({tier = optional(string, "standard")})`,
+			want:    []want{{`Variable "settings" attribute "tier" is now optional`, types.SeverityNotice}},
+		},
+		{
+			name:    "attribute removed",
+			oldType: `object({name = string, tags = optional(map(string), {})})`,
+			newType: `object({name = string})`,
+			want:    []want{{`Variable "settings" attribute "tags" was removed`, types.SeverityError}},
+		},
+		{
+			name:    "required attribute added",
+			oldType: `object({name = string})`,
+			newType: `object({name = string, owner = string})`,
+			want:    []want{{`Variable "settings" attribute "owner" was added as required`, types.SeverityError}},
+		},
+		{
+			name:    "optional attribute added",
+			oldType: `object({name = string})`,
+			newType: `object({name = string, owner = optional(string, "platform")})`,
+		},
+		{
+			name:    "optional default changed",
+			oldType: `object({tier = optional(string, "standard")})`,
+			newType: `object({tier = optional(string, "premium")})`,
+		},
+		{
+			name:    "nested object",
+			oldType: `object({network = optional(object({cidr = optional(string, "10.0.0.0/16"), name = optional(string)}), {})})`,
+			newType: `object({network = optional(object({cidr = string}), {cidr = "10.0.0.0/16"})})`,
+			want: []want{
+				{`Variable "settings" attribute "network.cidr" is no longer optional`, types.SeverityError},
+				{`Variable "settings" attribute "network.name" was removed`, types.SeverityError},
+			},
+		},
+		{
+			name:    "object in collection",
+			oldType: `list(object({port = optional(number, 443)}))`,
+			newType: `list(object({port = number}))`,
+			want:    []want{{`Variable "settings" attribute "[*].port" is no longer optional`, types.SeverityError}},
+		},
+		{
+			name:    "object in tuple",
+			oldType: `tuple([string, object({port = number})])`,
+			newType: `tuple([string, object({port = optional(number)})])`,
+			want:    []want{{`Variable "settings" attribute "[1].port" is now optional`, types.SeverityNotice}},
+		},
+		{
+			name:    "collection kind changed",
+			oldType: `list(object({port = optional(number)}))`,
+			newType: `set(object({port = number}))`,
+		},
+		{
+			name:    "not an object",
+			oldType: `string`,
+			newType: `number`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := types.NewModuleSnapshot("/old")
+			old.Variables["settings"] = &types.VariableSignature{Name: "settings", Type: tt.oldType}
+			new := types.NewModuleSnapshot("/new")
+			new.Variables["settings"] = &types.VariableSignature{Name: "settings", Type: tt.newType}
+
+			findings := (&BC006{}).Evaluate(old, new)
+			if len(findings) != len(tt.want) {
+				t.Fatalf("expected %d findings, got %d", len(tt.want), len(findings))
+			}
+			for i, w := range tt.want {
+				if findings[i].Message != w.message {
+					t.Errorf("findings[%d].Message = %q, want %q", i, findings[i].Message, w.message)
+				}
+				if findings[i].Severity != w.severity {
+					t.Errorf("findings[%d].Severity = %v, want %v", i, findings[i].Severity, w.severity)
+				}
+			}
+		})
+	}
+}
+
+func TestBC006_VariableRemoved_NoFinding(t *testing.T) {
+	old := types.NewModuleSnapshot("/old")
+	old.Variables["settings"] = &types.VariableSignature{Name: "settings", Type: `object({name = optional(string)})`}
+	new := types.NewModuleSnapshot("/new")
+
+	// Removed variables are reported by BC002
+	if findings := (&BC006{}).Evaluate(old, new); len(findings) != 0 {
+		t.Errorf("expected 0 findings, got %d", len(findings))
+	}
+}
+
+func TestBC004_BC006_OptionalBecameRequired(t *testing.T) {
+	old := types.NewModuleSnapshot("/old")
+	old.Variables["settings"] = &types.VariableSignature{
+		Name:           "settings",
+		Type:           `object({tier = optional(string, "standard")})`,
+		TypeConstraint: `object({tier = optional(string)})`,
+	}
+	new := types.NewModuleSnapshot("/new")
+	new.Variables["settings"] = &types.VariableSignature{
+		Name:           "settings",
+		Type:           `object({tier = string})`,
+		TypeConstraint: `object({tier = string})`,
+	}
+
+	// The change is reported once, by the dedicated rule
+	if findings := (&BC004{}).Evaluate(old, new); len(findings) != 0 {
+		t.Errorf("expected 0 BC004 findings, got %q", findings[0].Message)
+	}
+	if findings := (&BC006{}).Evaluate(old, new); len(findings) != 1 {
+		t.Errorf("expected 1 BC006 finding, got %d", len(findings))
+	}
+}
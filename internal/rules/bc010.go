@@ -78,7 +78,7 @@ func (r *BC010) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
 	// For each removed output, try to find a matching added output
 	for oldName, oldOutput := range removedOutputs {
 		match, similarity, found := FindBestMatch(oldName, addedOutputs, threshold)
-		if !found {
+		if !found || !meetsMinConfidence(similarity) {
 			continue
 		}
 
@@ -91,9 +91,11 @@ func (r *BC010) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
 			fmt.Sprintf("Output %q was renamed to %q", oldName, match),
 		).WithOldLocation(&oldOutput.DeclRange).
 			WithNewLocation(&newOutput.DeclRange).
+			WithRelatedLocation(&oldOutput.DeclRange).
 			WithDetail(fmt.Sprintf("Similarity: %.2f (threshold: %.2f)", similarity, threshold)).
 			WithMetadata("old_name", oldName).
-			WithMetadata("new_name", match)
+			WithMetadata("new_name", match).
+			WithMetadata("confidence", formatConfidence(similarity))
 
 		findings = append(findings, finding)
 
@@ -85,7 +85,8 @@ func (r *BC103) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
 				r.Name(),
 				r.DefaultSeverity(),
 				fmt.Sprintf("Duplicate moved block 'from' address: %q", moved.From),
-			).WithNewLocation(&moved.DeclRange)
+			).WithNewLocation(&moved.DeclRange).
+				WithRelatedLocation(fromLocs[moved.From])
 
 			findings = append(findings, finding)
 		} else {
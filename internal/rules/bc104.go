@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// BC104 detects moved blocks whose "from" address still exists
+type BC104 struct{}
+
+func init() {
+	Register(&BC104{})
+}
+
+func (r *BC104) ID() string {
+	return "BC104"
+}
+
+func (r *BC104) Name() string {
+	return "moved-from-still-exists"
+}
+
+func (r *BC104) Description() string {
+	return "A moved block's 'from' address still exists in the configuration, which Terraform rejects at plan time"
+}
+
+func (r *BC104) DefaultSeverity() types.Severity {
+	return types.SeverityError
+}
+
+func (r *BC104) Documentation() *RuleDoc {
+	return &RuleDoc{
+		ID:              r.ID(),
+		Name:            r.Name(),
+		DefaultSeverity: r.DefaultSeverity(),
+		Description:     r.Description(),
+		ExampleOld: `resource "aws_s3_bucket" "logs" {
+  bucket = "my-logs"
+}`,
+		ExampleNew: `resource "aws_s3_bucket" "logs" {
+  bucket = "my-logs"
+}
+
+resource "aws_s3_bucket" "app_logs" {
+  bucket = "my-logs"
+}
+
+moved {
+  from = aws_s3_bucket.logs  # Still declared above!
+  to   = aws_s3_bucket.app_logs
+}`,
+		Remediation: `Fix the moved block or the configuration:
+1. If the object was renamed, remove the old resource/module block
+2. If the old block should stay, remove the moved block
+3. Use an annotation if the check is a false positive:
+   # tfbreak:ignore moved-from-still-exists # reason`,
+	}
+}
+
+func (r *BC104) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
+	var findings []*types.Finding
+
+	for _, moved := range new.MovedBlocks {
+		decl := addressDeclRange(new, moved.From)
+		if decl == nil {
+			continue
+		}
+
+		finding := types.NewFinding(
+			r.ID(),
+			r.Name(),
+			r.DefaultSeverity(),
+			fmt.Sprintf("Moved block 'from' address %q still exists in the configuration", moved.From),
+		).WithNewLocation(&moved.DeclRange).
+			WithRelatedLocation(decl)
+
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
+
+// addressDeclRange returns where a resource or module address is declared in
+// the snapshot, or nil if it is not declared
+func addressDeclRange(snapshot *types.ModuleSnapshot, addr string) *types.FileRange {
+	switch {
+	case types.IsModuleAddress(addr):
+		if m, ok := snapshot.Modules[strings.TrimPrefix(addr, "module.")]; ok {
+			return &m.DeclRange
+		}
+	case types.IsResourceAddress(addr):
+		if r, ok := snapshot.Resources[addr]; ok {
+			return &r.DeclRange
+		}
+	}
+	return nil
+}
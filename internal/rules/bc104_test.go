@@ -0,0 +1,91 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+func newMovedSnapshot(from, to string) *types.ModuleSnapshot {
+	new := types.NewModuleSnapshot("/new")
+	new.MovedBlocks = []*types.MovedBlock{
+		{
+			From:      from,
+			To:        to,
+			DeclRange: types.FileRange{Filename: "moved.tf", Line: 1},
+		},
+	}
+	return new
+}
+
+func TestBC104_ValidMove(t *testing.T) {
+	rule := &BC104{}
+
+	old := types.NewModuleSnapshot("/old")
+	new := newMovedSnapshot("aws_s3_bucket.old", "aws_s3_bucket.new")
+	new.Resources["aws_s3_bucket.new"] = &types.ResourceSignature{Address: "aws_s3_bucket.new"}
+
+	findings := rule.Evaluate(old, new)
+	if len(findings) != 0 {
+		t.Errorf("expected no findings, got %d", len(findings))
+	}
+}
+
+func TestBC104_ResourceFromStillExists(t *testing.T) {
+	rule := &BC104{}
+
+	old := types.NewModuleSnapshot("/old")
+	new := newMovedSnapshot("aws_s3_bucket.old", "aws_s3_bucket.new")
+	new.Resources["aws_s3_bucket.old"] = &types.ResourceSignature{
+		Address:   "aws_s3_bucket.old",
+		DeclRange: types.FileRange{Filename: "main.tf", Line: 5},
+	}
+	new.Resources["aws_s3_bucket.new"] = &types.ResourceSignature{Address: "aws_s3_bucket.new"}
+
+	findings := rule.Evaluate(old, new)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(findings))
+	}
+	if findings[0].RuleID != "BC104" {
+		t.Errorf("RuleID = %q, want BC104", findings[0].RuleID)
+	}
+	if findings[0].Severity != types.SeverityError {
+		t.Errorf("Severity = %v, want ERROR", findings[0].Severity)
+	}
+	if findings[0].NewLocation == nil || findings[0].NewLocation.Filename != "moved.tf" {
+		t.Errorf("expected location of the moved block, got %+v", findings[0].NewLocation)
+	}
+	related := findings[0].RelatedLocations
+	if len(related) != 1 || related[0].Filename != "main.tf" || related[0].Line != 5 {
+		t.Errorf("expected related location of the resource still declared, got %+v", related)
+	}
+}
+
+func TestBC104_ModuleFromStillExists(t *testing.T) {
+	rule := &BC104{}
+
+	old := types.NewModuleSnapshot("/old")
+	new := newMovedSnapshot("module.old_vpc", "module.network")
+	new.Modules["old_vpc"] = &types.ModuleCallSignature{Name: "old_vpc"}
+	new.Modules["network"] = &types.ModuleCallSignature{Name: "network"}
+
+	findings := rule.Evaluate(old, new)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(findings))
+	}
+}
+
+func TestBC104_DanglingTargetLeftToBC103(t *testing.T) {
+	rule := &BC104{}
+
+	// The target doesn't exist, but the source is gone: BC103 reports this, BC104 doesn't
+	old := types.NewModuleSnapshot("/old")
+	new := newMovedSnapshot("aws_s3_bucket.old", "aws_s3_bucket.missing")
+
+	if findings := rule.Evaluate(old, new); len(findings) != 0 {
+		t.Errorf("expected no findings, got %d", len(findings))
+	}
+	if findings := (&BC103{}).Evaluate(old, new); len(findings) != 1 {
+		t.Errorf("expected BC103 to flag the dangling target, got %d findings", len(findings))
+	}
+}
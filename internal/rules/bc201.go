@@ -6,7 +6,7 @@ import (
 	"github.com/jokarl/tfbreak-core/internal/types"
 )
 
-// BC201 detects when provider requirements are removed or changed
+// BC201 detects when provider requirements are removed
 type BC201 struct{}
 
 func init() {
@@ -22,7 +22,7 @@ func (r *BC201) Name() string {
 }
 
 func (r *BC201) Description() string {
-	return "Provider requirement was removed or changed, which may break consumers using different provider versions"
+	return "Provider requirement was removed, which may break consumers relying on the module to declare it"
 }
 
 func (r *BC201) DefaultSeverity() types.Severity {
@@ -41,84 +41,56 @@ func (r *BC201) Documentation() *RuleDoc {
       source  = "hashicorp/aws"
       version = ">= 4.0"
     }
+    random = {
+      source = "hashicorp/random"
+    }
   }
 }`,
 		ExampleNew: `terraform {
   required_providers {
     aws = {
       source  = "hashicorp/aws"
-      version = ">= 5.0"  # Tightened constraint
+      version = ">= 4.0"
     }
+    # random removed!
   }
 }`,
-		Remediation: `This is a BREAKING change because consumers using older provider versions will fail.
+		Remediation: `This is a BREAKING change because the module no longer declares a provider it
+required. Consumers passing that provider to the module in a providers block will fail.
 
 Common scenarios:
 - Provider removed: consumers depending on that provider will fail
-- Version constraint tightened: consumers with older versions must upgrade
-- Source changed: effectively a different provider
+- Version constraint added or narrowed: reported separately by provider-version-narrowed (RC204)
+- Source changed: reported separately by provider-source-changed (BC203)
 
 Before making this change:
-1. Verify all consumers can use the new provider version
-2. Test with the minimum required version
-3. Document the provider version change in your changelog
+1. Verify no resources or data sources of the module still use the provider
+2. Tell consumers to remove the provider from their module blocks
+3. Document the removal in your changelog
 
 Use an annotation if this is intentional:
-   # tfbreak:ignore provider-version-constrained # provider upgrade for new features`,
+   # tfbreak:ignore provider-version-constrained # random provider no longer used`,
 	}
 }
 
 func (r *BC201) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
 	var findings []*types.Finding
 
-	// Check for providers that were removed or changed
-	for name, oldProvider := range old.RequiredProviders {
-		newProvider, exists := new.RequiredProviders[name]
-
-		if !exists {
-			// Provider was removed
-			finding := types.NewFinding(
-				r.ID(),
-				r.Name(),
-				r.DefaultSeverity(),
-				fmt.Sprintf("Provider %q was removed from required_providers", name),
-			)
-			findings = append(findings, finding)
-			continue
-		}
-
-		// Check if source changed
-		if oldProvider.Source != newProvider.Source {
-			finding := types.NewFinding(
-				r.ID(),
-				r.Name(),
-				r.DefaultSeverity(),
-				fmt.Sprintf("Provider %q source changed: %q -> %q", name, oldProvider.Source, newProvider.Source),
-			)
-			findings = append(findings, finding)
+	// Check for providers that were removed
+	for name := range old.RequiredProviders {
+		if _, exists := new.RequiredProviders[name]; exists {
+			// Source changes are handled by BC203, version constraint
+			// changes by RC204
 			continue
 		}
 
-		// Check if version constraint changed
-		if oldProvider.Version != newProvider.Version {
-			var message string
-			if oldProvider.Version == "" {
-				message = fmt.Sprintf("Provider %q version constraint added: %q", name, newProvider.Version)
-			} else if newProvider.Version == "" {
-				// Version constraint removed - still flag as it's a significant change
-				message = fmt.Sprintf("Provider %q version constraint removed (was %q)", name, oldProvider.Version)
-			} else {
-				message = fmt.Sprintf("Provider %q version constraint changed: %q -> %q", name, oldProvider.Version, newProvider.Version)
-			}
-
-			finding := types.NewFinding(
-				r.ID(),
-				r.Name(),
-				r.DefaultSeverity(),
-				message,
-			)
-			findings = append(findings, finding)
-		}
+		finding := types.NewFinding(
+			r.ID(),
+			r.Name(),
+			r.DefaultSeverity(),
+			fmt.Sprintf("Provider %q was removed from required_providers", name),
+		)
+		findings = append(findings, finding)
 	}
 
 	// Note: New providers added are not flagged (adding a dependency is not breaking)
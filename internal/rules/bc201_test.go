@@ -50,8 +50,9 @@ func TestBC201_ProviderVersionChanged(t *testing.T) {
 
 	findings := rule.Evaluate(old, new)
 
-	if len(findings) != 1 {
-		t.Fatalf("expected 1 finding when version changed, got %d", len(findings))
+	// Version constraint changes are reported by RC204
+	if len(findings) != 0 {
+		t.Fatalf("expected 0 findings when only the version changed, got %d", len(findings))
 	}
 }
 
@@ -72,8 +73,9 @@ func TestBC201_ProviderSourceChanged(t *testing.T) {
 
 	findings := rule.Evaluate(old, new)
 
-	if len(findings) != 1 {
-		t.Fatalf("expected 1 finding when source changed, got %d", len(findings))
+	// Source changes are reported by BC203
+	if len(findings) != 0 {
+		t.Fatalf("expected 0 findings when only source changed, got %d", len(findings))
 	}
 }
 
@@ -135,8 +137,9 @@ func TestBC201_VersionConstraintAdded(t *testing.T) {
 
 	findings := rule.Evaluate(old, new)
 
-	if len(findings) != 1 {
-		t.Fatalf("expected 1 finding when version constraint added, got %d", len(findings))
+	// Version constraint changes are reported by RC204
+	if len(findings) != 0 {
+		t.Fatalf("expected 0 findings when version constraint added, got %d", len(findings))
 	}
 }
 
@@ -157,9 +160,8 @@ func TestBC201_VersionConstraintRemoved(t *testing.T) {
 
 	findings := rule.Evaluate(old, new)
 
-	// Version constraint removal is still flagged as a significant change
-	if len(findings) != 1 {
-		t.Fatalf("expected 1 finding when version constraint removed, got %d", len(findings))
+	if len(findings) != 0 {
+		t.Fatalf("expected 0 findings when version constraint removed, got %d", len(findings))
 	}
 }
 
@@ -178,8 +180,8 @@ func TestBC201_MultipleProviders(t *testing.T) {
 
 	findings := rule.Evaluate(old, new)
 
-	if len(findings) != 2 {
-		t.Fatalf("expected 2 findings (aws changed, azurerm removed), got %d", len(findings))
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding (azurerm removed), got %d", len(findings))
 	}
 }
 
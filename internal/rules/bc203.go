@@ -0,0 +1,119 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// defaultProviderRegistryHost is the registry host assumed for provider
+// sources that do not name one
+const defaultProviderRegistryHost = "registry.terraform.io"
+
+// BC203 detects when the source of a required provider changes
+type BC203 struct{}
+
+func init() {
+	Register(&BC203{})
+}
+
+func (r *BC203) ID() string {
+	return "BC203"
+}
+
+func (r *BC203) Name() string {
+	return "provider-source-changed"
+}
+
+func (r *BC203) Description() string {
+	return "A required provider's source changed, so consumers resolve a different provider"
+}
+
+func (r *BC203) DefaultSeverity() types.Severity {
+	return types.SeverityError
+}
+
+func (r *BC203) Documentation() *RuleDoc {
+	return &RuleDoc{
+		ID:              r.ID(),
+		Name:            r.Name(),
+		DefaultSeverity: r.DefaultSeverity(),
+		Description:     r.Description(),
+		ExampleOld: `terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}`,
+		ExampleNew: `terraform {
+  required_providers {
+    aws = {
+      source  = "custom/aws"  # Different provider
+      version = ">= 5.0"
+    }
+  }
+}`,
+		Remediation: `This is a BREAKING change because the local provider name now refers to a
+different provider. Consumers must install the new provider, any provider
+configuration they pass to this module must be for the new source, and state
+recorded under the old provider must be migrated with
+"terraform state replace-provider".
+
+Sources are compared by registry host (case-insensitive, defaulting to
+registry.terraform.io), namespace and type. Spelling out the default host
+is not reported.
+
+Use an annotation if this is intentional:
+   # tfbreak:ignore provider-source-changed # moved to the partner namespace`,
+	}
+}
+
+func (r *BC203) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
+	var findings []*types.Finding
+
+	for name, oldProvider := range old.RequiredProviders {
+		newProvider, exists := new.RequiredProviders[name]
+		if !exists {
+			// Provider was removed - handled by BC201
+			continue
+		}
+
+		if normalizeProviderSource(name, oldProvider.Source) == normalizeProviderSource(name, newProvider.Source) {
+			continue
+		}
+
+		finding := types.NewFinding(
+			r.ID(),
+			r.Name(),
+			r.DefaultSeverity(),
+			fmt.Sprintf("Provider %q source changed: %q -> %q", name, oldProvider.Source, newProvider.Source),
+		)
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
+
+// normalizeProviderSource returns the fully qualified address for a provider
+// source, as "host/namespace/type". The host is lowercased and defaults to
+// registry.terraform.io, and an empty source defaults to hashicorp/<name> like
+// in Terraform. Namespace and type are kept as written. Sources that are not
+// valid addresses are returned unchanged.
+func normalizeProviderSource(localName, source string) string {
+	if source == "" {
+		source = "hashicorp/" + localName
+	}
+
+	parts := strings.Split(source, "/")
+	switch len(parts) {
+	case 2:
+		return defaultProviderRegistryHost + "/" + source
+	case 3:
+		return strings.ToLower(parts[0]) + "/" + parts[1] + "/" + parts[2]
+	default:
+		return source
+	}
+}
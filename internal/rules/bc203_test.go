@@ -0,0 +1,151 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+func TestBC203_SourceChanged(t *testing.T) {
+	rule := &BC203{}
+
+	old := types.NewModuleSnapshot("/old")
+	old.RequiredProviders["aws"] = &types.ProviderRequirement{Source: "hashicorp/aws", Version: ">= 5.0"}
+
+	new := types.NewModuleSnapshot("/new")
+	new.RequiredProviders["aws"] = &types.ProviderRequirement{Source: "custom/aws", Version: ">= 5.0"}
+
+	findings := rule.Evaluate(old, new)
+
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(findings))
+	}
+	f := findings[0]
+	if f.RuleID != "BC203" {
+		t.Errorf("RuleID = %q, want %q", f.RuleID, "BC203")
+	}
+	if f.Severity != types.SeverityError {
+		t.Errorf("Severity = %v, want %v", f.Severity, types.SeverityError)
+	}
+	want := `Provider "aws" source changed: "hashicorp/aws" -> "custom/aws"`
+	if f.Message != want {
+		t.Errorf("Message = %q, want %q", f.Message, want)
+	}
+
+	// The version-only rule must not report the same change
+	if bc201 := (&BC201{}).Evaluate(old, new); len(bc201) != 0 {
+		t.Errorf("expected no BC201 findings for a source-only change, got %d", len(bc201))
+	}
+}
+
+func TestBC203_VersionOnlyChange_NoFinding(t *testing.T) {
+	rule := &BC203{}
+
+	old := types.NewModuleSnapshot("/old")
+	old.RequiredProviders["aws"] = &types.ProviderRequirement{Source: "hashicorp/aws", Version: ">= 4.0"}
+
+	new := types.NewModuleSnapshot("/new")
+	new.RequiredProviders["aws"] = &types.ProviderRequirement{Source: "hashicorp/aws", Version: ">= 5.0"}
+
+	if findings := rule.Evaluate(old, new); len(findings) != 0 {
+		t.Errorf("expected 0 findings for version-only change, got %d", len(findings))
+	}
+	if rc204 := (&RC204{}).Evaluate(old, new); len(rc204) != 1 {
+		t.Errorf("expected 1 RC204 finding for version-only change, got %d", len(rc204))
+	}
+}
+
+func TestBC203_SourceAndVersionChanged(t *testing.T) {
+	old := types.NewModuleSnapshot("/old")
+	old.RequiredProviders["aws"] = &types.ProviderRequirement{Source: "hashicorp/aws", Version: ">= 4.0"}
+
+	new := types.NewModuleSnapshot("/new")
+	new.RequiredProviders["aws"] = &types.ProviderRequirement{Source: "custom/aws", Version: ">= 5.0"}
+
+	if findings := (&BC203{}).Evaluate(old, new); len(findings) != 1 {
+		t.Errorf("expected 1 BC203 finding, got %d", len(findings))
+	}
+	if findings := (&RC204{}).Evaluate(old, new); len(findings) != 1 {
+		t.Errorf("expected 1 RC204 finding, got %d", len(findings))
+	}
+}
+
+func TestBC203_EquivalentSources_NoFinding(t *testing.T) {
+	tests := []struct {
+		name      string
+		oldSource string
+		newSource string
+	}{
+		{"identical", "hashicorp/aws", "hashicorp/aws"},
+		{"default host spelled out", "hashicorp/aws", "registry.terraform.io/hashicorp/aws"},
+		{"host case", "Registry.Terraform.io/hashicorp/aws", "registry.terraform.io/hashicorp/aws"},
+		{"implied source", "", "hashicorp/aws"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := types.NewModuleSnapshot("/old")
+			old.RequiredProviders["aws"] = &types.ProviderRequirement{Source: tt.oldSource}
+
+			new := types.NewModuleSnapshot("/new")
+			new.RequiredProviders["aws"] = &types.ProviderRequirement{Source: tt.newSource}
+
+			if findings := (&BC203{}).Evaluate(old, new); len(findings) != 0 {
+				t.Errorf("expected 0 findings, got %d: %s", len(findings), findings[0].Message)
+			}
+		})
+	}
+}
+
+func TestBC203_DifferentSources(t *testing.T) {
+	tests := []struct {
+		name      string
+		oldSource string
+		newSource string
+	}{
+		{"namespace case", "hashicorp/aws", "HashiCorp/aws"},
+		{"type case", "hashicorp/aws", "hashicorp/AWS"},
+		{"registry host", "hashicorp/aws", "registry.example.com/hashicorp/aws"},
+		{"implied source", "", "custom/aws"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := types.NewModuleSnapshot("/old")
+			old.RequiredProviders["aws"] = &types.ProviderRequirement{Source: tt.oldSource}
+
+			new := types.NewModuleSnapshot("/new")
+			new.RequiredProviders["aws"] = &types.ProviderRequirement{Source: tt.newSource}
+
+			if findings := (&BC203{}).Evaluate(old, new); len(findings) != 1 {
+				t.Errorf("expected 1 finding, got %d", len(findings))
+			}
+		})
+	}
+}
+
+func TestBC203_ProviderAddedOrRemoved_NoFinding(t *testing.T) {
+	old := types.NewModuleSnapshot("/old")
+	old.RequiredProviders["aws"] = &types.ProviderRequirement{Source: "hashicorp/aws"}
+
+	new := types.NewModuleSnapshot("/new")
+	new.RequiredProviders["google"] = &types.ProviderRequirement{Source: "hashicorp/google"}
+
+	if findings := (&BC203{}).Evaluate(old, new); len(findings) != 0 {
+		t.Errorf("expected 0 findings, got %d", len(findings))
+	}
+}
+
+func TestBC203_Documentation(t *testing.T) {
+	doc := (&BC203{}).Documentation()
+
+	if doc.ID != "BC203" {
+		t.Errorf("Documentation ID = %q, want %q", doc.ID, "BC203")
+	}
+	if doc.Name != "provider-source-changed" {
+		t.Errorf("Documentation Name = %q, want %q", doc.Name, "provider-source-changed")
+	}
+	if doc.ExampleOld == "" || doc.ExampleNew == "" || doc.Remediation == "" {
+		t.Error("Documentation examples and remediation should not be empty")
+	}
+}
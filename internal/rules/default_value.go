@@ -0,0 +1,114 @@
+package rules
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/jokarl/tfbreak-core/internal/types"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// variableDefaultsEqual reports whether two variables have equivalent
+// defaults. Each default is converted to its variable's declared type first,
+// the way Terraform does, so representations that Terraform treats as the
+// same value compare equal: 1 and "1" for a number or string, "true" and
+// true for a bool, and set elements in any order.
+func variableDefaultsEqual(oldVar, newVar *types.VariableSignature) bool {
+	return canonicalDefaultsEqual(
+		oldVar.Default, variableType(oldVar),
+		newVar.Default, variableType(newVar),
+	)
+}
+
+// defaultsEqual reports whether two default values are equivalent when
+// their declared types are not known. Numbers compare by value, so 1 and
+// 1.0 are equal.
+func defaultsEqual(a, b interface{}) bool {
+	return canonicalDefaultsEqual(a, cty.DynamicPseudoType, b, cty.DynamicPseudoType)
+}
+
+// canonicalDefaultsEqual compares two defaults as cty values converted to
+// the given types. Values that cannot be converted fall back to comparing
+// their JSON serialization.
+func canonicalDefaultsEqual(a interface{}, aType cty.Type, b interface{}, bType cty.Type) bool {
+	// Handle nil cases
+	if a == nil && b == nil {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+
+	aVal, errA := defaultValue(a, aType)
+	bVal, errB := defaultValue(b, bType)
+	if errA != nil || errB != nil {
+		return jsonEqual(a, b)
+	}
+
+	eq := aVal.Equals(bVal)
+	return eq.IsKnown() && eq.True()
+}
+
+// defaultValue converts a JSON-compatible default to a cty value of type ty.
+// If the value does not conform to ty, it is returned with its implied type.
+func defaultValue(v interface{}, ty cty.Type) (cty.Value, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	implied, err := ctyjson.ImpliedType(data)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	val, err := ctyjson.Unmarshal(data, implied)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	if ty != cty.DynamicPseudoType {
+		if converted, err := convert.Convert(val, ty); err == nil {
+			return converted, nil
+		}
+	}
+	return val, nil
+}
+
+// variableType returns the declared type of a variable, or
+// cty.DynamicPseudoType if it has none or it cannot be parsed
+func variableType(v *types.VariableSignature) cty.Type {
+	src := v.TypeConstraint
+	if src == "" {
+		src = v.Type
+	}
+	if src == "" {
+		return cty.DynamicPseudoType
+	}
+
+	expr, diags := hclsyntax.ParseExpression([]byte(src), "type", hcl.InitialPos)
+	if diags.HasErrors() {
+		return cty.DynamicPseudoType
+	}
+	// The raw type may still carry optional() defaults
+	ty, _, diags := typeexpr.TypeConstraintWithDefaults(expr)
+	if diags.HasErrors() {
+		return cty.DynamicPseudoType
+	}
+	return ty
+}
+
+// jsonEqual compares two values by their JSON serialization
+func jsonEqual(a, b interface{}) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+
+	if errA != nil || errB != nil {
+		// If serialization fails, fall back to direct comparison
+		return a == b
+	}
+
+	return string(aJSON) == string(bJSON)
+}
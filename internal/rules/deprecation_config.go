@@ -0,0 +1,76 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// DefaultDeprecationMarker matches a deprecation marker that states the
+// version a variable is to be removed in, such as "(deprecated, remove in
+// v3)" or "DEPRECATED: will be removed in 2.0". The first capture group is
+// the version.
+const DefaultDeprecationMarker = `(?i)\bdeprecated\b.*?\bremoved?\s+in\s+v?(\d+(?:\.\d+){0,2})`
+
+// DeprecationSettings holds the configuration for the overdue removal rule (RC017)
+type DeprecationSettings struct {
+	// Marker matches a deprecation marker in a variable description. The
+	// capture group named "version", or else the first capture group, is
+	// the version the variable is to be removed in.
+	Marker *regexp.Regexp
+
+	// ModuleVersion is the version of the new module. RC017 reports nothing
+	// when it is empty.
+	ModuleVersion string
+}
+
+// DefaultDeprecationSettings returns the default settings, with the default
+// marker and no module version
+func DefaultDeprecationSettings() *DeprecationSettings {
+	return &DeprecationSettings{
+		Marker: regexp.MustCompile(DefaultDeprecationMarker),
+	}
+}
+
+// deprecationSettings is the current deprecation marker configuration
+// This is set by the engine based on the loaded config
+var deprecationSettings = DefaultDeprecationSettings()
+
+// SetDeprecationSettings updates the deprecation marker configuration
+func SetDeprecationSettings(settings *DeprecationSettings) {
+	if settings == nil {
+		deprecationSettings = DefaultDeprecationSettings()
+		return
+	}
+	deprecationSettings = settings
+}
+
+// GetDeprecationSettings returns the current deprecation marker configuration
+func GetDeprecationSettings() *DeprecationSettings {
+	return deprecationSettings
+}
+
+// ValidateModuleVersion checks that a module version can be compared with
+// the removal versions in deprecation markers: one to three numeric parts,
+// optionally prefixed with "v"
+func ValidateModuleVersion(version string) error {
+	if _, _, err := parseConstraintVersion(version); err != nil {
+		return fmt.Errorf("%w (must be a version like 1.2.3)", err)
+	}
+	return nil
+}
+
+// removalVersion returns the removal version stated by the deprecation
+// marker in a description, if it has one
+func (s *DeprecationSettings) removalVersion(description string) (string, bool) {
+	if s.Marker == nil {
+		return "", false
+	}
+	match := s.Marker.FindStringSubmatch(description)
+	if match == nil || len(match) < 2 {
+		return "", false
+	}
+	if i := s.Marker.SubexpIndex("version"); i > 0 {
+		return match[i], match[i] != ""
+	}
+	return match[1], match[1] != ""
+}
@@ -2,6 +2,11 @@ package rules
 
 import "github.com/jokarl/tfbreak-core/internal/types"
 
+// DefaultHelpURLBase is the base URL of the built-in rule documentation.
+// A rule's help URL is the base followed by its ID, which docs/rules.md
+// declares as an explicit anchor above the rule's heading.
+const DefaultHelpURLBase = "https://github.com/jokarl/tfbreak-core/blob/main/docs/rules.md#"
+
 // RuleDoc contains documentation for a rule
 type RuleDoc struct {
 	ID              string
@@ -37,3 +42,16 @@ func GetDocumentation(ruleID string) *RuleDoc {
 		Description:     r.Description(),
 	}
 }
+
+// HelpURL returns the documentation URL for a built-in rule, using base in
+// place of DefaultHelpURLBase when non-empty. Rules that are not registered
+// (e.g., plugin rules) have no help URL.
+func HelpURL(base, ruleID string) string {
+	if _, ok := DefaultRegistry.Get(ruleID); !ok {
+		return ""
+	}
+	if base == "" {
+		base = DefaultHelpURLBase
+	}
+	return base + ruleID
+}
@@ -0,0 +1,76 @@
+package rules
+
+import "github.com/jokarl/tfbreak-core/internal/types"
+
+// ruleDomains maps each built-in rule to the snapshot domains it compares.
+// A rule only reports differences between the old and new values of its
+// domains, so it cannot find anything when all of them are unchanged.
+//
+// Rules that are not listed always run. This includes the moved block rules
+// BC102, BC103, and BC104, which validate the new configuration on its own
+// and can report findings even if nothing changed, and RC017, which compares
+// the new configuration with the module version.
+var ruleDomains = map[string][]types.Domain{
+	"BC001": {types.DomainVariables},
+	"BC002": {types.DomainVariables},
+	"BC003": {types.DomainVariables},
+	"BC004": {types.DomainVariables},
+	"BC005": {types.DomainVariables},
+	"BC006": {types.DomainVariables},
+	"RC003": {types.DomainVariables},
+	"RC006": {types.DomainVariables},
+	"RC007": {types.DomainVariables},
+	"RC008": {types.DomainVariables},
+	"RC009": {types.DomainVariables},
+	"RC012": {types.DomainVariables},
+	"RC013": {types.DomainVariables},
+	"RC015": {types.DomainVariables},
+	"RC016": {types.DomainVariables},
+
+	"BC009": {types.DomainOutputs},
+	"BC010": {types.DomainOutputs},
+	"RC011": {types.DomainOutputs},
+	"RC014": {types.DomainOutputs},
+
+	// BC100 and BC101 also read moved blocks, but only to excuse a removal,
+	// and there is nothing removed while resources and modules are unchanged
+	"BC100": {types.DomainResources},
+	"BC101": {types.DomainModules},
+
+	"BC200": {types.DomainTerraform},
+	"BC201": {types.DomainProviders},
+	"RC202": {types.DomainProviderLocks},
+	"BC203": {types.DomainProviders},
+	"RC204": {types.DomainProviders},
+
+	"RC300": {types.DomainModules},
+	"RC301": {types.DomainModules},
+}
+
+// changedDomains returns the domains whose declarations differ between old
+// and new. Domains that could not be hashed count as changed.
+func changedDomains(old, new *types.ModuleSnapshot) map[types.Domain]bool {
+	oldHashes := old.DomainHashes()
+	newHashes := new.DomainHashes()
+
+	changed := make(map[types.Domain]bool, len(oldHashes))
+	for domain, oldHash := range oldHashes {
+		changed[domain] = oldHash == "" || oldHash != newHashes[domain]
+	}
+	return changed
+}
+
+// canSkip reports whether a rule can be skipped because none of its domains
+// changed. Rules without known domains are never skipped.
+func canSkip(ruleID string, changed map[types.Domain]bool) bool {
+	domains, ok := ruleDomains[ruleID]
+	if !ok {
+		return false
+	}
+	for _, domain := range domains {
+		if changed[domain] {
+			return false
+		}
+	}
+	return true
+}
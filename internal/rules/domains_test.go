@@ -0,0 +1,204 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/loader"
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// countingRule counts how often it is evaluated and reports nothing
+type countingRule struct {
+	id    string
+	calls int
+}
+
+func (r *countingRule) ID() string                      { return r.id }
+func (r *countingRule) Name() string                    { return "counting-" + r.id }
+func (r *countingRule) Description() string             { return "counts evaluations" }
+func (r *countingRule) DefaultSeverity() types.Severity { return types.SeverityError }
+func (r *countingRule) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
+	r.calls++
+	return nil
+}
+
+func TestChangedDomains_IgnoresSourceLocations(t *testing.T) {
+	old := types.NewModuleSnapshot("/old")
+	old.Variables["region"] = &types.VariableSignature{
+		Name:      "region",
+		Type:      "string",
+		DeclRange: types.FileRange{Filename: "/old/variables.tf", Line: 1},
+	}
+	new := types.NewModuleSnapshot("/new")
+	new.Variables["region"] = &types.VariableSignature{
+		Name:      "region",
+		Type:      "string",
+		DeclRange: types.FileRange{Filename: "/new/main.tf", Line: 40},
+	}
+
+	for domain, changed := range changedDomains(old, new) {
+		if changed {
+			t.Errorf("domain %s reported as changed", domain)
+		}
+	}
+
+	new.Variables["region"].Default = "eu-west-1"
+	changed := changedDomains(old, new)
+	if !changed[types.DomainVariables] {
+		t.Error("expected variables to be changed")
+	}
+	if changed[types.DomainOutputs] || changed[types.DomainResources] {
+		t.Errorf("expected only variables to be changed, got %v", changed)
+	}
+}
+
+func TestEngine_SkipsRulesWithUnchangedDomains(t *testing.T) {
+	variableRule := &countingRule{id: "BC001"}
+	outputRule := &countingRule{id: "BC009"}
+	movedRule := &countingRule{id: "BC104"}
+	pluginRule := &countingRule{id: "PLUGIN001"}
+
+	registry := NewRegistry()
+	for _, rule := range []*countingRule{variableRule, outputRule, movedRule, pluginRule} {
+		registry.Register(rule)
+	}
+	engine := NewEngine(registry)
+
+	old := types.NewModuleSnapshot("/old")
+	old.Outputs["id"] = &types.OutputSignature{Name: "id"}
+	new := types.NewModuleSnapshot("/new")
+	new.Outputs["id"] = &types.OutputSignature{Name: "id", Sensitive: true}
+
+	engine.Evaluate(old, new)
+
+	if variableRule.calls != 0 {
+		t.Errorf("expected the variable rule to be skipped, evaluated %d times", variableRule.calls)
+	}
+	if outputRule.calls != 1 {
+		t.Errorf("expected the output rule to run, evaluated %d times", outputRule.calls)
+	}
+	// Rules without a known domain always run
+	if movedRule.calls != 1 || pluginRule.calls != 1 {
+		t.Errorf("expected unmapped rules to run, got %d and %d evaluations", movedRule.calls, pluginRule.calls)
+	}
+}
+
+func TestRuleDomains_CoverRegisteredRules(t *testing.T) {
+	// Every built-in rule is either mapped or deliberately always run
+	alwaysRun := map[string]bool{"BC102": true, "BC103": true, "BC104": true, "RC017": true}
+	for _, rule := range DefaultRegistry.All() {
+		if _, ok := ruleDomains[rule.ID()]; !ok && !alwaysRun[rule.ID()] {
+			t.Errorf("rule %s has no domain mapping", rule.ID())
+		}
+	}
+}
+
+// TestEngine_SkippingNeverMissesFindings evaluates every scenario in both
+// directions and against itself, with and without skipping, and requires
+// the same findings.
+func TestEngine_SkippingNeverMissesFindings(t *testing.T) {
+	SetRenameDetectionSettings(&RenameDetectionSettings{Enabled: true, SimilarityThreshold: 0.5})
+	defer SetRenameDetectionSettings(DefaultRenameDetectionSettings())
+
+	_, filename, _, _ := runtime.Caller(0)
+	scenariosDir := filepath.Join(filepath.Dir(filename), "..", "..", "testdata", "scenarios")
+	entries, err := os.ReadDir(scenariosDir)
+	if err != nil {
+		t.Fatalf("failed to read scenarios: %v", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		t.Run(entry.Name(), func(t *testing.T) {
+			oldSnap, err := loader.Load(filepath.Join(scenariosDir, entry.Name(), "old"))
+			if err != nil {
+				t.Fatalf("failed to load old config: %v", err)
+			}
+			newSnap, err := loader.Load(filepath.Join(scenariosDir, entry.Name(), "new"))
+			if err != nil {
+				t.Fatalf("failed to load new config: %v", err)
+			}
+
+			pairs := [][2]*types.ModuleSnapshot{
+				{oldSnap, newSnap},
+				{newSnap, oldSnap},
+				{oldSnap, oldSnap},
+				{newSnap, newSnap},
+			}
+			for i, pair := range pairs {
+				fast := NewDefaultEngine()
+				full := NewDefaultEngine()
+				full.evaluateAll = true
+
+				got := findingKeys(fast.Evaluate(pair[0], pair[1]))
+				want := findingKeys(full.Evaluate(pair[0], pair[1]))
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("pair %d: skipping changed findings\n got: %v\nwant: %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+// findingKeys identifies findings by rule and message, sorted because rules
+// report variables in map order
+func findingKeys(findings []*types.Finding) []string {
+	keys := make([]string, len(findings))
+	for i, f := range findings {
+		keys[i] = f.RuleID + " " + f.Message
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// largeSnapshot builds a module with many declarations of every kind
+func largeSnapshot(path string) *types.ModuleSnapshot {
+	s := types.NewModuleSnapshot(path)
+	for i := 0; i < 500; i++ {
+		name := fmt.Sprintf("var_%d", i)
+		s.Variables[name] = &types.VariableSignature{
+			Name:    name,
+			Type:    "map(string)",
+			Default: map[string]interface{}{"key": name},
+		}
+		out := fmt.Sprintf("out_%d", i)
+		s.Outputs[out] = &types.OutputSignature{Name: out}
+		addr := fmt.Sprintf("aws_s3_bucket.b%d", i)
+		s.Resources[addr] = &types.ResourceSignature{Type: "aws_s3_bucket", Name: fmt.Sprintf("b%d", i), Address: addr}
+	}
+	for i := 0; i < 50; i++ {
+		name := fmt.Sprintf("mod_%d", i)
+		s.Modules[name] = &types.ModuleCallSignature{Name: name, Source: "./modules/" + name, Address: "module." + name}
+	}
+	return s
+}
+
+// BenchmarkEngineEvaluate_LocalizedEdit compares a large module in which a
+// single output changed, with and without skipping unchanged domains
+func BenchmarkEngineEvaluate_LocalizedEdit(b *testing.B) {
+	old := largeSnapshot("/old")
+	new := largeSnapshot("/new")
+	new.Outputs["out_0"].Sensitive = true
+
+	for _, evaluateAll := range []bool{false, true} {
+		name := "skip-unchanged"
+		if evaluateAll {
+			name = "evaluate-all"
+		}
+		b.Run(name, func(b *testing.B) {
+			engine := NewDefaultEngine()
+			engine.evaluateAll = evaluateAll
+			for i := 0; i < b.N; i++ {
+				engine.Evaluate(old, new)
+			}
+		})
+	}
+}
@@ -1,11 +1,21 @@
 package rules
 
-import "github.com/jokarl/tfbreak-core/internal/types"
+import (
+	"github.com/jokarl/tfbreak-core/internal/parallel"
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
 
-// Engine evaluates rules against module snapshots
+// Engine evaluates rules against module snapshots. Once configured, an
+// Engine can be used from multiple goroutines.
 type Engine struct {
 	registry *Registry
 	config   map[string]*RuleConfig
+
+	// parallelism is the number of rules evaluated concurrently (0 = GOMAXPROCS)
+	parallelism int
+
+	// evaluateAll turns off skipping rules whose domains are unchanged
+	evaluateAll bool
 }
 
 // NewEngine creates a new Engine with the given registry
@@ -23,9 +33,22 @@ func NewDefaultEngine() *Engine {
 	for _, rule := range DefaultRegistry.All() {
 		e.config[rule.ID()] = DefaultRuleConfig(rule)
 	}
+	// Rules that a scope enables are off by default
+	for _, ruleIDs := range scopeRules {
+		for _, ruleID := range ruleIDs {
+			e.DisableRule(ruleID)
+		}
+	}
 	return e
 }
 
+// SetParallelism sets how many rules are evaluated concurrently. 0 uses
+// GOMAXPROCS and 1 evaluates rules one after another. Findings are returned
+// in the same order either way.
+func (e *Engine) SetParallelism(n int) {
+	e.parallelism = n
+}
+
 // SetConfig sets the configuration for a specific rule
 func (e *Engine) SetConfig(ruleID string, config *RuleConfig) {
 	e.config[ruleID] = config
@@ -70,15 +93,42 @@ func (e *Engine) DisableAllRules() {
 	}
 }
 
-// Evaluate runs all enabled rules against the old and new snapshots
+// DisableNonInterfaceRules disables every rule that does not check the
+// module's public interface (see IsInterfaceRule)
+func (e *Engine) DisableNonInterfaceRules() {
+	for _, rule := range e.registry.All() {
+		if !IsInterfaceRule(rule.ID()) {
+			e.DisableRule(rule.ID())
+		}
+	}
+}
+
+// Evaluate runs all enabled rules against the old and new snapshots.
+// Rules whose snapshot domains are the same in both (see ruleDomains) are
+// skipped, since they have nothing to compare.
 func (e *Engine) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
-	var findings []*types.Finding
+	var changed map[types.Domain]bool
+	if !e.evaluateAll {
+		changed = changedDomains(old, new)
+	}
 
+	var enabled []Rule
 	for _, rule := range e.registry.All() {
-		cfg := e.GetConfig(rule.ID())
-		if cfg == nil || !cfg.Enabled {
+		if cfg := e.GetConfig(rule.ID()); cfg == nil || !cfg.Enabled {
 			continue
 		}
+		if changed != nil && canSkip(rule.ID(), changed) {
+			continue
+		}
+		enabled = append(enabled, rule)
+	}
+
+	// Rules are independent, so they run concurrently. Findings are
+	// collected per rule to keep them in registration order.
+	perRule := make([][]*types.Finding, len(enabled))
+	parallel.ForEach(e.parallelism, len(enabled), func(i int) {
+		rule := enabled[i]
+		cfg := e.GetConfig(rule.ID())
 
 		ruleFindings := rule.Evaluate(old, new)
 		for _, f := range ruleFindings {
@@ -86,8 +136,13 @@ func (e *Engine) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
 			if cfg.Severity != rule.DefaultSeverity() {
 				f.Severity = cfg.Severity
 			}
-			findings = append(findings, f)
 		}
+		perRule[i] = ruleFindings
+	})
+
+	var findings []*types.Finding
+	for _, ruleFindings := range perRule {
+		findings = append(findings, ruleFindings...)
 	}
 
 	// Apply rename detection suppression if enabled
@@ -209,6 +264,43 @@ func extractQuotedName(message string) string {
 type CheckOptions struct {
 	// IncludeRemediation populates remediation text for each finding
 	IncludeRemediation bool
+
+	// FailOnCategory sets per-category fail thresholds on the result,
+	// overriding failOn. See types.CheckResult.FailOnCategory.
+	FailOnCategory map[types.Category]types.Severity
+
+	// TreatWarningsAsErrors and TreatRiskyAsErrors are set on the result.
+	// See types.CheckResult.TreatWarningsAsErrors.
+	TreatWarningsAsErrors bool
+	TreatRiskyAsErrors    bool
+
+	// EscalateRequired raises the severity of findings for removed required
+	// variables. See applyImpactEscalation.
+	EscalateRequired bool
+
+	// EscalateFanOut raises the severity of findings for removed variables
+	// that at least this many outputs depend on; 0 disables it. See
+	// applyImpactEscalation.
+	EscalateFanOut int
+
+	// HelpURLBase overrides DefaultHelpURLBase when populating each
+	// finding's help URL
+	HelpURLBase string
+
+	// IgnoreWhitespace compares variable type expressions and validation
+	// conditions in a canonical layout, so purely cosmetic edits to them
+	// produce no findings. See normalizeSnapshot.
+	IgnoreWhitespace bool
+
+	// PathOverrides change the settings of rules for findings in matching
+	// files. See PathOverride.
+	PathOverrides []PathOverride
+
+	// OldRoot and NewRoot are the directories PathOverrides patterns are
+	// relative to. They default to oldPath and newPath; a check of one
+	// module among many sets them to the directories it was found in.
+	OldRoot string
+	NewRoot string
 }
 
 // Check runs the engine and returns a complete CheckResult
@@ -219,13 +311,34 @@ func (e *Engine) Check(oldPath, newPath string, old, new *types.ModuleSnapshot,
 // CheckWithOptions runs the engine with additional options
 func (e *Engine) CheckWithOptions(oldPath, newPath string, old, new *types.ModuleSnapshot, failOn types.Severity, opts CheckOptions) *types.CheckResult {
 	result := types.NewCheckResult(oldPath, newPath, failOn)
+	result.FailOnCategory = opts.FailOnCategory
+	result.TreatWarningsAsErrors = opts.TreatWarningsAsErrors
+	result.TreatRiskyAsErrors = opts.TreatRiskyAsErrors
+
+	if opts.IgnoreWhitespace {
+		old, new = normalizeSnapshot(old), normalizeSnapshot(new)
+	}
 
 	findings := e.Evaluate(old, new)
+	if opts.EscalateRequired || opts.EscalateFanOut > 0 {
+		applyImpactEscalation(findings, old, opts)
+	}
+	if len(opts.PathOverrides) > 0 {
+		oldRoot, newRoot := opts.OldRoot, opts.NewRoot
+		if oldRoot == "" {
+			oldRoot = oldPath
+		}
+		if newRoot == "" {
+			newRoot = newPath
+		}
+		findings = applyPathOverrides(findings, opts.PathOverrides, oldRoot, newRoot)
+	}
 	for _, f := range findings {
 		// Populate remediation if requested
 		if opts.IncludeRemediation {
 			e.populateRemediation(f)
 		}
+		f.HelpURL = HelpURL(opts.HelpURLBase, f.RuleID)
 		result.AddFinding(f)
 	}
 
@@ -1,6 +1,8 @@
 package rules
 
 import (
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/jokarl/tfbreak-core/internal/types"
@@ -132,6 +134,63 @@ func TestEngineDisableAllRulesThenEnable(t *testing.T) {
 	}
 }
 
+func TestEngineDisableNonInterfaceRules(t *testing.T) {
+	engine := NewDefaultEngine()
+	engine.DisableNonInterfaceRules()
+
+	old := types.NewModuleSnapshot("/old")
+	old.Variables["removed_var"] = &types.VariableSignature{Name: "removed_var"}
+	old.Outputs["removed_output"] = &types.OutputSignature{Name: "removed_output"}
+	old.Resources["aws_s3_bucket.main"] = &types.ResourceSignature{
+		Type:    "aws_s3_bucket",
+		Name:    "main",
+		Address: "aws_s3_bucket.main",
+	}
+
+	new := types.NewModuleSnapshot("/new")
+
+	ruleIDs := make(map[string]bool)
+	for _, f := range engine.Evaluate(old, new) {
+		ruleIDs[f.RuleID] = true
+	}
+
+	if !ruleIDs["BC002"] || !ruleIDs["BC009"] {
+		t.Errorf("expected interface rules BC002 and BC009 to run, got %v", ruleIDs)
+	}
+	if ruleIDs["BC100"] {
+		t.Error("BC100 should be skipped in interface-only mode")
+	}
+}
+
+func TestIsInterfaceRule(t *testing.T) {
+	tests := map[string]bool{
+		"BC001": true,
+		"RC013": true,
+		"BC009": true,
+		"BC100": false,
+		"BC104": false,
+		"BC201": true,
+		"RC202": true,
+		"BC203": true,
+		"RC301": true,
+		"AZ001": false,
+		"BC":    false,
+	}
+	for id, want := range tests {
+		if got := IsInterfaceRule(id); got != want {
+			t.Errorf("IsInterfaceRule(%q) = %v, want %v", id, got, want)
+		}
+	}
+}
+
+func TestInterfaceRules_CoverRegisteredRules(t *testing.T) {
+	for _, rule := range DefaultRegistry.All() {
+		if _, ok := interfaceRules[rule.ID()]; !ok {
+			t.Errorf("rule %s is not classified in interfaceRules", rule.ID())
+		}
+	}
+}
+
 func TestEngineCheck(t *testing.T) {
 	engine := NewDefaultEngine()
 
@@ -165,6 +224,63 @@ func TestEngineCheckPass(t *testing.T) {
 	}
 }
 
+func TestEngineCheck_HelpURL(t *testing.T) {
+	old := types.NewModuleSnapshot("/old")
+	new := types.NewModuleSnapshot("/new")
+	new.Variables["new_required"] = &types.VariableSignature{
+		Name:     "new_required",
+		Required: true,
+	}
+
+	tests := []struct {
+		name string
+		base string
+		want string
+	}{
+		{"default base", "", DefaultHelpURLBase + "BC001"},
+		{"custom base", "https://docs.example.com/rules/", "https://docs.example.com/rules/BC001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewDefaultEngine()
+			result := engine.CheckWithOptions("/old", "/new", old, new, types.SeverityError, CheckOptions{HelpURLBase: tt.base})
+
+			if len(result.Findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(result.Findings))
+			}
+			if got := result.Findings[0].HelpURL; got != tt.want {
+				t.Errorf("HelpURL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHelpURL_AllRules(t *testing.T) {
+	docs, err := os.ReadFile("../../docs/rules.md")
+	if err != nil {
+		t.Fatalf("failed to read rules.md: %v", err)
+	}
+
+	for _, r := range DefaultRegistry.All() {
+		url := HelpURL("", r.ID())
+		if url == "" {
+			t.Errorf("rule %s has no help URL", r.ID())
+			continue
+		}
+		// The URL fragment must match an explicit anchor in rules.md;
+		// GitHub's generated heading anchors differ from the rule ID.
+		anchor := url[strings.LastIndex(url, "#")+1:]
+		if !strings.Contains(string(docs), `<a id="`+anchor+`"></a>`) {
+			t.Errorf("rule %s: rules.md has no anchor for %q", r.ID(), url)
+		}
+	}
+
+	if got := HelpURL("", "PLUGIN001"); got != "" {
+		t.Errorf("HelpURL() for unregistered rule = %q, want empty", got)
+	}
+}
+
 func TestEngine_BC003_SuppressesBC001_BC002(t *testing.T) {
 	// Enable rename detection
 	SetRenameDetectionSettings(&RenameDetectionSettings{
@@ -348,3 +464,84 @@ func TestExtractQuotedName(t *testing.T) {
 		})
 	}
 }
+
+func TestEngine_MinConfidence_RevertsToAddRemove(t *testing.T) {
+	SetRenameDetectionSettings(&RenameDetectionSettings{
+		Enabled:             true,
+		SimilarityThreshold: 0.70,
+		MinConfidence:       0.90,
+	})
+	defer SetRenameDetectionSettings(DefaultRenameDetectionSettings())
+
+	engine := NewDefaultEngine()
+
+	old := types.NewModuleSnapshot("/old")
+	old.Variables["api_key"] = &types.VariableSignature{
+		Name:     "api_key",
+		Required: true,
+	}
+
+	new := types.NewModuleSnapshot("/new")
+	new.Variables["api_key_v2"] = &types.VariableSignature{
+		Name:     "api_key_v2",
+		Required: true,
+	}
+
+	findings := engine.Evaluate(old, new)
+
+	ruleIDs := make(map[string]int)
+	for _, f := range findings {
+		ruleIDs[f.RuleID]++
+	}
+
+	if ruleIDs["BC003"] != 0 {
+		t.Errorf("Expected low-confidence rename to be dropped, got %d BC003 findings", ruleIDs["BC003"])
+	}
+	if ruleIDs["BC001"] != 1 {
+		t.Errorf("Expected BC001 to be reported, got %d findings", ruleIDs["BC001"])
+	}
+	if ruleIDs["BC002"] != 1 {
+		t.Errorf("Expected BC002 to be reported, got %d findings", ruleIDs["BC002"])
+	}
+}
+
+func TestEngineParallelism(t *testing.T) {
+	old := types.NewModuleSnapshot("/old")
+	new := types.NewModuleSnapshot("/new")
+	old.Variables["removed"] = &types.VariableSignature{Name: "removed"}
+	old.Variables["retyped"] = &types.VariableSignature{Name: "retyped", Type: "string", Default: "a"}
+	new.Variables["retyped"] = &types.VariableSignature{Name: "retyped", Type: "number", Default: 1}
+	new.Variables["added"] = &types.VariableSignature{Name: "added", Required: true}
+	old.Outputs["gone"] = &types.OutputSignature{Name: "gone"}
+	old.Outputs["secret"] = &types.OutputSignature{Name: "secret"}
+	new.Outputs["secret"] = &types.OutputSignature{Name: "secret", Sensitive: true}
+	old.RequiredProviders["aws"] = &types.ProviderRequirement{Source: "hashicorp/aws", Version: ">= 4.0"}
+	new.RequiredProviders["aws"] = &types.ProviderRequirement{Source: "custom/aws", Version: ">= 5.0"}
+
+	evaluate := func(parallelism int) []string {
+		engine := NewDefaultEngine()
+		engine.SetConfig("BC001", &RuleConfig{Enabled: true, Severity: types.SeverityNotice})
+		engine.SetParallelism(parallelism)
+
+		var ids []string
+		for _, f := range engine.Evaluate(old, new) {
+			ids = append(ids, f.RuleID+":"+f.Severity.String())
+		}
+		return ids
+	}
+
+	sequential := evaluate(1)
+	if len(sequential) < 6 {
+		t.Fatalf("expected findings from several rules, got %v", sequential)
+	}
+	if sequential[0] != "BC001:NOTICE" {
+		t.Errorf("configured severity not applied: %v", sequential)
+	}
+
+	// Findings come back in rule registration order however rules are run
+	for _, parallelism := range []int{0, 4} {
+		if got := evaluate(parallelism); strings.Join(got, ",") != strings.Join(sequential, ",") {
+			t.Errorf("parallelism %d: findings = %v, want %v", parallelism, got, sequential)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package rules
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// applyImpactEscalation raises the severity of findings whose impact is
+// higher than their rule's configured severity suggests. Currently this
+// covers removed variables (BC002): removing a required variable breaks
+// every caller, while removing an optional one only breaks callers that
+// set it explicitly (opts.EscalateRequired). Likewise, a variable that
+// many outputs depend on changes more of the module's interface than an
+// unused one (opts.EscalateFanOut). Escalated findings are raised one level
+// (up to ERROR), however many reasons apply, and record their original
+// severity in the "escalated_from" metadata.
+func applyImpactEscalation(findings []*types.Finding, old *types.ModuleSnapshot, opts CheckOptions) {
+	for _, f := range findings {
+		if f.RuleID != "BC002" {
+			continue
+		}
+
+		name := extractVariableNameFromBC002(f)
+		v, ok := old.Variables[name]
+		if !ok {
+			continue
+		}
+
+		escalate := opts.EscalateRequired && v.Required
+		if opts.EscalateFanOut > 0 {
+			if fanOut := variableFanOut(old, name); fanOut >= opts.EscalateFanOut {
+				f.WithMetadata("fan_out", strconv.Itoa(fanOut))
+				escalate = true
+			}
+		}
+
+		if escalate {
+			escalateSeverity(f)
+		}
+	}
+}
+
+// variableFanOut returns the number of outputs in snap whose values depend
+// on the named variable
+func variableFanOut(snap *types.ModuleSnapshot, name string) int {
+	count := 0
+	for _, o := range snap.Outputs {
+		if slices.Contains(o.VariableRefs, name) {
+			count++
+		}
+	}
+	return count
+}
+
+// escalateSeverity raises a finding's severity by one level. Deprecations
+// describe a future break rather than a current one, so like notices they
+// are raised to WARNING.
+func escalateSeverity(f *types.Finding) {
+	if f.Severity >= types.SeverityError {
+		return
+	}
+	f.WithMetadata("escalated_from", f.Severity.String())
+	if f.Severity == types.SeverityWarning {
+		f.Severity = types.SeverityError
+	} else {
+		f.Severity = types.SeverityWarning
+	}
+}
@@ -0,0 +1,188 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+func newEscalationSnapshots() (*types.ModuleSnapshot, *types.ModuleSnapshot) {
+	old := types.NewModuleSnapshot("/old")
+	old.Variables["required_var"] = &types.VariableSignature{
+		Name:     "required_var",
+		Required: true,
+	}
+	old.Variables["optional_var"] = &types.VariableSignature{
+		Name:     "optional_var",
+		Default:  "x",
+		Required: false,
+	}
+	return old, types.NewModuleSnapshot("/new")
+}
+
+func newWarningBC002Engine() *Engine {
+	engine := NewDefaultEngine()
+	cfg := engine.GetConfig("BC002")
+	cfg.Severity = types.SeverityWarning
+	engine.SetConfig("BC002", cfg)
+	return engine
+}
+
+func severitiesByMessage(result *types.CheckResult) map[string]*types.Finding {
+	findings := make(map[string]*types.Finding)
+	for _, f := range result.Findings {
+		findings[extractQuotedName(f.Message)] = f
+	}
+	return findings
+}
+
+func TestEngine_EscalateRequired(t *testing.T) {
+	old, new := newEscalationSnapshots()
+
+	result := newWarningBC002Engine().CheckWithOptions("/old", "/new", old, new, types.SeverityError, CheckOptions{
+		EscalateRequired: true,
+	})
+	findings := severitiesByMessage(result)
+
+	required := findings["required_var"]
+	if required == nil || required.Severity != types.SeverityError {
+		t.Fatalf("required_var finding = %+v, want ERROR", required)
+	}
+	if required.Metadata["escalated_from"] != "WARNING" {
+		t.Errorf("escalated_from = %q, want WARNING", required.Metadata["escalated_from"])
+	}
+
+	optional := findings["optional_var"]
+	if optional == nil || optional.Severity != types.SeverityWarning {
+		t.Fatalf("optional_var finding = %+v, want WARNING", optional)
+	}
+	if _, ok := optional.Metadata["escalated_from"]; ok {
+		t.Error("optional_var should not be escalated")
+	}
+
+	if result.Result != "FAIL" {
+		t.Errorf("Result = %q, want FAIL from the escalated finding", result.Result)
+	}
+}
+
+func TestEngine_EscalateRequiredDisabled(t *testing.T) {
+	old, new := newEscalationSnapshots()
+
+	result := newWarningBC002Engine().CheckWithOptions("/old", "/new", old, new, types.SeverityError, CheckOptions{})
+
+	for name, f := range severitiesByMessage(result) {
+		if f.Severity != types.SeverityWarning {
+			t.Errorf("%s severity = %s, want WARNING without escalation", name, f.Severity)
+		}
+	}
+	if result.Result != "PASS" {
+		t.Errorf("Result = %q, want PASS", result.Result)
+	}
+}
+
+func TestEngine_EscalateRequiredCapsAtError(t *testing.T) {
+	old, new := newEscalationSnapshots()
+
+	result := NewDefaultEngine().CheckWithOptions("/old", "/new", old, new, types.SeverityError, CheckOptions{
+		EscalateRequired: true,
+	})
+
+	required := severitiesByMessage(result)["required_var"]
+	if required == nil || required.Severity != types.SeverityError {
+		t.Fatalf("required_var finding = %+v, want ERROR", required)
+	}
+	if _, ok := required.Metadata["escalated_from"]; ok {
+		t.Error("finding already at ERROR should not record escalation")
+	}
+}
+
+func TestEscalateSeverity(t *testing.T) {
+	tests := []struct {
+		from types.Severity
+		want types.Severity
+	}{
+		{types.SeverityNotice, types.SeverityWarning},
+		{types.SeverityDeprecation, types.SeverityWarning},
+		{types.SeverityWarning, types.SeverityError},
+		{types.SeverityError, types.SeverityError},
+	}
+	for _, tt := range tests {
+		f := types.NewFinding("BC002", "input-removed", tt.from, "removed")
+		escalateSeverity(f)
+		if f.Severity != tt.want {
+			t.Errorf("escalateSeverity(%s) = %s, want %s", tt.from, f.Severity, tt.want)
+		}
+	}
+}
+
+func newFanOutSnapshots() (*types.ModuleSnapshot, *types.ModuleSnapshot) {
+	old := types.NewModuleSnapshot("/old")
+	for _, name := range []string{"shared_var", "unused_var"} {
+		old.Variables[name] = &types.VariableSignature{Name: name, Default: "x"}
+	}
+	for _, name := range []string{"id", "arn", "name"} {
+		old.Outputs[name] = &types.OutputSignature{Name: name, VariableRefs: []string{"shared_var"}}
+	}
+	new := types.NewModuleSnapshot("/new")
+	new.Outputs = old.Outputs
+	return old, new
+}
+
+func TestEngine_EscalateFanOut(t *testing.T) {
+	old, new := newFanOutSnapshots()
+
+	result := newWarningBC002Engine().CheckWithOptions("/old", "/new", old, new, types.SeverityError, CheckOptions{
+		EscalateFanOut: 3,
+	})
+	findings := severitiesByMessage(result)
+
+	shared := findings["shared_var"]
+	if shared == nil || shared.Severity != types.SeverityError {
+		t.Fatalf("shared_var finding = %+v, want ERROR", shared)
+	}
+	if shared.Metadata["escalated_from"] != "WARNING" || shared.Metadata["fan_out"] != "3" {
+		t.Errorf("shared_var metadata = %v, want escalated_from WARNING and fan_out 3", shared.Metadata)
+	}
+
+	unused := findings["unused_var"]
+	if unused == nil || unused.Severity != types.SeverityWarning {
+		t.Fatalf("unused_var finding = %+v, want WARNING", unused)
+	}
+	if _, ok := unused.Metadata["fan_out"]; ok {
+		t.Error("unused_var should not be escalated")
+	}
+}
+
+func TestEngine_EscalateFanOutBelowThreshold(t *testing.T) {
+	old, new := newFanOutSnapshots()
+
+	for _, opts := range []CheckOptions{{}, {EscalateFanOut: 4}} {
+		result := newWarningBC002Engine().CheckWithOptions("/old", "/new", old, new, types.SeverityError, opts)
+		for name, f := range severitiesByMessage(result) {
+			if f.Severity != types.SeverityWarning {
+				t.Errorf("EscalateFanOut=%d: %s severity = %s, want WARNING", opts.EscalateFanOut, name, f.Severity)
+			}
+		}
+	}
+}
+
+func TestEngine_EscalateOncePerFinding(t *testing.T) {
+	old, new := newFanOutSnapshots()
+	old.Variables["shared_var"].Default = nil
+	old.Variables["shared_var"].Required = true
+
+	engine := NewDefaultEngine()
+	cfg := engine.GetConfig("BC002")
+	cfg.Severity = types.SeverityNotice
+	engine.SetConfig("BC002", cfg)
+
+	result := engine.CheckWithOptions("/old", "/new", old, new, types.SeverityError, CheckOptions{
+		EscalateRequired: true,
+		EscalateFanOut:   2,
+	})
+
+	shared := severitiesByMessage(result)["shared_var"]
+	if shared == nil || shared.Severity != types.SeverityWarning {
+		t.Fatalf("shared_var finding = %+v, want a single escalation to WARNING", shared)
+	}
+}
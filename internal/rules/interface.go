@@ -0,0 +1,52 @@
+package rules
+
+// interfaceRules records, for each built-in rule, whether it checks a
+// module's public interface: its variables and outputs, version constraints
+// and provider locks, or the sources and versions of the modules it calls.
+// Resource and moved block rules only affect existing state, so they are
+// not interface rules. Every built-in rule must be listed.
+var interfaceRules = map[string]bool{
+	"BC001": true,
+	"BC002": true,
+	"BC003": true,
+	"BC004": true,
+	"BC005": true,
+	"BC006": true,
+	"RC003": true,
+	"RC006": true,
+	"RC007": true,
+	"RC008": true,
+	"RC009": true,
+	"RC012": true,
+	"RC013": true,
+	"RC015": true,
+	"RC016": true,
+	"RC017": true,
+
+	"BC009": true,
+	"BC010": true,
+	"RC011": true,
+	"RC014": true,
+
+	"BC100": false,
+	"BC101": false,
+	"BC102": false,
+	"BC103": false,
+	"BC104": false,
+
+	"BC200": true,
+	"BC201": true,
+	"RC202": true,
+	"BC203": true,
+	"RC204": true,
+
+	"RC300": true,
+	"RC301": true,
+}
+
+// IsInterfaceRule returns true if the built-in rule checks a module's public
+// interface (see interfaceRules). Rules that are not built in (e.g., plugin
+// rules) return false.
+func IsInterfaceRule(ruleID string) bool {
+	return interfaceRules[ruleID]
+}
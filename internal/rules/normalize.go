@@ -0,0 +1,181 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// normalizeSnapshot returns a copy of a snapshot whose variable type
+// expressions and validation conditions are rewritten in a canonical
+// layout, so reformatting them (whitespace, line breaks, comments, trailing
+// commas) does not look like a change to the rules that compare them as
+// source text. The input snapshot is not modified.
+func normalizeSnapshot(s *types.ModuleSnapshot) *types.ModuleSnapshot {
+	normalized := *s
+	normalized.Variables = make(map[string]*types.VariableSignature, len(s.Variables))
+	for name, v := range s.Variables {
+		nv := *v
+		nv.Type = normalizeExpression(v.Type)
+		nv.TypeConstraint = normalizeExpression(v.TypeConstraint)
+		if v.Validations != nil {
+			nv.Validations = make([]types.ValidationBlock, len(v.Validations))
+			for i, validation := range v.Validations {
+				validation.Condition = normalizeExpression(validation.Condition)
+				nv.Validations[i] = validation
+			}
+		}
+		normalized.Variables[name] = &nv
+	}
+	return &normalized
+}
+
+// normalizeExpression reformats an HCL expression canonically: comments are
+// dropped, line breaks separating object attributes become commas, trailing
+// commas are dropped, and tokens are joined with single spaces where they
+// are needed. Template contents are kept as written. Expressions that do
+// not lex are returned unchanged.
+func normalizeExpression(src string) string {
+	if src == "" {
+		return src
+	}
+	tokens, diags := hclsyntax.LexExpression([]byte(src), "expr", hcl.InitialPos)
+	if diags.HasErrors() {
+		return src
+	}
+	return joinTokens(significantTokens(tokens))
+}
+
+// bracket is an open bracket in significantTokens. Line breaks separate
+// the items of an object constructor, but not of a for expression in braces.
+type bracket struct {
+	typ    hclsyntax.TokenType
+	object bool
+}
+
+// significantTokens drops comments, line breaks, and trailing commas,
+// turning line breaks between object attributes into commas
+func significantTokens(tokens hclsyntax.Tokens) []hclsyntax.Token {
+	var out []hclsyntax.Token
+	var open []bracket
+	templates := 0
+
+	last := func() hclsyntax.TokenType {
+		if len(out) == 0 {
+			return hclsyntax.TokenNil
+		}
+		return out[len(out)-1].Type
+	}
+
+	for _, tok := range tokens {
+		if templates > 0 {
+			switch tok.Type {
+			case hclsyntax.TokenOQuote, hclsyntax.TokenOHeredoc:
+				templates++
+			case hclsyntax.TokenCQuote, hclsyntax.TokenCHeredoc:
+				templates--
+			}
+			out = append(out, tok)
+			continue
+		}
+
+		switch tok.Type {
+		case hclsyntax.TokenEOF:
+			continue
+		case hclsyntax.TokenComment, hclsyntax.TokenNewline:
+			// Line comments end with the line break they replace
+			if tok.Type == hclsyntax.TokenComment && !strings.HasSuffix(string(tok.Bytes), "\n") {
+				continue
+			}
+			if len(open) > 0 && open[len(open)-1].object {
+				if prev := last(); prev != hclsyntax.TokenOBrace && prev != hclsyntax.TokenComma {
+					out = append(out, hclsyntax.Token{Type: hclsyntax.TokenComma, Bytes: []byte(",")})
+				}
+			}
+			continue
+		case hclsyntax.TokenOQuote, hclsyntax.TokenOHeredoc:
+			templates++
+		case hclsyntax.TokenOParen, hclsyntax.TokenOBrack, hclsyntax.TokenOBrace:
+			open = append(open, bracket{typ: tok.Type, object: tok.Type == hclsyntax.TokenOBrace})
+		case hclsyntax.TokenCParen, hclsyntax.TokenCBrack, hclsyntax.TokenCBrace:
+			if len(open) > 0 {
+				open = open[:len(open)-1]
+			}
+			if last() == hclsyntax.TokenComma {
+				out = out[:len(out)-1]
+			}
+		case hclsyntax.TokenIdent:
+			if string(tok.Bytes) == "for" && last() == hclsyntax.TokenOBrace && len(open) > 0 {
+				open[len(open)-1].object = false
+			}
+		}
+		out = append(out, tok)
+	}
+	return out
+}
+
+// joinTokens joins tokens with a single space where one is needed for
+// readability. Template contents are joined without spaces, since their
+// literal parts carry their own.
+func joinTokens(tokens []hclsyntax.Token) string {
+	var b strings.Builder
+	templates := 0
+	for i, tok := range tokens {
+		closing := tok.Type == hclsyntax.TokenCQuote || tok.Type == hclsyntax.TokenCHeredoc
+		if i > 0 && templates == 0 && needsSpace(tokens, i) {
+			b.WriteByte(' ')
+		}
+		b.Write(tok.Bytes)
+
+		switch {
+		case tok.Type == hclsyntax.TokenOQuote || tok.Type == hclsyntax.TokenOHeredoc:
+			templates++
+		case closing && templates > 0:
+			templates--
+		}
+	}
+	return b.String()
+}
+
+// needsSpace reports whether tokens[i] is separated from the token before it
+func needsSpace(tokens []hclsyntax.Token, i int) bool {
+	prev, cur := tokens[i-1], tokens[i]
+
+	switch prev.Type {
+	case hclsyntax.TokenOParen, hclsyntax.TokenOBrack, hclsyntax.TokenOBrace,
+		hclsyntax.TokenDot, hclsyntax.TokenBang:
+		return false
+	case hclsyntax.TokenMinus:
+		// Unary minus binds to its operand
+		if i < 2 || !endsValue(tokens[i-2].Type) {
+			return false
+		}
+	}
+
+	switch cur.Type {
+	case hclsyntax.TokenCParen, hclsyntax.TokenCBrack, hclsyntax.TokenCBrace,
+		hclsyntax.TokenComma, hclsyntax.TokenDot, hclsyntax.TokenEllipsis:
+		return false
+	case hclsyntax.TokenOParen:
+		// Function call
+		return prev.Type != hclsyntax.TokenIdent
+	case hclsyntax.TokenOBrack:
+		// Index
+		return !endsValue(prev.Type)
+	}
+	return true
+}
+
+// endsValue reports whether a token can end an operand, as opposed to an
+// operator or open bracket
+func endsValue(t hclsyntax.TokenType) bool {
+	switch t {
+	case hclsyntax.TokenIdent, hclsyntax.TokenNumberLit, hclsyntax.TokenCParen,
+		hclsyntax.TokenCBrack, hclsyntax.TokenCBrace, hclsyntax.TokenCQuote,
+		hclsyntax.TokenCHeredoc:
+		return true
+	}
+	return false
+}
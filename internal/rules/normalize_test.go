@@ -0,0 +1,93 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+func TestNormalizeExpression(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"primitive", "string", "string"},
+		{"call spacing", "map( string )", "map(string)"},
+		{"object on one line", "object({a=string,b=list(number)})", "object({a = string, b = list(number)})"},
+		{
+			"object across lines with comments",
+			"object({\n  a = string # name\n  // tags\n  b = list(number), /* ids */\n})",
+			"object({a = string, b = list(number)})",
+		},
+		{"optional default", `object({ tier = optional(string, "standard") })`, `object({tier = optional(string, "standard")})`},
+		{"trailing comma", `contains([ "dev", "prod", ], var.env)`, `contains(["dev", "prod"], var.env)`},
+		{
+			"list across lines",
+			"contains([\n  \"dev\",  # development\n  \"prod\",\n], var.env)",
+			`contains(["dev", "prod"], var.env)`,
+		},
+		{"operators", "var.n>=-1&&var.n<=10", "var.n >= -1 && var.n <= 10"},
+		{"binary minus", "var.n -1 > 0", "var.n - 1 > 0"},
+		{"index", "var.list [0] == var.map[\"k\"]", `var.list[0] == var.map["k"]`},
+		{"template kept as written", `"${ var.a }-x  y"`, `"${var.a}-x  y"`},
+		{"for expression in braces", "{\n  for k, v in var.m :\n  k => v\n}", "{for k, v in var.m : k => v}"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		if got := normalizeExpression(tt.src); got != tt.want {
+			t.Errorf("%s: normalizeExpression(%q) = %q, want %q", tt.name, tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeSnapshot_LeavesInputUnchanged(t *testing.T) {
+	old := types.NewModuleSnapshot("/old")
+	old.Variables["env"] = &types.VariableSignature{
+		Name:           "env",
+		Type:           "map( string )",
+		TypeConstraint: "map( string )",
+		Validations:    []types.ValidationBlock{{Condition: "length(var.env)>0", ErrorMessage: "empty"}},
+	}
+
+	normalized := normalizeSnapshot(old)
+
+	v := normalized.Variables["env"]
+	if v.Type != "map(string)" || v.TypeConstraint != "map(string)" {
+		t.Errorf("type = %q, constraint = %q, want map(string)", v.Type, v.TypeConstraint)
+	}
+	if v.Validations[0].Condition != "length(var.env) > 0" || v.Validations[0].ErrorMessage != "empty" {
+		t.Errorf("validation = %+v", v.Validations[0])
+	}
+	if orig := old.Variables["env"]; orig.Type != "map( string )" || orig.Validations[0].Condition != "length(var.env)>0" {
+		t.Errorf("input snapshot was modified: %+v", orig)
+	}
+}
+
+func TestEngine_IgnoreWhitespace_CommentedContainsList(t *testing.T) {
+	variable := func(path, condition string) *types.ModuleSnapshot {
+		s := types.NewModuleSnapshot(path)
+		s.Variables["env"] = &types.VariableSignature{
+			Name:        "env",
+			Type:        "string",
+			Validations: []types.ValidationBlock{{Condition: condition}},
+		}
+		return s
+	}
+	old := variable("/old", `contains(["dev", "prod"], var.env)`)
+	new := variable("/new", "contains([\n  \"dev\", # development\n], var.env)")
+
+	engine := NewDefaultEngine()
+	result := engine.CheckWithOptions("/old", "/new", old, new, types.SeverityError, CheckOptions{IgnoreWhitespace: true})
+
+	// Comments no longer hide the list from RC013
+	var found bool
+	for _, f := range result.Findings {
+		if f.RuleID == "RC013" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected RC013 for the removed value, got %+v", result.Findings)
+	}
+}
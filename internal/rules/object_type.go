@@ -0,0 +1,141 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+// attributeChangeKind classifies how an object type attribute changed
+type attributeChangeKind string
+
+const (
+	// attributeRemoved is an attribute that no longer exists
+	attributeRemoved attributeChangeKind = "removed"
+	// attributeAddedRequired is a new attribute without optional()
+	attributeAddedRequired attributeChangeKind = "added"
+	// attributeRequired is an attribute that lost optional()
+	attributeRequired attributeChangeKind = "required"
+	// attributeOptional is an attribute that gained optional()
+	attributeOptional attributeChangeKind = "optional"
+)
+
+// attributeChange is a change to one attribute of an object type. Path
+// names the attribute the way optional() default paths do, e.g.
+// "network.cidr" or "rules[*].port".
+type attributeChange struct {
+	Path string
+	Kind attributeChangeKind
+}
+
+// diffObjectAttributes returns the attributes of the object types within
+// old and new that were removed, added as required, or whose optional()
+// marker changed. Object types are compared wherever both types have the
+// same structure around them: inside other objects, the elements of
+// collections of the same kind, and tuples of the same length. Adding an
+// optional attribute is not a change callers notice and is not reported.
+func diffObjectAttributes(old, new cty.Type) []attributeChange {
+	var changes []attributeChange
+	walkObjectAttributes(old, new, "", &changes)
+	return changes
+}
+
+func walkObjectAttributes(old, new cty.Type, path string, changes *[]attributeChange) {
+	switch {
+	case old.IsObjectType() && new.IsObjectType():
+		oldAttrs, newAttrs := old.AttributeTypes(), new.AttributeTypes()
+		for _, name := range sortedAttributeNames(oldAttrs, newAttrs) {
+			attrPath := joinAttributePath(path, name)
+			oldAttr, inOld := oldAttrs[name]
+			newAttr, inNew := newAttrs[name]
+			switch {
+			case !inNew:
+				*changes = append(*changes, attributeChange{Path: attrPath, Kind: attributeRemoved})
+				continue
+			case !inOld:
+				if !new.AttributeOptional(name) {
+					*changes = append(*changes, attributeChange{Path: attrPath, Kind: attributeAddedRequired})
+				}
+				continue
+			case old.AttributeOptional(name) && !new.AttributeOptional(name):
+				*changes = append(*changes, attributeChange{Path: attrPath, Kind: attributeRequired})
+			case !old.AttributeOptional(name) && new.AttributeOptional(name):
+				*changes = append(*changes, attributeChange{Path: attrPath, Kind: attributeOptional})
+			}
+			walkObjectAttributes(oldAttr, newAttr, attrPath, changes)
+		}
+	case sameCollectionKind(old, new):
+		walkObjectAttributes(old.ElementType(), new.ElementType(), path+"[*]", changes)
+	case old.IsTupleType() && new.IsTupleType():
+		oldElems, newElems := old.TupleElementTypes(), new.TupleElementTypes()
+		if len(oldElems) != len(newElems) {
+			return
+		}
+		for i := range oldElems {
+			walkObjectAttributes(oldElems[i], newElems[i], fmt.Sprintf("%s[%d]", path, i), changes)
+		}
+	}
+}
+
+// sameTypeIgnoringAttributes reports whether old and new are the same type
+// apart from the attribute sets and optional() markers of object types,
+// i.e. whether diffObjectAttributes accounts for every difference
+func sameTypeIgnoringAttributes(old, new cty.Type) bool {
+	switch {
+	case old.IsObjectType() && new.IsObjectType():
+		oldAttrs, newAttrs := old.AttributeTypes(), new.AttributeTypes()
+		for name, oldAttr := range oldAttrs {
+			if newAttr, ok := newAttrs[name]; ok && !sameTypeIgnoringAttributes(oldAttr, newAttr) {
+				return false
+			}
+		}
+		return true
+	case sameCollectionKind(old, new):
+		return sameTypeIgnoringAttributes(old.ElementType(), new.ElementType())
+	case old.IsTupleType() && new.IsTupleType():
+		oldElems, newElems := old.TupleElementTypes(), new.TupleElementTypes()
+		if len(oldElems) != len(newElems) {
+			return false
+		}
+		for i := range oldElems {
+			if !sameTypeIgnoringAttributes(oldElems[i], newElems[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return old.Equals(new)
+	}
+}
+
+// sameCollectionKind reports whether old and new are both lists, both sets,
+// or both maps
+func sameCollectionKind(old, new cty.Type) bool {
+	return (old.IsListType() && new.IsListType()) ||
+		(old.IsSetType() && new.IsSetType()) ||
+		(old.IsMapType() && new.IsMapType())
+}
+
+// sortedAttributeNames returns the attribute names of both objects, sorted
+func sortedAttributeNames(a, b map[string]cty.Type) []string {
+	names := make([]string, 0, len(a)+len(b))
+	for name := range a {
+		names = append(names, name)
+	}
+	for name := range b {
+		if _, ok := a[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// joinAttributePath appends an attribute name to an attribute path
+func joinAttributePath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
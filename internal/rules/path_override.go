@@ -0,0 +1,83 @@
+package rules
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// PathOverride changes the settings of a rule for its findings in files
+// matching a glob, so that e.g. published modules can be checked more
+// strictly than internal ones. Unset fields keep the finding as it is.
+type PathOverride struct {
+	// Pattern is a doublestar glob matched against the finding's file,
+	// relative to the compared directory, e.g. "modules/**"
+	Pattern string
+
+	// RuleID is the rule whose findings the override applies to
+	RuleID string
+
+	// Enabled set to false drops the rule's findings in matching files.
+	// A rule that is disabled everywhere does not run, so an override
+	// cannot enable it for some paths.
+	Enabled *bool
+
+	// Severity replaces the severity of the rule's findings in matching files
+	Severity *types.Severity
+}
+
+// applyPathOverrides applies overrides to the findings whose file matches
+// their pattern and returns the findings still enabled. A finding's file is
+// its new location relative to newRoot, or its old location relative to
+// oldRoot if it has no new location. When several overrides match, the last
+// one wins.
+func applyPathOverrides(findings []*types.Finding, overrides []PathOverride, oldRoot, newRoot string) []*types.Finding {
+	kept := findings[:0]
+	for _, f := range findings {
+		path := findingPath(f, oldRoot, newRoot)
+		enabled := true
+		for _, o := range overrides {
+			if o.RuleID != f.RuleID || path == "" {
+				continue
+			}
+			if match, _ := doublestar.Match(o.Pattern, path); !match {
+				continue
+			}
+			if o.Enabled != nil {
+				enabled = *o.Enabled
+			}
+			if o.Severity != nil {
+				f.Severity = *o.Severity
+			}
+		}
+		if enabled {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// findingPath returns the slash-separated path of a finding's file relative
+// to the directory it was loaded from, or empty if it has no location
+func findingPath(f *types.Finding, oldRoot, newRoot string) string {
+	filename, root := "", ""
+	switch {
+	case f.NewLocation != nil && f.NewLocation.Filename != "":
+		filename, root = f.NewLocation.Filename, newRoot
+	case f.OldLocation != nil && f.OldLocation.Filename != "":
+		filename, root = f.OldLocation.Filename, oldRoot
+	default:
+		return ""
+	}
+
+	// The loader records absolute filenames
+	if abs, err := filepath.Abs(root); err == nil && filepath.IsAbs(filename) {
+		if rel, err := filepath.Rel(abs, filename); err == nil && !strings.HasPrefix(rel, "..") {
+			filename = rel
+		}
+	}
+	return filepath.ToSlash(filename)
+}
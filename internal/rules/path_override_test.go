@@ -0,0 +1,126 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+func TestApplyPathOverrides(t *testing.T) {
+	enabled, disabled := true, false
+	errorSev, noticeSev := types.SeverityError, types.SeverityNotice
+
+	finding := func(ruleID string, severity types.Severity, oldFile, newFile string) *types.Finding {
+		f := types.NewFinding(ruleID, "", severity, ruleID)
+		if oldFile != "" {
+			f.OldLocation = &types.FileRange{Filename: oldFile, Line: 1}
+		}
+		if newFile != "" {
+			f.NewLocation = &types.FileRange{Filename: newFile, Line: 1}
+		}
+		return f
+	}
+
+	tests := []struct {
+		name         string
+		overrides    []PathOverride
+		finding      *types.Finding
+		wantKept     bool
+		wantSeverity types.Severity
+	}{
+		{
+			name:         "severity raised in matching path",
+			overrides:    []PathOverride{{Pattern: "modules/**", RuleID: "RC006", Severity: &errorSev}},
+			finding:      finding("RC006", types.SeverityWarning, "/old/modules/vpc/variables.tf", "/new/modules/vpc/variables.tf"),
+			wantKept:     true,
+			wantSeverity: types.SeverityError,
+		},
+		{
+			name:         "other path unchanged",
+			overrides:    []PathOverride{{Pattern: "modules/**", RuleID: "RC006", Severity: &errorSev}},
+			finding:      finding("RC006", types.SeverityWarning, "/old/environments/dev/variables.tf", "/new/environments/dev/variables.tf"),
+			wantKept:     true,
+			wantSeverity: types.SeverityWarning,
+		},
+		{
+			name:         "other rule unchanged",
+			overrides:    []PathOverride{{Pattern: "modules/**", RuleID: "BC004", Severity: &noticeSev}},
+			finding:      finding("RC006", types.SeverityWarning, "", "/new/modules/vpc/variables.tf"),
+			wantKept:     true,
+			wantSeverity: types.SeverityWarning,
+		},
+		{
+			name:      "disabled in matching path",
+			overrides: []PathOverride{{Pattern: "environments/**", RuleID: "BC002", Enabled: &disabled}},
+			finding:   finding("BC002", types.SeverityError, "/old/environments/dev/variables.tf", ""),
+			wantKept:  false,
+		},
+		{
+			name: "last matching override wins",
+			overrides: []PathOverride{
+				{Pattern: "**", RuleID: "BC002", Enabled: &disabled, Severity: &noticeSev},
+				{Pattern: "modules/**", RuleID: "BC002", Enabled: &enabled},
+			},
+			finding:      finding("BC002", types.SeverityError, "/old/modules/vpc/main.tf", ""),
+			wantKept:     true,
+			wantSeverity: types.SeverityNotice,
+		},
+		{
+			name:         "finding without location unchanged",
+			overrides:    []PathOverride{{Pattern: "**", RuleID: "BC200", Enabled: &disabled}},
+			finding:      finding("BC200", types.SeverityError, "", ""),
+			wantKept:     true,
+			wantSeverity: types.SeverityError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept := applyPathOverrides([]*types.Finding{tt.finding}, tt.overrides, "/old", "/new")
+			if !tt.wantKept {
+				if len(kept) != 0 {
+					t.Errorf("expected the finding to be dropped")
+				}
+				return
+			}
+			if len(kept) != 1 {
+				t.Fatalf("expected the finding to be kept")
+			}
+			if kept[0].Severity != tt.wantSeverity {
+				t.Errorf("Severity = %v, want %v", kept[0].Severity, tt.wantSeverity)
+			}
+		})
+	}
+}
+
+func TestEngineCheck_PathOverrides(t *testing.T) {
+	old := types.NewModuleSnapshot("/repo/old/modules/vpc")
+	new := types.NewModuleSnapshot("/repo/new/modules/vpc")
+	new.Variables["new_required"] = &types.VariableSignature{
+		Name:      "new_required",
+		Required:  true,
+		DeclRange: types.FileRange{Filename: "/repo/new/modules/vpc/variables.tf", Line: 1},
+	}
+
+	warning := types.SeverityWarning
+	opts := CheckOptions{
+		PathOverrides: []PathOverride{{Pattern: "modules/**", RuleID: "BC001", Severity: &warning}},
+		OldRoot:       "/repo/old",
+		NewRoot:       "/repo/new",
+	}
+	result := NewDefaultEngine().CheckWithOptions("/repo/old/modules/vpc", "/repo/new/modules/vpc", old, new, types.SeverityError, opts)
+
+	if len(result.Findings) != 1 || result.Findings[0].Severity != types.SeverityWarning {
+		t.Fatalf("expected one BC001 finding at WARNING, got %+v", result.Findings)
+	}
+	if result.Result != "PASS" {
+		t.Errorf("Result = %q, want PASS once the override lowered the severity", result.Result)
+	}
+
+	// Without roots, paths are relative to the module itself
+	opts.OldRoot, opts.NewRoot = "", ""
+	result = NewDefaultEngine().CheckWithOptions("/repo/old/modules/vpc", "/repo/new/modules/vpc", old, new, types.SeverityError, opts)
+	if result.Result != "FAIL" {
+		t.Errorf("Result = %q, want FAIL when the pattern does not match", result.Result)
+	}
+}
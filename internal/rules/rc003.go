@@ -87,7 +87,7 @@ func (r *RC003) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
 	// For each removed variable, try to find a matching added optional variable
 	for oldName, oldVar := range removedVars {
 		match, similarity, found := FindBestMatch(oldName, addedOptionalVars, threshold)
-		if !found {
+		if !found || !meetsMinConfidence(similarity) {
 			continue
 		}
 
@@ -100,9 +100,11 @@ func (r *RC003) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
 			fmt.Sprintf("Variable %q was renamed to %q", oldName, match),
 		).WithOldLocation(&oldVar.DeclRange).
 			WithNewLocation(&newVar.DeclRange).
+			WithRelatedLocation(&oldVar.DeclRange).
 			WithDetail(fmt.Sprintf("Similarity: %.2f (threshold: %.2f). Callers explicitly setting %q will have their value ignored.", similarity, threshold, oldName)).
 			WithMetadata("old_name", oldName).
-			WithMetadata("new_name", match)
+			WithMetadata("new_name", match).
+			WithMetadata("confidence", formatConfidence(similarity))
 
 		findings = append(findings, finding)
 
@@ -68,8 +68,8 @@ func (r *RC006) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
 			continue
 		}
 
-		// Compare default values using JSON serialization
-		if !defaultsEqual(oldVar.Default, newVar.Default) {
+		// Compare default values as their declared types
+		if !variableDefaultsEqual(oldVar, newVar) {
 			finding := types.NewFinding(
 				r.ID(),
 				r.Name(),
@@ -86,28 +86,6 @@ func (r *RC006) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
 	return findings
 }
 
-// defaultsEqual compares two default values using JSON serialization
-func defaultsEqual(a, b interface{}) bool {
-	// Handle nil cases
-	if a == nil && b == nil {
-		return true
-	}
-	if a == nil || b == nil {
-		return false
-	}
-
-	// Serialize to JSON for comparison
-	aJSON, errA := json.Marshal(a)
-	bJSON, errB := json.Marshal(b)
-
-	if errA != nil || errB != nil {
-		// If serialization fails, fall back to direct comparison
-		return a == b
-	}
-
-	return string(aJSON) == string(bJSON)
-}
-
 // formatDefault formats a default value for display
 func formatDefault(v interface{}) string {
 	if v == nil {
@@ -1,6 +1,7 @@
 package rules
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/jokarl/tfbreak-core/internal/types"
@@ -117,3 +118,39 @@ func TestRC006_ComplexDefaultUnchanged(t *testing.T) {
 		t.Errorf("expected 0 findings when complex default unchanged, got %d", len(findings))
 	}
 }
+
+func TestRC006_EquivalentDefaults(t *testing.T) {
+	tests := []struct {
+		name       string
+		typ        string
+		oldDefault interface{}
+		newDefault interface{}
+		wantChange bool
+	}{
+		{"int and float", "number", 1, 1.0, false},
+		{"json number", "", json.Number("1.0"), float64(1), false},
+		{"number as string", "number", "1", 1, false},
+		{"string as number", "string", 1, "1", false},
+		{"bool as string", "bool", "true", true, false},
+		{"set order", "set(string)", []interface{}{"a", "b"}, []interface{}{"b", "a"}, false},
+		{"nested numbers", "map(list(number))", map[string]interface{}{"x": []interface{}{1, 2}}, map[string]interface{}{"x": []interface{}{1.0, "2"}}, false},
+		{"list order", "list(string)", []interface{}{"a", "b"}, []interface{}{"b", "a"}, true},
+		{"untyped string and number", "", "1", 1, true},
+		{"different number", "number", 1, 1.5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := types.NewModuleSnapshot("/old")
+			old.Variables["v"] = &types.VariableSignature{Name: "v", Type: tt.typ, Default: tt.oldDefault}
+
+			new := types.NewModuleSnapshot("/new")
+			new.Variables["v"] = &types.VariableSignature{Name: "v", Type: tt.typ, Default: tt.newDefault}
+
+			findings := (&RC006{}).Evaluate(old, new)
+			if got := len(findings) == 1; got != tt.wantChange {
+				t.Errorf("changed = %v, want %v (findings: %d)", got, tt.wantChange, len(findings))
+			}
+		})
+	}
+}
@@ -46,6 +46,8 @@ func (r *RC007) Documentation() *RuleDoc {
   nullable = false  # No longer accepts null!
 }`,
 		Remediation: `This is a RISKY change because callers explicitly passing null will fail.
+If the variable has no default, the finding is raised to ERROR: callers
+passing null have no default to fall back to and always fail.
 Consider:
 1. Keep nullable = true if callers may pass null
 2. Provide a meaningful default value for callers passing null
@@ -82,6 +84,13 @@ func (r *RC007) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
 		).WithOldLocation(&oldVar.DeclRange).
 			WithNewLocation(&newVar.DeclRange)
 
+		// Without a default, null cannot be replaced by one, so every caller
+		// passing null now fails
+		if !newNullable && newVar.Required {
+			finding.Message += " (no default to fall back to)"
+			escalateSeverity(finding)
+		}
+
 		findings = append(findings, finding)
 	}
 
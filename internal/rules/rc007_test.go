@@ -1,6 +1,7 @@
 package rules
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/jokarl/tfbreak-core/internal/types"
@@ -48,6 +49,77 @@ func TestRC007_NullableChanged_TrueToFalse(t *testing.T) {
 	}
 }
 
+func TestRC007_NullableFalseWithoutDefault_Error(t *testing.T) {
+	rule := &RC007{}
+
+	old := types.NewModuleSnapshot("/old")
+	old.Variables["my_var"] = &types.VariableSignature{
+		Name:     "my_var",
+		Required: true,
+		DeclRange: types.FileRange{
+			Filename: "variables.tf",
+			Line:     1,
+		},
+	}
+
+	new := types.NewModuleSnapshot("/new")
+	new.Variables["my_var"] = &types.VariableSignature{
+		Name:     "my_var",
+		Nullable: boolPtr(false),
+		Required: true,
+		DeclRange: types.FileRange{
+			Filename: "variables.tf",
+			Line:     1,
+		},
+	}
+
+	findings := rule.Evaluate(old, new)
+
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(findings))
+	}
+
+	f := findings[0]
+	if f.Severity != types.SeverityError {
+		t.Errorf("Severity = %v, want %v", f.Severity, types.SeverityError)
+	}
+	if f.Metadata["escalated_from"] != "WARNING" {
+		t.Errorf("escalated_from = %q, want WARNING", f.Metadata["escalated_from"])
+	}
+	if !strings.Contains(f.Message, "no default") {
+		t.Errorf("expected message to mention the missing default, got %q", f.Message)
+	}
+}
+
+func TestRC007_NullableFalseWithDefault_Warning(t *testing.T) {
+	rule := &RC007{}
+
+	old := types.NewModuleSnapshot("/old")
+	old.Variables["my_var"] = &types.VariableSignature{
+		Name:    "my_var",
+		Default: "a",
+	}
+
+	new := types.NewModuleSnapshot("/new")
+	new.Variables["my_var"] = &types.VariableSignature{
+		Name:     "my_var",
+		Default:  "a",
+		Nullable: boolPtr(false),
+	}
+
+	findings := rule.Evaluate(old, new)
+
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(findings))
+	}
+	if findings[0].Severity != types.SeverityWarning {
+		t.Errorf("Severity = %v, want %v", findings[0].Severity, types.SeverityWarning)
+	}
+	if _, ok := findings[0].Metadata["escalated_from"]; ok {
+		t.Error("expected no escalation for a variable with a default")
+	}
+}
+
 func TestRC007_NullableChanged_FalseToTrue(t *testing.T) {
 	rule := &RC007{}
 
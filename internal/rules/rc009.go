@@ -0,0 +1,115 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// RC009 detects when an optional() object attribute default is removed or changed
+type RC009 struct{}
+
+func init() {
+	Register(&RC009{})
+}
+
+func (r *RC009) ID() string {
+	return "RC009"
+}
+
+func (r *RC009) Name() string {
+	return "input-optional-default-changed"
+}
+
+func (r *RC009) Description() string {
+	return "An optional object attribute's default value was removed or changed, which may cause unexpected behavior"
+}
+
+func (r *RC009) DefaultSeverity() types.Severity {
+	return types.SeverityWarning
+}
+
+func (r *RC009) Documentation() *RuleDoc {
+	return &RuleDoc{
+		ID:              r.ID(),
+		Name:            r.Name(),
+		DefaultSeverity: r.DefaultSeverity(),
+		Description:     r.Description(),
+		ExampleOld: `variable "settings" {
+  type = object({
+    name = string
+    tier = optional(string, "standard")
+  })
+}`,
+		ExampleNew: `variable "settings" {
+  type = object({
+    name = string
+    tier = optional(string, "premium")  # Changed!
+  })
+}`,
+		Remediation: `This is a RISKY change because callers that omit the attribute
+silently get a different value (or null if the default was removed). Consider:
+1. Documenting the change in your changelog
+2. Notifying callers so they can set the attribute explicitly
+3. Using an annotation if this is intentional:
+   # tfbreak:ignore input-optional-default-changed # intentional upgrade`,
+	}
+}
+
+func (r *RC009) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
+	var findings []*types.Finding
+
+	for name, oldVar := range old.Variables {
+		newVar, exists := new.Variables[name]
+		if !exists {
+			// Variable was removed - handled by BC002
+			continue
+		}
+
+		paths := make([]string, 0, len(oldVar.OptionalDefaults))
+		for path := range oldVar.OptionalDefaults {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			oldDefault := oldVar.OptionalDefaults[path]
+			if oldDefault == nil {
+				// No default before, nothing to remove or change
+				continue
+			}
+
+			newDefault, exists := newVar.OptionalDefaults[path]
+			if !exists {
+				// Attribute removed or no longer optional - handled by BC006
+				continue
+			}
+
+			var message string
+			switch {
+			case newDefault == nil:
+				message = fmt.Sprintf("Variable %q optional attribute %q default removed (was %s)",
+					name, path, formatDefault(oldDefault))
+			case !defaultsEqual(oldDefault, newDefault):
+				message = fmt.Sprintf("Variable %q optional attribute %q default changed: %s -> %s",
+					name, path, formatDefault(oldDefault), formatDefault(newDefault))
+			default:
+				continue
+			}
+
+			finding := types.NewFinding(
+				r.ID(),
+				r.Name(),
+				r.DefaultSeverity(),
+				message,
+			).WithOldLocation(&oldVar.DeclRange).
+				WithNewLocation(&newVar.DeclRange).
+				WithMetadata("attribute", path)
+
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
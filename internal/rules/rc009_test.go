@@ -0,0 +1,150 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+func optionalDefaultsSnapshot(path string, defaults map[string]interface{}) *types.ModuleSnapshot {
+	snap := types.NewModuleSnapshot(path)
+	snap.Variables["settings"] = &types.VariableSignature{
+		Name:             "settings",
+		Required:         true,
+		OptionalDefaults: defaults,
+		DeclRange: types.FileRange{
+			Filename: "variables.tf",
+			Line:     1,
+		},
+	}
+	return snap
+}
+
+func TestRC009_DefaultChanged(t *testing.T) {
+	rule := &RC009{}
+
+	old := optionalDefaultsSnapshot("/old", map[string]interface{}{"tier": "standard"})
+	new := optionalDefaultsSnapshot("/new", map[string]interface{}{"tier": "premium"})
+
+	findings := rule.Evaluate(old, new)
+
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(findings))
+	}
+
+	f := findings[0]
+	if f.RuleID != "RC009" {
+		t.Errorf("RuleID = %q, want %q", f.RuleID, "RC009")
+	}
+	if f.Severity != types.SeverityWarning {
+		t.Errorf("Severity = %v, want %v", f.Severity, types.SeverityWarning)
+	}
+	if f.Metadata["attribute"] != "tier" {
+		t.Errorf("Metadata[attribute] = %q, want %q", f.Metadata["attribute"], "tier")
+	}
+}
+
+func TestRC009_DefaultRemoved(t *testing.T) {
+	rule := &RC009{}
+
+	old := optionalDefaultsSnapshot("/old", map[string]interface{}{"network.cidr": "10.0.0.0/16"})
+	new := optionalDefaultsSnapshot("/new", map[string]interface{}{"network.cidr": nil})
+
+	findings := rule.Evaluate(old, new)
+
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding for removed default, got %d", len(findings))
+	}
+	if findings[0].Metadata["attribute"] != "network.cidr" {
+		t.Errorf("Metadata[attribute] = %q, want %q", findings[0].Metadata["attribute"], "network.cidr")
+	}
+}
+
+func TestRC009_NoChange(t *testing.T) {
+	rule := &RC009{}
+
+	defaults := map[string]interface{}{
+		"tier":   "standard",
+		"labels": map[string]interface{}{},
+	}
+	old := optionalDefaultsSnapshot("/old", defaults)
+	new := optionalDefaultsSnapshot("/new", map[string]interface{}{
+		"tier":   "standard",
+		"labels": map[string]interface{}{},
+	})
+
+	findings := rule.Evaluate(old, new)
+
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings, got %d", len(findings))
+	}
+}
+
+func TestRC009_DefaultAdded(t *testing.T) {
+	rule := &RC009{}
+
+	old := optionalDefaultsSnapshot("/old", map[string]interface{}{"replicas": nil})
+	new := optionalDefaultsSnapshot("/new", map[string]interface{}{"replicas": float64(2)})
+
+	findings := rule.Evaluate(old, new)
+
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings when a default is added, got %d", len(findings))
+	}
+}
+
+func TestRC009_AttributeRemoved(t *testing.T) {
+	rule := &RC009{}
+
+	// Attribute removal is a type change handled by BC004
+	old := optionalDefaultsSnapshot("/old", map[string]interface{}{"tier": "standard"})
+	new := optionalDefaultsSnapshot("/new", nil)
+
+	findings := rule.Evaluate(old, new)
+
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings when attribute is removed, got %d", len(findings))
+	}
+}
+
+func TestRC009_NoDefaultEitherSide(t *testing.T) {
+	rule := &RC009{}
+
+	// optional(number) in both versions: the attribute was null when omitted
+	// and still is
+	old := optionalDefaultsSnapshot("/old", map[string]interface{}{"replicas": nil})
+	new := optionalDefaultsSnapshot("/new", map[string]interface{}{"replicas": nil})
+
+	if findings := rule.Evaluate(old, new); len(findings) != 0 {
+		t.Errorf("expected 0 findings for an attribute without a default, got %d", len(findings))
+	}
+}
+
+func TestRC009_DistinctFromVariableDefault(t *testing.T) {
+	// Only an attribute default changed: RC009 fires, RC006 does not
+	old := optionalDefaultsSnapshot("/old", map[string]interface{}{"tier": "standard"})
+	new := optionalDefaultsSnapshot("/new", map[string]interface{}{"tier": "premium"})
+	for _, snap := range []*types.ModuleSnapshot{old, new} {
+		snap.Variables["settings"].Required = false
+		snap.Variables["settings"].Default = map[string]interface{}{"name": "app"}
+	}
+
+	if findings := (&RC009{}).Evaluate(old, new); len(findings) != 1 {
+		t.Errorf("RC009: expected 1 finding, got %d", len(findings))
+	}
+	if findings := (&RC006{}).Evaluate(old, new); len(findings) != 0 {
+		t.Errorf("RC006: expected 0 findings for an attribute default change, got %d", len(findings))
+	}
+
+	// Only the whole-variable default changed: RC006 fires, RC009 does not
+	new = optionalDefaultsSnapshot("/new", map[string]interface{}{"tier": "standard"})
+	new.Variables["settings"].Required = false
+	new.Variables["settings"].Default = map[string]interface{}{"name": "web"}
+
+	if findings := (&RC009{}).Evaluate(old, new); len(findings) != 0 {
+		t.Errorf("RC009: expected 0 findings for a variable default change, got %d", len(findings))
+	}
+	if findings := (&RC006{}).Evaluate(old, new); len(findings) != 1 {
+		t.Errorf("RC006: expected 1 finding, got %d", len(findings))
+	}
+}
@@ -6,7 +6,8 @@ import (
 	"github.com/jokarl/tfbreak-core/internal/types"
 )
 
-// RC011 detects when an output's sensitive attribute changes
+// RC011 detects when an output's sensitive attribute is removed. The opposite
+// direction, marking an output sensitive, is reported by RC014.
 type RC011 struct{}
 
 func init() {
@@ -22,7 +23,7 @@ func (r *RC011) Name() string {
 }
 
 func (r *RC011) Description() string {
-	return "An output's sensitive attribute changed, which affects plan visibility and downstream consumers"
+	return "An output is no longer marked sensitive, which may expose its value in plans and logs"
 }
 
 func (r *RC011) DefaultSeverity() types.Severity {
@@ -37,22 +38,17 @@ func (r *RC011) Documentation() *RuleDoc {
 		Description:     r.Description(),
 		ExampleOld: `output "connection_string" {
   value     = "postgres://${var.host}:${var.port}"
-  sensitive = false
+  sensitive = true
 }`,
 		ExampleNew: `output "connection_string" {
   value     = "postgres://${var.host}:${var.port}"
-  sensitive = true  # Now marked as sensitive
+  sensitive = false  # No longer marked as sensitive
 }`,
-		Remediation: `This is a RISKY change because it affects how the output appears in plans and logs.
+		Remediation: `This is a RISKY change because the output value may now appear in plain text
+in terraform plan/apply output and logs, for this module and its consumers.
 
-If sensitive was added (false -> true):
-- Output values will be redacted in terraform plan/apply output
-- Downstream modules consuming this output must handle sensitive values
-- State file will mark the value as sensitive
-
-If sensitive was removed (true -> false):
-- Output values may now appear in plain text in logs and plans
-- Review security implications before removing sensitive marking
+Review the security implications before removing the sensitive marking.
+Marking an output sensitive is reported separately by RC014.
 
 Use an annotation if this change is intentional:
    # tfbreak:ignore output-sensitive-changed # security classification updated`,
@@ -69,8 +65,8 @@ func (r *RC011) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
 			continue
 		}
 
-		// Check if sensitive actually changed
-		if oldOutput.Sensitive == newOutput.Sensitive {
+		// Only removal of sensitive can leak the value; adding it is RC014
+		if !oldOutput.Sensitive || newOutput.Sensitive {
 			continue
 		}
 
@@ -0,0 +1,87 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// RC014 detects when an output that was not sensitive is marked sensitive.
+// The opposite direction, removing the marking, is reported by RC011.
+type RC014 struct{}
+
+func init() {
+	Register(&RC014{})
+}
+
+func (r *RC014) ID() string {
+	return "RC014"
+}
+
+func (r *RC014) Name() string {
+	return "output-sensitive-added"
+}
+
+func (r *RC014) Description() string {
+	return "An output is now marked sensitive, which may break consumers that use its value in non-sensitive contexts"
+}
+
+func (r *RC014) DefaultSeverity() types.Severity {
+	return types.SeverityWarning
+}
+
+func (r *RC014) Documentation() *RuleDoc {
+	return &RuleDoc{
+		ID:              r.ID(),
+		Name:            r.Name(),
+		DefaultSeverity: r.DefaultSeverity(),
+		Description:     r.Description(),
+		ExampleOld: `output "connection_string" {
+  value = "postgres://${var.host}:${var.port}"
+}`,
+		ExampleNew: `output "connection_string" {
+  value     = "postgres://${var.host}:${var.port}"
+  sensitive = true  # Now marked as sensitive
+}`,
+		Remediation: `This is a RISKY change because sensitivity propagates to every consumer of the output:
+- Root modules that re-export the value in a non-sensitive output fail with
+  "Output refers to sensitive values" until they add sensitive = true
+- Values used in for_each keys or resource names are rejected by Terraform
+- The value is redacted in terraform plan/apply output
+
+Document the change so consumers can mark their outputs sensitive, and
+consider releasing it as a major version.
+
+Use an annotation if this change is intentional:
+   # tfbreak:ignore output-sensitive-added # value now contains credentials`,
+	}
+}
+
+func (r *RC014) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
+	var findings []*types.Finding
+
+	for name, oldOutput := range old.Outputs {
+		newOutput, exists := new.Outputs[name]
+		if !exists {
+			// Output was removed - handled by BC009
+			continue
+		}
+
+		// Only newly added sensitivity breaks consumers; removal is RC011
+		if oldOutput.Sensitive || !newOutput.Sensitive {
+			continue
+		}
+
+		finding := types.NewFinding(
+			r.ID(),
+			r.Name(),
+			r.DefaultSeverity(),
+			fmt.Sprintf("Output %q is now marked sensitive", name),
+		).WithOldLocation(&oldOutput.DeclRange).
+			WithNewLocation(&newOutput.DeclRange)
+
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
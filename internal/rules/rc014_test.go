@@ -0,0 +1,136 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+func TestRC014_SensitiveAdded(t *testing.T) {
+	rule := &RC014{}
+
+	old := types.NewModuleSnapshot("/old")
+	old.Outputs["my_output"] = &types.OutputSignature{
+		Name:      "my_output",
+		Sensitive: false,
+		DeclRange: types.FileRange{Filename: "outputs.tf", Line: 1},
+	}
+
+	new := types.NewModuleSnapshot("/new")
+	new.Outputs["my_output"] = &types.OutputSignature{
+		Name:      "my_output",
+		Sensitive: true,
+		DeclRange: types.FileRange{Filename: "outputs.tf", Line: 1},
+	}
+
+	findings := rule.Evaluate(old, new)
+
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(findings))
+	}
+
+	f := findings[0]
+	if f.RuleID != "RC014" {
+		t.Errorf("RuleID = %q, want %q", f.RuleID, "RC014")
+	}
+	if f.Severity != types.SeverityWarning {
+		t.Errorf("Severity = %v, want %v", f.Severity, types.SeverityWarning)
+	}
+	if f.OldLocation == nil || f.NewLocation == nil {
+		t.Error("expected both old and new locations")
+	}
+}
+
+func TestRC014_SensitiveRemoved_NoFinding(t *testing.T) {
+	rule := &RC014{}
+
+	old := types.NewModuleSnapshot("/old")
+	old.Outputs["my_output"] = &types.OutputSignature{Name: "my_output", Sensitive: true}
+
+	new := types.NewModuleSnapshot("/new")
+	new.Outputs["my_output"] = &types.OutputSignature{Name: "my_output", Sensitive: false}
+
+	// Removing sensitive is reported by RC011
+	if findings := rule.Evaluate(old, new); len(findings) != 0 {
+		t.Errorf("expected 0 findings for true->false, got %d", len(findings))
+	}
+}
+
+func TestRC014_SensitiveUnchanged_NoFinding(t *testing.T) {
+	rule := &RC014{}
+
+	for _, sensitive := range []bool{false, true} {
+		old := types.NewModuleSnapshot("/old")
+		old.Outputs["my_output"] = &types.OutputSignature{Name: "my_output", Sensitive: sensitive}
+
+		new := types.NewModuleSnapshot("/new")
+		new.Outputs["my_output"] = &types.OutputSignature{Name: "my_output", Sensitive: sensitive}
+
+		if findings := rule.Evaluate(old, new); len(findings) != 0 {
+			t.Errorf("expected 0 findings when sensitive unchanged (%v), got %d", sensitive, len(findings))
+		}
+	}
+}
+
+func TestRC014_NewOutput_NoFinding(t *testing.T) {
+	rule := &RC014{}
+
+	old := types.NewModuleSnapshot("/old")
+
+	new := types.NewModuleSnapshot("/new")
+	new.Outputs["new_output"] = &types.OutputSignature{Name: "new_output", Sensitive: true}
+
+	if findings := rule.Evaluate(old, new); len(findings) != 0 {
+		t.Errorf("expected 0 findings for new output, got %d", len(findings))
+	}
+}
+
+func TestRC014_OutputRemoved_NoFinding(t *testing.T) {
+	rule := &RC014{}
+
+	old := types.NewModuleSnapshot("/old")
+	old.Outputs["my_output"] = &types.OutputSignature{Name: "my_output", Sensitive: false}
+
+	new := types.NewModuleSnapshot("/new")
+	// Output removed - should be handled by BC009, not RC014
+
+	if findings := rule.Evaluate(old, new); len(findings) != 0 {
+		t.Errorf("expected 0 findings when output removed, got %d", len(findings))
+	}
+}
+
+func TestRC014_RC011_NoDoubleReport(t *testing.T) {
+	old := types.NewModuleSnapshot("/old")
+	old.Outputs["added"] = &types.OutputSignature{Name: "added", Sensitive: false}
+	old.Outputs["removed"] = &types.OutputSignature{Name: "removed", Sensitive: true}
+
+	new := types.NewModuleSnapshot("/new")
+	new.Outputs["added"] = &types.OutputSignature{Name: "added", Sensitive: true}
+	new.Outputs["removed"] = &types.OutputSignature{Name: "removed", Sensitive: false}
+
+	added := (&RC014{}).Evaluate(old, new)
+	removed := (&RC011{}).Evaluate(old, new)
+
+	if len(added) != 1 || added[0].Message != `Output "added" is now marked sensitive` {
+		t.Errorf("RC014 findings = %v, want one for \"added\"", added)
+	}
+	if len(removed) != 1 || removed[0].Message != `Output "removed" sensitive changed: true -> false` {
+		t.Errorf("RC011 findings = %v, want one for \"removed\"", removed)
+	}
+}
+
+func TestRC014_Documentation(t *testing.T) {
+	rule := &RC014{}
+
+	doc := rule.Documentation()
+
+	if doc.ID != "RC014" {
+		t.Errorf("Documentation ID = %q, want %q", doc.ID, "RC014")
+	}
+	if doc.Name != "output-sensitive-added" {
+		t.Errorf("Documentation Name = %q, want %q", doc.Name, "output-sensitive-added")
+	}
+	if doc.ExampleOld == "" || doc.ExampleNew == "" || doc.Remediation == "" {
+		t.Error("Documentation examples and remediation should not be empty")
+	}
+}
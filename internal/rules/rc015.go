@@ -0,0 +1,102 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// deprecatedMarker matches the word "deprecated" in a description, in any case
+var deprecatedMarker = regexp.MustCompile(`(?i)\bdeprecated\b`)
+
+// RC015 detects when a variable's description gains a deprecation marker,
+// announcing that the variable will be removed in a future version
+type RC015 struct{}
+
+func init() {
+	Register(&RC015{})
+}
+
+// ID returns the unique identifier for this rule.
+func (r *RC015) ID() string {
+	return "RC015"
+}
+
+// Name returns the human-readable name for this rule.
+func (r *RC015) Name() string {
+	return "input-deprecated"
+}
+
+// Description returns a description of what this rule detects.
+func (r *RC015) Description() string {
+	return "A variable was marked deprecated in its description, so callers setting it should plan to stop before it is removed"
+}
+
+// DefaultSeverity returns the default severity level for this rule.
+func (r *RC015) DefaultSeverity() types.Severity {
+	return types.SeverityDeprecation
+}
+
+// Documentation returns the documentation for this rule.
+func (r *RC015) Documentation() *RuleDoc {
+	return &RuleDoc{
+		ID:              r.ID(),
+		Name:            r.Name(),
+		DefaultSeverity: r.DefaultSeverity(),
+		Description:     r.Description(),
+		ExampleOld: `variable "instance_size" {
+  type        = string
+  description = "Size of the instance"
+  default     = "small"
+}`,
+		ExampleNew: `variable "instance_size" {
+  type        = string
+  description = "DEPRECATED: use instance_type instead. Size of the instance"
+  default     = "small"
+}`,
+		Remediation: `Nothing breaks yet: the variable still exists and works as before.
+
+Callers setting this variable should move off it before it is removed in a
+future version. Removing it later is reported as input-removed.
+
+Document the replacement and the planned removal version in the description
+and your changelog.`,
+	}
+}
+
+// Evaluate reports variables whose description became marked as deprecated.
+func (r *RC015) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
+	var findings []*types.Finding
+
+	for name, oldVar := range old.Variables {
+		newVar, exists := new.Variables[name]
+		if !exists {
+			// Variable was removed - handled by BC002
+			continue
+		}
+
+		if isDeprecatedDescription(oldVar.Description) || !isDeprecatedDescription(newVar.Description) {
+			continue
+		}
+
+		finding := types.NewFinding(
+			r.ID(),
+			r.Name(),
+			r.DefaultSeverity(),
+			fmt.Sprintf("Variable %q is now marked deprecated", name),
+		).WithDetail(newVar.Description).
+			WithOldLocation(&oldVar.DeclRange).
+			WithNewLocation(&newVar.DeclRange)
+
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
+
+// isDeprecatedDescription returns true if a description carries a
+// deprecation marker
+func isDeprecatedDescription(description string) bool {
+	return deprecatedMarker.MatchString(description)
+}
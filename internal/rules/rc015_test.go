@@ -0,0 +1,84 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+func TestRC015_MarkedDeprecated(t *testing.T) {
+	rule := &RC015{}
+
+	old := types.NewModuleSnapshot("/old")
+	old.Variables["instance_size"] = &types.VariableSignature{
+		Name:        "instance_size",
+		Description: "Size of the instance",
+		DeclRange:   types.FileRange{Filename: "variables.tf", Line: 1},
+	}
+
+	new := types.NewModuleSnapshot("/new")
+	new.Variables["instance_size"] = &types.VariableSignature{
+		Name:        "instance_size",
+		Description: "DEPRECATED: use instance_type instead",
+		DeclRange:   types.FileRange{Filename: "variables.tf", Line: 1},
+	}
+
+	findings := rule.Evaluate(old, new)
+
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(findings))
+	}
+
+	f := findings[0]
+	if f.RuleID != "RC015" {
+		t.Errorf("RuleID = %q, want %q", f.RuleID, "RC015")
+	}
+	if f.Severity != types.SeverityDeprecation {
+		t.Errorf("Severity = %v, want %v", f.Severity, types.SeverityDeprecation)
+	}
+	if f.Detail != "DEPRECATED: use instance_type instead" {
+		t.Errorf("Detail = %q, want the new description", f.Detail)
+	}
+	if f.OldLocation == nil || f.NewLocation == nil {
+		t.Error("expected both old and new locations")
+	}
+}
+
+func TestRC015_NoFinding(t *testing.T) {
+	tests := []struct {
+		name   string
+		oldDoc string
+		newDoc string
+	}{
+		{"already deprecated", "Deprecated: use foo", "Deprecated, removed in v3: use foo"},
+		{"no marker", "Size of the instance", "Size of the instance in GB"},
+		{"marker removed", "(deprecated) Size", "Size"},
+		{"marker inside a word", "Size", "Size, see undeprecated_size"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := types.NewModuleSnapshot("/old")
+			old.Variables["size"] = &types.VariableSignature{Name: "size", Description: tt.oldDoc}
+
+			new := types.NewModuleSnapshot("/new")
+			new.Variables["size"] = &types.VariableSignature{Name: "size", Description: tt.newDoc}
+
+			if findings := (&RC015{}).Evaluate(old, new); len(findings) != 0 {
+				t.Errorf("expected 0 findings, got %d", len(findings))
+			}
+		})
+	}
+}
+
+func TestRC015_RemovedVariable_NoFinding(t *testing.T) {
+	old := types.NewModuleSnapshot("/old")
+	old.Variables["size"] = &types.VariableSignature{Name: "size", Description: "Size"}
+
+	new := types.NewModuleSnapshot("/new")
+
+	// Removal is reported by BC002
+	if findings := (&RC015{}).Evaluate(old, new); len(findings) != 0 {
+		t.Errorf("expected 0 findings, got %d", len(findings))
+	}
+}
@@ -0,0 +1,170 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// RC016 detects when validation conditions are removed from a variable
+type RC016 struct{}
+
+func init() {
+	Register(&RC016{})
+}
+
+// ID returns the unique identifier for this rule.
+func (r *RC016) ID() string {
+	return "RC016"
+}
+
+// Name returns the human-readable name for this rule.
+func (r *RC016) Name() string {
+	return "validation-removed"
+}
+
+// Description returns a description of what this rule detects.
+func (r *RC016) Description() string {
+	return "Validation conditions were removed from a variable, so values that were rejected before may now be accepted"
+}
+
+// DefaultSeverity returns the default severity level for this rule.
+func (r *RC016) DefaultSeverity() types.Severity {
+	return types.SeverityNotice
+}
+
+// Documentation returns the documentation for this rule.
+func (r *RC016) Documentation() *RuleDoc {
+	return &RuleDoc{
+		ID:              r.ID(),
+		Name:            r.Name(),
+		DefaultSeverity: r.DefaultSeverity(),
+		Description:     r.Description(),
+		ExampleOld: `variable "environment" {
+  type = string
+
+  validation {
+    condition     = contains(["dev", "staging", "prod"], var.environment)
+    error_message = "Environment must be dev, staging, or prod."
+  }
+}`,
+		ExampleNew: `variable "environment" {
+  type = string
+}`,
+		Remediation: `This is a NOTICE change because it loosens the variable's constraints.
+
+Every value that passed validation before still passes, so no caller breaks.
+Values that were rejected before are now accepted, and the module must handle
+them: a removed check may have protected a resource argument that only accepts
+some values, in which case the error now surfaces at plan or apply time.
+
+Conditions are compared as text. A condition replaced by a different one is
+reported separately as a WARNING, since the new condition may reject values
+the old one accepted. Adding validation blocks is reported as validation-added.
+
+Before proceeding:
+1. Ensure the module handles the values the removed validation rejected
+2. Document the loosened constraint in your changelog`,
+	}
+}
+
+// Evaluate checks for validation conditions being removed from variables.
+func (r *RC016) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
+	var findings []*types.Finding
+
+	for name, oldVar := range old.Variables {
+		newVar, exists := new.Variables[name]
+		if !exists {
+			// Variable was removed - handled by BC002
+			continue
+		}
+
+		// Conditions are compared as sets, so reordered validation blocks
+		// are not reported. Removed conditions are paired up with added ones
+		// in order; each pair is a changed condition.
+		removed := diffConditions(oldVar.Validations, newVar.Validations)
+		added := diffConditions(newVar.Validations, oldVar.Validations)
+		changed := min(len(removed), len(added))
+
+		// A changed condition may reject values the old one accepted
+		if changed > 0 {
+			message := fmt.Sprintf("Variable %q: validation condition changed", name)
+			if changed > 1 {
+				message = fmt.Sprintf("Variable %q: %d validation conditions changed", name, changed)
+			}
+			pairs := make([]string, changed)
+			for i := range pairs {
+				pairs[i] = removed[i] + " -> " + added[i]
+			}
+
+			finding := types.NewFinding(
+				r.ID(),
+				r.Name(),
+				types.SeverityWarning,
+				message,
+			).WithDetail("Changed conditions:\n  " + strings.Join(pairs, "\n  ")).
+				WithOldLocation(&oldVar.DeclRange).
+				WithNewLocation(&newVar.DeclRange)
+
+			findings = append(findings, finding)
+		}
+
+		removed = removed[changed:]
+		if len(removed) == 0 {
+			continue
+		}
+
+		var message string
+		if len(newVar.Validations) == 0 {
+			message = fmt.Sprintf("Variable %q: all validation blocks removed (had %d)",
+				name, len(oldVar.Validations))
+		} else {
+			message = fmt.Sprintf("Variable %q: %d of %d validation conditions removed",
+				name, len(removed), len(oldVar.Validations))
+		}
+
+		finding := types.NewFinding(
+			r.ID(),
+			r.Name(),
+			r.DefaultSeverity(),
+			message,
+		).WithDetail("Removed conditions:\n  " + strings.Join(removed, "\n  ")).
+			WithOldLocation(&oldVar.DeclRange).
+			WithNewLocation(&newVar.DeclRange)
+
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
+
+// diffConditions returns the conditions of validations that no validation in
+// others has, in their order in validations
+func diffConditions(validations, others []types.ValidationBlock) []string {
+	kept := make(map[string]bool, len(others))
+	for _, v := range others {
+		kept[conditionKey(v.Condition)] = true
+	}
+
+	var diff []string
+	seen := make(map[string]bool, len(validations))
+	for _, v := range validations {
+		key := conditionKey(v.Condition)
+		if !kept[key] && !seen[key] {
+			seen[key] = true
+			diff = append(diff, v.Condition)
+		}
+	}
+	return diff
+}
+
+// conditionKey identifies a condition when comparing validations. contains()
+// conditions on the same variable are matched up regardless of their values,
+// which RC013 compares.
+func conditionKey(condition string) string {
+	if pattern := ParseContainsPattern(condition); pattern != nil {
+		return "contains:" + pattern.VarName
+	}
+	return condition
+}
@@ -0,0 +1,170 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+func TestRC016_Metadata(t *testing.T) {
+	r := &RC016{}
+
+	if r.ID() != "RC016" {
+		t.Errorf("expected ID 'RC016', got %q", r.ID())
+	}
+	if r.Name() != "validation-removed" {
+		t.Errorf("expected Name 'validation-removed', got %q", r.Name())
+	}
+	if r.DefaultSeverity() != types.SeverityNotice {
+		t.Errorf("expected severity NOTICE, got %v", r.DefaultSeverity())
+	}
+	doc := r.Documentation()
+	if doc == nil || doc.ExampleOld == "" || doc.ExampleNew == "" {
+		t.Error("expected Documentation with examples")
+	}
+	if _, ok := DefaultRegistry.Get("RC016"); !ok {
+		t.Error("expected RC016 to be registered")
+	}
+}
+
+func TestRC016_Evaluate(t *testing.T) {
+	validations := func(conditions ...string) *types.VariableSignature {
+		v := &types.VariableSignature{Name: "environment", Type: "string", ValidationCount: len(conditions)}
+		for _, c := range conditions {
+			v.Validations = append(v.Validations, types.ValidationBlock{Condition: c})
+		}
+		return v
+	}
+
+	tests := []struct {
+		name         string
+		oldVar       *types.VariableSignature
+		newVar       *types.VariableSignature
+		wantMessage  string
+		wantDetail   string
+		wantSeverity types.Severity
+	}{
+		{
+			name:         "all validations removed",
+			oldVar:       validations(`contains(["dev", "prod"], var.environment)`),
+			newVar:       validations(),
+			wantMessage:  `Variable "environment": all validation blocks removed (had 1)`,
+			wantDetail:   `contains(["dev", "prod"], var.environment)`,
+			wantSeverity: types.SeverityNotice,
+		},
+		{
+			name:         "one of several removed",
+			oldVar:       validations("length(var.environment) > 0", "length(var.environment) < 10", `var.environment != "test"`),
+			newVar:       validations(`var.environment != "test"`),
+			wantMessage:  `Variable "environment": 2 of 3 validation conditions removed`,
+			wantDetail:   "length(var.environment) > 0\n  length(var.environment) < 10",
+			wantSeverity: types.SeverityNotice,
+		},
+		{
+			name:   "validations reordered",
+			oldVar: validations("length(var.environment) > 0", `var.environment != "test"`),
+			newVar: validations(`var.environment != "test"`, "length(var.environment) > 0"),
+		},
+		{
+			name:   "validation added",
+			oldVar: validations(),
+			newVar: validations("length(var.environment) > 0"),
+		},
+		{
+			// A tightened condition may reject values that passed before
+			name:         "validation condition changed",
+			oldVar:       validations("length(var.environment) > 0"),
+			newVar:       validations("length(var.environment) > 5"),
+			wantMessage:  `Variable "environment": validation condition changed`,
+			wantDetail:   "Changed conditions:\n  length(var.environment) > 0 -> length(var.environment) > 5",
+			wantSeverity: types.SeverityWarning,
+		},
+		{
+			// Changed contains() values are reported by RC013
+			name:   "contains values changed",
+			oldVar: validations(`contains(["dev", "staging", "prod"], var.environment)`),
+			newVar: validations(`contains(["dev", "staging"], var.environment)`),
+		},
+		{
+			// The remaining added condition is reported by RC012
+			name:         "validation replaced by more validations",
+			oldVar:       validations("length(var.environment) > 0"),
+			newVar:       validations("length(var.environment) > 1", `var.environment != "test"`),
+			wantMessage:  `Variable "environment": validation condition changed`,
+			wantDetail:   "Changed conditions:\n  length(var.environment) > 0 -> length(var.environment) > 1",
+			wantSeverity: types.SeverityWarning,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := types.NewModuleSnapshot("/old")
+			old.Variables["environment"] = tt.oldVar
+			new := types.NewModuleSnapshot("/new")
+			new.Variables["environment"] = tt.newVar
+
+			findings := (&RC016{}).Evaluate(old, new)
+			if tt.wantMessage == "" {
+				if len(findings) != 0 {
+					t.Fatalf("expected no findings, got %q", findings[0].Message)
+				}
+				return
+			}
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			if findings[0].Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", findings[0].Message, tt.wantMessage)
+			}
+			if !strings.HasSuffix(findings[0].Detail, tt.wantDetail) {
+				t.Errorf("Detail = %q, want it to list %q", findings[0].Detail, tt.wantDetail)
+			}
+			if findings[0].Severity != tt.wantSeverity {
+				t.Errorf("Severity = %v, want %v", findings[0].Severity, tt.wantSeverity)
+			}
+		})
+	}
+}
+
+func TestRC016_ChangedAndRemoved(t *testing.T) {
+	validations := func(conditions ...string) *types.VariableSignature {
+		v := &types.VariableSignature{Name: "environment", Type: "string", ValidationCount: len(conditions)}
+		for _, c := range conditions {
+			v.Validations = append(v.Validations, types.ValidationBlock{Condition: c})
+		}
+		return v
+	}
+	old := types.NewModuleSnapshot("/old")
+	old.Variables["environment"] = validations("length(var.environment) > 0", "length(var.environment) < 10")
+	new := types.NewModuleSnapshot("/new")
+	new.Variables["environment"] = validations("length(var.environment) > 5")
+
+	findings := (&RC016{}).Evaluate(old, new)
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d", len(findings))
+	}
+	if findings[0].Severity != types.SeverityWarning || findings[0].Message != `Variable "environment": validation condition changed` {
+		t.Errorf("first finding = %v %q, want a WARNING for the changed condition", findings[0].Severity, findings[0].Message)
+	}
+	if _, ok := findings[0].Metadata["escalated_from"]; ok {
+		t.Error("changed condition should not be marked as escalated")
+	}
+	if findings[1].Severity != types.SeverityNotice || findings[1].Message != `Variable "environment": 1 of 2 validation conditions removed` {
+		t.Errorf("second finding = %v %q, want a NOTICE for the removed condition", findings[1].Severity, findings[1].Message)
+	}
+	if !strings.HasSuffix(findings[1].Detail, "length(var.environment) < 10") {
+		t.Errorf("Detail = %q, want the removed condition", findings[1].Detail)
+	}
+}
+
+func TestRC016_VariableRemoved_NoFinding(t *testing.T) {
+	old := types.NewModuleSnapshot("/old")
+	old.Variables["environment"] = &types.VariableSignature{Name: "environment", ValidationCount: 1}
+	new := types.NewModuleSnapshot("/new")
+
+	// Removed variables are reported by BC002
+	if findings := (&RC016{}).Evaluate(old, new); len(findings) != 0 {
+		t.Errorf("expected 0 findings, got %d", len(findings))
+	}
+}
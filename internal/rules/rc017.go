@@ -0,0 +1,101 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// RC017 detects variables that are still present in a module version at or
+// after the removal version stated in their deprecation marker
+type RC017 struct{}
+
+func init() {
+	Register(&RC017{})
+}
+
+// ID returns the unique identifier for this rule.
+func (r *RC017) ID() string {
+	return "RC017"
+}
+
+// Name returns the human-readable name for this rule.
+func (r *RC017) Name() string {
+	return "input-removal-overdue"
+}
+
+// Description returns a description of what this rule detects.
+func (r *RC017) Description() string {
+	return "A variable's description says it is removed in a version the module has reached, but the variable is still present"
+}
+
+// DefaultSeverity returns the default severity level for this rule.
+func (r *RC017) DefaultSeverity() types.Severity {
+	return types.SeverityWarning
+}
+
+// Documentation returns the documentation for this rule.
+func (r *RC017) Documentation() *RuleDoc {
+	return &RuleDoc{
+		ID:              r.ID(),
+		Name:            r.Name(),
+		DefaultSeverity: r.DefaultSeverity(),
+		Description:     r.Description(),
+		ExampleOld: `variable "instance_size" {
+  type        = string
+  description = "(deprecated, remove in v3) Size of the instance"
+  default     = "small"
+}`,
+		ExampleNew: `# Checked with --module-version 3.0.0
+variable "instance_size" {
+  type        = string
+  description = "(deprecated, remove in v3) Size of the instance"
+  default     = "small"
+}`,
+		Remediation: `Remove the variable as announced, which is reported as input-removed,
+or move the removal version in the description to a later release and
+tell callers about the new date.
+
+This rule only runs when the module version is known, from
+--module-version or module_version in the deprecation config block. The
+marker pattern can be changed with marker in the same block.`,
+	}
+}
+
+// Evaluate reports variables of the new module whose deprecation marker
+// states a removal version at or before the module version.
+func (r *RC017) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
+	settings := GetDeprecationSettings()
+	if settings == nil || settings.ModuleVersion == "" {
+		return nil
+	}
+	current, _, err := parseConstraintVersion(settings.ModuleVersion)
+	if err != nil {
+		return nil
+	}
+
+	var findings []*types.Finding
+
+	for name, newVar := range new.Variables {
+		removal, ok := settings.removalVersion(newVar.Description)
+		if !ok {
+			continue
+		}
+		removalVersion, _, err := parseConstraintVersion(removal)
+		if err != nil || current.compare(removalVersion) < 0 {
+			continue
+		}
+
+		finding := types.NewFinding(
+			r.ID(),
+			r.Name(),
+			r.DefaultSeverity(),
+			fmt.Sprintf("Variable %q was to be removed in %s but is still present in %s", name, removal, settings.ModuleVersion),
+		).WithDetail(newVar.Description).
+			WithNewLocation(&newVar.DeclRange)
+
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
@@ -0,0 +1,103 @@
+package rules
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+func TestRC017_RemovalOverdue(t *testing.T) {
+	rule := &RC017{}
+
+	tests := []struct {
+		name          string
+		description   string
+		moduleVersion string
+		wantFinding   bool
+	}{
+		{"past removal version", "(deprecated, remove in v3) Size of the instance", "3.1.0", true},
+		{"at removal version", "(deprecated, remove in v3) Size of the instance", "3.0.0", true},
+		{"before removal version", "(deprecated, remove in v3) Size of the instance", "2.9.9", false},
+		{"minor removal version", "DEPRECATED: will be removed in 2.4. Use instance_type", "v2.4", true},
+		{"minor removal version not reached", "DEPRECATED: will be removed in 2.4. Use instance_type", "2.3.7", false},
+		{"patch removal version", "Deprecated, removed in v1.2.3", "1.2.4", true},
+		{"deprecated without a version", "Deprecated: use instance_type", "9.0.0", false},
+		{"no marker", "Size of the instance, removed in v1 of the old API", "9.0.0", false},
+		{"no module version", "(deprecated, remove in v3) Size of the instance", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := DefaultDeprecationSettings()
+			settings.ModuleVersion = tt.moduleVersion
+			SetDeprecationSettings(settings)
+			defer SetDeprecationSettings(nil)
+
+			snap := types.NewModuleSnapshot("/new")
+			snap.Variables["instance_size"] = &types.VariableSignature{
+				Name:        "instance_size",
+				Description: tt.description,
+				DeclRange:   types.FileRange{Filename: "variables.tf", Line: 3},
+			}
+
+			findings := rule.Evaluate(snap, snap)
+			if !tt.wantFinding {
+				if len(findings) != 0 {
+					t.Errorf("expected no findings, got %d", len(findings))
+				}
+				return
+			}
+
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			f := findings[0]
+			if f.RuleID != "RC017" || f.Severity != types.SeverityWarning {
+				t.Errorf("unexpected finding %s at %v", f.RuleID, f.Severity)
+			}
+			if f.Detail != tt.description {
+				t.Errorf("Detail = %q, want the description", f.Detail)
+			}
+			if f.NewLocation == nil || f.NewLocation.Line != 3 {
+				t.Errorf("expected the new location, got %+v", f.NewLocation)
+			}
+		})
+	}
+}
+
+func TestRC017_CustomMarker(t *testing.T) {
+	rule := &RC017{}
+
+	SetDeprecationSettings(&DeprecationSettings{
+		Marker:        regexp.MustCompile(`@deprecated\s+\S+\s+until=(?P<version>[\d.]+)`),
+		ModuleVersion: "5.0",
+	})
+	defer SetDeprecationSettings(nil)
+
+	snap := types.NewModuleSnapshot("/new")
+	snap.Variables["old"] = &types.VariableSignature{Name: "old", Description: "@deprecated use_new until=5.0"}
+	snap.Variables["later"] = &types.VariableSignature{Name: "later", Description: "@deprecated use_new until=6.0"}
+	snap.Variables["default_marker"] = &types.VariableSignature{Name: "default_marker", Description: "(deprecated, remove in v3)"}
+
+	findings := rule.Evaluate(snap, snap)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(findings))
+	}
+	if findings[0].Message != `Variable "old" was to be removed in 5.0 but is still present in 5.0` {
+		t.Errorf("unexpected message: %s", findings[0].Message)
+	}
+}
+
+func TestValidateModuleVersion(t *testing.T) {
+	for _, version := range []string{"1", "1.2", "1.2.3", "v3.0.0"} {
+		if err := ValidateModuleVersion(version); err != nil {
+			t.Errorf("ValidateModuleVersion(%q) = %v, want nil", version, err)
+		}
+	}
+	for _, version := range []string{"", "latest", "1.2.3.4", "1.2.3-rc1"} {
+		if err := ValidateModuleVersion(version); err == nil {
+			t.Errorf("ValidateModuleVersion(%q) = nil, want an error", version)
+		}
+	}
+}
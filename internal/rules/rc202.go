@@ -0,0 +1,123 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// RC202 detects when a provider's recorded package hashes change in the
+// dependency lock file while its version stays the same
+type RC202 struct{}
+
+func init() {
+	Register(&RC202{})
+}
+
+// ID returns the unique identifier for this rule.
+func (r *RC202) ID() string {
+	return "RC202"
+}
+
+// Name returns the human-readable name for this rule.
+func (r *RC202) Name() string {
+	return "provider-hashes-changed"
+}
+
+// Description returns a description of what this rule detects.
+func (r *RC202) Description() string {
+	return "A provider's package hashes changed in .terraform.lock.hcl without a version change, which may indicate a re-published provider or a changed platform set"
+}
+
+// DefaultSeverity returns the default severity level for this rule.
+func (r *RC202) DefaultSeverity() types.Severity {
+	return types.SeverityNotice
+}
+
+// Documentation returns the documentation for this rule.
+func (r *RC202) Documentation() *RuleDoc {
+	return &RuleDoc{
+		ID:              r.ID(),
+		Name:            r.Name(),
+		DefaultSeverity: r.DefaultSeverity(),
+		Description:     r.Description(),
+		ExampleOld: `provider "registry.terraform.io/hashicorp/aws" {
+  version = "5.31.0"
+  hashes = [
+    "h1:abc...",
+  ]
+}`,
+		ExampleNew: `provider "registry.terraform.io/hashicorp/aws" {
+  version = "5.31.0"
+  hashes = [
+    "h1:def...",  # Different checksum for the same version
+  ]
+}`,
+		Remediation: `This rule only runs with --compare-providers-lock-strict.
+
+A hash change at the same version usually means one of:
+- Hashes were added for more platforms (terraform providers lock -platform=...)
+- The provider package was re-published upstream
+
+Verify the change is expected before merging. If the hashes were only extended
+for additional platforms, no action is needed.
+
+Use an annotation if this is intentional:
+   # tfbreak:ignore provider-hashes-changed # added linux_arm64 hashes`,
+	}
+}
+
+// Evaluate compares the lock file entries of both snapshots.
+func (r *RC202) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
+	var findings []*types.Finding
+
+	for addr, oldLock := range old.ProviderLocks {
+		newLock, exists := new.ProviderLocks[addr]
+		if !exists || oldLock.Version != newLock.Version {
+			// Hashes are expected to change with the version
+			continue
+		}
+
+		added, removed := diffHashes(oldLock.Hashes, newLock.Hashes)
+		if len(added) == 0 && len(removed) == 0 {
+			continue
+		}
+
+		finding := types.NewFinding(
+			r.ID(),
+			r.Name(),
+			r.DefaultSeverity(),
+			fmt.Sprintf("Provider %q hashes changed at version %s", addr, newLock.Version),
+		).WithDetail(fmt.Sprintf("%d hash(es) added, %d hash(es) removed", len(added), len(removed))).
+			WithOldLocation(&oldLock.DeclRange).
+			WithNewLocation(&newLock.DeclRange)
+
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
+
+// diffHashes returns the hashes only in new (added) and only in old (removed)
+func diffHashes(old, new []string) (added, removed []string) {
+	oldSet := make(map[string]bool, len(old))
+	for _, h := range old {
+		oldSet[h] = true
+	}
+	newSet := make(map[string]bool, len(new))
+	for _, h := range new {
+		newSet[h] = true
+		if !oldSet[h] {
+			added = append(added, h)
+		}
+	}
+	for _, h := range old {
+		if !newSet[h] {
+			removed = append(removed, h)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
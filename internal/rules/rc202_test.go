@@ -0,0 +1,86 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+func lockSnapshot(path, version string, hashes ...string) *types.ModuleSnapshot {
+	snap := types.NewModuleSnapshot(path)
+	snap.ProviderLocks["registry.terraform.io/hashicorp/aws"] = &types.ProviderLock{
+		Address: "registry.terraform.io/hashicorp/aws",
+		Version: version,
+		Hashes:  hashes,
+	}
+	return snap
+}
+
+func TestRC202_HashesChanged(t *testing.T) {
+	rule := &RC202{}
+
+	old := lockSnapshot("/old", "5.31.0", "h1:aaa", "zh:bbb")
+	new := lockSnapshot("/new", "5.31.0", "h1:aaa", "h1:ccc", "zh:bbb")
+
+	findings := rule.Evaluate(old, new)
+
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(findings))
+	}
+
+	f := findings[0]
+	if f.RuleID != "RC202" {
+		t.Errorf("RuleID = %q, want %q", f.RuleID, "RC202")
+	}
+	if f.Severity != types.SeverityNotice {
+		t.Errorf("Severity = %v, want %v", f.Severity, types.SeverityNotice)
+	}
+	if f.Detail != "1 hash(es) added, 0 hash(es) removed" {
+		t.Errorf("Detail = %q", f.Detail)
+	}
+}
+
+func TestRC202_HashesReordered_NoFinding(t *testing.T) {
+	rule := &RC202{}
+
+	old := lockSnapshot("/old", "5.31.0", "h1:aaa", "zh:bbb")
+	new := lockSnapshot("/new", "5.31.0", "zh:bbb", "h1:aaa")
+
+	if findings := rule.Evaluate(old, new); len(findings) != 0 {
+		t.Errorf("expected 0 findings for reordered hashes, got %d", len(findings))
+	}
+}
+
+func TestRC202_VersionChanged_NoFinding(t *testing.T) {
+	rule := &RC202{}
+
+	old := lockSnapshot("/old", "5.31.0", "h1:aaa")
+	new := lockSnapshot("/new", "5.32.0", "h1:ddd")
+
+	if findings := rule.Evaluate(old, new); len(findings) != 0 {
+		t.Errorf("expected 0 findings when the version changed, got %d", len(findings))
+	}
+}
+
+func TestRC202_ProviderRemoved_NoFinding(t *testing.T) {
+	rule := &RC202{}
+
+	old := lockSnapshot("/old", "5.31.0", "h1:aaa")
+	new := types.NewModuleSnapshot("/new")
+
+	if findings := rule.Evaluate(old, new); len(findings) != 0 {
+		t.Errorf("expected 0 findings when the provider was removed, got %d", len(findings))
+	}
+}
+
+func TestRC202_Documentation(t *testing.T) {
+	rule := &RC202{}
+	doc := rule.Documentation()
+
+	if doc.ID != "RC202" {
+		t.Errorf("doc.ID = %q, want %q", doc.ID, "RC202")
+	}
+	if doc.Remediation == "" {
+		t.Error("doc.Remediation should not be empty")
+	}
+}
@@ -0,0 +1,122 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// RC204 detects when a required provider's version constraint is added or
+// narrowed
+type RC204 struct{}
+
+func init() {
+	Register(&RC204{})
+}
+
+// ID returns the unique identifier for this rule.
+func (r *RC204) ID() string {
+	return "RC204"
+}
+
+// Name returns the human-readable name for this rule.
+func (r *RC204) Name() string {
+	return "provider-version-narrowed"
+}
+
+// Description returns a description of what this rule detects.
+func (r *RC204) Description() string {
+	return "A required provider's version constraint was added or narrowed, which may exclude provider versions consumers use"
+}
+
+// DefaultSeverity returns the default severity level for this rule.
+func (r *RC204) DefaultSeverity() types.Severity {
+	return types.SeverityWarning
+}
+
+// Documentation returns the documentation for this rule.
+func (r *RC204) Documentation() *RuleDoc {
+	return &RuleDoc{
+		ID:              r.ID(),
+		Name:            r.Name(),
+		DefaultSeverity: r.DefaultSeverity(),
+		Description:     r.Description(),
+		ExampleOld: `terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 3.0"
+    }
+  }
+}`,
+		ExampleNew: `terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 4.0"  # Excludes 3.x and 5.x!
+    }
+  }
+}`,
+		Remediation: `This is a RISKY change because the provider version constraint allows fewer
+versions than before.
+
+Common scenarios:
+- Minimum version raised (e.g., >= 3.0 to >= 4.0) - consumers on older versions must upgrade
+- Upper bound added (e.g., >= 3.0 to ~> 4.0) - consumers on newer versions must downgrade
+- Constraint added - the provider version becomes pinned
+
+Terraform selects a single provider version that satisfies the constraints of
+the root module and every module it calls, so a narrower constraint can make
+the configuration of a consumer unsatisfiable.
+
+Before proceeding:
+1. Keep the constraint as wide as the module actually supports
+2. Document the new provider version requirement in your changelog
+3. Consider releasing the change as a major version
+
+Widening a constraint or removing it is not reported.
+
+Use an annotation if this change is intentional:
+   # tfbreak:ignore provider-version-narrowed # requires aws provider 4.x features`,
+	}
+}
+
+// Evaluate checks for added or narrowed provider version constraints.
+func (r *RC204) Evaluate(old, new *types.ModuleSnapshot) []*types.Finding {
+	var findings []*types.Finding
+
+	for name, oldProvider := range old.RequiredProviders {
+		newProvider, exists := new.RequiredProviders[name]
+		if !exists {
+			// Provider was removed - handled by BC201
+			continue
+		}
+
+		change := classifyConstraintChange(oldProvider.Version, newProvider.Version)
+
+		var message string
+		switch change {
+		case constraintAdded:
+			message = fmt.Sprintf("Provider %q version constraint added: %q", name, newProvider.Version)
+		case constraintNarrowed:
+			message = fmt.Sprintf("Provider %q version constraint narrowed: %q -> %q", name, oldProvider.Version, newProvider.Version)
+		case constraintChanged:
+			message = fmt.Sprintf("Provider %q version constraint changed: %q -> %q", name, oldProvider.Version, newProvider.Version)
+		default:
+			// Unchanged, widened, and removed constraints allow every
+			// version they allowed before
+			continue
+		}
+
+		finding := types.NewFinding(
+			r.ID(),
+			r.Name(),
+			r.DefaultSeverity(),
+			message,
+		).WithMetadata("change", string(change))
+
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
@@ -0,0 +1,118 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+func TestRC204_Metadata(t *testing.T) {
+	r := &RC204{}
+
+	if r.ID() != "RC204" {
+		t.Errorf("expected ID 'RC204', got %q", r.ID())
+	}
+	if r.Name() != "provider-version-narrowed" {
+		t.Errorf("expected Name 'provider-version-narrowed', got %q", r.Name())
+	}
+	if r.DefaultSeverity() != types.SeverityWarning {
+		t.Errorf("expected severity RISKY, got %v", r.DefaultSeverity())
+	}
+	doc := r.Documentation()
+	if doc == nil || doc.ExampleOld == "" || doc.ExampleNew == "" {
+		t.Error("expected Documentation with examples")
+	}
+	if _, ok := DefaultRegistry.Get("RC204"); !ok {
+		t.Error("expected RC204 to be registered")
+	}
+}
+
+func TestRC204_Evaluate(t *testing.T) {
+	tests := []struct {
+		name        string
+		oldVersion  string
+		newVersion  string
+		wantChange  string
+		wantMessage string
+	}{
+		{"unchanged", ">= 4.0", ">= 4.0", "", ""},
+		{"equivalent spelling", ">=4.0", ">= 4.0.0", "", ""},
+		{"added", "", "~> 5.0", "added", `Provider "aws" version constraint added: "~> 5.0"`},
+		{"removed", ">= 4.0", "", "", ""},
+		{"minimum raised", ">= 3.0", ">= 4.0", "narrowed", `Provider "aws" version constraint narrowed: ">= 3.0" -> ">= 4.0"`},
+		{"upper bound added", ">= 3.0", "~> 4.0", "narrowed", `Provider "aws" version constraint narrowed: ">= 3.0" -> "~> 4.0"`},
+		{"pessimistic patch", "~> 4.1", "~> 4.1.0", "narrowed", `Provider "aws" version constraint narrowed: "~> 4.1" -> "~> 4.1.0"`},
+		{"moved to next major", "~> 4.0", "~> 5.0", "narrowed", `Provider "aws" version constraint narrowed: "~> 4.0" -> "~> 5.0"`},
+		{"version excluded", ">= 4.0", ">= 4.0, != 4.2.0", "narrowed", `Provider "aws" version constraint narrowed: ">= 4.0" -> ">= 4.0, != 4.2.0"`},
+		{"minimum lowered", ">= 4.0", ">= 3.0", "", ""},
+		{"upper bound dropped", "~> 4.0", ">= 4.0", "", ""},
+		{"exact to range", "4.2.0", ">= 4.0, < 5.0", "", ""},
+		{"unparsable", ">= 4.0", ">= 4.0.0-beta", "changed", `Provider "aws" version constraint changed: ">= 4.0" -> ">= 4.0.0-beta"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := types.NewModuleSnapshot("/old")
+			old.RequiredProviders["aws"] = &types.ProviderRequirement{Source: "hashicorp/aws", Version: tt.oldVersion}
+			new := types.NewModuleSnapshot("/new")
+			new.RequiredProviders["aws"] = &types.ProviderRequirement{Source: "hashicorp/aws", Version: tt.newVersion}
+
+			findings := (&RC204{}).Evaluate(old, new)
+			if tt.wantChange == "" {
+				if len(findings) != 0 {
+					t.Fatalf("expected no findings, got %q", findings[0].Message)
+				}
+				return
+			}
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			if findings[0].Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", findings[0].Message, tt.wantMessage)
+			}
+			if got := findings[0].Metadata["change"]; got != tt.wantChange {
+				t.Errorf("change = %q, want %q", got, tt.wantChange)
+			}
+		})
+	}
+}
+
+func TestRC204_ProviderRemovedOrAdded_NoFinding(t *testing.T) {
+	old := types.NewModuleSnapshot("/old")
+	old.RequiredProviders["aws"] = &types.ProviderRequirement{Source: "hashicorp/aws", Version: ">= 4.0"}
+
+	new := types.NewModuleSnapshot("/new")
+	new.RequiredProviders["google"] = &types.ProviderRequirement{Source: "hashicorp/google", Version: ">= 5.0"}
+
+	// Removed providers are reported by BC201; added ones are not a change
+	if findings := (&RC204{}).Evaluate(old, new); len(findings) != 0 {
+		t.Errorf("expected 0 findings, got %d", len(findings))
+	}
+}
+
+func TestClassifyConstraintChange(t *testing.T) {
+	tests := []struct {
+		old, new string
+		want     versionConstraintChange
+	}{
+		{"", "", constraintUnchanged},
+		{"", ">= 1.0", constraintAdded},
+		{">= 1.0", "", constraintRemoved},
+		{"= 1.2.3", "1.2.3", constraintUnchanged},
+		{"~> 1", ">= 1.0, < 2.0", constraintUnchanged},
+		{"~> 1.2.3", ">= 1.2.3, < 1.3", constraintUnchanged},
+		{"> 1.0", ">= 1.0", constraintWidened},
+		{">= 1.0", "> 1.0", constraintNarrowed},
+		{"< 2.0", "<= 2.0", constraintWidened},
+		{"!= 1.5.0", ">= 0.0.0", constraintWidened},
+		{">= 1.0, != 1.5.0", ">= 1.0", constraintWidened},
+		{">= 2.0, < 1.0", ">= 1.0", constraintWidened},
+		{"~> 1.0", "~> 2.0", constraintNarrowed},
+		{">= 1.0", "latest", constraintChanged},
+	}
+	for _, tt := range tests {
+		if got := classifyConstraintChange(tt.old, tt.new); got != tt.want {
+			t.Errorf("classifyConstraintChange(%q, %q) = %s, want %s", tt.old, tt.new, got, tt.want)
+		}
+	}
+}
@@ -1,12 +1,18 @@
 package rules
 
-import "sync"
-
-// Registry holds all registered rules
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Registry holds all registered rules. It is safe for concurrent use. Once
+// frozen, no more rules can be registered and reads no longer take a lock.
 type Registry struct {
-	mu    sync.RWMutex
-	rules map[string]Rule
-	order []string // preserve registration order
+	mu     sync.RWMutex
+	rules  map[string]Rule
+	order  []string // preserve registration order
+	frozen atomic.Bool
 }
 
 // NewRegistry creates a new empty Registry
@@ -17,22 +23,49 @@ func NewRegistry() *Registry {
 	}
 }
 
-// Register adds a rule to the registry
+// Register adds a rule to the registry. It panics if the registry is frozen.
 func (r *Registry) Register(rule Rule) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	id := rule.ID()
+	if r.frozen.Load() {
+		panic(fmt.Sprintf("rules: Register(%s) called after the registry was frozen", id))
+	}
 	if _, exists := r.rules[id]; !exists {
 		r.order = append(r.order, id)
 	}
 	r.rules[id] = rule
 }
 
+// Freeze makes the registry read-only, so that it can be read from any
+// number of goroutines without contention. Built-in rules register
+// themselves in this package's init functions; the CLI freezes the default
+// registry from its own init, which runs after them.
+func (r *Registry) Freeze() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.frozen.Store(true)
+}
+
+// Frozen reports whether the registry has been frozen
+func (r *Registry) Frozen() bool {
+	return r.frozen.Load()
+}
+
+// rlock acquires the read lock unless the registry is frozen, and returns
+// the function that releases it
+func (r *Registry) rlock() func() {
+	if r.frozen.Load() {
+		return func() {}
+	}
+	r.mu.RLock()
+	return r.mu.RUnlock
+}
+
 // Get returns a rule by ID
 func (r *Registry) Get(id string) (Rule, bool) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	defer r.rlock()()
 
 	rule, ok := r.rules[id]
 	return rule, ok
@@ -40,8 +73,7 @@ func (r *Registry) Get(id string) (Rule, bool) {
 
 // All returns all registered rules in registration order
 func (r *Registry) All() []Rule {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	defer r.rlock()()
 
 	result := make([]Rule, 0, len(r.order))
 	for _, id := range r.order {
@@ -52,8 +84,7 @@ func (r *Registry) All() []Rule {
 
 // IDs returns all rule IDs in registration order
 func (r *Registry) IDs() []string {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	defer r.rlock()()
 
 	result := make([]string, len(r.order))
 	copy(result, r.order)
@@ -62,8 +93,7 @@ func (r *Registry) IDs() []string {
 
 // GetByName returns a rule by its human-readable name
 func (r *Registry) GetByName(name string) (Rule, bool) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	defer r.rlock()()
 
 	for _, rule := range r.rules {
 		if rule.Name() == name {
@@ -75,8 +105,7 @@ func (r *Registry) GetByName(name string) (Rule, bool) {
 
 // NameToIDMap returns a map from rule names to rule IDs
 func (r *Registry) NameToIDMap() map[string]string {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	defer r.rlock()()
 
 	result := make(map[string]string, len(r.rules))
 	for _, rule := range r.rules {
@@ -0,0 +1,72 @@
+package rules
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestRegistry_ConcurrentReads(t *testing.T) {
+	for _, frozen := range []bool{false, true} {
+		r := NewRegistry()
+		for _, rule := range DefaultRegistry.All() {
+			r.Register(rule)
+		}
+		if frozen {
+			r.Freeze()
+		}
+
+		want := len(r.IDs())
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					all := r.All()
+					if len(all) != want {
+						t.Errorf("All() returned %d rules, want %d", len(all), want)
+						return
+					}
+					for _, rule := range all {
+						if _, ok := r.Get(rule.ID()); !ok {
+							t.Errorf("Get(%q) not found", rule.ID())
+						}
+					}
+					r.NameToIDMap()
+				}
+			}()
+		}
+
+		// Unfrozen registries also accept writes concurrently with reads
+		if !frozen {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for _, rule := range DefaultRegistry.All() {
+					r.Register(rule)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+func TestRegistry_RegisterAfterFreezePanics(t *testing.T) {
+	r := NewRegistry()
+	r.Register(&BC001{})
+	r.Freeze()
+
+	if !r.Frozen() {
+		t.Fatal("expected registry to be frozen")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Register to panic on a frozen registry")
+		}
+		if _, ok := r.Get("BC002"); ok {
+			t.Error("frozen registry must not change")
+		}
+	}()
+	r.Register(&BC002{})
+}
@@ -1,9 +1,16 @@
 package rules
 
+import "fmt"
+
 // RenameDetectionSettings holds the configuration for rename detection rules
 type RenameDetectionSettings struct {
 	Enabled             bool
 	SimilarityThreshold float64
+
+	// MinConfidence is the minimum similarity score a match must reach to be
+	// reported as a rename. Matches below it revert to separate add/remove findings.
+	// Zero means no additional gate beyond SimilarityThreshold.
+	MinConfidence float64
 }
 
 // DefaultRenameDetectionSettings returns the default settings (disabled)
@@ -44,3 +51,22 @@ func GetSimilarityThreshold() float64 {
 	}
 	return renameSettings.SimilarityThreshold
 }
+
+// GetMinConfidence returns the current minimum confidence for rename findings
+func GetMinConfidence() float64 {
+	if renameSettings == nil {
+		return 0
+	}
+	return renameSettings.MinConfidence
+}
+
+// meetsMinConfidence returns true if a similarity score is high enough to be
+// treated as a rename. A score equal to the minimum is accepted.
+func meetsMinConfidence(similarity float64) bool {
+	return similarity >= GetMinConfidence()
+}
+
+// formatConfidence formats a similarity score for finding metadata
+func formatConfidence(similarity float64) string {
+	return fmt.Sprintf("%.2f", similarity)
+}
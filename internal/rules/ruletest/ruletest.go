@@ -0,0 +1,37 @@
+// Package ruletest helps test rules against Terraform source instead of
+// snapshots built by hand. Sources are parsed in memory by the same loader
+// tfbreak check uses, so tests exercise the real parsing path.
+package ruletest
+
+import (
+	"fmt"
+
+	"github.com/jokarl/tfbreak-core/internal/loader"
+	"github.com/jokarl/tfbreak-core/internal/rules"
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// SnapshotFromHCL parses the files of a module, given as a map from filename
+// (e.g. "variables.tf") to contents, into a snapshot. A .tf.json suffix
+// selects JSON syntax.
+func SnapshotFromHCL(files map[string]string) (*types.ModuleSnapshot, error) {
+	sources := make(map[string][]byte, len(files))
+	for name, src := range files {
+		sources[name] = []byte(src)
+	}
+	return loader.LoadSources(".", sources)
+}
+
+// RunRule parses the old and new versions of a module and returns the
+// findings of rule for the change between them
+func RunRule(rule rules.Rule, oldHCL, newHCL map[string]string) ([]*types.Finding, error) {
+	old, err := SnapshotFromHCL(oldHCL)
+	if err != nil {
+		return nil, fmt.Errorf("old: %w", err)
+	}
+	new, err := SnapshotFromHCL(newHCL)
+	if err != nil {
+		return nil, fmt.Errorf("new: %w", err)
+	}
+	return rule.Evaluate(old, new), nil
+}
@@ -0,0 +1,105 @@
+package ruletest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/rules"
+)
+
+func TestSnapshotFromHCL(t *testing.T) {
+	snap, err := SnapshotFromHCL(map[string]string{
+		"variables.tf": `variable "name" {
+  type = string
+}
+`,
+		"outputs.tf.json": `{"output": {"id": {"value": "x"}}}`,
+	})
+	if err != nil {
+		t.Fatalf("SnapshotFromHCL() error = %v", err)
+	}
+
+	v := snap.Variables["name"]
+	if v == nil || !v.Required {
+		t.Fatalf("expected required variable name, got %+v", v)
+	}
+	if v.DeclRange.Filename != "variables.tf" || v.DeclRange.Line != 1 {
+		t.Errorf("DeclRange = %+v, want variables.tf:1", v.DeclRange)
+	}
+	if snap.Outputs["id"] == nil {
+		t.Error("expected output id from the JSON file")
+	}
+}
+
+func TestSnapshotFromHCL_Empty(t *testing.T) {
+	snap, err := SnapshotFromHCL(nil)
+	if err != nil {
+		t.Fatalf("SnapshotFromHCL() error = %v", err)
+	}
+	if !snap.IsEmpty() {
+		t.Error("expected an empty snapshot")
+	}
+}
+
+func TestSnapshotFromHCL_Invalid(t *testing.T) {
+	_, err := SnapshotFromHCL(map[string]string{"main.tf": `variable "name" {`})
+	if err == nil {
+		t.Fatal("expected error for invalid HCL")
+	}
+	if !strings.Contains(err.Error(), "main.tf") {
+		t.Errorf("expected error to name the file, got: %v", err)
+	}
+}
+
+func TestRunRule(t *testing.T) {
+	rule, ok := rules.DefaultRegistry.Get("BC001")
+	if !ok {
+		t.Fatal("BC001 not registered")
+	}
+
+	findings, err := RunRule(rule,
+		map[string]string{"variables.tf": `variable "name" {
+  type = string
+}
+`},
+		map[string]string{"variables.tf": `variable "name" {
+  type = string
+}
+
+variable "region" {
+  type = string
+}
+`},
+	)
+	if err != nil {
+		t.Fatalf("RunRule() error = %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(findings))
+	}
+	if findings[0].RuleID != "BC001" || findings[0].NewLocation == nil || findings[0].NewLocation.Line != 5 {
+		t.Errorf("unexpected finding: %+v", findings[0])
+	}
+}
+
+func TestRunRule_NoChange(t *testing.T) {
+	rule, _ := rules.DefaultRegistry.Get("BC002")
+	src := map[string]string{"variables.tf": `variable "name" {}`}
+
+	findings, err := RunRule(rule, src, src)
+	if err != nil {
+		t.Fatalf("RunRule() error = %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected no findings, got %d", len(findings))
+	}
+}
+
+func TestRunRule_Invalid(t *testing.T) {
+	rule, _ := rules.DefaultRegistry.Get("BC002")
+
+	_, err := RunRule(rule, map[string]string{}, map[string]string{"main.tf": `variable {`})
+	if err == nil || !strings.HasPrefix(err.Error(), "new: ") {
+		t.Errorf("expected an error for the new module, got: %v", err)
+	}
+}
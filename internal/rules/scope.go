@@ -0,0 +1,76 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+// Scope is the kind of module being compared. The same change can break the
+// callers of a shared module while only mattering to the operators of a root
+// module, so the scope adjusts which rules run and how severe they are.
+type Scope string
+
+const (
+	// ScopeModule is a reusable module called by other configurations. Its
+	// variables, outputs, and version constraints are its public interface.
+	ScopeModule Scope = "module"
+	// ScopeRoot is a deployable root module applied directly. Nothing calls
+	// it, but its state and dependency lock file are used as they are.
+	ScopeRoot Scope = "root"
+)
+
+// Scopes returns all scopes
+func Scopes() []Scope {
+	return []Scope{ScopeModule, ScopeRoot}
+}
+
+// ParseScope parses a string into a Scope
+func ParseScope(s string) (Scope, error) {
+	switch Scope(strings.ToLower(s)) {
+	case ScopeModule:
+		return ScopeModule, nil
+	case ScopeRoot:
+		return ScopeRoot, nil
+	default:
+		return "", fmt.Errorf("unknown scope: %s (must be 'module' or 'root')", s)
+	}
+}
+
+// scopeSeverities overrides the default severity of rules per scope.
+// Rules that are not listed keep their default severity.
+var scopeSeverities = map[Scope]map[string]types.Severity{
+	ScopeRoot: {
+		// Variables of a root module are set from tfvars files, where an
+		// undeclared variable is only a warning
+		"BC002": types.SeverityWarning,
+		// Outputs of a root module are only read through remote state
+		"BC009": types.SeverityWarning,
+		// A root module picks its provider versions for itself; there are no
+		// callers whose constraints could conflict
+		"BC201": types.SeverityNotice,
+		"RC204": types.SeverityNotice,
+	},
+}
+
+// scopeRules lists rules that are off by default but enabled per scope
+var scopeRules = map[Scope][]string{
+	// Terraform only uses the dependency lock file of the root module
+	ScopeRoot: {"RC202"},
+}
+
+// ApplyScope adjusts the rule set for scope: it enables the rules that only
+// matter in that scope and sets the severities that differ from the rule
+// defaults. ScopeModule matches the rule defaults and changes nothing.
+func (e *Engine) ApplyScope(scope Scope) {
+	for _, ruleID := range scopeRules[scope] {
+		e.EnableRule(ruleID)
+	}
+	for ruleID, severity := range scopeSeverities[scope] {
+		if cfg := e.GetConfig(ruleID); cfg != nil {
+			cfg.Severity = severity
+			e.SetConfig(ruleID, cfg)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/jokarl/tfbreak-core/internal/types"
+)
+
+func TestParseScope(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Scope
+		wantErr bool
+	}{
+		{"module", ScopeModule, false},
+		{"root", ScopeRoot, false},
+		{"Root", ScopeRoot, false},
+		{"library", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseScope(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseScope(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseScope(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestEngine_ApplyScope(t *testing.T) {
+	// Module scope matches the rule defaults
+	engine := NewDefaultEngine()
+	engine.ApplyScope(ScopeModule)
+	for _, rule := range engine.registry.All() {
+		cfg := engine.GetConfig(rule.ID())
+		if cfg.Severity != rule.DefaultSeverity() {
+			t.Errorf("module scope changed %s severity to %s", rule.ID(), cfg.Severity)
+		}
+	}
+	if engine.GetConfig("RC202").Enabled {
+		t.Error("module scope should not enable RC202")
+	}
+
+	engine = NewDefaultEngine()
+	engine.ApplyScope(ScopeRoot)
+	for ruleID, want := range map[string]types.Severity{
+		"BC001": types.SeverityError,
+		"BC002": types.SeverityWarning,
+		"BC009": types.SeverityWarning,
+		"BC100": types.SeverityError,
+		"BC201": types.SeverityNotice,
+		"RC204": types.SeverityNotice,
+	} {
+		if got := engine.GetConfig(ruleID).Severity; got != want {
+			t.Errorf("root scope: %s severity = %s, want %s", ruleID, got, want)
+		}
+	}
+	if !engine.GetConfig("RC202").Enabled {
+		t.Error("root scope should enable RC202")
+	}
+}
+
+func TestEngine_ApplyScope_ChangesFindings(t *testing.T) {
+	old := types.NewModuleSnapshot("/old")
+	old.Variables["region"] = &types.VariableSignature{Name: "region", Default: "us-east-1"}
+	new := types.NewModuleSnapshot("/new")
+
+	engine := NewDefaultEngine()
+	engine.ApplyScope(ScopeRoot)
+	result := engine.Check("/old", "/new", old, new, types.SeverityError)
+
+	if result.Result != "PASS" || result.Summary.Warning != 1 {
+		t.Errorf("root scope: result = %s, summary = %+v, want PASS with 1 warning", result.Result, result.Summary)
+	}
+}
@@ -0,0 +1,240 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// versionConstraintChange classifies how a version constraint changed
+type versionConstraintChange string
+
+const (
+	constraintUnchanged versionConstraintChange = "unchanged"
+	constraintAdded     versionConstraintChange = "added"
+	constraintRemoved   versionConstraintChange = "removed"
+	constraintWidened   versionConstraintChange = "widened"
+	constraintNarrowed  versionConstraintChange = "narrowed"
+
+	// constraintChanged is a change that could not be classified because a
+	// constraint did not parse
+	constraintChanged versionConstraintChange = "changed"
+)
+
+// classifyConstraintChange compares two Terraform version constraint
+// strings by the set of versions they allow. A constraint is narrowed if it
+// no longer allows some version it allowed before, even if it also allows
+// new ones, and widened if it allows everything it allowed before and more.
+func classifyConstraintChange(oldConstraint, newConstraint string) versionConstraintChange {
+	oldConstraint, newConstraint = strings.TrimSpace(oldConstraint), strings.TrimSpace(newConstraint)
+	switch {
+	case oldConstraint == newConstraint:
+		return constraintUnchanged
+	case oldConstraint == "":
+		return constraintAdded
+	case newConstraint == "":
+		return constraintRemoved
+	}
+
+	oldRanges, errOld := parseVersionConstraint(oldConstraint)
+	newRanges, errNew := parseVersionConstraint(newConstraint)
+	if errOld != nil || errNew != nil {
+		return constraintChanged
+	}
+
+	switch {
+	case !rangesCover(newRanges, oldRanges):
+		return constraintNarrowed
+	case rangesCover(oldRanges, newRanges):
+		return constraintUnchanged
+	default:
+		return constraintWidened
+	}
+}
+
+// constraintVersion is a major.minor.patch version. Missing parts are zero.
+type constraintVersion [3]int
+
+func (v constraintVersion) compare(o constraintVersion) int {
+	for i := range v {
+		if v[i] != o[i] {
+			if v[i] < o[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionBound is one end of a versionRange
+type versionBound struct {
+	version   constraintVersion
+	inclusive bool
+
+	// unbounded marks an upper bound without a limit
+	unbounded bool
+}
+
+// versionRange is a contiguous range of versions
+type versionRange struct {
+	lower, upper versionBound
+}
+
+// anyVersion is the range an empty constraint allows
+var anyVersion = versionRange{
+	lower: versionBound{inclusive: true},
+	upper: versionBound{unbounded: true},
+}
+
+func (r versionRange) empty() bool {
+	if r.upper.unbounded {
+		return false
+	}
+	c := r.lower.version.compare(r.upper.version)
+	return c > 0 || (c == 0 && !(r.lower.inclusive && r.upper.inclusive))
+}
+
+// covers reports whether r contains every version in o
+func (r versionRange) covers(o versionRange) bool {
+	switch c := r.lower.version.compare(o.lower.version); {
+	case c > 0, c == 0 && !r.lower.inclusive && o.lower.inclusive:
+		return false
+	}
+	if r.upper.unbounded {
+		return true
+	}
+	if o.upper.unbounded {
+		return false
+	}
+	c := r.upper.version.compare(o.upper.version)
+	return c > 0 || (c == 0 && (r.upper.inclusive || !o.upper.inclusive))
+}
+
+// intersect returns the versions in both r and o
+func (r versionRange) intersect(o versionRange) versionRange {
+	result := r
+	if c := o.lower.version.compare(r.lower.version); c > 0 || (c == 0 && !o.lower.inclusive) {
+		result.lower = o.lower
+	}
+	if !o.upper.unbounded {
+		if r.upper.unbounded {
+			result.upper = o.upper
+		} else if c := o.upper.version.compare(r.upper.version); c < 0 || (c == 0 && !o.upper.inclusive) {
+			result.upper = o.upper
+		}
+	}
+	return result
+}
+
+// rangesCover reports whether the union of ranges contains every version in
+// the union of others. Ranges from parseVersionConstraint never touch, so
+// each of others must lie within a single range.
+func rangesCover(ranges, others []versionRange) bool {
+	for _, o := range others {
+		covered := false
+		for _, r := range ranges {
+			if r.covers(o) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// parseVersionConstraint parses a comma-separated Terraform version
+// constraint into the ranges of versions it allows
+func parseVersionConstraint(constraint string) ([]versionRange, error) {
+	ranges := []versionRange{anyVersion}
+	for _, clause := range strings.Split(constraint, ",") {
+		clauseRanges, err := parseConstraintClause(strings.TrimSpace(clause))
+		if err != nil {
+			return nil, err
+		}
+
+		var next []versionRange
+		for _, a := range ranges {
+			for _, b := range clauseRanges {
+				if r := a.intersect(b); !r.empty() {
+					next = append(next, r)
+				}
+			}
+		}
+		ranges = next
+	}
+	return ranges, nil
+}
+
+// constraintOperators lists the operators of a constraint clause, longest
+// first so that ">=" is not read as ">"
+var constraintOperators = []string{"~>", ">=", "<=", "!=", ">", "<", "="}
+
+// parseConstraintClause parses a single operator and version, such as
+// "~> 4.0", into the ranges of versions it allows
+func parseConstraintClause(clause string) ([]versionRange, error) {
+	op := "="
+	for _, candidate := range constraintOperators {
+		if strings.HasPrefix(clause, candidate) {
+			op = candidate
+			clause = strings.TrimSpace(clause[len(candidate):])
+			break
+		}
+	}
+
+	v, parts, err := parseConstraintVersion(clause)
+	if err != nil {
+		return nil, err
+	}
+
+	at := versionBound{version: v, inclusive: true}
+	below := versionBound{version: v}
+	switch op {
+	case "=":
+		return []versionRange{{lower: at, upper: at}}, nil
+	case "!=":
+		return []versionRange{
+			{lower: anyVersion.lower, upper: below},
+			{lower: below, upper: anyVersion.upper},
+		}, nil
+	case ">":
+		return []versionRange{{lower: below, upper: anyVersion.upper}}, nil
+	case ">=":
+		return []versionRange{{lower: at, upper: anyVersion.upper}}, nil
+	case "<":
+		return []versionRange{{lower: anyVersion.lower, upper: below}}, nil
+	case "<=":
+		return []versionRange{{lower: anyVersion.lower, upper: at}}, nil
+	default:
+		// "~>" allows the rightmost given part to increase: ~> 4.1 means
+		// >= 4.1, < 5.0 and ~> 4.1.2 means >= 4.1.2, < 4.2.0
+		limit := constraintVersion{v[0] + 1, 0, 0}
+		if parts == 3 {
+			limit = constraintVersion{v[0], v[1] + 1, 0}
+		}
+		return []versionRange{{lower: at, upper: versionBound{version: limit}}}, nil
+	}
+}
+
+// parseConstraintVersion parses a version of one to three numeric parts and
+// returns it with the number of parts given. Pre-release versions are not
+// supported.
+func parseConstraintVersion(s string) (constraintVersion, int, error) {
+	var v constraintVersion
+	s = strings.TrimPrefix(s, "v")
+	parts := strings.Split(s, ".")
+	if s == "" || len(parts) > 3 {
+		return v, 0, fmt.Errorf("invalid version %q", s)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, 0, fmt.Errorf("invalid version %q", s)
+		}
+		v[i] = n
+	}
+	return v, len(parts), nil
+}
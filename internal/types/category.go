@@ -0,0 +1,63 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Category groups rules by the kind of change they detect
+type Category string
+
+const (
+	// CategoryBreaking covers changes that will break callers or destroy state (BC rules)
+	CategoryBreaking Category = "breaking"
+	// CategoryRisky covers changes that may cause unexpected behavior (RC rules)
+	CategoryRisky Category = "risky"
+	// CategoryAdvisory covers informational changes
+	CategoryAdvisory Category = "advisory"
+)
+
+// Categories returns all known categories
+func Categories() []Category {
+	return []Category{CategoryBreaking, CategoryRisky, CategoryAdvisory}
+}
+
+// ParseCategory parses a string into a Category
+func ParseCategory(s string) (Category, error) {
+	switch Category(strings.ToLower(s)) {
+	case CategoryBreaking:
+		return CategoryBreaking, nil
+	case CategoryRisky:
+		return CategoryRisky, nil
+	case CategoryAdvisory:
+		return CategoryAdvisory, nil
+	default:
+		return "", fmt.Errorf("unknown category: %s", s)
+	}
+}
+
+// CategoryForRuleID returns the category of a built-in rule based on its ID prefix.
+// BC rules are breaking, RC rules are risky, and anything else is advisory.
+func CategoryForRuleID(ruleID string) Category {
+	switch {
+	case strings.HasPrefix(ruleID, "BC"):
+		return CategoryBreaking
+	case strings.HasPrefix(ruleID, "RC"):
+		return CategoryRisky
+	default:
+		return CategoryAdvisory
+	}
+}
+
+// CategoryForSeverity returns the category matching a severity.
+// Used for findings whose rules carry no category of their own (e.g., plugin rules).
+func CategoryForSeverity(s Severity) Category {
+	switch s {
+	case SeverityError:
+		return CategoryBreaking
+	case SeverityWarning:
+		return CategoryRisky
+	default:
+		return CategoryAdvisory
+	}
+}
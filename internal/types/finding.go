@@ -1,5 +1,12 @@
 package types
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+)
+
 // Finding represents a single rule violation or observation
 type Finding struct {
 	// RuleID is the unique identifier for the rule (e.g., "BC001")
@@ -11,6 +18,9 @@ type Finding struct {
 	// Severity is the severity level of this finding
 	Severity Severity `json:"severity"`
 
+	// Category is the kind of change this finding reports (breaking, risky, advisory)
+	Category Category `json:"category,omitempty"`
+
 	// Message is a short description of the finding
 	Message string `json:"message"`
 
@@ -23,12 +33,21 @@ type Finding struct {
 	// NewLocation is the source location in the new config (nil if not applicable)
 	NewLocation *FileRange `json:"new_location,omitempty"`
 
+	// RelatedLocations are other source locations the finding refers to,
+	// such as the old declaration of a renamed variable or the resource a
+	// moved block conflicts with
+	RelatedLocations []FileRange `json:"related_locations,omitempty"`
+
 	// Ignored indicates if this finding was suppressed by an annotation
 	Ignored bool `json:"ignored"`
 
 	// IgnoreReason is the reason provided in the ignore annotation
 	IgnoreReason string `json:"ignore_reason,omitempty"`
 
+	// Module is the module path, relative to the scanned root, that the
+	// finding belongs to. Only set in recursive mode ("." for the root module).
+	Module string `json:"module,omitempty"`
+
 	// Metadata contains rule-specific metadata for advanced processing
 	// Used by rename detection rules to store old/new names for suppression logic
 	Metadata map[string]string `json:"metadata,omitempty"`
@@ -36,6 +55,10 @@ type Finding struct {
 	// Remediation provides guidance on how to fix this issue
 	// Only populated when --include-remediation flag is set
 	Remediation string `json:"remediation,omitempty"`
+
+	// HelpURL links to the rule's documentation: the built-in rule
+	// documentation, or the link a plugin rule provides
+	HelpURL string `json:"help_url,omitempty"`
 }
 
 // NewFinding creates a new Finding with the given parameters
@@ -44,6 +67,7 @@ func NewFinding(ruleID, ruleName string, severity Severity, message string) *Fin
 		RuleID:   ruleID,
 		RuleName: ruleName,
 		Severity: severity,
+		Category: CategoryForRuleID(ruleID),
 		Message:  message,
 	}
 }
@@ -66,6 +90,14 @@ func (f *Finding) WithNewLocation(loc *FileRange) *Finding {
 	return f
 }
 
+// WithRelatedLocation appends a related location and returns the finding for chaining
+func (f *Finding) WithRelatedLocation(loc *FileRange) *Finding {
+	if loc != nil {
+		f.RelatedLocations = append(f.RelatedLocations, *loc)
+	}
+	return f
+}
+
 // WithMetadata sets metadata and returns the finding for chaining
 func (f *Finding) WithMetadata(key, value string) *Finding {
 	if f.Metadata == nil {
@@ -81,6 +113,61 @@ func (f *Finding) WithRemediation(remediation string) *Finding {
 	return f
 }
 
+// EffectiveCategory returns the finding's category, deriving it from the
+// rule ID if it was not set explicitly
+func (f *Finding) EffectiveCategory() Category {
+	if f.Category != "" {
+		return f.Category
+	}
+	return CategoryForRuleID(f.RuleID)
+}
+
+// Fingerprint returns a stable identifier for this finding.
+// It is derived from the rule ID, the module in recursive mode, the base
+// name of the file the finding refers to, and the message with whitespace
+// collapsed, so it survives line shifts, reflowed messages, and differing
+// checkout directories (e.g., temporary git worktrees) between runs, while
+// the same finding in two modules differs.
+func (f *Finding) Fingerprint() string {
+	return fingerprint(f.RuleID, f.modulePath(), f.locationFilename(), strings.Join(strings.Fields(f.Message), " "))
+}
+
+// ShortID returns the first 8 characters of Fingerprint, short enough for
+// humans and ticketing bots to quote when referring to a finding across runs
+func (f *Finding) ShortID() string {
+	return f.Fingerprint()[:8]
+}
+
+// RuleFingerprint returns a stable identifier for the rule and location of
+// this finding. Unlike Fingerprint, it leaves out the message, so every
+// finding of a rule in the same file of the same module shares it.
+func (f *Finding) RuleFingerprint() string {
+	return fingerprint(f.RuleID, f.modulePath(), f.locationFilename())
+}
+
+// modulePath returns the module path with forward slashes, so that results
+// written on Windows match those written elsewhere
+func (f *Finding) modulePath() string {
+	return strings.ReplaceAll(f.Module, `\`, "/")
+}
+
+// locationFilename returns the base name of the file the finding refers to,
+// preferring the new location
+func (f *Finding) locationFilename() string {
+	if f.NewLocation != nil {
+		return filepath.Base(f.NewLocation.Filename)
+	} else if f.OldLocation != nil {
+		return filepath.Base(f.OldLocation.Filename)
+	}
+	return ""
+}
+
+// fingerprint hashes the given fields into a short hex identifier
+func fingerprint(fields ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
+
 // CheckResult represents the result of running a check
 type CheckResult struct {
 	// OldPath is the path to the old configuration
@@ -89,6 +176,14 @@ type CheckResult struct {
 	// NewPath is the path to the new configuration
 	NewPath string `json:"new_path"`
 
+	// OldRef is the git ref the old configuration was checked out from,
+	// empty when comparing directories
+	OldRef string `json:"old_ref,omitempty"`
+
+	// NewRef is the git ref the new configuration was checked out from,
+	// empty when it is a directory or the working tree
+	NewRef string `json:"new_ref,omitempty"`
+
 	// Findings is the list of all findings
 	Findings []*Finding `json:"findings"`
 
@@ -100,15 +195,101 @@ type CheckResult struct {
 
 	// FailOn is the severity threshold used for the result
 	FailOn Severity `json:"fail_on"`
+
+	// Modules lists the relative paths of all modules checked in recursive
+	// mode, including those without findings. Empty for single-module runs.
+	Modules []string `json:"modules,omitempty"`
+
+	// FailOnCategory holds per-category severity thresholds. When set, it
+	// replaces FailOn: a finding fails the check only if its category has a
+	// threshold and its severity meets it. Categories absent from the map
+	// never fail the check.
+	FailOnCategory map[Category]Severity `json:"fail_on_category,omitempty"`
+
+	// TreatWarningsAsErrors fails the check on any non-ignored finding of
+	// WARNING or above, whatever FailOn or FailOnCategory say. Findings keep
+	// their severity; only the result changes.
+	TreatWarningsAsErrors bool `json:"treat_warnings_as_errors,omitempty"`
+
+	// TreatRiskyAsErrors is TreatWarningsAsErrors limited to findings in
+	// the risky category
+	TreatRiskyAsErrors bool `json:"treat_risky_as_errors,omitempty"`
+
+	// Annotations summarizes how tfbreak:ignore annotations were applied,
+	// or is nil if annotations were not processed
+	Annotations *AnnotationStats `json:"annotations,omitempty"`
+
+	// Warnings are problems tfbreak ran into during the check that are not
+	// findings, such as a module it had to skip. They do not affect Result.
+	Warnings []ResultWarning `json:"warnings,omitempty"`
+
+	// TimedOut is set when the run was cut short by --timeout, so Findings
+	// cover only the part of the comparison that finished
+	TimedOut bool `json:"timed_out,omitempty"`
+}
+
+// Sources of result warnings
+const (
+	WarningSourceLoader      = "loader"
+	WarningSourcePlugin      = "plugin"
+	WarningSourceAnnotations = "annotations"
+	WarningSourceTimeout     = "timeout"
+)
+
+// ResultWarning is a problem tfbreak ran into during a check, recorded so
+// machine-readable output describes the run as well as the findings
+type ResultWarning struct {
+	// Source is the part of tfbreak that raised the warning, one of the
+	// WarningSource constants
+	Source string `json:"source"`
+
+	// Message describes the problem
+	Message string `json:"message"`
+
+	// Module is the module the warning concerns in recursive mode
+	Module string `json:"module,omitempty"`
+
+	// Location is the file or directory the warning concerns, if any
+	Location *FileRange `json:"location,omitempty"`
+}
+
+// AnnotationStats counts the ignore annotations found in a check by what
+// became of them. Each annotation is counted once: Matched, Expired, and
+// GovernanceViolations cover annotations that apply to at least one
+// finding, and the four of them add up to Parsed.
+type AnnotationStats struct {
+	// Parsed is the number of annotations read from source files and the
+	// sidecar file
+	Parsed int `json:"parsed"`
+
+	// Matched annotations suppressed at least one finding
+	Matched int `json:"matched"`
+
+	// Unmatched annotations apply to no finding
+	Unmatched int `json:"unmatched"`
+
+	// Expired annotations apply to a finding but are past their expiry date
+	Expired int `json:"expired"`
+
+	// GovernanceViolations apply to a finding but break an annotation
+	// policy, such as require_reason or deny_rule_ids
+	GovernanceViolations int `json:"governance_violations"`
 }
 
 // Summary contains counts of findings by severity
 type Summary struct {
-	Error   int `json:"error"`
-	Warning int `json:"warning"`
-	Notice  int `json:"notice"`
-	Ignored int `json:"ignored"`
-	Total   int `json:"total"`
+	Error       int `json:"error"`
+	Warning     int `json:"warning"`
+	Deprecation int `json:"deprecation"`
+	Notice      int `json:"notice"`
+	Ignored     int `json:"ignored"`
+	Total       int `json:"total"`
+
+	// ByRule counts non-ignored findings per rule ID
+	ByRule map[string]int `json:"by_rule,omitempty"`
+
+	// ByCategory counts non-ignored findings per category
+	ByCategory map[string]int `json:"by_category,omitempty"`
 }
 
 // NewCheckResult creates a new CheckResult
@@ -126,6 +307,11 @@ func (r *CheckResult) AddFinding(f *Finding) {
 	r.Findings = append(r.Findings, f)
 }
 
+// AddWarning records a warning from the given source
+func (r *CheckResult) AddWarning(source, message string) {
+	r.Warnings = append(r.Warnings, ResultWarning{Source: source, Message: message})
+}
+
 // Compute calculates the summary and result
 func (r *CheckResult) Compute() {
 	r.Summary = Summary{}
@@ -134,11 +320,20 @@ func (r *CheckResult) Compute() {
 			r.Summary.Ignored++
 			continue
 		}
+		if r.Summary.ByRule == nil {
+			r.Summary.ByRule = make(map[string]int)
+			r.Summary.ByCategory = make(map[string]int)
+		}
+		r.Summary.ByRule[f.RuleID]++
+		r.Summary.ByCategory[string(f.EffectiveCategory())]++
+
 		switch f.Severity {
 		case SeverityError:
 			r.Summary.Error++
 		case SeverityWarning:
 			r.Summary.Warning++
+		case SeverityDeprecation:
+			r.Summary.Deprecation++
 		case SeverityNotice:
 			r.Summary.Notice++
 		}
@@ -147,13 +342,11 @@ func (r *CheckResult) Compute() {
 
 	// Determine pass/fail based on policy
 	failed := false
-	switch r.FailOn {
-	case SeverityError:
-		failed = r.Summary.Error > 0
-	case SeverityWarning:
-		failed = r.Summary.Error > 0 || r.Summary.Warning > 0
-	case SeverityNotice:
-		failed = r.Summary.Error > 0 || r.Summary.Warning > 0 || r.Summary.Notice > 0
+	for _, f := range r.Findings {
+		if r.Fails(f) {
+			failed = true
+			break
+		}
 	}
 
 	if failed {
@@ -162,3 +355,66 @@ func (r *CheckResult) Compute() {
 		r.Result = "PASS"
 	}
 }
+
+// RemoveKnownRules drops findings whose rule already fired at the same
+// location in a previous result, matched by RuleFingerprint, and returns how
+// many were dropped. What remains are the rules newly triggered since the
+// previous run. Call Compute afterwards to update the summary.
+func (r *CheckResult) RemoveKnownRules(previous *CheckResult) int {
+	known := make(map[string]bool, len(previous.Findings))
+	for _, f := range previous.Findings {
+		known[f.RuleFingerprint()] = true
+	}
+
+	kept := make([]*Finding, 0, len(r.Findings))
+	for _, f := range r.Findings {
+		if !known[f.RuleFingerprint()] {
+			kept = append(kept, f)
+		}
+	}
+	removed := len(r.Findings) - len(kept)
+	r.Findings = kept
+	return removed
+}
+
+// IgnoreKnownFindings marks the findings that also appear in a reference
+// result, matched by Fingerprint, as ignored with the given
+// reason, and returns how many it marked. What still counts are the
+// findings that are new relative to the reference run. Call Compute
+// afterwards to update the summary and result.
+func (r *CheckResult) IgnoreKnownFindings(reference *CheckResult, reason string) int {
+	known := make(map[string]bool, len(reference.Findings))
+	for _, f := range reference.Findings {
+		known[f.Fingerprint()] = true
+	}
+
+	marked := 0
+	for _, f := range r.Findings {
+		if !f.Ignored && known[f.Fingerprint()] {
+			f.Ignored = true
+			f.IgnoreReason = reason
+			marked++
+		}
+	}
+	return marked
+}
+
+// Fails reports whether a finding fails the check under the result's
+// policy: ignored findings never do, warnings do if they are treated as
+// errors, and otherwise the finding must meet the threshold for its
+// category, or FailOn if there are no category thresholds
+func (r *CheckResult) Fails(f *Finding) bool {
+	if f.Ignored {
+		return false
+	}
+	if f.Severity.AtLeast(SeverityWarning) {
+		if r.TreatWarningsAsErrors || (r.TreatRiskyAsErrors && f.EffectiveCategory() == CategoryRisky) {
+			return true
+		}
+	}
+	if r.FailOnCategory != nil {
+		threshold, ok := r.FailOnCategory[f.EffectiveCategory()]
+		return ok && f.Severity.AtLeast(threshold)
+	}
+	return f.Severity.AtLeast(r.FailOn)
+}
@@ -1,6 +1,11 @@
 package types
 
-import "testing"
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
 
 func TestNewFinding(t *testing.T) {
 	f := NewFinding("BC001", "required-input-added", SeverityError, "test message")
@@ -82,19 +87,59 @@ func TestCheckResultAddFinding(t *testing.T) {
 	}
 }
 
+func TestCheckResultCompute_Breakdown(t *testing.T) {
+	r := NewCheckResult("/old", "/new", SeverityError)
+	for _, f := range []*Finding{
+		NewFinding("BC001", "required-input-added", SeverityError, "a"),
+		NewFinding("BC001", "required-input-added", SeverityError, "b"),
+		NewFinding("BC002", "input-removed", SeverityError, "c"),
+		NewFinding("RC006", "input-default-changed", SeverityWarning, "d"),
+		{RuleID: "BC002", Severity: SeverityError, Ignored: true},
+	} {
+		r.AddFinding(f)
+	}
+	r.Compute()
+
+	wantByRule := map[string]int{"BC001": 2, "BC002": 1, "RC006": 1}
+	if !reflect.DeepEqual(r.Summary.ByRule, wantByRule) {
+		t.Errorf("ByRule = %v, want %v", r.Summary.ByRule, wantByRule)
+	}
+
+	// The per-rule counts add up to the non-ignored findings
+	sum := 0
+	for _, n := range r.Summary.ByRule {
+		sum += n
+	}
+	if want := r.Summary.Error + r.Summary.Warning + r.Summary.Deprecation + r.Summary.Notice; sum != want {
+		t.Errorf("ByRule total = %d, want %d", sum, want)
+	}
+
+	wantByCategory := map[string]int{"breaking": 3, "risky": 1}
+	if !reflect.DeepEqual(r.Summary.ByCategory, wantByCategory) {
+		t.Errorf("ByCategory = %v, want %v", r.Summary.ByCategory, wantByCategory)
+	}
+
+	// Recomputing starts from scratch
+	r.Findings = nil
+	r.Compute()
+	if r.Summary.ByRule != nil || r.Summary.ByCategory != nil {
+		t.Errorf("expected no breakdown without findings, got %v / %v", r.Summary.ByRule, r.Summary.ByCategory)
+	}
+}
+
 func TestCheckResultCompute(t *testing.T) {
 	tests := []struct {
-		name       string
-		findings   []*Finding
-		failOn     Severity
-		wantResult string
+		name        string
+		findings    []*Finding
+		failOn      Severity
+		wantResult  string
 		wantSummary Summary
 	}{
 		{
-			name:       "no findings passes",
-			findings:   nil,
-			failOn:     SeverityError,
-			wantResult: "PASS",
+			name:        "no findings passes",
+			findings:    nil,
+			failOn:      SeverityError,
+			wantResult:  "PASS",
 			wantSummary: Summary{Total: 0},
 		},
 		{
@@ -102,8 +147,8 @@ func TestCheckResultCompute(t *testing.T) {
 			findings: []*Finding{
 				{RuleID: "BC001", Severity: SeverityError},
 			},
-			failOn:     SeverityError,
-			wantResult: "FAIL",
+			failOn:      SeverityError,
+			wantResult:  "FAIL",
 			wantSummary: Summary{Error: 1, Total: 1},
 		},
 		{
@@ -111,8 +156,8 @@ func TestCheckResultCompute(t *testing.T) {
 			findings: []*Finding{
 				{RuleID: "BC001", Severity: SeverityWarning},
 			},
-			failOn:     SeverityError,
-			wantResult: "PASS",
+			failOn:      SeverityError,
+			wantResult:  "PASS",
 			wantSummary: Summary{Warning: 1, Total: 1},
 		},
 		{
@@ -120,8 +165,8 @@ func TestCheckResultCompute(t *testing.T) {
 			findings: []*Finding{
 				{RuleID: "BC001", Severity: SeverityWarning},
 			},
-			failOn:     SeverityWarning,
-			wantResult: "FAIL",
+			failOn:      SeverityWarning,
+			wantResult:  "FAIL",
 			wantSummary: Summary{Warning: 1, Total: 1},
 		},
 		{
@@ -129,8 +174,8 @@ func TestCheckResultCompute(t *testing.T) {
 			findings: []*Finding{
 				{RuleID: "BC001", Severity: SeverityNotice},
 			},
-			failOn:     SeverityWarning,
-			wantResult: "PASS",
+			failOn:      SeverityWarning,
+			wantResult:  "PASS",
 			wantSummary: Summary{Notice: 1, Total: 1},
 		},
 		{
@@ -138,8 +183,35 @@ func TestCheckResultCompute(t *testing.T) {
 			findings: []*Finding{
 				{RuleID: "BC001", Severity: SeverityNotice},
 			},
-			failOn:     SeverityNotice,
-			wantResult: "FAIL",
+			failOn:      SeverityNotice,
+			wantResult:  "FAIL",
+			wantSummary: Summary{Notice: 1, Total: 1},
+		},
+		{
+			name: "deprecation with warning threshold passes",
+			findings: []*Finding{
+				{RuleID: "RC015", Severity: SeverityDeprecation},
+			},
+			failOn:      SeverityWarning,
+			wantResult:  "PASS",
+			wantSummary: Summary{Deprecation: 1, Total: 1},
+		},
+		{
+			name: "deprecation with deprecation threshold fails",
+			findings: []*Finding{
+				{RuleID: "RC015", Severity: SeverityDeprecation},
+			},
+			failOn:      SeverityDeprecation,
+			wantResult:  "FAIL",
+			wantSummary: Summary{Deprecation: 1, Total: 1},
+		},
+		{
+			name: "notice with deprecation threshold passes",
+			findings: []*Finding{
+				{RuleID: "BC001", Severity: SeverityNotice},
+			},
+			failOn:      SeverityDeprecation,
+			wantResult:  "PASS",
 			wantSummary: Summary{Notice: 1, Total: 1},
 		},
 		{
@@ -147,8 +219,8 @@ func TestCheckResultCompute(t *testing.T) {
 			findings: []*Finding{
 				{RuleID: "BC001", Severity: SeverityError, Ignored: true},
 			},
-			failOn:     SeverityError,
-			wantResult: "PASS",
+			failOn:      SeverityError,
+			wantResult:  "PASS",
 			wantSummary: Summary{Ignored: 1, Total: 1},
 		},
 		{
@@ -159,8 +231,8 @@ func TestCheckResultCompute(t *testing.T) {
 				{RuleID: "BC003", Severity: SeverityNotice},
 				{RuleID: "BC004", Severity: SeverityError, Ignored: true},
 			},
-			failOn:     SeverityError,
-			wantResult: "FAIL",
+			failOn:      SeverityError,
+			wantResult:  "FAIL",
 			wantSummary: Summary{Error: 1, Warning: 1, Notice: 1, Ignored: 1, Total: 4},
 		},
 	}
@@ -176,9 +248,350 @@ func TestCheckResultCompute(t *testing.T) {
 			if r.Result != tt.wantResult {
 				t.Errorf("Result = %q, want %q", r.Result, tt.wantResult)
 			}
-			if r.Summary != tt.wantSummary {
-				t.Errorf("Summary = %+v, want %+v", r.Summary, tt.wantSummary)
+			// Per-rule and per-category counts are covered by TestCheckResultCompute_Breakdown
+			got := r.Summary
+			got.ByRule, got.ByCategory = nil, nil
+			if !reflect.DeepEqual(got, tt.wantSummary) {
+				t.Errorf("Summary = %+v, want %+v", got, tt.wantSummary)
 			}
 		})
 	}
 }
+
+func TestCheckResultCompute_FailOnCategory(t *testing.T) {
+	// Breaking fails on ERROR, risky fails on WARNING, advisory is off
+	thresholds := map[Category]Severity{
+		CategoryBreaking: SeverityError,
+		CategoryRisky:    SeverityWarning,
+	}
+
+	tests := []struct {
+		name       string
+		findings   []*Finding
+		wantResult string
+	}{
+		{
+			name: "breaking error fails",
+			findings: []*Finding{
+				{RuleID: "BC001", Severity: SeverityError},
+			},
+			wantResult: "FAIL",
+		},
+		{
+			name: "breaking downgraded to warning passes",
+			findings: []*Finding{
+				{RuleID: "BC001", Severity: SeverityWarning},
+			},
+			wantResult: "PASS",
+		},
+		{
+			name: "risky warning fails",
+			findings: []*Finding{
+				{RuleID: "RC006", Severity: SeverityWarning},
+			},
+			wantResult: "FAIL",
+		},
+		{
+			name: "advisory error passes when off",
+			findings: []*Finding{
+				{RuleID: "plugin/some_rule", Severity: SeverityError, Category: CategoryAdvisory},
+			},
+			wantResult: "PASS",
+		},
+		{
+			name: "explicit category overrides rule ID prefix",
+			findings: []*Finding{
+				{RuleID: "BC001", Severity: SeverityError, Category: CategoryAdvisory},
+			},
+			wantResult: "PASS",
+		},
+		{
+			name: "mixed categories with ignored risky finding",
+			findings: []*Finding{
+				{RuleID: "BC001", Severity: SeverityWarning},
+				{RuleID: "RC006", Severity: SeverityWarning, Ignored: true},
+				{RuleID: "plugin/some_rule", Severity: SeverityError, Category: CategoryAdvisory},
+			},
+			wantResult: "PASS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewCheckResult("/old", "/new", SeverityNotice)
+			r.FailOnCategory = thresholds
+			for _, f := range tt.findings {
+				r.AddFinding(f)
+			}
+			r.Compute()
+
+			if r.Result != tt.wantResult {
+				t.Errorf("Result = %q, want %q", r.Result, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestCheckResultCompute_TreatWarningsAsErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		findings       []*Finding
+		failOnCategory map[Category]Severity
+		warnings       bool
+		risky          bool
+		wantResult     string
+	}{
+		{
+			name:       "warning passes without the flag",
+			findings:   []*Finding{{RuleID: "RC006", Severity: SeverityWarning}},
+			wantResult: "PASS",
+		},
+		{
+			name:       "warning fails with the flag",
+			findings:   []*Finding{{RuleID: "RC006", Severity: SeverityWarning}},
+			warnings:   true,
+			wantResult: "FAIL",
+		},
+		{
+			name:       "notice still passes",
+			findings:   []*Finding{{RuleID: "RC014", Severity: SeverityNotice}},
+			warnings:   true,
+			wantResult: "PASS",
+		},
+		{
+			name:       "ignored warning passes",
+			findings:   []*Finding{{RuleID: "RC006", Severity: SeverityWarning, Ignored: true}},
+			warnings:   true,
+			wantResult: "PASS",
+		},
+		{
+			name:     "overrides a disabled category",
+			findings: []*Finding{{RuleID: "plugin/some_rule", Severity: SeverityWarning, Category: CategoryAdvisory}},
+			failOnCategory: map[Category]Severity{
+				CategoryBreaking: SeverityError,
+			},
+			warnings:   true,
+			wantResult: "FAIL",
+		},
+		{
+			name:       "risky warning fails with the risky variant",
+			findings:   []*Finding{{RuleID: "RC006", Severity: SeverityWarning}},
+			risky:      true,
+			wantResult: "FAIL",
+		},
+		{
+			name:       "breaking warning passes with the risky variant",
+			findings:   []*Finding{{RuleID: "BC001", Severity: SeverityWarning}},
+			risky:      true,
+			wantResult: "PASS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewCheckResult("/old", "/new", SeverityError)
+			r.FailOnCategory = tt.failOnCategory
+			r.TreatWarningsAsErrors = tt.warnings
+			r.TreatRiskyAsErrors = tt.risky
+			for _, f := range tt.findings {
+				r.AddFinding(f)
+			}
+			r.Compute()
+
+			if r.Result != tt.wantResult {
+				t.Errorf("Result = %q, want %q", r.Result, tt.wantResult)
+			}
+			// The reported severities stay as they are
+			for i, f := range r.Findings {
+				if f.Severity != tt.findings[i].Severity {
+					t.Errorf("finding %d severity = %s, want %s", i, f.Severity, tt.findings[i].Severity)
+				}
+			}
+			if r.Summary.Error != 0 {
+				t.Errorf("Summary.Error = %d, want 0", r.Summary.Error)
+			}
+		})
+	}
+}
+
+func TestCategoryForRuleID(t *testing.T) {
+	tests := []struct {
+		ruleID string
+		want   Category
+	}{
+		{"BC001", CategoryBreaking},
+		{"RC006", CategoryRisky},
+		{"azurerm/some_rule", CategoryAdvisory},
+	}
+	for _, tt := range tests {
+		if got := CategoryForRuleID(tt.ruleID); got != tt.want {
+			t.Errorf("CategoryForRuleID(%q) = %q, want %q", tt.ruleID, got, tt.want)
+		}
+	}
+
+	if got := NewFinding("RC006", "input-default-changed", SeverityWarning, "msg").Category; got != CategoryRisky {
+		t.Errorf("NewFinding category = %q, want %q", got, CategoryRisky)
+	}
+}
+
+func TestFindingFingerprint(t *testing.T) {
+	a := NewFinding("BC002", "input-removed", SeverityError, `Variable "foo" was removed`).
+		WithOldLocation(&FileRange{Filename: "/tmp/worktree-1/variables.tf", Line: 3})
+	b := NewFinding("BC002", "input-removed", SeverityError, `Variable "foo" was removed`).
+		WithOldLocation(&FileRange{Filename: "/tmp/worktree-2/variables.tf", Line: 9})
+	c := NewFinding("BC002", "input-removed", SeverityError, `Variable "bar" was removed`).
+		WithOldLocation(&FileRange{Filename: "/tmp/worktree-1/variables.tf", Line: 3})
+
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Error("fingerprint should ignore checkout directory and line number")
+	}
+	if a.Fingerprint() == c.Fingerprint() {
+		t.Error("fingerprint should differ for different messages")
+	}
+	if len(a.Fingerprint()) != 16 {
+		t.Errorf("fingerprint length = %d, want 16", len(a.Fingerprint()))
+	}
+}
+
+func TestFindingShortID(t *testing.T) {
+	newFinding := func(variable string, line int) *Finding {
+		return NewFinding("BC002", "input-removed", SeverityError, fmt.Sprintf("Variable %q was removed", variable)).
+			WithOldLocation(&FileRange{Filename: fmt.Sprintf("/tmp/worktree-%d/variables.tf", line), Line: line})
+	}
+	first := newFinding("foo", 3)
+	rerun := newFinding("foo", 9)
+	other := newFinding("bar", 3)
+
+	if len(first.ShortID()) != 8 {
+		t.Errorf("short ID length = %d, want 8", len(first.ShortID()))
+	}
+	if !strings.HasPrefix(first.Fingerprint(), first.ShortID()) {
+		t.Errorf("short ID %q is not a prefix of fingerprint %q", first.ShortID(), first.Fingerprint())
+	}
+	if first.ShortID() != rerun.ShortID() {
+		t.Errorf("short ID changed between runs: %q vs %q", first.ShortID(), rerun.ShortID())
+	}
+	if first.ShortID() == other.ShortID() {
+		t.Error("short ID should differ for distinct findings")
+	}
+
+	// With --recursive, modules often share file names
+	vpc, eks := newFinding("foo", 3), newFinding("foo", 3)
+	vpc.Module, eks.Module = "modules/vpc", "modules/eks"
+	if vpc.ShortID() == eks.ShortID() {
+		t.Error("short ID should differ for the same finding in different modules")
+	}
+}
+
+func TestFindingRuleFingerprint(t *testing.T) {
+	a := NewFinding("RC006", "input-default-changed", SeverityWarning, `Variable "foo" default changed`).
+		WithNewLocation(&FileRange{Filename: "/tmp/worktree-1/variables.tf", Line: 3})
+	b := NewFinding("RC006", "input-default-changed", SeverityWarning, `Variable "bar" default changed`).
+		WithNewLocation(&FileRange{Filename: "/tmp/worktree-2/variables.tf", Line: 9})
+	c := NewFinding("RC006", "input-default-changed", SeverityWarning, `Variable "foo" default changed`).
+		WithNewLocation(&FileRange{Filename: "/tmp/worktree-1/main.tf", Line: 3})
+
+	if a.RuleFingerprint() != b.RuleFingerprint() {
+		t.Error("rule fingerprint should ignore the message, checkout directory, and line number")
+	}
+	if a.RuleFingerprint() == c.RuleFingerprint() {
+		t.Error("rule fingerprint should differ for different files")
+	}
+
+	b.Module = "modules/vpc"
+	if a.RuleFingerprint() == b.RuleFingerprint() {
+		t.Error("rule fingerprint should differ for different modules")
+	}
+
+	// A module path written on Windows matches the same path elsewhere
+	a.Module = `modules\vpc`
+	if a.RuleFingerprint() != b.RuleFingerprint() {
+		t.Error("rule fingerprint should not depend on the path separator")
+	}
+}
+
+func TestCheckResultRemoveKnownRules(t *testing.T) {
+	loc := func(file string) *FileRange { return &FileRange{Filename: file, Line: 1} }
+
+	previous := NewCheckResult("old", "new", SeverityError)
+	previous.AddFinding(NewFinding("RC006", "input-default-changed", SeverityWarning, `Variable "a" default changed`).WithNewLocation(loc("variables.tf")))
+	previous.AddFinding(NewFinding("BC002", "input-removed", SeverityError, `Variable "b" was removed`).WithOldLocation(loc("variables.tf")))
+
+	current := NewCheckResult("old", "new", SeverityError)
+	// Same rule and file as before, different variable: known
+	current.AddFinding(NewFinding("RC006", "input-default-changed", SeverityWarning, `Variable "c" default changed`).WithNewLocation(loc("variables.tf")))
+	// Rule that did not fire before: new
+	current.AddFinding(NewFinding("BC001", "required-input-added", SeverityError, `New required variable "d" has no default`).WithNewLocation(loc("variables.tf")))
+	// Known rule in a different file: new
+	current.AddFinding(NewFinding("BC002", "input-removed", SeverityError, `Variable "e" was removed`).WithOldLocation(loc("inputs.tf")))
+
+	if removed := current.RemoveKnownRules(previous); removed != 1 {
+		t.Errorf("RemoveKnownRules() = %d, want 1", removed)
+	}
+	current.Compute()
+
+	var got []string
+	for _, f := range current.Findings {
+		got = append(got, f.RuleID)
+	}
+	if want := []string{"BC001", "BC002"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("remaining rules = %v, want %v", got, want)
+	}
+	if current.Summary.Error != 2 || current.Summary.Warning != 0 {
+		t.Errorf("summary = %+v, want 2 errors and no warnings", current.Summary)
+	}
+}
+
+func TestCheckResultIgnoreKnownFindings(t *testing.T) {
+	loc := func(file string) *FileRange { return &FileRange{Filename: file, Line: 1} }
+
+	reference := NewCheckResult("old", "new", SeverityError)
+	reference.AddFinding(NewFinding("BC002", "input-removed", SeverityError, `Variable "a" was removed`).WithOldLocation(loc("/tmp/wt-1/variables.tf")))
+	reference.AddFinding(&Finding{RuleID: "BC002", Severity: SeverityError, Message: `Variable "b" was removed`, OldLocation: loc("variables.tf"), Module: "modules/vpc"})
+
+	current := NewCheckResult("old", "new", SeverityError)
+	// Same finding from another checkout directory: known
+	current.AddFinding(NewFinding("BC002", "input-removed", SeverityError, `Variable "a" was removed`).WithOldLocation(loc("/tmp/wt-2/variables.tf")))
+	// Same rule and file, different message: new
+	current.AddFinding(NewFinding("BC002", "input-removed", SeverityError, `Variable "c" was removed`).WithOldLocation(loc("variables.tf")))
+	// Same finding in a different module: new
+	current.AddFinding(&Finding{RuleID: "BC002", Severity: SeverityError, Message: `Variable "b" was removed`, OldLocation: loc("variables.tf"), Module: "modules/db"})
+
+	if marked := current.IgnoreKnownFindings(reference, "known"); marked != 1 {
+		t.Errorf("IgnoreKnownFindings() = %d, want 1", marked)
+	}
+	current.Compute()
+
+	if !current.Findings[0].Ignored || current.Findings[0].IgnoreReason != "known" {
+		t.Errorf("known finding = %+v, want it ignored with the given reason", current.Findings[0])
+	}
+	if current.Findings[1].Ignored || current.Findings[2].Ignored {
+		t.Error("new findings must not be ignored")
+	}
+	if current.Summary.Error != 2 || current.Summary.Ignored != 1 || current.Result != "FAIL" {
+		t.Errorf("summary = %+v, result = %s, want 2 errors, 1 ignored, FAIL", current.Summary, current.Result)
+	}
+
+	// Nothing new relative to the reference passes
+	again := NewCheckResult("old", "new", SeverityError)
+	again.AddFinding(NewFinding("BC002", "input-removed", SeverityError, `Variable "a" was removed`).WithOldLocation(loc("variables.tf")))
+	again.IgnoreKnownFindings(reference, "known")
+	again.Compute()
+	if again.Result != "PASS" {
+		t.Errorf("Result = %s, want PASS when all findings are known", again.Result)
+	}
+}
+
+func TestCheckResult_AddWarning(t *testing.T) {
+	result := NewCheckResult("/old", "/new", SeverityError)
+	result.AddWarning(WarningSourcePlugin, "plugin failed to load")
+	result.Compute()
+
+	want := []ResultWarning{{Source: WarningSourcePlugin, Message: "plugin failed to load"}}
+	if !reflect.DeepEqual(result.Warnings, want) {
+		t.Errorf("Warnings = %+v, want %+v", result.Warnings, want)
+	}
+	if result.Result != "PASS" {
+		t.Errorf("Result = %q, want PASS: warnings do not fail the check", result.Result)
+	}
+}
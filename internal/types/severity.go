@@ -12,6 +12,8 @@ type Severity int
 const (
 	// SeverityNotice is informational, no action needed
 	SeverityNotice Severity = iota
+	// SeverityDeprecation works today but is announced to break in a future version
+	SeverityDeprecation
 	// SeverityWarning may cause unexpected behavior changes
 	SeverityWarning
 	// SeverityError will break callers or destroy state
@@ -25,6 +27,8 @@ func (s Severity) String() string {
 		return "ERROR"
 	case SeverityWarning:
 		return "WARNING"
+	case SeverityDeprecation:
+		return "DEPRECATION"
 	case SeverityNotice:
 		return "NOTICE"
 	default:
@@ -58,6 +62,8 @@ func ParseSeverity(s string) (Severity, error) {
 		return SeverityError, nil
 	case "WARNING":
 		return SeverityWarning, nil
+	case "DEPRECATION":
+		return SeverityDeprecation, nil
 	case "NOTICE":
 		return SeverityNotice, nil
 	default:
@@ -12,6 +12,7 @@ func TestSeverityString(t *testing.T) {
 	}{
 		{SeverityError, "ERROR"},
 		{SeverityWarning, "WARNING"},
+		{SeverityDeprecation, "DEPRECATION"},
 		{SeverityNotice, "NOTICE"},
 		{Severity(99), "UNKNOWN"},
 	}
@@ -42,6 +43,11 @@ func TestSeverityComparison(t *testing.T) {
 		{"notice at least error", SeverityNotice, SeverityError, false},
 		{"notice at least warning", SeverityNotice, SeverityWarning, false},
 		{"notice at least notice", SeverityNotice, SeverityNotice, true},
+		{"deprecation at least notice", SeverityDeprecation, SeverityNotice, true},
+		{"deprecation at least deprecation", SeverityDeprecation, SeverityDeprecation, true},
+		{"deprecation at least warning", SeverityDeprecation, SeverityWarning, false},
+		{"warning at least deprecation", SeverityWarning, SeverityDeprecation, true},
+		{"notice at least deprecation", SeverityNotice, SeverityDeprecation, false},
 	}
 
 	for _, tt := range tests {
@@ -67,6 +73,8 @@ func TestParseSeverity(t *testing.T) {
 		{"warning", SeverityWarning, false},
 		{"NOTICE", SeverityNotice, false},
 		{"notice", SeverityNotice, false},
+		{"DEPRECATION", SeverityDeprecation, false},
+		{"deprecation", SeverityDeprecation, false},
 		{"invalid", SeverityNotice, true},
 		{"", SeverityNotice, true},
 	}
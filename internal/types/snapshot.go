@@ -34,6 +34,10 @@ type ModuleSnapshot struct {
 
 	// RequiredProviders maps provider names to their requirements
 	RequiredProviders map[string]*ProviderRequirement `json:"required_providers,omitempty"`
+
+	// ProviderLocks maps provider addresses (e.g., "registry.terraform.io/hashicorp/aws")
+	// to their entries in .terraform.lock.hcl. Empty if the module has no lock file.
+	ProviderLocks map[string]*ProviderLock `json:"provider_locks,omitempty"`
 }
 
 // NewModuleSnapshot creates a new empty ModuleSnapshot
@@ -46,9 +50,19 @@ func NewModuleSnapshot(path string) *ModuleSnapshot {
 		Modules:           make(map[string]*ModuleCallSignature),
 		MovedBlocks:       make([]*MovedBlock, 0),
 		RequiredProviders: make(map[string]*ProviderRequirement),
+		ProviderLocks:     make(map[string]*ProviderLock),
 	}
 }
 
+// IsEmpty reports whether the module declares nothing the rules compare:
+// no variables, outputs, resources, module calls, moved blocks, or version
+// constraints. The dependency lock file alone does not make a module.
+func (s *ModuleSnapshot) IsEmpty() bool {
+	return len(s.Variables) == 0 && len(s.Outputs) == 0 && len(s.Resources) == 0 &&
+		len(s.Modules) == 0 && len(s.MovedBlocks) == 0 &&
+		s.RequiredVersion == "" && len(s.RequiredProviders) == 0
+}
+
 // VariableSignature represents the signature of a Terraform variable
 type VariableSignature struct {
 	// Name is the variable name
@@ -57,6 +71,22 @@ type VariableSignature struct {
 	// Type is the normalized type expression (e.g., "string", "list(string)")
 	Type string `json:"type,omitempty"`
 
+	// TypeExplicit is true if the variable declares a type argument. A
+	// variable without one has an empty Type and accepts any value, like an
+	// explicit type = any.
+	TypeExplicit bool `json:"type_explicit,omitempty"`
+
+	// TypeConstraint is Type with optional() attribute defaults stripped,
+	// so type comparisons are not affected by default-only changes.
+	// Empty if the type expression could not be parsed.
+	TypeConstraint string `json:"type_constraint,omitempty"`
+
+	// OptionalDefaults maps object attribute paths declared with optional()
+	// to their default values (e.g., "network.tier" -> "standard").
+	// Collection elements use "[*]" and tuple elements "[N]" in the path.
+	// A nil value means the attribute is optional without a default.
+	OptionalDefaults map[string]interface{} `json:"optional_defaults,omitempty"`
+
 	// Default is the JSON-serialized default value, nil if no default
 	Default interface{} `json:"default,omitempty"`
 
@@ -91,6 +121,12 @@ type ValidationBlock struct {
 
 	// ErrorMessage is the error message shown when validation fails
 	ErrorMessage string `json:"error_message,omitempty"`
+
+	// ReferencedVariables lists the variables the condition references as
+	// var.<name>, sorted and without duplicates. It includes the validated
+	// variable itself; any other name means the condition depends on
+	// another input (Terraform 1.9+).
+	ReferencedVariables []string `json:"referenced_variables,omitempty"`
 }
 
 // HasDefault returns true if the variable has a default value
@@ -118,6 +154,10 @@ type OutputSignature struct {
 	// Sensitive indicates if the output is marked sensitive
 	Sensitive bool `json:"sensitive,omitempty"`
 
+	// VariableRefs lists the input variables the output's value depends
+	// on, directly or through locals, sorted by name
+	VariableRefs []string `json:"variable_refs,omitempty"`
+
 	// DeclRange is the source location of the declaration
 	DeclRange FileRange `json:"pos"`
 }
@@ -176,6 +216,24 @@ type ProviderRequirement struct {
 	Version string `json:"version,omitempty"`
 }
 
+// ProviderLock represents a provider entry in the dependency lock file
+type ProviderLock struct {
+	// Address is the provider address (e.g., "registry.terraform.io/hashicorp/aws")
+	Address string `json:"address"`
+
+	// Version is the selected provider version
+	Version string `json:"version,omitempty"`
+
+	// Constraints is the version constraint recorded when the version was selected
+	Constraints string `json:"constraints,omitempty"`
+
+	// Hashes lists the recorded package checksums
+	Hashes []string `json:"hashes,omitempty"`
+
+	// DeclRange is the source location of the provider block
+	DeclRange FileRange `json:"decl_range"`
+}
+
 // IsResourceAddress returns true if the address refers to a resource (type.name format)
 func IsResourceAddress(addr string) bool {
 	// Resource addresses have the format "type.name" without "module." prefix
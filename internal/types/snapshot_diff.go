@@ -0,0 +1,96 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ChangeCount tallies the declarations of one kind that were added,
+// removed, or changed between two snapshots
+type ChangeCount struct {
+	Added   int
+	Removed int
+	Changed int
+}
+
+// String formats the count as "+added/-removed/~changed"
+func (c ChangeCount) String() string {
+	return fmt.Sprintf("+%d/-%d/~%d", c.Added, c.Removed, c.Changed)
+}
+
+// SnapshotDiff tallies the structural differences between two snapshots,
+// independent of which rules report them
+type SnapshotDiff struct {
+	Variables ChangeCount
+	Outputs   ChangeCount
+	Resources ChangeCount
+	Modules   ChangeCount
+}
+
+// DiffSnapshots counts the variables, outputs, resources, and module calls
+// added, removed, or changed from old to new. A declaration counts as
+// changed if any part of its signature other than its source location
+// differs.
+func DiffSnapshots(old, new *ModuleSnapshot) SnapshotDiff {
+	return SnapshotDiff{
+		Variables: countChanges(old.Variables, new.Variables, func(v VariableSignature) VariableSignature {
+			v.DeclRange = FileRange{}
+			return v
+		}),
+		Outputs: countChanges(old.Outputs, new.Outputs, func(o OutputSignature) OutputSignature {
+			o.DeclRange = FileRange{}
+			return o
+		}),
+		Resources: countChanges(old.Resources, new.Resources, func(r ResourceSignature) ResourceSignature {
+			r.DeclRange = FileRange{}
+			return r
+		}),
+		Modules: countChanges(old.Modules, new.Modules, func(m ModuleCallSignature) ModuleCallSignature {
+			m.DeclRange = FileRange{}
+			return m
+		}),
+	}
+}
+
+// countChanges compares two maps of signatures by key. withoutRange returns
+// a copy of a signature with its source location cleared, so moving a
+// declaration within or between files does not count as a change.
+func countChanges[S any](old, new map[string]*S, withoutRange func(S) S) ChangeCount {
+	var count ChangeCount
+	for name, oldSig := range old {
+		newSig, ok := new[name]
+		if !ok {
+			count.Removed++
+			continue
+		}
+		if !reflect.DeepEqual(withoutRange(*oldSig), withoutRange(*newSig)) {
+			count.Changed++
+		}
+	}
+	for name := range new {
+		if _, ok := old[name]; !ok {
+			count.Added++
+		}
+	}
+	return count
+}
+
+// Add returns the sum of two diffs, for aggregating modules
+func (d SnapshotDiff) Add(other SnapshotDiff) SnapshotDiff {
+	add := func(a, b ChangeCount) ChangeCount {
+		return ChangeCount{Added: a.Added + b.Added, Removed: a.Removed + b.Removed, Changed: a.Changed + b.Changed}
+	}
+	return SnapshotDiff{
+		Variables: add(d.Variables, other.Variables),
+		Outputs:   add(d.Outputs, other.Outputs),
+		Resources: add(d.Resources, other.Resources),
+		Modules:   add(d.Modules, other.Modules),
+	}
+}
+
+// String formats the diff as a one-line tally, e.g.
+// "variables +1/-0/~2, outputs +0/-1/~0, resources +0/-0/~0, modules +0/-0/~1"
+func (d SnapshotDiff) String() string {
+	return fmt.Sprintf("variables %s, outputs %s, resources %s, modules %s",
+		d.Variables, d.Outputs, d.Resources, d.Modules)
+}
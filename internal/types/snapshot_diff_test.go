@@ -0,0 +1,68 @@
+package types
+
+import "testing"
+
+func TestDiffSnapshots(t *testing.T) {
+	old := NewModuleSnapshot("old")
+	new := NewModuleSnapshot("new")
+
+	// Variables: "a" unchanged but moved, "b" default changed, "c" removed, "d" added
+	old.Variables["a"] = &VariableSignature{Name: "a", Type: "string", DeclRange: FileRange{Filename: "variables.tf", Line: 1}}
+	new.Variables["a"] = &VariableSignature{Name: "a", Type: "string", DeclRange: FileRange{Filename: "inputs.tf", Line: 9}}
+	old.Variables["b"] = &VariableSignature{Name: "b", Default: "x"}
+	new.Variables["b"] = &VariableSignature{Name: "b", Default: "y"}
+	old.Variables["c"] = &VariableSignature{Name: "c"}
+	new.Variables["d"] = &VariableSignature{Name: "d", Required: true}
+
+	// Outputs: "id" became sensitive, "arn" removed
+	old.Outputs["id"] = &OutputSignature{Name: "id"}
+	new.Outputs["id"] = &OutputSignature{Name: "id", Sensitive: true}
+	old.Outputs["arn"] = &OutputSignature{Name: "arn"}
+
+	// Resources: two added
+	new.Resources["aws_s3_bucket.logs"] = &ResourceSignature{Type: "aws_s3_bucket", Name: "logs", Address: "aws_s3_bucket.logs"}
+	new.Resources["aws_s3_bucket.data"] = &ResourceSignature{Type: "aws_s3_bucket", Name: "data", Address: "aws_s3_bucket.data"}
+
+	// Modules: version bumped
+	old.Modules["vpc"] = &ModuleCallSignature{Name: "vpc", Source: "org/vpc/aws", Version: "1.0.0"}
+	new.Modules["vpc"] = &ModuleCallSignature{Name: "vpc", Source: "org/vpc/aws", Version: "2.0.0"}
+
+	diff := DiffSnapshots(old, new)
+	want := SnapshotDiff{
+		Variables: ChangeCount{Added: 1, Removed: 1, Changed: 1},
+		Outputs:   ChangeCount{Removed: 1, Changed: 1},
+		Resources: ChangeCount{Added: 2},
+		Modules:   ChangeCount{Changed: 1},
+	}
+	if diff != want {
+		t.Errorf("DiffSnapshots() = %+v, want %+v", diff, want)
+	}
+
+	wantString := "variables +1/-1/~1, outputs +0/-1/~1, resources +2/-0/~0, modules +0/-0/~1"
+	if diff.String() != wantString {
+		t.Errorf("String() = %q, want %q", diff.String(), wantString)
+	}
+}
+
+func TestDiffSnapshots_Identical(t *testing.T) {
+	snap := NewModuleSnapshot("dir")
+	snap.Variables["a"] = &VariableSignature{Name: "a", Default: map[string]interface{}{"k": "v"}}
+
+	if diff := DiffSnapshots(snap, snap); diff != (SnapshotDiff{}) {
+		t.Errorf("DiffSnapshots() = %+v, want no changes", diff)
+	}
+}
+
+func TestSnapshotDiffAdd(t *testing.T) {
+	a := SnapshotDiff{Variables: ChangeCount{Added: 1}, Modules: ChangeCount{Changed: 2}}
+	b := SnapshotDiff{Variables: ChangeCount{Added: 2, Removed: 1}, Outputs: ChangeCount{Changed: 1}}
+
+	want := SnapshotDiff{
+		Variables: ChangeCount{Added: 3, Removed: 1},
+		Outputs:   ChangeCount{Changed: 1},
+		Modules:   ChangeCount{Changed: 2},
+	}
+	if got := a.Add(b); got != want {
+		t.Errorf("Add() = %+v, want %+v", got, want)
+	}
+}
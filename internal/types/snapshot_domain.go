@@ -0,0 +1,77 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// Domain is a part of a module snapshot that rules compare between the old
+// and new configuration
+type Domain string
+
+const (
+	DomainVariables     Domain = "variables"
+	DomainOutputs       Domain = "outputs"
+	DomainResources     Domain = "resources"
+	DomainModules       Domain = "modules"
+	DomainTerraform     Domain = "terraform"      // required_version
+	DomainProviders     Domain = "providers"      // required_providers
+	DomainProviderLocks Domain = "provider_locks" // .terraform.lock.hcl
+)
+
+// DomainHashes returns a hash of each domain of the snapshot. Source
+// locations are left out, so two snapshots have the same hash for a domain
+// exactly when its declarations are the same, wherever they are written.
+// A domain that cannot be hashed maps to "".
+func (s *ModuleSnapshot) DomainHashes() map[Domain]string {
+	return map[Domain]string{
+		DomainVariables: hashDomain(s.Variables, func(v VariableSignature) VariableSignature {
+			v.DeclRange = FileRange{}
+			return v
+		}),
+		DomainOutputs: hashDomain(s.Outputs, func(o OutputSignature) OutputSignature {
+			o.DeclRange = FileRange{}
+			return o
+		}),
+		DomainResources: hashDomain(s.Resources, func(r ResourceSignature) ResourceSignature {
+			r.DeclRange = FileRange{}
+			return r
+		}),
+		DomainModules: hashDomain(s.Modules, func(m ModuleCallSignature) ModuleCallSignature {
+			m.DeclRange = FileRange{}
+			return m
+		}),
+		DomainTerraform: hashValue(s.RequiredVersion),
+		DomainProviders: hashDomain(s.RequiredProviders, func(p ProviderRequirement) ProviderRequirement {
+			return p
+		}),
+		DomainProviderLocks: hashDomain(s.ProviderLocks, func(l ProviderLock) ProviderLock {
+			l.DeclRange = FileRange{}
+			return l
+		}),
+	}
+}
+
+// hashDomain hashes a map of signatures with their source locations
+// cleared by withoutRange. A nil map hashes like an empty one.
+func hashDomain[S any](m map[string]*S, withoutRange func(S) S) string {
+	cleared := make(map[string]S, len(m))
+	for name, sig := range m {
+		if sig != nil {
+			cleared[name] = withoutRange(*sig)
+		}
+	}
+	return hashValue(cleared)
+}
+
+// hashValue hashes the JSON encoding of v, whose map keys encoding/json
+// writes in sorted order
+func hashValue(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
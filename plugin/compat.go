@@ -0,0 +1,125 @@
+// Package plugin provides plugin discovery, loading, and execution for tfbreak.
+//
+// This file implements the plugin protocol compatibility gate, which turns
+// a handshake with a plugin built against an unsupported SDK into a clear
+// error instead of a gRPC failure.
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	goplugin "github.com/hashicorp/go-plugin"
+
+	sdkplugin "github.com/jokarl/tfbreak-plugin-sdk/plugin"
+)
+
+// MinProtocolVersion and MaxProtocolVersion bound the plugin protocol
+// versions this build of tfbreak can talk to. Plugins report the protocol
+// version of the tfbreak-plugin-sdk they were built with during the
+// handshake; plugins outside the range are refused.
+const (
+	MinProtocolVersion = 1
+	MaxProtocolVersion = sdkplugin.ProtocolVersion
+)
+
+// IncompatiblePluginError is returned when a plugin speaks a protocol
+// version outside MinProtocolVersion and MaxProtocolVersion
+type IncompatiblePluginError struct {
+	Plugin          string
+	ProtocolVersion int
+}
+
+func (e *IncompatiblePluginError) Error() string {
+	hint := "rebuild it against a tfbreak-plugin-sdk release supported by this tfbreak, or upgrade tfbreak"
+	if e.ProtocolVersion < MinProtocolVersion {
+		hint = "upgrade the plugin, or rebuild it against a newer tfbreak-plugin-sdk"
+	}
+	return fmt.Sprintf("plugin %s uses plugin protocol version %d, but this tfbreak supports %s; %s",
+		e.Plugin, e.ProtocolVersion, supportedProtocolRange(), hint)
+}
+
+// supportedProtocolRange describes the supported protocol versions
+func supportedProtocolRange() string {
+	if MinProtocolVersion == MaxProtocolVersion {
+		return "version " + strconv.Itoa(MinProtocolVersion)
+	}
+	return fmt.Sprintf("versions %d to %d", MinProtocolVersion, MaxProtocolVersion)
+}
+
+// versionedPlugins returns the plugin set for every supported protocol
+// version, so go-plugin accepts any of them during the handshake
+func versionedPlugins() map[int]goplugin.PluginSet {
+	versions := make(map[int]goplugin.PluginSet, MaxProtocolVersion-MinProtocolVersion+1)
+	for v := MinProtocolVersion; v <= MaxProtocolVersion; v++ {
+		versions[v] = sdkplugin.PluginMap
+	}
+	return versions
+}
+
+// pluginVersionRe matches go-plugin's handshake error for a protocol
+// version the client does not accept
+var pluginVersionRe = regexp.MustCompile(`(?i)incompatible API version with plugin\. Plugin version: (\d+)`)
+
+// checkProtocolError returns an IncompatiblePluginError if err is go-plugin
+// refusing the plugin's protocol version, or nil otherwise
+func checkProtocolError(name string, err error) error {
+	m := pluginVersionRe.FindStringSubmatch(err.Error())
+	if m == nil {
+		return nil
+	}
+	version, convErr := strconv.Atoi(m[1])
+	if convErr != nil {
+		return nil
+	}
+	return &IncompatiblePluginError{Plugin: name, ProtocolVersion: version}
+}
+
+// Compatibility is the result of checking an installed plugin against this
+// build of tfbreak
+type Compatibility struct {
+	// Info is the discovered plugin
+	Info PluginInfo
+
+	// ProtocolVersion is the protocol version the plugin reported, or 0 if
+	// the handshake did not get that far
+	ProtocolVersion int
+
+	// RuleSetVersion is the plugin's own version, if it loaded
+	RuleSetVersion string
+
+	// RuleCount is the number of rules the plugin provides, if it loaded
+	RuleCount int
+
+	// Err is why the plugin cannot be used, or nil if it is compatible
+	Err error
+}
+
+// Compatible reports whether the plugin can be used
+func (c Compatibility) Compatible() bool {
+	return c.Err == nil
+}
+
+// Verify starts a plugin, checks that it completes the handshake with a
+// supported protocol version and serves a rule set, and stops it again
+func (l *Loader) Verify(info PluginInfo) Compatibility {
+	result := Compatibility{Info: info}
+
+	loaded, err := l.Load(info)
+	if err != nil {
+		result.Err = err
+		var incompatible *IncompatiblePluginError
+		if errors.As(err, &incompatible) {
+			result.ProtocolVersion = incompatible.ProtocolVersion
+		}
+		return result
+	}
+	defer loaded.Close()
+
+	result.ProtocolVersion = loaded.ProtocolVersion
+	result.RuleSetVersion = loaded.RuleSet.RuleSetVersion()
+	result.RuleCount = len(loaded.RuleSet.RuleNames())
+	return result
+}
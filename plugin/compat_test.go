@@ -0,0 +1,65 @@
+package plugin
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// writeFakePlugin writes a script that completes the go-plugin handshake
+// line with the given protocol version and then waits to be killed
+func writeFakePlugin(t *testing.T, protocolVersion string) PluginInfo {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake plugin is a shell script")
+	}
+
+	path := filepath.Join(t.TempDir(), "tfbreak-ruleset-fake")
+	script := "#!/bin/sh\necho '1|" + protocolVersion + "|unix|/nonexistent.sock|grpc'\nexec sleep 10\n"
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return PluginInfo{Name: "fake", Path: path, Enabled: true}
+}
+
+func TestLoader_Load_IncompatibleProtocol(t *testing.T) {
+	for version, want := range map[string]int{"0": 0, "99": 99} {
+		info := writeFakePlugin(t, version)
+
+		_, err := NewLoader().Load(info)
+		var incompatible *IncompatiblePluginError
+		if !errors.As(err, &incompatible) {
+			t.Fatalf("protocol %s: Load() error = %v, want IncompatiblePluginError", version, err)
+		}
+		if incompatible.Plugin != "fake" || incompatible.ProtocolVersion != want {
+			t.Errorf("protocol %s: unexpected error %+v", version, incompatible)
+		}
+		if !strings.Contains(err.Error(), "plugin fake uses plugin protocol version "+version+", but this tfbreak supports version 1") {
+			t.Errorf("protocol %s: unexpected message: %v", version, err)
+		}
+	}
+}
+
+func TestLoader_Verify_Incompatible(t *testing.T) {
+	info := writeFakePlugin(t, "99")
+
+	result := NewLoader().Verify(info)
+	if result.Compatible() {
+		t.Fatal("expected an out-of-range plugin to be incompatible")
+	}
+	if result.ProtocolVersion != 99 {
+		t.Errorf("ProtocolVersion = %d, want 99", result.ProtocolVersion)
+	}
+	if result.Info.Name != "fake" {
+		t.Errorf("Info.Name = %q, want fake", result.Info.Name)
+	}
+}
+
+func TestCheckProtocolError_OtherErrors(t *testing.T) {
+	if err := checkProtocolError("fake", errors.New("plugin exited before we could connect")); err != nil {
+		t.Errorf("checkProtocolError() = %v, want nil for unrelated errors", err)
+	}
+}
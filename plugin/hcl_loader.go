@@ -11,7 +11,8 @@ import (
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
-	"github.com/hashicorp/hcl/v2/hclparse"
+
+	"github.com/jokarl/tfbreak-core/internal/hclcache"
 )
 
 // LoadHCLFiles loads all .tf files from the given directory and returns them
@@ -41,7 +42,6 @@ func LoadHCLFiles(dir string) (map[string]*hcl.File, error) {
 		return nil, fmt.Errorf("failed to read directory: %w", err)
 	}
 
-	parser := hclparse.NewParser()
 	files := make(map[string]*hcl.File)
 
 	for _, entry := range entries {
@@ -55,7 +55,8 @@ func LoadHCLFiles(dir string) (map[string]*hcl.File, error) {
 		}
 
 		filePath := filepath.Join(absDir, name)
-		file, diags := parser.ParseHCLFile(filePath)
+		// Shared with the loader, so files are not parsed twice per check
+		file, diags := hclcache.Default.ParseFile(filePath)
 		if diags.HasErrors() {
 			// Log warning but continue - don't fail on parse errors for individual files
 			continue
@@ -94,7 +95,6 @@ func LoadHCLFilesWithFilter(dir string, filter func(filename string) bool) (map[
 		return nil, fmt.Errorf("failed to read directory: %w", err)
 	}
 
-	parser := hclparse.NewParser()
 	files := make(map[string]*hcl.File)
 
 	for _, entry := range entries {
@@ -113,7 +113,8 @@ func LoadHCLFilesWithFilter(dir string, filter func(filename string) bool) (map[
 		}
 
 		filePath := filepath.Join(absDir, name)
-		file, diags := parser.ParseHCLFile(filePath)
+		// Shared with the loader, so files are not parsed twice per check
+		file, diags := hclcache.Default.ParseFile(filePath)
 		if diags.HasErrors() {
 			// Log warning but continue - don't fail on parse errors for individual files
 			continue
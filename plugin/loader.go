@@ -24,6 +24,8 @@ type LoadedPlugin struct {
 	RuleSet tflint.RuleSet
 	// Client is the go-plugin client for managing the plugin process.
 	Client *goplugin.Client
+	// ProtocolVersion is the plugin protocol version negotiated in the handshake.
+	ProtocolVersion int
 }
 
 // Close terminates the plugin process.
@@ -64,12 +66,13 @@ func (l *Loader) Load(info PluginInfo) (*LoadedPlugin, error) {
 		return nil, fmt.Errorf("plugin binary not found: %s", info.Path)
 	}
 
-	// Create the plugin client configuration
+	// Create the plugin client configuration, accepting every supported
+	// protocol version in the handshake
 	clientConfig := &goplugin.ClientConfig{
-		HandshakeConfig: sdkplugin.Handshake,
-		Plugins:         sdkplugin.PluginMap,
-		Cmd:             exec.Command(info.Path),
-		Logger:          l.logger,
+		HandshakeConfig:  sdkplugin.Handshake,
+		VersionedPlugins: versionedPlugins(),
+		Cmd:              exec.Command(info.Path),
+		Logger:           l.logger,
 		AllowedProtocols: []goplugin.Protocol{
 			goplugin.ProtocolGRPC,
 		},
@@ -82,6 +85,10 @@ func (l *Loader) Load(info PluginInfo) (*LoadedPlugin, error) {
 	rpcClient, err := client.Client()
 	if err != nil {
 		client.Kill()
+		// A plugin built against an unsupported SDK fails the handshake
+		if incompatible := checkProtocolError(info.Name, err); incompatible != nil {
+			return nil, incompatible
+		}
 		return nil, fmt.Errorf("failed to connect to plugin %s: %w", info.Name, err)
 	}
 
@@ -100,9 +107,10 @@ func (l *Loader) Load(info PluginInfo) (*LoadedPlugin, error) {
 	}
 
 	return &LoadedPlugin{
-		Info:    info,
-		RuleSet: ruleSet,
-		Client:  client,
+		Info:            info,
+		RuleSet:         ruleSet,
+		Client:          client,
+		ProtocolVersion: client.NegotiatedVersion(),
 	}, nil
 }
 
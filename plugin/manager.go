@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/hcl/v2"
 
 	"github.com/jokarl/tfbreak-core/internal/config"
+	"github.com/jokarl/tfbreak-core/internal/parallel"
 	"github.com/jokarl/tfbreak-core/internal/types"
 	"github.com/jokarl/tfbreak-plugin-sdk/tflint"
 )
@@ -32,6 +33,9 @@ type Manager struct {
 	config  *config.Config
 	logger  hclog.Logger
 	mu      sync.RWMutex
+
+	// parallelism is the number of plugins executed concurrently (0 = GOMAXPROCS)
+	parallelism int
 }
 
 // NewManager creates a new plugin manager.
@@ -49,6 +53,14 @@ func NewManager(cfg *config.Config) *Manager {
 	}
 }
 
+// SetParallelism sets how many plugins execute their rules concurrently.
+// 0 uses GOMAXPROCS and 1 executes plugins one after another.
+func (m *Manager) SetParallelism(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.parallelism = n
+}
+
 // DiscoverAndLoad discovers plugins and loads enabled ones.
 // Returns the number of plugins loaded and any errors encountered.
 func (m *Manager) DiscoverAndLoad() (int, []error) {
@@ -133,28 +145,41 @@ func (m *Manager) toSDKConfig() *tflint.Config {
 // ExecuteRules executes all loaded plugin rules against the provided configurations.
 // Returns a slice of findings from all plugins.
 func (m *Manager) ExecuteRules(oldFiles, newFiles map[string]*hcl.File) ([]*types.Finding, []error) {
+	return m.ExecuteRulesWithRefs(oldFiles, newFiles, RefContext{})
+}
+
+// ExecuteRulesWithRefs executes all loaded plugin rules like ExecuteRules,
+// exposing the given ref context to plugins through the runner.
+func (m *Manager) ExecuteRulesWithRefs(oldFiles, newFiles map[string]*hcl.File, refs RefContext) ([]*types.Finding, []error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	// Each plugin runs in its own process with its own runner, so plugins
+	// execute concurrently. Results are kept in plugin order.
+	findings := make([][]*types.Finding, len(m.plugins))
+	errs := make([]error, len(m.plugins))
+	parallel.ForEach(m.parallelism, len(m.plugins), func(i int) {
+		findings[i], errs[i] = m.executePluginRules(m.plugins[i], oldFiles, newFiles, refs)
+	})
+
 	var allFindings []*types.Finding
 	var allErrors []error
 
-	for _, p := range m.plugins {
-		findings, err := m.executePluginRules(p, oldFiles, newFiles)
-		if err != nil {
-			allErrors = append(allErrors, fmt.Errorf("plugin %s: %w", p.Info.Name, err))
+	for i, p := range m.plugins {
+		if errs[i] != nil {
+			allErrors = append(allErrors, fmt.Errorf("plugin %s: %w", p.Info.Name, errs[i]))
 			continue
 		}
-		allFindings = append(allFindings, findings...)
+		allFindings = append(allFindings, findings[i]...)
 	}
 
 	return allFindings, allErrors
 }
 
 // executePluginRules executes rules for a single plugin.
-func (m *Manager) executePluginRules(p *LoadedPlugin, oldFiles, newFiles map[string]*hcl.File) ([]*types.Finding, error) {
+func (m *Manager) executePluginRules(p *LoadedPlugin, oldFiles, newFiles map[string]*hcl.File, refs RefContext) ([]*types.Finding, error) {
 	// Create a runner that provides old/new configurations to the plugin
-	runner := NewRunner(oldFiles, newFiles)
+	runner := NewRunnerWithRefs(oldFiles, newFiles, refs)
 
 	// The RuleSet from the loader should implement RuleSetWithCheck
 	// (the SDK's GRPCRuleSetClient has a Check method)
@@ -185,12 +210,15 @@ func (m *Manager) issueToFinding(issue Issue, pluginName string) *types.Finding
 	// Convert SDK severity to internal severity
 	severity := m.convertSeverity(issue.Rule.Severity())
 
-	// Create the finding
+	// Create the finding. Plugin rules have no category of their own, so it
+	// is derived from the severity the plugin declares.
 	finding := &types.Finding{
 		RuleID:   fmt.Sprintf("%s/%s", pluginName, issue.Rule.Name()),
 		RuleName: issue.Rule.Name(),
 		Severity: severity,
+		Category: types.CategoryForSeverity(severity),
 		Message:  issue.Message,
+		HelpURL:  issue.Rule.Link(),
 	}
 
 	// Set location from range
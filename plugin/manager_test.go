@@ -1,12 +1,16 @@
 package plugin
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
+	goplugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/hcl/v2"
 
 	"github.com/jokarl/tfbreak-core/internal/config"
 	"github.com/jokarl/tfbreak-core/internal/types"
+	sdkplugin "github.com/jokarl/tfbreak-plugin-sdk/plugin"
 	"github.com/jokarl/tfbreak-plugin-sdk/tflint"
 )
 
@@ -315,3 +319,166 @@ func TestManager_DiscoverAndLoad_MultipleCallsAreSafe(t *testing.T) {
 		t.Errorf("DiscoverAndLoad() counts differ: %d vs %d", count1, count2)
 	}
 }
+
+// refEchoRule is a plugin rule that emits an issue containing the ref
+// context it decoded from the runner.
+type refEchoRule struct {
+	tflint.DefaultRule
+}
+
+func (r *refEchoRule) Name() string { return "ref_echo" }
+func (r *refEchoRule) Link() string { return "" }
+
+func (r *refEchoRule) Check(runner tflint.Runner) error {
+	var refs struct {
+		BaseRef    string `json:"base_ref"`
+		HeadRef    string `json:"head_ref"`
+		ModulePath string `json:"module_path"`
+	}
+	if err := runner.DecodeRuleConfig(RefContextConfigName, &refs); err != nil {
+		return err
+	}
+	message := refs.BaseRef + ".." + refs.HeadRef + " in " + refs.ModulePath
+	return runner.EmitIssue(r, message, hcl.Range{Filename: "main.tf"})
+}
+
+// TestManager_ExecuteRulesWithRefs serves the rule over gRPC, the way an
+// out-of-process plugin is served, so the ref context has to cross the
+// plugin protocol.
+func TestManager_ExecuteRulesWithRefs(t *testing.T) {
+	client, server := goplugin.TestPluginGRPCConn(t, false, map[string]goplugin.Plugin{
+		sdkplugin.PluginName: &sdkplugin.RuleSetPlugin{Impl: &tflint.BuiltinRuleSet{
+			Name:    "echo",
+			Version: "0.1.0",
+			Rules:   []tflint.Rule{&refEchoRule{}},
+		}},
+	})
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense(sdkplugin.PluginName)
+	if err != nil {
+		t.Fatalf("Dispense() error = %v", err)
+	}
+
+	mgr := NewManager(config.Default())
+	mgr.plugins = []*LoadedPlugin{{
+		Info:    PluginInfo{Name: "echo"},
+		RuleSet: raw.(tflint.RuleSet),
+	}}
+
+	refs := RefContext{BaseRef: "v1.0.0", HeadRef: "v2.0.0", ModulePath: "modules/vpc"}
+	findings, errs := mgr.ExecuteRulesWithRefs(nil, nil, refs)
+	if len(errs) != 0 {
+		t.Fatalf("ExecuteRulesWithRefs() errors = %v", errs)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("got %d findings, want 1", len(findings))
+	}
+	if want := "v1.0.0..v2.0.0 in modules/vpc"; findings[0].Message != want {
+		t.Errorf("Message = %q, want %q", findings[0].Message, want)
+	}
+
+	// ExecuteRules keeps working for callers without ref context
+	findings, _ = mgr.ExecuteRules(nil, nil)
+	if len(findings) != 1 || findings[0].Message != ".. in " {
+		t.Errorf("ExecuteRules() findings = %v, want one with empty refs", findings)
+	}
+}
+
+// parallelTestRule is a plugin rule for TestManager_ExecuteRules_Parallelism
+type parallelTestRule struct {
+	tflint.DefaultRule
+	name string
+}
+
+func (r *parallelTestRule) Name() string                     { return r.name }
+func (r *parallelTestRule) Link() string                     { return "" }
+func (r *parallelTestRule) Check(runner tflint.Runner) error { return nil }
+
+// parallelTestRuleSet emits one issue per rule, or fails if err is set
+type parallelTestRuleSet struct {
+	tflint.BuiltinRuleSet
+	err error
+}
+
+func (rs *parallelTestRuleSet) Check(runner tflint.Runner) error {
+	if rs.err != nil {
+		return rs.err
+	}
+	for _, r := range rs.Rules {
+		if err := runner.EmitIssue(r, "issue from "+r.Name(), hcl.Range{Filename: "main.tf"}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// linkTestRule is a plugin rule that documents itself with a link
+type linkTestRule struct {
+	tflint.DefaultRule
+}
+
+func (r *linkTestRule) Name() string                     { return "documented_rule" }
+func (r *linkTestRule) Link() string                     { return "https://example.com/rules/documented_rule" }
+func (r *linkTestRule) Check(runner tflint.Runner) error { return nil }
+
+func TestManager_ExecuteRules_HelpURLFromLink(t *testing.T) {
+	mgr := NewManager(config.Default())
+	mgr.plugins = []*LoadedPlugin{
+		{Info: PluginInfo{Name: "docs"}, RuleSet: &parallelTestRuleSet{BuiltinRuleSet: tflint.BuiltinRuleSet{
+			Name:  "docs",
+			Rules: []tflint.Rule{&linkTestRule{}, &parallelTestRule{name: "undocumented_rule"}},
+		}}},
+	}
+
+	findings, errs := mgr.ExecuteRules(map[string]*hcl.File{}, map[string]*hcl.File{})
+	if len(errs) != 0 {
+		t.Fatalf("ExecuteRules() errors = %v", errs)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("got %d findings, want 2", len(findings))
+	}
+	if want := "https://example.com/rules/documented_rule"; findings[0].HelpURL != want {
+		t.Errorf("HelpURL = %q, want %q", findings[0].HelpURL, want)
+	}
+	if findings[1].HelpURL != "" {
+		t.Errorf("HelpURL = %q, want none for a rule without a link", findings[1].HelpURL)
+	}
+}
+
+func TestManager_ExecuteRules_Parallelism(t *testing.T) {
+	run := func(parallelism int) ([]*types.Finding, []error) {
+		mgr := NewManager(config.Default())
+		for i := 0; i < 6; i++ {
+			rs := &parallelTestRuleSet{BuiltinRuleSet: tflint.BuiltinRuleSet{
+				Name:  fmt.Sprintf("p%d", i),
+				Rules: []tflint.Rule{&parallelTestRule{name: fmt.Sprintf("p%d_a", i)}, &parallelTestRule{name: fmt.Sprintf("p%d_b", i)}},
+			}}
+			if i == 3 {
+				rs.err = errors.New("boom")
+			}
+			mgr.plugins = append(mgr.plugins, &LoadedPlugin{Info: PluginInfo{Name: rs.Name}, RuleSet: rs})
+		}
+		mgr.SetParallelism(parallelism)
+		return mgr.ExecuteRules(map[string]*hcl.File{}, map[string]*hcl.File{})
+	}
+
+	sequentialFindings, sequentialErrs := run(1)
+	if len(sequentialFindings) != 10 || len(sequentialErrs) != 1 {
+		t.Fatalf("sequential run: %d findings, %d errors, want 10 and 1", len(sequentialFindings), len(sequentialErrs))
+	}
+
+	parallelFindings, parallelErrs := run(0)
+	if len(parallelFindings) != len(sequentialFindings) {
+		t.Fatalf("parallel run returned %d findings, want %d", len(parallelFindings), len(sequentialFindings))
+	}
+	for i := range sequentialFindings {
+		if parallelFindings[i].RuleID != sequentialFindings[i].RuleID || parallelFindings[i].Message != sequentialFindings[i].Message {
+			t.Errorf("finding %d = %s, want %s", i, parallelFindings[i].RuleID, sequentialFindings[i].RuleID)
+		}
+	}
+	if len(parallelErrs) != 1 || parallelErrs[0].Error() != sequentialErrs[0].Error() {
+		t.Errorf("parallel errors = %v, want %v", parallelErrs, sequentialErrs)
+	}
+}
@@ -1,6 +1,8 @@
 package plugin
 
 import (
+	"encoding/json"
+
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclparse"
 	"github.com/jokarl/tfbreak-plugin-sdk/hclext"
@@ -13,18 +15,42 @@ import (
 type Runner struct {
 	oldFiles map[string]*hcl.File
 	newFiles map[string]*hcl.File
+	refs     RefContext
 	// Issues contains all issues emitted during rule execution.
 	Issues Issues
 }
 
+// RefContext describes what is being compared, so plugin rules can include
+// it in their messages. All fields are empty when comparing plain directories.
+type RefContext struct {
+	// BaseRef is the git ref of the old configuration (e.g., "main", "v1.0.0")
+	BaseRef string `json:"base_ref"`
+	// HeadRef is the git ref of the new configuration. Empty when the new
+	// configuration is the working tree.
+	HeadRef string `json:"head_ref"`
+	// ModulePath is the path of the module being compared
+	ModulePath string `json:"module_path"`
+}
+
+// RefContextConfigName is the rule name under which DecodeRuleConfig returns
+// the RefContext. DecodeRuleConfig is part of the plugin protocol, so unlike
+// extra runner methods it reaches plugins running in their own process.
+const RefContextConfigName = "tfbreak_ref_context"
+
 // Ensure Runner implements tflint.Runner at compile time.
 var _ tflint.Runner = (*Runner)(nil)
 
 // NewRunner creates a new Runner with the provided old and new HCL files.
 func NewRunner(oldFiles, newFiles map[string]*hcl.File) *Runner {
+	return NewRunnerWithRefs(oldFiles, newFiles, RefContext{})
+}
+
+// NewRunnerWithRefs creates a new Runner that also exposes the given ref context.
+func NewRunnerWithRefs(oldFiles, newFiles map[string]*hcl.File, refs RefContext) *Runner {
 	return &Runner{
 		oldFiles: oldFiles,
 		newFiles: newFiles,
+		refs:     refs,
 		Issues:   make(Issues, 0),
 	}
 }
@@ -86,10 +112,17 @@ func (r *Runner) EmitIssue(rule tflint.Rule, message string, issueRange hcl.Rang
 }
 
 // DecodeRuleConfig decodes rule-specific configuration.
-// This is a stub implementation that returns nil (no config).
-// Full implementation will be added when gRPC communication is implemented.
-func (r *Runner) DecodeRuleConfig(_ string, _ any) error {
-	return nil
+// Rule-specific configuration is not supported yet, so only the
+// RefContextConfigName entry is decoded; other rules get no config.
+func (r *Runner) DecodeRuleConfig(ruleName string, target any) error {
+	if ruleName != RefContextConfigName {
+		return nil
+	}
+	data, err := json.Marshal(r.refs)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
 }
 
 // getModuleContent extracts content from files using the schema.
@@ -590,3 +590,47 @@ func TestLabelsMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestRunner_DecodeRefContext(t *testing.T) {
+	refs := RefContext{
+		BaseRef:    "v1.0.0",
+		HeadRef:    "main",
+		ModulePath: "modules/vpc",
+	}
+	runner := NewRunnerWithRefs(nil, nil, refs)
+
+	var got RefContext
+	if err := runner.DecodeRuleConfig(RefContextConfigName, &got); err != nil {
+		t.Fatalf("DecodeRuleConfig() error = %v", err)
+	}
+	if got != refs {
+		t.Errorf("DecodeRuleConfig() = %+v, want %+v", got, refs)
+	}
+
+	// The gRPC runner server decodes into a map before encoding it for the plugin
+	var raw map[string]interface{}
+	if err := runner.DecodeRuleConfig(RefContextConfigName, &raw); err != nil {
+		t.Fatalf("DecodeRuleConfig() error = %v", err)
+	}
+	if raw["base_ref"] != "v1.0.0" || raw["head_ref"] != "main" || raw["module_path"] != "modules/vpc" {
+		t.Errorf("DecodeRuleConfig() map = %v", raw)
+	}
+
+	// Other rules still get no config
+	var other map[string]interface{}
+	if err := runner.DecodeRuleConfig("test_rule", &other); err != nil || other != nil {
+		t.Errorf("DecodeRuleConfig(test_rule) = %v, %v, want no config", other, err)
+	}
+}
+
+func TestRunner_NoRefs(t *testing.T) {
+	runner := NewRunner(nil, nil)
+
+	var got RefContext
+	if err := runner.DecodeRuleConfig(RefContextConfigName, &got); err != nil {
+		t.Fatalf("DecodeRuleConfig() error = %v", err)
+	}
+	if got != (RefContext{}) {
+		t.Errorf("expected empty ref context, got %+v", got)
+	}
+}
@@ -0,0 +1,8 @@
+# Test BC006: object attribute no longer optional
+# New state: tier is required
+variable "settings" {
+  type = object({
+    name = string
+    tier = string
+  })
+}
@@ -0,0 +1,8 @@
+# Test BC006: object attribute no longer optional
+# Old state: tier is optional with a default
+variable "settings" {
+  type = object({
+    name = string
+    tier = optional(string, "standard")
+  })
+}
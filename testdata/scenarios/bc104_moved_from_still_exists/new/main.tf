@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    null = {
+      source = "hashicorp/null"
+    }
+  }
+}
+
+# Resource copied to a new address, but the old block was not removed
+
+resource "null_resource" "old_name" {
+  triggers = {
+    value = "example"
+  }
+}
+
+resource "null_resource" "new_name" {
+  triggers = {
+    value = "example"
+  }
+}
+
+moved {
+  from = null_resource.old_name
+  to   = null_resource.new_name
+}
@@ -0,0 +1,13 @@
+terraform {
+  required_providers {
+    null = {
+      source = "hashicorp/null"
+    }
+  }
+}
+
+resource "null_resource" "old_name" {
+  triggers = {
+    value = "example"
+  }
+}
@@ -0,0 +1,8 @@
+terraform {
+  required_providers {
+    aws = {
+      source  = "custom/aws"
+      version = ">= 5.0"
+    }
+  }
+}
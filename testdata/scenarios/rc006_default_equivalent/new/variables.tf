@@ -0,0 +1,17 @@
+variable "replicas" {
+  type    = number
+  default = 1.0
+}
+
+variable "tags" {
+  type = map(string)
+  default = {
+    env  = "dev"
+    team = "platform"
+  }
+}
+
+variable "zones" {
+  type    = set(string)
+  default = ["b", "a"]
+}
@@ -0,0 +1,14 @@
+variable "replicas" {
+  type    = number
+  default = 1
+}
+
+variable "tags" {
+  type    = map(string)
+  default = { team = "platform", env = "dev" }
+}
+
+variable "zones" {
+  type    = set(string)
+  default = ["a", "b"]
+}
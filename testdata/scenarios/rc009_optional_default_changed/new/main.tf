@@ -0,0 +1,8 @@
+variable "settings" {
+  type = object({
+    name     = string
+    tier     = optional(string, "premium")
+    replicas = optional(number)
+    labels   = optional(map(string), {})
+  })
+}
@@ -0,0 +1,8 @@
+variable "settings" {
+  type = object({
+    name     = string
+    tier     = optional(string, "standard")
+    replicas = optional(number, 2)
+    labels   = optional(map(string), {})
+  })
+}
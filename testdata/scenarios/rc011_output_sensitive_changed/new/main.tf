@@ -1,4 +1,4 @@
 output "connection_string" {
   value     = "postgres://localhost:5432/db"
-  sensitive = true
+  sensitive = false
 }
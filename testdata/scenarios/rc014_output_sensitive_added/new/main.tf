@@ -0,0 +1,4 @@
+output "connection_string" {
+  value     = "postgres://localhost:5432/db"
+  sensitive = true
+}
@@ -0,0 +1,6 @@
+# Test RC016: validation block removed
+# New state: same variable without validation
+variable "environment" {
+  type        = string
+  description = "Deployment environment"
+}
@@ -0,0 +1,11 @@
+# Test RC016: validation block removed
+# Old state: variable with validation
+variable "environment" {
+  type        = string
+  description = "Deployment environment"
+
+  validation {
+    condition     = contains(["dev", "staging", "prod"], var.environment)
+    error_message = "Environment must be dev, staging, or prod."
+  }
+}
@@ -0,0 +1,34 @@
+# Same interface as before, reformatted and commented
+variable "settings" {
+  type = object({
+    name  = string                           # display name
+    tier  = optional(string, "standard")
+    zones = list(number),                    // availability zones
+  })
+  default = {
+    name  = "app"
+    zones = [
+      1,
+      2,
+    ]
+  }
+}
+
+variable "tags" {
+  type    = map(string)
+  default = {}
+}
+
+variable "environment" {
+  type    = string
+  default = "dev"
+
+  validation {
+    condition = contains([
+      "dev",
+      "staging", /* pre-production */
+      "prod",
+    ], var.environment)
+    error_message = "Environment must be dev, staging, or prod."
+  }
+}
@@ -0,0 +1,19 @@
+variable "settings" {
+  type    = object({ name = string, tier = optional(string, "standard"), zones = list(number) })
+  default = { name = "app", zones = [1, 2] }
+}
+
+variable "tags" {
+  type    = map( string )
+  default = {}
+}
+
+variable "environment" {
+  type    = string
+  default = "dev"
+
+  validation {
+    condition     = contains(["dev", "staging", "prod"], var.environment)
+    error_message = "Environment must be dev, staging, or prod."
+  }
+}